@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"dynamo-modeling/internal/backfill"
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+const usage = `Usage:
+  backfill list [flags]         list the registered backfills
+  backfill run <name> [flags]   rewrite the items of a backfill, resuming from its checkpoints
+
+Run flags:
+  -dry-run       print the changes without writing items or checkpoints
+  -segments N    parallel scan segments (default 4)
+  -page-size N   items evaluated per Scan request (default 100)
+  -rate N        items per second across all segments, 0 for unlimited
+  -reset         discard the checkpoints of an earlier run and start over
+
+Flags are otherwise the same as the server's, e.g. -dynamodb-endpoint and -dynamodb-table.
+`
+
+func main() {
+	if len(os.Args) < 2 || (os.Args[1] != "list" && os.Args[1] != "run") {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command, args := os.Args[1], os.Args[2:]
+
+	var name string
+	if command == "run" {
+		if len(args) == 0 || args[0] == "" || args[0][0] == '-' {
+			fmt.Fprint(os.Stderr, "backfill run needs the name of a backfill\n\n"+usage)
+			os.Exit(2)
+		}
+		name, args = args[0], args[1:]
+	}
+
+	// 1. 設定の読み込み（バックフィル用のフラグも同じフラグセットで受け取る）
+	fs := flag.NewFlagSet("backfill "+command, flag.ContinueOnError)
+	var opts backfill.Options
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "print the changes without writing items or checkpoints")
+	fs.IntVar(&opts.Segments, "segments", 4, "parallel scan segments")
+	fs.IntVar(&opts.PageSize, "page-size", 100, "items evaluated per Scan request")
+	fs.Float64Var(&opts.Rate, "rate", 0, "items per second across all segments, 0 for unlimited")
+	fs.BoolVar(&opts.Reset, "reset", false, "discard the checkpoints of an earlier run and start over")
+	cfg, err := config.LoadWithFlags(fs, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	// Ctrl+C で中断しても、次の実行はチェックポイントから再開する
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 2. ランナーの初期化
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
+	if err != nil {
+		slog.Error("Failed to initialize DynamoDB client", "error", err)
+		os.Exit(1)
+	}
+	runner, err := backfill.NewRunner(client, backfill.All())
+	if err != nil {
+		slog.Error("Invalid backfills", "error", err)
+		os.Exit(1)
+	}
+
+	// 3. コマンド実行
+	if command == "list" {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tTYPE\tDESCRIPTION")
+		for _, b := range runner.Backfills() {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", b.Name, b.Type, b.Description)
+		}
+		_ = w.Flush()
+		return
+	}
+
+	report, err := runner.Run(ctx, name, opts)
+	if report != nil {
+		for _, diff := range report.Diffs {
+			fmt.Print(diff)
+		}
+		verb := "changed"
+		if opts.DryRun {
+			verb = "would change"
+		}
+		fmt.Printf("%s: scanned %d, %s %d, conflicts %d\n", name, report.Scanned, verb, report.Changed, report.Conflicts)
+	}
+	if err != nil {
+		slog.Error("Backfill failed", "backfill", name, "error", err)
+		os.Exit(1)
+	}
+}
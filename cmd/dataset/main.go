@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/dataset"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+const usage = `Usage:
+  dataset export [-type T1,T2] [-o FILE] [flags]          write items as DynamoDB JSON lines
+  dataset import [-format jsonl|workbench] [flags] FILE   write items from a file, - for stdin
+
+Export flags:
+  -type T1,T2         only items with one of these Type values (default: all but bookkeeping)
+  -o FILE             output file (default: stdout)
+
+Import flags:
+  -format FORMAT      jsonl (default) or workbench for a NoSQL Workbench data model
+  -model-table NAME   table of the Workbench model to import, when it has several
+
+Flags are otherwise the same as the server's, e.g. -dynamodb-endpoint and -dynamodb-table.
+`
+
+func main() {
+	if len(os.Args) < 2 || (os.Args[1] != "export" && os.Args[1] != "import") {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command := os.Args[1]
+
+	// 1. 設定の読み込み（ツール用のフラグも同じフラグセットで受け取る）
+	fs := flag.NewFlagSet("dataset "+command, flag.ContinueOnError)
+	itemTypes := fs.String("type", "", "comma-separated Type values to export")
+	output := fs.String("o", "", "output file for export")
+	format := fs.String("format", "jsonl", "import format: jsonl or workbench")
+	modelTable := fs.String("model-table", "", "table of the NoSQL Workbench model to import")
+	cfg, err := config.LoadWithFlags(fs, os.Args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
+	if err != nil {
+		slog.Error("Failed to initialize DynamoDB client", "error", err)
+		os.Exit(1)
+	}
+
+	// 2. コマンド実行
+	switch command {
+	case "export":
+		err = export(ctx, client, *itemTypes, *output)
+	case "import":
+		if fs.NArg() != 1 {
+			fmt.Fprint(os.Stderr, "dataset import needs one file\n\n"+usage)
+			os.Exit(2)
+		}
+		err = importFile(ctx, client, fs.Arg(0), *format, *modelTable)
+	}
+	if err != nil {
+		slog.Error("Dataset command failed", "command", command, "error", err)
+		os.Exit(1)
+	}
+}
+
+func export(ctx context.Context, client *infrastructure.DynamoDBClient, itemTypes, output string) error {
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	var filter []string
+	if itemTypes != "" {
+		filter = strings.Split(itemTypes, ",")
+	}
+	count, err := dataset.Export(ctx, client.GetTable(), w, filter)
+	if err != nil {
+		return err
+	}
+	slog.Info("Exported items", "table", client.TableName, "count", count)
+	return nil
+}
+
+func importFile(ctx context.Context, client *infrastructure.DynamoDBClient, path, format, modelTable string) error {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var (
+		count int
+		err   error
+	)
+	switch format {
+	case "jsonl":
+		count, err = dataset.ImportJSONL(ctx, client.GetTable(), r)
+	case "workbench":
+		count, err = dataset.ImportWorkbench(ctx, client.GetTable(), r, modelTable)
+	default:
+		return fmt.Errorf("unknown format %q, use jsonl or workbench", format)
+	}
+	if err != nil {
+		return fmt.Errorf("import stopped after %d items: %w", count, err)
+	}
+	fmt.Printf("✅ %d 件のアイテムを %s にインポートしました\n", count, client.TableName)
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/infrastructure"
+	"dynamo-modeling/internal/migration"
+)
+
+const usage = `Usage: migrate <command> [flags]
+
+Commands:
+  up      apply pending migrations in version order
+  status  list every migration and when it was applied
+  plan    list the migrations that up would apply
+
+Flags are the same as the server's, e.g. -dynamodb-endpoint and -dynamodb-table.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command := os.Args[1]
+	if command == "-h" || command == "-help" || command == "help" {
+		fmt.Fprint(os.Stdout, usage)
+		return
+	}
+	if command != "up" && command != "status" && command != "plan" {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+
+	// 設定の読み込み（デフォルト < YAMLファイル < 環境変数 < フラグ）
+	cfg, err := config.Load(os.Args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprint(os.Stderr, usage)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	// Ctrl+C で実行中のマイグレーションを中断する（適用済みのものは記録済み）
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
+	if err != nil {
+		slog.Error("Failed to initialize DynamoDB client", "error", err)
+		os.Exit(1)
+	}
+	runner, err := migration.NewRunner(client, migration.All())
+	if err != nil {
+		slog.Error("Invalid migrations", "error", err)
+		os.Exit(1)
+	}
+
+	switch command {
+	case "up":
+		err = up(ctx, runner)
+	case "status":
+		err = status(ctx, runner)
+	case "plan":
+		err = plan(ctx, runner, cfg.DynamoDB.TableName)
+	}
+	if err != nil {
+		slog.Error("Migration command failed", "command", command, "error", err)
+		os.Exit(1)
+	}
+}
+
+func up(ctx context.Context, runner *migration.Runner) error {
+	done, err := runner.Up(ctx)
+	for _, m := range done {
+		fmt.Printf("✅ %s\n", m.Label())
+	}
+	if err != nil {
+		return err
+	}
+	if len(done) == 0 {
+		fmt.Println("No pending migrations")
+	}
+	return nil
+}
+
+func status(ctx context.Context, runner *migration.Runner) error {
+	statuses, err := runner.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.Applied {
+			appliedAt = s.AppliedAt.Format("2006-01-02T15:04:05Z07:00")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+	return w.Flush()
+}
+
+func plan(ctx context.Context, runner *migration.Runner, tableName string) error {
+	pending, err := runner.Plan(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		fmt.Printf("Table %s is up to date\n", tableName)
+		return nil
+	}
+
+	fmt.Printf("%d migration(s) would be applied to %s:\n", len(pending), tableName)
+	for _, m := range pending {
+		fmt.Printf("  %s  %s\n", m.Label(), m.Description)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/infrastructure"
+	"dynamo-modeling/internal/seed"
+)
+
+func main() {
+	// 1. 設定の読み込み（ツール用のフラグも同じフラグセットで受け取る）
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	seedValue := fs.Int64("seed", 1, "random seed; the same seed generates the same data")
+	scale := fs.Float64("scale", 1, "multiplier of 1,000 customers, 200 products, 3 warehouses and 3,000 orders")
+	days := fs.Int("days", 365, "spread orders over this many past days")
+	cfg, err := config.LoadWithFlags(fs, os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+	if cfg.Storage != config.StorageDynamoDB {
+		// インメモリストアはプロセス終了で消えるため、投入先にならない
+		slog.Error("Seeding needs DynamoDB storage", "storage", cfg.Storage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
+	if err != nil {
+		slog.Error("Failed to initialize DynamoDB client", "error", err)
+		os.Exit(1)
+	}
+
+	// 2. 実際のリポジトリとユースケースを通してデータを投入
+	seeder := seed.NewSeeder(seed.Repositories{
+		Customers:  repository.NewDynamoCustomerRepository(client),
+		Products:   repository.NewDynamoProductRepository(client),
+		Orders:     repository.NewDynamoOrderRepository(client),
+		Warehouses: repository.NewDynamoWarehouseRepository(client),
+		Shipments:  repository.NewDynamoShipmentRepository(client),
+	})
+	started := time.Now()
+	summary, err := seeder.Run(ctx, seed.Options{Seed: *seedValue, Scale: *scale, Days: *days, Now: started})
+	if err != nil {
+		slog.Error("Seeding failed", "error", err)
+		os.Exit(1)
+	}
+
+	// 3. 結果の表示
+	fmt.Printf("✅ %s にシードデータを投入しました（seed=%d, scale=%g, %s）\n",
+		client.TableName, *seedValue, *scale, time.Since(started).Round(time.Second))
+	fmt.Printf("  顧客: %d  倉庫: %d  商品: %d  注文: %d  出荷: %d\n",
+		summary.Customers, summary.Warehouses, summary.Products, summary.Orders, summary.Shipments)
+	for _, status := range []entity.OrderStatus{
+		entity.OrderStatusPending, entity.OrderStatusConfirmed, entity.OrderStatusShipped,
+		entity.OrderStatusDelivered, entity.OrderStatusCancelled,
+	} {
+		fmt.Printf("  %-10s %d\n", status, summary.Statuses[status])
+	}
+}
@@ -2,12 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -16,6 +17,10 @@ import (
 	"dynamo-modeling/internal/adapter/openapi"
 	"dynamo-modeling/internal/adapter/presenter"
 	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/auth"
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/domain/entity"
+	domainrepo "dynamo-modeling/internal/domain/repository"
 	"dynamo-modeling/internal/handler"
 	"dynamo-modeling/internal/infrastructure"
 	"dynamo-modeling/internal/usecase"
@@ -25,29 +30,88 @@ func main() {
 	slog.Info("DynamoDB + Clean Architecture Online Shop API")
 	slog.Info("Starting server...")
 
-	// DynamoDB設定
-	dbConfig := infrastructure.DynamoDBConfig{
-		Region:    "ap-northeast-1",
-		Endpoint:  "http://localhost:8000", // DynamoDB Local
-		TableName: "OnlineShop",
+	// 設定の読み込み（デフォルト < YAMLファイル < 環境変数 < フラグ）
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
 	}
-
-	// DynamoDBクライアント初期化
-	ctx := context.Background()
-	dbClient, err := infrastructure.NewDynamoDBClient(ctx, dbConfig)
 	if err != nil {
-		slog.Error("Failed to initialize DynamoDB client", "error", err)
+		slog.Error("Failed to load configuration", "error", err)
 		os.Exit(1)
 	}
 
+	// トークン検証キーの読み込み（認証を無効にするのはローカル開発だけ）
+	var verifier *auth.Verifier
+	if cfg.Auth.Disabled {
+		slog.Warn("Authentication is disabled, every request is accepted")
+	} else {
+		verifier, err = auth.NewVerifier(cfg.Auth.Verifier())
+		if err != nil {
+			slog.Error("Failed to load token verification keys", "error", err)
+			os.Exit(1)
+		}
+	}
+
 	// Repository層を初期化
-	customerRepo := repository.NewDynamoCustomerRepository(dbClient)
-	productRepo := repository.NewDynamoProductRepository(dbClient)
-	orderRepo := repository.NewDynamoOrderRepository(dbClient)
+	var (
+		customerRepo  domainrepo.CustomerRepository
+		productRepo   domainrepo.ProductRepository
+		orderRepo     domainrepo.OrderRepository
+		warehouseRepo domainrepo.WarehouseRepository
+		invoiceRepo   domainrepo.InvoiceRepository
+		shipmentRepo  domainrepo.ShipmentRepository
+		returnRepo    domainrepo.ReturnRepository
+		idempotency   domainrepo.IdempotencyRepository
+	)
+
+	switch cfg.Storage {
+	case config.StorageDynamoDB:
+		// DynamoDBクライアント初期化
+		dbClient, err := infrastructure.NewDynamoDBClient(context.Background(), cfg.DynamoDB.Infrastructure())
+		if err != nil {
+			slog.Error("Failed to initialize DynamoDB client", "error", err)
+			os.Exit(1)
+		}
+
+		customerRepo = repository.NewDynamoCustomerRepository(dbClient)
+		productRepo = repository.NewDynamoProductRepository(dbClient)
+		orderRepo = repository.NewDynamoOrderRepository(dbClient)
+		warehouseRepo = repository.NewDynamoWarehouseRepository(dbClient)
+		invoiceRepo = repository.NewDynamoInvoiceRepository(dbClient)
+		shipmentRepo = repository.NewDynamoShipmentRepository(dbClient)
+		returnRepo = repository.NewDynamoReturnRepository(dbClient)
+		idempotency = repository.NewDynamoIdempotencyRepository(dbClient)
+
+	case config.StorageMemory:
+		// インメモリストア（プロセス終了でデータは消える）
+		slog.Warn("Using in-memory storage, data will be lost on shutdown")
+		store, err := repository.NewMemoryStore()
+		if err != nil {
+			slog.Error("Failed to initialize memory store", "error", err)
+			os.Exit(1)
+		}
+
+		customerRepo = repository.NewMemoryCustomerRepository(store)
+		productRepo = repository.NewMemoryProductRepository(store)
+		orderRepo = repository.NewMemoryOrderRepository(store)
+		warehouseRepo = repository.NewMemoryWarehouseRepository(store)
+		invoiceRepo = repository.NewMemoryInvoiceRepository(store)
+		shipmentRepo = repository.NewMemoryShipmentRepository(store)
+		returnRepo = repository.NewMemoryReturnRepository(store)
+		idempotency = repository.NewMemoryIdempotencyRepository(store)
+
+	}
+
+	// ドメインイベントの購読者を登録（通知・分析・監査はここに追加する）
+	events := usecase.NewEventDispatcher()
+	events.Subscribe(func(ctx context.Context, event entity.DomainEvent) error {
+		slog.InfoContext(ctx, "Domain event", "event", event.EventName(), "occurredAt", event.OccurredAt())
+		return nil
+	})
 
 	// UseCase層を初期化
 	// Customer UseCases
-	createCustomerUseCase := usecase.NewCreateCustomerUseCase(customerRepo)
+	createCustomerUseCase := usecase.NewCreateCustomerUseCase(customerRepo, events)
 	getCustomerUseCase := usecase.NewGetCustomerUseCase(customerRepo)
 	listCustomersUseCase := usecase.NewListCustomersUseCase(customerRepo)
 	updateCustomerUseCase := usecase.NewUpdateCustomerUseCase(customerRepo)
@@ -61,15 +125,51 @@ func main() {
 	deleteProductUseCase := usecase.NewDeleteProductUseCase(productRepo)
 
 	// Order UseCases
-	createOrderUseCase := usecase.NewCreateOrderUseCase(orderRepo, customerRepo, productRepo)
+	createOrderUseCase := usecase.NewCreateOrderUseCase(orderRepo, customerRepo, productRepo, events)
 	getOrderUseCase := usecase.NewGetOrderUseCase(orderRepo)
 	listOrdersUseCase := usecase.NewListOrdersUseCase(orderRepo)
-	updateOrderStatusUseCase := usecase.NewUpdateOrderStatusUseCase(orderRepo)
+	listProductOrdersUseCase := usecase.NewListProductOrdersUseCase(orderRepo)
+	cancelOrderUseCase := usecase.NewCancelOrderUseCase(orderRepo, productRepo, shipmentRepo, events)
+	updateOrderStatusUseCase := usecase.NewUpdateOrderStatusUseCase(orderRepo, cancelOrderUseCase, events)
+	getOrderHistoryUseCase := usecase.NewGetOrderHistoryUseCase(orderRepo)
+
+	// Warehouse UseCases
+	createWarehouseUseCase := usecase.NewCreateWarehouseUseCase(warehouseRepo)
+	getWarehouseUseCase := usecase.NewGetWarehouseUseCase(warehouseRepo)
+	setInventoryUseCase := usecase.NewSetInventoryUseCase(warehouseRepo, productRepo)
+	listWarehouseInventoryUseCase := usecase.NewListWarehouseInventoryUseCase(warehouseRepo)
+	getProductInventoryUseCase := usecase.NewGetProductInventoryUseCase(warehouseRepo)
+
+	// Invoice UseCases
+	issueInvoiceUseCase := usecase.NewIssueInvoiceUseCase(invoiceRepo, orderRepo)
+	getInvoiceUseCase := usecase.NewGetInvoiceUseCase(invoiceRepo)
+	getOrderInvoiceUseCase := usecase.NewGetOrderInvoiceUseCase(invoiceRepo)
+	listCustomerInvoicesUseCase := usecase.NewListCustomerInvoicesUseCase(invoiceRepo)
+	recordPaymentUseCase := usecase.NewRecordPaymentUseCase(invoiceRepo)
+
+	// Shipment UseCases
+	createShipmentUseCase := usecase.NewCreateShipmentUseCase(shipmentRepo, orderRepo, warehouseRepo, events)
+	getShipmentUseCase := usecase.NewGetShipmentUseCase(shipmentRepo)
+	listOrderShipmentsUseCase := usecase.NewListOrderShipmentsUseCase(shipmentRepo)
+	listWarehouseShipmentsUseCase := usecase.NewListWarehouseShipmentsUseCase(shipmentRepo)
+	deliverShipmentUseCase := usecase.NewDeliverShipmentUseCase(shipmentRepo, orderRepo, events)
+
+	// Return UseCases
+	requestReturnUseCase := usecase.NewRequestReturnUseCase(returnRepo, orderRepo, events)
+	getReturnUseCase := usecase.NewGetReturnUseCase(returnRepo)
+	listOrderReturnsUseCase := usecase.NewListOrderReturnsUseCase(returnRepo)
+	listReturnsUseCase := usecase.NewListReturnsUseCase(returnRepo)
+	approveReturnUseCase := usecase.NewApproveReturnUseCase(returnRepo, orderRepo, productRepo, warehouseRepo, events)
+	rejectReturnUseCase := usecase.NewRejectReturnUseCase(returnRepo, orderRepo, events)
 
 	// Presenter層を初期化
 	customerPresenter := presenter.NewCustomerPresenter()
 	productPresenter := presenter.NewProductPresenter()
 	orderPresenter := presenter.NewOrderPresenter()
+	warehousePresenter := presenter.NewWarehousePresenter()
+	invoicePresenter := presenter.NewInvoicePresenter()
+	shipmentPresenter := presenter.NewShipmentPresenter()
+	returnPresenter := presenter.NewReturnPresenter()
 
 	// Controller層を初期化
 	customerController := controller.NewCustomerController(
@@ -94,20 +194,66 @@ func main() {
 		createOrderUseCase,
 		getOrderUseCase,
 		listOrdersUseCase,
+		listProductOrdersUseCase,
 		updateOrderStatusUseCase,
+		cancelOrderUseCase,
+		getOrderHistoryUseCase,
 		orderPresenter,
 	)
 
+	warehouseController := controller.NewWarehouseController(
+		createWarehouseUseCase,
+		getWarehouseUseCase,
+		setInventoryUseCase,
+		listWarehouseInventoryUseCase,
+		getProductInventoryUseCase,
+		warehousePresenter,
+	)
+
+	invoiceController := controller.NewInvoiceController(
+		issueInvoiceUseCase,
+		getInvoiceUseCase,
+		getOrderInvoiceUseCase,
+		listCustomerInvoicesUseCase,
+		recordPaymentUseCase,
+		invoicePresenter,
+	)
+
+	shipmentController := controller.NewShipmentController(
+		createShipmentUseCase,
+		getShipmentUseCase,
+		listOrderShipmentsUseCase,
+		listWarehouseShipmentsUseCase,
+		deliverShipmentUseCase,
+		shipmentPresenter,
+	)
+
+	returnController := controller.NewReturnController(
+		requestReturnUseCase,
+		getReturnUseCase,
+		listOrderReturnsUseCase,
+		listReturnsUseCase,
+		approveReturnUseCase,
+		rejectReturnUseCase,
+		getOrderUseCase,
+		returnPresenter,
+	)
+
 	// Handler層を初期化
-	apiHandler := handler.NewAPIHandler(customerController, productController, orderController)
+	apiHandler := handler.NewAPIHandler(customerController, productController, orderController, warehouseController, invoiceController, shipmentController, returnController)
 
 	// Echoサーバー作成
 	e := echo.New()
+	e.HTTPErrorHandler = handler.ErrorHandler
 
 	// ミドルウェア設定
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
+	if verifier != nil {
+		e.Use(handler.Authenticate(verifier))
+	}
+	e.Use(handler.Idempotency(idempotency, handler.IdempotentRoutes...))
 
 	// OpenAPIハンドラーを登録
 	openapi.RegisterHandlers(e, apiHandler)
@@ -116,8 +262,8 @@ func main() {
 
 	// グレースフルシャットダウン設定
 	go func() {
-		slog.Info("Server starting on :8080")
-		if err := e.Start(":8080"); err != nil && err != http.ErrServerClosed {
+		slog.Info("Server starting", "addr", cfg.Server.Addr)
+		if err := e.Start(cfg.Server.Addr); err != nil && err != http.ErrServerClosed {
 			slog.Error("Server failed to start", "error", err)
 			os.Exit(1)
 		}
@@ -131,7 +277,7 @@ func main() {
 	slog.Info("Server shutting down...")
 
 	// グレースフルシャットダウン
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	if err := e.Shutdown(ctx); err != nil {
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/infrastructure"
+	"dynamo-modeling/internal/stream"
+)
+
+const usage = `Usage:
+  stream run [-name NAME] [-from-latest] [flags]   log order, product and customer changes from the table stream
+  stream dead-letters [-name NAME] [flags]         list records the handlers kept failing on
+
+Flags:
+  -name NAME          consumer name; checkpoints and dead letters are kept per name (default: change-log)
+  -from-latest        start shards without a checkpoint at new records instead of the oldest ones
+
+Flags are otherwise the same as the server's, e.g. -dynamodb-endpoint and -dynamodb-table.
+The stream is enabled by migration 0006_enable_stream.
+`
+
+func main() {
+	if len(os.Args) < 2 || (os.Args[1] != "run" && os.Args[1] != "dead-letters") {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command := os.Args[1]
+
+	// 1. 設定の読み込み（ツール用のフラグも同じフラグセットで受け取る）
+	fs := flag.NewFlagSet("stream "+command, flag.ContinueOnError)
+	name := fs.String("name", "change-log", "consumer name")
+	fromLatest := fs.Bool("from-latest", false, "start shards without a checkpoint at new records")
+	cfg, err := config.LoadWithFlags(fs, os.Args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dynamoCfg := cfg.DynamoDB.Infrastructure()
+	client, err := infrastructure.NewDynamoDBClient(ctx, dynamoCfg)
+	if err != nil {
+		slog.Error("Failed to initialize DynamoDB client", "error", err)
+		os.Exit(1)
+	}
+
+	// 2. コマンド実行
+	switch command {
+	case "run":
+		err = run(ctx, client, dynamoCfg, *name, *fromLatest)
+	case "dead-letters":
+		err = listDeadLetters(ctx, client, *name)
+	}
+	if err != nil {
+		slog.Error("Stream command failed", "command", command, "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, client *infrastructure.DynamoDBClient, cfg infrastructure.DynamoDBConfig, name string, fromLatest bool) error {
+	streams, err := infrastructure.NewDynamoDBStreamsClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	consumer, err := stream.NewConsumer(client, streams, name, stream.Options{FromLatest: fromLatest})
+	if err != nil {
+		return err
+	}
+
+	// 変更内容をログに出すハンドラーを登録
+	handlers := []struct {
+		itemType string
+		fn       stream.HandlerFunc
+	}{
+		{"ORDER", stream.Typed(logOrder)},
+		{"PRODUCT", stream.Typed(logProduct)},
+		{"CUSTOMER", stream.Typed(logCustomer)},
+	}
+	for _, h := range handlers {
+		if err := consumer.Register(h.itemType, "log", h.fn); err != nil {
+			return err
+		}
+	}
+
+	slog.Info("Consuming the table stream", "table", client.TableName, "consumer", name)
+	return consumer.Run(ctx)
+}
+
+func logOrder(ctx context.Context, change stream.Change[repository.OrderItem]) error {
+	switch {
+	case change.New == nil:
+		slog.Info("Order removed", "orderId", change.Old.ID)
+	case change.Old == nil:
+		slog.Info("Order placed", "orderId", change.New.ID, "customerId", change.New.CustomerID, "total", change.New.Total)
+	case change.Old.Status != change.New.Status:
+		slog.Info("Order status changed", "orderId", change.New.ID, "from", change.Old.Status, "to", change.New.Status)
+	}
+	return nil
+}
+
+func logProduct(ctx context.Context, change stream.Change[repository.ProductItem]) error {
+	switch {
+	case change.New == nil:
+		slog.Info("Product removed", "productId", change.Old.ID)
+	case change.Old == nil:
+		slog.Info("Product created", "productId", change.New.ID, "name", change.New.Name, "price", change.New.Price)
+	default:
+		if change.Old.Stock != change.New.Stock {
+			slog.Info("Product stock changed", "productId", change.New.ID, "from", change.Old.Stock, "to", change.New.Stock)
+		}
+		if change.Old.Price != change.New.Price {
+			slog.Info("Product price changed", "productId", change.New.ID, "from", change.Old.Price, "to", change.New.Price)
+		}
+	}
+	return nil
+}
+
+func logCustomer(ctx context.Context, change stream.Change[repository.CustomerItem]) error {
+	switch {
+	case change.New == nil:
+		slog.Info("Customer removed", "customerId", change.Old.ID)
+	case change.Old == nil:
+		slog.Info("Customer registered", "customerId", change.New.ID)
+	case change.Old.Email != change.New.Email:
+		slog.Info("Customer email changed", "customerId", change.New.ID)
+	}
+	return nil
+}
+
+func listDeadLetters(ctx context.Context, client *infrastructure.DynamoDBClient, name string) error {
+	deadLetters, err := stream.DeadLetters(ctx, client.GetTable(), name)
+	if err != nil {
+		return err
+	}
+	if len(deadLetters) == 0 {
+		fmt.Printf("✅ %s のデッドレターはありません\n", name)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FAILED AT\tHANDLER\tEVENT\tTYPE\tKEY\tATTEMPTS\tERROR")
+	for _, dl := range deadLetters {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			dl.FailedAt.Format("2006-01-02 15:04:05"), dl.Handler, dl.EventName, dl.ItemType, dl.Keys["PK"], dl.Attempts, dl.Error)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/auth"
+)
+
+// actorHeader names who makes a request, kept in the order status history
+const actorHeader = "X-Actor"
+
+// defaultActor is the actor of requests that do not name one
+const defaultActor = "api"
+
+// requestActor returns who makes the request: the subject of its token, or the X-Actor header
+// when authentication is disabled
+func requestActor(ctx echo.Context) string {
+	if principal := auth.FromContext(ctx.Request().Context()); principal != nil {
+		return principal.Subject
+	}
+	if actor := ctx.Request().Header.Get(actorHeader); actor != "" {
+		return actor
+	}
+	return defaultActor
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/auth"
+	"dynamo-modeling/internal/usecase"
+)
+
+// authorizeCustomer rejects a request whose caller may not act for the customer. Requests without
+// a principal are let through; they only reach the controllers when authentication is disabled.
+func authorizeCustomer(ctx echo.Context, customerID string) error {
+	principal := auth.FromContext(ctx.Request().Context())
+	if principal == nil || principal.CanActFor(customerID) {
+		return nil
+	}
+	return echo.NewHTTPError(http.StatusForbidden, "Access to another customer is not allowed")
+}
+
+// authorizeOrder rejects a request whose caller may not act for the customer of the order.
+// The order is only loaded for callers that are limited to their own orders.
+func authorizeOrder(ctx echo.Context, getOrderUseCase *usecase.GetOrderUseCase, orderId string) error {
+	principal := auth.FromContext(ctx.Request().Context())
+	if principal == nil || principal.IsAdmin() {
+		return nil
+	}
+
+	order, err := getOrderUseCase.Execute(context.Background(), usecase.GetOrderCommand{OrderID: orderId})
+	if err != nil {
+		return err
+	}
+	return authorizeCustomer(ctx, order.CustomerID().String())
+}
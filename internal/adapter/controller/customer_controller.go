@@ -64,7 +64,7 @@ func (c *CustomerController) CreateCustomer(ctx echo.Context) error {
 
 	customer, err := c.createCustomerUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusInternalServerError, "creation_failed", err.Error())
+		return err
 	}
 
 	// 3. Presenter呼び出し
@@ -77,6 +77,9 @@ func (c *CustomerController) GetCustomer(ctx echo.Context, customerId string) er
 	if customerId == "" {
 		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Customer ID is required")
 	}
+	if err := authorizeCustomer(ctx, customerId); err != nil {
+		return err
+	}
 
 	// 2. UseCase呼び出し
 	command := usecase.GetCustomerCommand{
@@ -85,7 +88,7 @@ func (c *CustomerController) GetCustomer(ctx echo.Context, customerId string) er
 
 	customer, err := c.getCustomerUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusNotFound, "not_found", "Customer not found")
+		return err
 	}
 
 	// 3. Presenter呼び出し
@@ -102,16 +105,17 @@ func (c *CustomerController) ListCustomers(ctx echo.Context, params openapi.List
 
 	// 2. UseCase呼び出し
 	command := usecase.ListCustomersCommand{
-		Limit: limit,
+		Limit:  limit,
+		Cursor: params.Cursor,
 	}
 
-	customers, err := c.listCustomersUseCase.Execute(context.Background(), command)
+	customers, nextCursor, err := c.listCustomersUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusInternalServerError, "list_failed", err.Error())
+		return err
 	}
 
 	// 3. Presenter呼び出し
-	return c.presenter.PresentCustomers(ctx, http.StatusOK, customers)
+	return c.presenter.PresentCustomers(ctx, http.StatusOK, customers, nextCursor)
 }
 
 // UpdateCustomer handles customer update
@@ -125,6 +129,9 @@ func (c *CustomerController) UpdateCustomer(ctx echo.Context, customerId string)
 	if customerId == "" {
 		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Customer ID is required")
 	}
+	if err := authorizeCustomer(ctx, customerId); err != nil {
+		return err
+	}
 
 	// 2. UseCase呼び出し
 	command := usecase.UpdateCustomerCommand{
@@ -135,7 +142,7 @@ func (c *CustomerController) UpdateCustomer(ctx echo.Context, customerId string)
 
 	customer, err := c.updateCustomerUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusInternalServerError, "update_failed", err.Error())
+		return err
 	}
 
 	// 3. Presenter呼び出し
@@ -156,7 +163,7 @@ func (c *CustomerController) DeleteCustomer(ctx echo.Context, customerId string)
 
 	err := c.deleteCustomerUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusInternalServerError, "deletion_failed", err.Error())
+		return err
 	}
 
 	// 3. レスポンス（204 No Content）
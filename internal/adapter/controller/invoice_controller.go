@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/adapter/presenter"
+	"dynamo-modeling/internal/usecase"
+)
+
+// InvoiceController handles invoice and payment requests
+type InvoiceController struct {
+	issueInvoiceUseCase         *usecase.IssueInvoiceUseCase
+	getInvoiceUseCase           *usecase.GetInvoiceUseCase
+	getOrderInvoiceUseCase      *usecase.GetOrderInvoiceUseCase
+	listCustomerInvoicesUseCase *usecase.ListCustomerInvoicesUseCase
+	recordPaymentUseCase        *usecase.RecordPaymentUseCase
+	presenter                   *presenter.InvoicePresenter
+}
+
+// NewInvoiceController creates a new invoice controller
+func NewInvoiceController(
+	issueInvoiceUseCase *usecase.IssueInvoiceUseCase,
+	getInvoiceUseCase *usecase.GetInvoiceUseCase,
+	getOrderInvoiceUseCase *usecase.GetOrderInvoiceUseCase,
+	listCustomerInvoicesUseCase *usecase.ListCustomerInvoicesUseCase,
+	recordPaymentUseCase *usecase.RecordPaymentUseCase,
+	presenter *presenter.InvoicePresenter,
+) *InvoiceController {
+	return &InvoiceController{
+		issueInvoiceUseCase:         issueInvoiceUseCase,
+		getInvoiceUseCase:           getInvoiceUseCase,
+		getOrderInvoiceUseCase:      getOrderInvoiceUseCase,
+		listCustomerInvoicesUseCase: listCustomerInvoicesUseCase,
+		recordPaymentUseCase:        recordPaymentUseCase,
+		presenter:                   presenter,
+	}
+}
+
+// IssueInvoice handles issuing the invoice for an order
+func (c *InvoiceController) IssueInvoice(ctx echo.Context, orderId string) error {
+	// 1. バリデーション
+	if orderId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Order ID is required")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.IssueInvoiceCommand{
+		OrderID: orderId,
+	}
+
+	invoice, err := c.issueInvoiceUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentInvoice(ctx, http.StatusCreated, invoice)
+}
+
+// GetOrderInvoice handles getting the invoice for an order
+func (c *InvoiceController) GetOrderInvoice(ctx echo.Context, orderId string) error {
+	// 1. バリデーション
+	if orderId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Order ID is required")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.GetOrderInvoiceCommand{
+		OrderID: orderId,
+	}
+
+	invoice, err := c.getOrderInvoiceUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentInvoice(ctx, http.StatusOK, invoice)
+}
+
+// GetInvoice handles getting an invoice by ID
+func (c *InvoiceController) GetInvoice(ctx echo.Context, invoiceId string) error {
+	// 1. バリデーション
+	if invoiceId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invoice ID is required")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.GetInvoiceCommand{
+		InvoiceID: invoiceId,
+	}
+
+	invoice, err := c.getInvoiceUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentInvoice(ctx, http.StatusOK, invoice)
+}
+
+// GetCustomerInvoices handles listing a customer's invoices in an issue date range
+func (c *InvoiceController) GetCustomerInvoices(ctx echo.Context, customerId string, params openapi.GetCustomerInvoicesParams) error {
+	// 1. パラメータバリデーション
+	if customerId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Customer ID is required")
+	}
+
+	limit := 100 // デフォルト値
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = *params.Limit
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.ListCustomerInvoicesCommand{
+		CustomerID: customerId,
+		From:       params.From,
+		To:         params.To,
+		Limit:      limit,
+		Cursor:     params.Cursor,
+	}
+
+	invoices, nextCursor, err := c.listCustomerInvoicesUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentInvoices(ctx, http.StatusOK, invoices, nextCursor)
+}
+
+// RecordPayment handles recording a payment against an invoice
+func (c *InvoiceController) RecordPayment(ctx echo.Context, invoiceId string) error {
+	// 1. リクエスト解析・バリデーション
+	var request openapi.PaymentRequest
+	if err := ctx.Bind(&request); err != nil {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invalid request body")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.RecordPaymentCommand{
+		InvoiceID: invoiceId,
+		Method:    string(request.Method),
+		Amount:    int64(request.Amount),
+	}
+
+	invoice, err := c.recordPaymentUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentInvoice(ctx, http.StatusOK, invoice)
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 
@@ -16,7 +17,10 @@ type OrderController struct {
 	createOrderUseCase       *usecase.CreateOrderUseCase
 	getOrderUseCase          *usecase.GetOrderUseCase
 	listOrdersUseCase        *usecase.ListOrdersUseCase
+	listProductOrdersUseCase *usecase.ListProductOrdersUseCase
 	updateOrderStatusUseCase *usecase.UpdateOrderStatusUseCase
+	cancelOrderUseCase       *usecase.CancelOrderUseCase
+	getOrderHistoryUseCase   *usecase.GetOrderHistoryUseCase
 	presenter                *presenter.OrderPresenter
 }
 
@@ -25,14 +29,20 @@ func NewOrderController(
 	createOrderUseCase *usecase.CreateOrderUseCase,
 	getOrderUseCase *usecase.GetOrderUseCase,
 	listOrdersUseCase *usecase.ListOrdersUseCase,
+	listProductOrdersUseCase *usecase.ListProductOrdersUseCase,
 	updateOrderStatusUseCase *usecase.UpdateOrderStatusUseCase,
+	cancelOrderUseCase *usecase.CancelOrderUseCase,
+	getOrderHistoryUseCase *usecase.GetOrderHistoryUseCase,
 	presenter *presenter.OrderPresenter,
 ) *OrderController {
 	return &OrderController{
 		createOrderUseCase:       createOrderUseCase,
 		getOrderUseCase:          getOrderUseCase,
 		listOrdersUseCase:        listOrdersUseCase,
+		listProductOrdersUseCase: listProductOrdersUseCase,
 		updateOrderStatusUseCase: updateOrderStatusUseCase,
+		cancelOrderUseCase:       cancelOrderUseCase,
+		getOrderHistoryUseCase:   getOrderHistoryUseCase,
 		presenter:                presenter,
 	}
 }
@@ -44,6 +54,9 @@ func (c *OrderController) CreateOrder(ctx echo.Context) error {
 	if err := ctx.Bind(&request); err != nil {
 		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invalid request body")
 	}
+	if err := authorizeCustomer(ctx, request.CustomerId); err != nil {
+		return err
+	}
 
 	// 2. コマンド構築
 	var items []usecase.CreateOrderItemCommand
@@ -57,12 +70,13 @@ func (c *OrderController) CreateOrder(ctx echo.Context) error {
 	command := usecase.CreateOrderCommand{
 		CustomerID: request.CustomerId,
 		Items:      items,
+		Actor:      requestActor(ctx),
 	}
 
 	// 3. UseCase呼び出し
 	order, err := c.createOrderUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusBadRequest, "creation_failed", err.Error())
+		return err
 	}
 
 	// 4. Presenter呼び出し
@@ -83,7 +97,10 @@ func (c *OrderController) GetOrder(ctx echo.Context, orderId string) error {
 
 	order, err := c.getOrderUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusNotFound, "not_found", "Order not found")
+		return err
+	}
+	if err := authorizeCustomer(ctx, order.CustomerID().String()); err != nil {
+		return err
 	}
 
 	// 3. Presenter呼び出し
@@ -107,15 +124,16 @@ func (c *OrderController) ListOrders(ctx echo.Context, params openapi.ListOrders
 	command := usecase.ListOrdersCommand{
 		CustomerID: customerID,
 		Limit:      limit,
+		Cursor:     params.Cursor,
 	}
 
-	orders, err := c.listOrdersUseCase.Execute(context.Background(), command)
+	orders, nextCursor, err := c.listOrdersUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusInternalServerError, "list_failed", err.Error())
+		return err
 	}
 
 	// 3. Presenter呼び出し
-	return c.presenter.PresentOrders(ctx, http.StatusOK, orders)
+	return c.presenter.PresentOrders(ctx, http.StatusOK, orders, nextCursor)
 }
 
 // GetCustomerOrders handles getting orders by customer ID
@@ -124,6 +142,9 @@ func (c *OrderController) GetCustomerOrders(ctx echo.Context, customerId string,
 	if customerId == "" {
 		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Customer ID is required")
 	}
+	if err := authorizeCustomer(ctx, customerId); err != nil {
+		return err
+	}
 
 	// 2. パラメータ処理
 	limit := 100 // デフォルト値
@@ -135,15 +156,47 @@ func (c *OrderController) GetCustomerOrders(ctx echo.Context, customerId string,
 	command := usecase.ListOrdersCommand{
 		CustomerID: &customerId,
 		Limit:      limit,
+		Cursor:     params.Cursor,
 	}
 
-	orders, err := c.listOrdersUseCase.Execute(context.Background(), command)
+	orders, nextCursor, err := c.listOrdersUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusInternalServerError, "list_failed", err.Error())
+		return err
 	}
 
 	// 4. Presenter呼び出し
-	return c.presenter.PresentOrders(ctx, http.StatusOK, orders)
+	return c.presenter.PresentOrders(ctx, http.StatusOK, orders, nextCursor)
+}
+
+// GetProductOrders handles getting the orders containing a product in a date range
+func (c *OrderController) GetProductOrders(ctx echo.Context, productId string, params openapi.GetProductOrdersParams) error {
+	// 1. バリデーション
+	if productId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Product ID is required")
+	}
+
+	// 2. パラメータ処理
+	limit := 100 // デフォルト値
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = *params.Limit
+	}
+
+	// 3. UseCase呼び出し
+	command := usecase.ListProductOrdersCommand{
+		ProductID: productId,
+		From:      params.From,
+		To:        params.To,
+		Limit:     limit,
+		Cursor:    params.Cursor,
+	}
+
+	orders, nextCursor, err := c.listProductOrdersUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 4. Presenter呼び出し
+	return c.presenter.PresentOrders(ctx, http.StatusOK, orders, nextCursor)
 }
 
 // UpdateOrderStatus handles order status update
@@ -162,13 +215,76 @@ func (c *OrderController) UpdateOrderStatus(ctx echo.Context, orderId string) er
 	command := usecase.UpdateOrderStatusCommand{
 		OrderID: orderId,
 		Status:  string(request.Status),
+		Actor:   requestActor(ctx),
+	}
+	if request.Reason != nil {
+		command.Reason = *request.Reason
 	}
 
 	order, err := c.updateOrderStatusUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusBadRequest, "update_failed", err.Error())
+		return err
 	}
 
 	// 3. Presenter呼び出し
 	return c.presenter.PresentOrder(ctx, http.StatusOK, order)
 }
+
+// CancelOrder handles order cancellation
+func (c *OrderController) CancelOrder(ctx echo.Context, orderId string) error {
+	// 1. リクエスト解析・バリデーション
+	var request openapi.CancelOrderRequest
+	if err := ctx.Bind(&request); err != nil {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invalid request body")
+	}
+
+	if orderId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Order ID is required")
+	}
+	if strings.TrimSpace(request.Reason) == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Reason is required")
+	}
+	if err := authorizeOrder(ctx, c.getOrderUseCase, orderId); err != nil {
+		return err
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.CancelOrderCommand{
+		OrderID: orderId,
+		Reason:  request.Reason,
+		Actor:   requestActor(ctx),
+	}
+
+	order, err := c.cancelOrderUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentOrder(ctx, http.StatusOK, order)
+}
+
+// GetOrderHistory handles getting the status history of an order
+func (c *OrderController) GetOrderHistory(ctx echo.Context, orderId string) error {
+	// 1. バリデーション
+	if orderId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Order ID is required")
+	}
+
+	if err := authorizeOrder(ctx, c.getOrderUseCase, orderId); err != nil {
+		return err
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.GetOrderHistoryCommand{
+		OrderID: orderId,
+	}
+
+	history, err := c.getOrderHistoryUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentOrderHistory(ctx, http.StatusOK, orderId, history)
+}
@@ -40,6 +40,9 @@ func NewProductController(
 	}
 }
 
+// stockNotAccepted rejects the stock field that product requests accepted before warehouses existed
+const stockNotAccepted = "stock is no longer accepted: set the product's inventory with PUT /warehouses/{warehouseId}/inventory/{productId}"
+
 // CreateProduct handles product creation
 func (c *ProductController) CreateProduct(ctx echo.Context) error {
 	// 1. リクエスト解析・バリデーション
@@ -47,18 +50,20 @@ func (c *ProductController) CreateProduct(ctx echo.Context) error {
 	if err := ctx.Bind(&request); err != nil {
 		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invalid request body")
 	}
+	if request.Stock != nil {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", stockNotAccepted)
+	}
 
 	// 2. UseCase呼び出し
 	command := usecase.CreateProductCommand{
 		Name:        request.Name,
 		Description: request.Description,
 		Price:       int64(request.Price),
-		Stock:       request.Stock,
 	}
 
 	product, err := c.createProductUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusBadRequest, "creation_failed", err.Error())
+		return err
 	}
 
 	// 3. Presenter呼び出し
@@ -79,7 +84,7 @@ func (c *ProductController) GetProduct(ctx echo.Context, productId string) error
 
 	product, err := c.getProductUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusNotFound, "not_found", "Product not found")
+		return err
 	}
 
 	// 3. Presenter呼び出し
@@ -96,16 +101,17 @@ func (c *ProductController) ListProducts(ctx echo.Context, params openapi.ListPr
 
 	// 2. UseCase呼び出し
 	command := usecase.ListProductsCommand{
-		Limit: limit,
+		Limit:  limit,
+		Cursor: params.Cursor,
 	}
 
-	products, err := c.listProductsUseCase.Execute(context.Background(), command)
+	products, nextCursor, err := c.listProductsUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusInternalServerError, "list_failed", err.Error())
+		return err
 	}
 
 	// 3. Presenter呼び出し
-	return c.presenter.PresentProducts(ctx, http.StatusOK, products)
+	return c.presenter.PresentProducts(ctx, http.StatusOK, products, nextCursor)
 }
 
 // UpdateProduct handles product update
@@ -115,6 +121,9 @@ func (c *ProductController) UpdateProduct(ctx echo.Context, productId string) er
 	if err := ctx.Bind(&request); err != nil {
 		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invalid request body")
 	}
+	if request.Stock != nil {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", stockNotAccepted)
+	}
 
 	if productId == "" {
 		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Product ID is required")
@@ -126,12 +135,11 @@ func (c *ProductController) UpdateProduct(ctx echo.Context, productId string) er
 		Name:        request.Name,
 		Description: request.Description,
 		Price:       int64(request.Price),
-		Stock:       request.Stock,
 	}
 
 	product, err := c.updateProductUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusBadRequest, "update_failed", err.Error())
+		return err
 	}
 
 	// 3. Presenter呼び出し
@@ -152,7 +160,7 @@ func (c *ProductController) DeleteProduct(ctx echo.Context, productId string) er
 
 	err := c.deleteProductUseCase.Execute(context.Background(), command)
 	if err != nil {
-		return c.presenter.PresentError(ctx, http.StatusInternalServerError, "deletion_failed", err.Error())
+		return err
 	}
 
 	// 3. 成功レスポンス（204 No Content）
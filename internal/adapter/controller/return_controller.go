@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/adapter/presenter"
+	"dynamo-modeling/internal/usecase"
+)
+
+// ReturnController handles return request requests from customers and back-office staff
+type ReturnController struct {
+	requestReturnUseCase    *usecase.RequestReturnUseCase
+	getReturnUseCase        *usecase.GetReturnUseCase
+	listOrderReturnsUseCase *usecase.ListOrderReturnsUseCase
+	listReturnsUseCase      *usecase.ListReturnsUseCase
+	approveReturnUseCase    *usecase.ApproveReturnUseCase
+	rejectReturnUseCase     *usecase.RejectReturnUseCase
+	getOrderUseCase         *usecase.GetOrderUseCase // checks who owns the order of a return
+	presenter               *presenter.ReturnPresenter
+}
+
+// NewReturnController creates a new return controller
+func NewReturnController(
+	requestReturnUseCase *usecase.RequestReturnUseCase,
+	getReturnUseCase *usecase.GetReturnUseCase,
+	listOrderReturnsUseCase *usecase.ListOrderReturnsUseCase,
+	listReturnsUseCase *usecase.ListReturnsUseCase,
+	approveReturnUseCase *usecase.ApproveReturnUseCase,
+	rejectReturnUseCase *usecase.RejectReturnUseCase,
+	getOrderUseCase *usecase.GetOrderUseCase,
+	presenter *presenter.ReturnPresenter,
+) *ReturnController {
+	return &ReturnController{
+		requestReturnUseCase:    requestReturnUseCase,
+		getReturnUseCase:        getReturnUseCase,
+		listOrderReturnsUseCase: listOrderReturnsUseCase,
+		listReturnsUseCase:      listReturnsUseCase,
+		approveReturnUseCase:    approveReturnUseCase,
+		rejectReturnUseCase:     rejectReturnUseCase,
+		getOrderUseCase:         getOrderUseCase,
+		presenter:               presenter,
+	}
+}
+
+// RequestReturn handles a customer asking to send back lines of a delivered order
+func (c *ReturnController) RequestReturn(ctx echo.Context, orderId string) error {
+	// 1. リクエスト解析・バリデーション
+	var request openapi.ReturnRequest
+	if err := ctx.Bind(&request); err != nil {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invalid request body")
+	}
+
+	if orderId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Order ID is required")
+	}
+	if strings.TrimSpace(request.Reason) == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Reason is required")
+	}
+	if err := authorizeOrder(ctx, c.getOrderUseCase, orderId); err != nil {
+		return err
+	}
+
+	// 2. UseCase呼び出し
+	items := make([]usecase.ReturnItemCommand, len(request.Items))
+	for i, item := range request.Items {
+		items[i] = usecase.ReturnItemCommand{
+			ProductID: item.ProductId,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	command := usecase.RequestReturnCommand{
+		OrderID: orderId,
+		Items:   items,
+		Reason:  request.Reason,
+	}
+
+	returnRequest, err := c.requestReturnUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentReturn(ctx, http.StatusCreated, returnRequest)
+}
+
+// ListOrderReturns handles listing the return requests of an order
+func (c *ReturnController) ListOrderReturns(ctx echo.Context, orderId string) error {
+	// 1. バリデーション
+	if orderId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Order ID is required")
+	}
+	if err := authorizeOrder(ctx, c.getOrderUseCase, orderId); err != nil {
+		return err
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.ListOrderReturnsCommand{
+		OrderID: orderId,
+	}
+
+	requests, err := c.listOrderReturnsUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentReturns(ctx, http.StatusOK, requests, nil)
+}
+
+// ListReturns handles listing return requests by status for back-office review
+func (c *ReturnController) ListReturns(ctx echo.Context, params openapi.ListReturnsParams) error {
+	// 1. パラメータバリデーション
+	limit := 100 // デフォルト値
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = *params.Limit
+	}
+
+	status := ""
+	if params.Status != nil {
+		status = string(*params.Status)
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.ListReturnsCommand{
+		Status: status,
+		Limit:  limit,
+		Cursor: params.Cursor,
+	}
+
+	requests, nextCursor, err := c.listReturnsUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentReturns(ctx, http.StatusOK, requests, nextCursor)
+}
+
+// GetReturn handles getting a return request by ID
+func (c *ReturnController) GetReturn(ctx echo.Context, returnId string) error {
+	// 1. バリデーション
+	if returnId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Return ID is required")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.GetReturnCommand{
+		ReturnID: returnId,
+	}
+
+	returnRequest, err := c.getReturnUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+	if err := authorizeOrder(ctx, c.getOrderUseCase, returnRequest.OrderID().String()); err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentReturn(ctx, http.StatusOK, returnRequest)
+}
+
+// ApproveReturn handles approving a return, which refunds and restocks the returned lines
+func (c *ReturnController) ApproveReturn(ctx echo.Context, returnId string) error {
+	// 1. リクエスト解析・バリデーション
+	var request openapi.ReturnApprovalRequest
+	if err := ctx.Bind(&request); err != nil {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invalid request body")
+	}
+
+	if returnId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Return ID is required")
+	}
+	if request.WarehouseId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Warehouse ID is required")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.ApproveReturnCommand{
+		ReturnID:    returnId,
+		WarehouseID: request.WarehouseId,
+		Actor:       requestActor(ctx),
+	}
+	if request.Note != nil {
+		command.Note = *request.Note
+	}
+
+	returnRequest, err := c.approveReturnUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentReturn(ctx, http.StatusOK, returnRequest)
+}
+
+// RejectReturn handles rejecting a return
+func (c *ReturnController) RejectReturn(ctx echo.Context, returnId string) error {
+	// 1. リクエスト解析・バリデーション
+	var request openapi.ReturnRejectionRequest
+	if err := ctx.Bind(&request); err != nil {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invalid request body")
+	}
+
+	if returnId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Return ID is required")
+	}
+	if strings.TrimSpace(request.Note) == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Note is required")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.RejectReturnCommand{
+		ReturnID: returnId,
+		Note:     request.Note,
+	}
+
+	returnRequest, err := c.rejectReturnUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentReturn(ctx, http.StatusOK, returnRequest)
+}
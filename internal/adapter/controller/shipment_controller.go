@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/adapter/presenter"
+	"dynamo-modeling/internal/usecase"
+)
+
+// ShipmentController handles shipment requests
+type ShipmentController struct {
+	createShipmentUseCase         *usecase.CreateShipmentUseCase
+	getShipmentUseCase            *usecase.GetShipmentUseCase
+	listOrderShipmentsUseCase     *usecase.ListOrderShipmentsUseCase
+	listWarehouseShipmentsUseCase *usecase.ListWarehouseShipmentsUseCase
+	deliverShipmentUseCase        *usecase.DeliverShipmentUseCase
+	presenter                     *presenter.ShipmentPresenter
+}
+
+// NewShipmentController creates a new shipment controller
+func NewShipmentController(
+	createShipmentUseCase *usecase.CreateShipmentUseCase,
+	getShipmentUseCase *usecase.GetShipmentUseCase,
+	listOrderShipmentsUseCase *usecase.ListOrderShipmentsUseCase,
+	listWarehouseShipmentsUseCase *usecase.ListWarehouseShipmentsUseCase,
+	deliverShipmentUseCase *usecase.DeliverShipmentUseCase,
+	presenter *presenter.ShipmentPresenter,
+) *ShipmentController {
+	return &ShipmentController{
+		createShipmentUseCase:         createShipmentUseCase,
+		getShipmentUseCase:            getShipmentUseCase,
+		listOrderShipmentsUseCase:     listOrderShipmentsUseCase,
+		listWarehouseShipmentsUseCase: listWarehouseShipmentsUseCase,
+		deliverShipmentUseCase:        deliverShipmentUseCase,
+		presenter:                     presenter,
+	}
+}
+
+// CreateShipment handles shipping order items from a warehouse
+func (c *ShipmentController) CreateShipment(ctx echo.Context, orderId string) error {
+	// 1. リクエスト解析・バリデーション
+	var request openapi.ShipmentRequest
+	if err := ctx.Bind(&request); err != nil {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invalid request body")
+	}
+
+	// 2. UseCase呼び出し
+	items := make([]usecase.ShipmentItemCommand, len(request.Items))
+	for i, item := range request.Items {
+		items[i] = usecase.ShipmentItemCommand{
+			ProductID: item.ProductId,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	command := usecase.CreateShipmentCommand{
+		OrderID:        orderId,
+		WarehouseID:    request.WarehouseId,
+		Carrier:        request.Carrier,
+		TrackingNumber: request.TrackingNumber,
+		Items:          items,
+		Actor:          requestActor(ctx),
+	}
+
+	shipment, err := c.createShipmentUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentShipment(ctx, http.StatusCreated, shipment)
+}
+
+// ListOrderShipments handles listing the shipments of an order
+func (c *ShipmentController) ListOrderShipments(ctx echo.Context, orderId string) error {
+	// 1. バリデーション
+	if orderId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Order ID is required")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.ListOrderShipmentsCommand{
+		OrderID: orderId,
+	}
+
+	shipments, err := c.listOrderShipmentsUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentShipments(ctx, http.StatusOK, shipments, nil)
+}
+
+// GetShipment handles getting a shipment by ID
+func (c *ShipmentController) GetShipment(ctx echo.Context, shipmentId string) error {
+	// 1. バリデーション
+	if shipmentId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Shipment ID is required")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.GetShipmentCommand{
+		ShipmentID: shipmentId,
+	}
+
+	shipment, err := c.getShipmentUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentShipment(ctx, http.StatusOK, shipment)
+}
+
+// DeliverShipment handles marking a shipment as delivered
+func (c *ShipmentController) DeliverShipment(ctx echo.Context, shipmentId string) error {
+	// 1. バリデーション
+	if shipmentId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Shipment ID is required")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.DeliverShipmentCommand{
+		ShipmentID: shipmentId,
+		Actor:      requestActor(ctx),
+	}
+
+	shipment, err := c.deliverShipmentUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentShipment(ctx, http.StatusOK, shipment)
+}
+
+// ListWarehouseShipments handles listing the shipments sent from a warehouse
+func (c *ShipmentController) ListWarehouseShipments(ctx echo.Context, warehouseId string, params openapi.ListWarehouseShipmentsParams) error {
+	// 1. パラメータバリデーション
+	if warehouseId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Warehouse ID is required")
+	}
+
+	limit := 100 // デフォルト値
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = *params.Limit
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.ListWarehouseShipmentsCommand{
+		WarehouseID: warehouseId,
+		Limit:       limit,
+		Cursor:      params.Cursor,
+	}
+
+	shipments, nextCursor, err := c.listWarehouseShipmentsUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentShipments(ctx, http.StatusOK, shipments, nextCursor)
+}
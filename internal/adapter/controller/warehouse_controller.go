@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/adapter/presenter"
+	"dynamo-modeling/internal/usecase"
+)
+
+// WarehouseController handles warehouse and inventory requests
+type WarehouseController struct {
+	createWarehouseUseCase        *usecase.CreateWarehouseUseCase
+	getWarehouseUseCase           *usecase.GetWarehouseUseCase
+	setInventoryUseCase           *usecase.SetInventoryUseCase
+	listWarehouseInventoryUseCase *usecase.ListWarehouseInventoryUseCase
+	getProductInventoryUseCase    *usecase.GetProductInventoryUseCase
+	presenter                     *presenter.WarehousePresenter
+}
+
+// NewWarehouseController creates a new warehouse controller
+func NewWarehouseController(
+	createWarehouseUseCase *usecase.CreateWarehouseUseCase,
+	getWarehouseUseCase *usecase.GetWarehouseUseCase,
+	setInventoryUseCase *usecase.SetInventoryUseCase,
+	listWarehouseInventoryUseCase *usecase.ListWarehouseInventoryUseCase,
+	getProductInventoryUseCase *usecase.GetProductInventoryUseCase,
+	presenter *presenter.WarehousePresenter,
+) *WarehouseController {
+	return &WarehouseController{
+		createWarehouseUseCase:        createWarehouseUseCase,
+		getWarehouseUseCase:           getWarehouseUseCase,
+		setInventoryUseCase:           setInventoryUseCase,
+		listWarehouseInventoryUseCase: listWarehouseInventoryUseCase,
+		getProductInventoryUseCase:    getProductInventoryUseCase,
+		presenter:                     presenter,
+	}
+}
+
+// CreateWarehouse handles warehouse creation
+func (c *WarehouseController) CreateWarehouse(ctx echo.Context) error {
+	// 1. リクエスト解析・バリデーション
+	var request openapi.WarehouseRequest
+	if err := ctx.Bind(&request); err != nil {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invalid request body")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.CreateWarehouseCommand{
+		Name:     request.Name,
+		Location: request.Location,
+	}
+
+	warehouse, err := c.createWarehouseUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentWarehouse(ctx, http.StatusCreated, warehouse)
+}
+
+// GetWarehouse handles getting a warehouse by ID
+func (c *WarehouseController) GetWarehouse(ctx echo.Context, warehouseId string) error {
+	// 1. バリデーション
+	if warehouseId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Warehouse ID is required")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.GetWarehouseCommand{
+		WarehouseID: warehouseId,
+	}
+
+	warehouse, err := c.getWarehouseUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentWarehouse(ctx, http.StatusOK, warehouse)
+}
+
+// ListWarehouseInventory handles listing the inventory held in a warehouse
+func (c *WarehouseController) ListWarehouseInventory(ctx echo.Context, warehouseId string, params openapi.ListWarehouseInventoryParams) error {
+	// 1. パラメータバリデーション
+	if warehouseId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Warehouse ID is required")
+	}
+
+	limit := 100 // デフォルト値
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = *params.Limit
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.ListWarehouseInventoryCommand{
+		WarehouseID: warehouseId,
+		Limit:       limit,
+		Cursor:      params.Cursor,
+	}
+
+	inventories, nextCursor, err := c.listWarehouseInventoryUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentInventories(ctx, http.StatusOK, inventories, nextCursor)
+}
+
+// SetInventory handles setting a product's quantity in a warehouse
+func (c *WarehouseController) SetInventory(ctx echo.Context, warehouseId string, productId string) error {
+	// 1. リクエスト解析・バリデーション
+	var request openapi.InventoryRequest
+	if err := ctx.Bind(&request); err != nil {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Invalid request body")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.SetInventoryCommand{
+		WarehouseID: warehouseId,
+		ProductID:   productId,
+		Quantity:    request.Quantity,
+	}
+
+	inventory, err := c.setInventoryUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentInventory(ctx, http.StatusOK, inventory)
+}
+
+// GetProductInventory handles getting a product's inventory across all warehouses
+func (c *WarehouseController) GetProductInventory(ctx echo.Context, productId string) error {
+	// 1. バリデーション
+	if productId == "" {
+		return c.presenter.PresentError(ctx, http.StatusBadRequest, "validation_error", "Product ID is required")
+	}
+
+	// 2. UseCase呼び出し
+	command := usecase.GetProductInventoryCommand{
+		ProductID: productId,
+	}
+
+	inventories, err := c.getProductInventoryUseCase.Execute(context.Background(), command)
+	if err != nil {
+		return err
+	}
+
+	// 3. Presenter呼び出し
+	return c.presenter.PresentInventories(ctx, http.StatusOK, inventories, nil)
+}
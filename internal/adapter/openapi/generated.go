@@ -24,13 +24,49 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for InvoiceResponseStatus.
+const (
+	InvoiceResponseStatusIssued        InvoiceResponseStatus = "issued"
+	InvoiceResponseStatusPaid          InvoiceResponseStatus = "paid"
+	InvoiceResponseStatusPartiallyPaid InvoiceResponseStatus = "partially_paid"
+)
+
+// Defines values for ListReturnsParamsStatus.
+const (
+	ListReturnsParamsStatusApproved  ListReturnsParamsStatus = "approved"
+	ListReturnsParamsStatusRejected  ListReturnsParamsStatus = "rejected"
+	ListReturnsParamsStatusRequested ListReturnsParamsStatus = "requested"
+)
+
 // Defines values for OrderResponseStatus.
 const (
-	OrderResponseStatusCancelled OrderResponseStatus = "cancelled"
-	OrderResponseStatusConfirmed OrderResponseStatus = "confirmed"
-	OrderResponseStatusDelivered OrderResponseStatus = "delivered"
-	OrderResponseStatusPending   OrderResponseStatus = "pending"
-	OrderResponseStatusShipped   OrderResponseStatus = "shipped"
+	OrderResponseStatusCancelled         OrderResponseStatus = "cancelled"
+	OrderResponseStatusConfirmed         OrderResponseStatus = "confirmed"
+	OrderResponseStatusDelivered         OrderResponseStatus = "delivered"
+	OrderResponseStatusPartiallyRefunded OrderResponseStatus = "partially_refunded"
+	OrderResponseStatusPending           OrderResponseStatus = "pending"
+	OrderResponseStatusReturned          OrderResponseStatus = "returned"
+	OrderResponseStatusShipped           OrderResponseStatus = "shipped"
+)
+
+// Defines values for PaymentMethod.
+const (
+	PaymentMethodCreditCard   PaymentMethod = "credit_card"
+	PaymentMethodDiscountCode PaymentMethod = "discount_code"
+	PaymentMethodGiftCard     PaymentMethod = "gift_card"
+)
+
+// Defines values for ReturnResponseStatus.
+const (
+	ReturnResponseStatusApproved  ReturnResponseStatus = "approved"
+	ReturnResponseStatusRejected  ReturnResponseStatus = "rejected"
+	ReturnResponseStatusRequested ReturnResponseStatus = "requested"
+)
+
+// Defines values for ShipmentResponseStatus.
+const (
+	ShipmentResponseStatusDelivered ShipmentResponseStatus = "delivered"
+	ShipmentResponseStatusInTransit ShipmentResponseStatus = "in_transit"
 )
 
 // Defines values for UpdateOrderStatusJSONBodyStatus.
@@ -42,6 +78,20 @@ const (
 	UpdateOrderStatusJSONBodyStatusShipped   UpdateOrderStatusJSONBodyStatus = "shipped"
 )
 
+// CancelOrderRequest defines model for CancelOrderRequest.
+type CancelOrderRequest struct {
+	// Reason Why the order is cancelled, kept in the status history
+	Reason string `json:"reason"`
+}
+
+// CustomerListResponse defines model for CustomerListResponse.
+type CustomerListResponse struct {
+	Customers []CustomerResponse `json:"customers"`
+
+	// NextCursor Cursor for the next page of customers; omitted on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
 // CustomerRequest defines model for CustomerRequest.
 type CustomerRequest struct {
 	// Email Customer email address (must be unique)
@@ -81,6 +131,115 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// InventoryListResponse defines model for InventoryListResponse.
+type InventoryListResponse struct {
+	Inventory []InventoryResponse `json:"inventory"`
+
+	// NextCursor Cursor for the next page of inventory; omitted on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// InventoryRequest defines model for InventoryRequest.
+type InventoryRequest struct {
+	// Quantity Quantity of the product held in the warehouse
+	Quantity int `json:"quantity"`
+}
+
+// InventoryResponse defines model for InventoryResponse.
+type InventoryResponse struct {
+	// ProductId Product unique identifier
+	ProductId string `json:"product_id"`
+
+	// Quantity Quantity of the product held in the warehouse
+	Quantity int `json:"quantity"`
+
+	// UpdatedAt Inventory last update timestamp
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// WarehouseId Warehouse unique identifier
+	WarehouseId string `json:"warehouse_id"`
+}
+
+// InvoiceLineResponse defines model for InvoiceLineResponse.
+type InvoiceLineResponse struct {
+	// ProductId Product unique identifier
+	ProductId string `json:"product_id"`
+
+	// Quantity Billed quantity
+	Quantity int `json:"quantity"`
+
+	// Total Line total in cents
+	Total int `json:"total"`
+
+	// UnitPrice Unit price in cents
+	UnitPrice int `json:"unit_price"`
+}
+
+// InvoiceListResponse defines model for InvoiceListResponse.
+type InvoiceListResponse struct {
+	Invoices []InvoiceResponse `json:"invoices"`
+
+	// NextCursor Cursor for the next page of invoices; omitted on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// InvoiceResponse defines model for InvoiceResponse.
+type InvoiceResponse struct {
+	// AmountPaid Total of the payments made so far, in cents
+	AmountPaid int `json:"amount_paid"`
+
+	// Balance Amount still to be paid, in cents
+	Balance int `json:"balance"`
+
+	// CustomerId Invoiced customer identifier
+	CustomerId string `json:"customer_id"`
+
+	// Id Invoice unique identifier
+	Id string `json:"id"`
+
+	// IssuedAt Invoice issue timestamp
+	IssuedAt time.Time `json:"issued_at"`
+
+	// Lines Billed order lines
+	Lines []InvoiceLineResponse `json:"lines"`
+
+	// OrderId Invoiced order identifier
+	OrderId string `json:"order_id"`
+
+	// Payments Payments keyed by payment ID
+	Payments map[string]PaymentResponse `json:"payments"`
+
+	// Status Invoice payment status
+	Status InvoiceResponseStatus `json:"status"`
+
+	// Subtotal Total of the lines before tax, in cents
+	Subtotal int `json:"subtotal"`
+
+	// Tax Tax amount in cents
+	Tax int `json:"tax"`
+
+	// TaxRate Tax rate in percent
+	TaxRate int `json:"tax_rate"`
+
+	// Total Amount billed, tax included, in cents
+	Total int `json:"total"`
+
+	// UpdatedAt Invoice last update timestamp
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// InvoiceResponseStatus Invoice payment status
+type InvoiceResponseStatus string
+
+// OrderHistoryResponse defines model for OrderHistoryResponse.
+type OrderHistoryResponse struct {
+	// Entries Status changes, oldest first
+	Entries []OrderStatusChangeResponse `json:"entries"`
+
+	// OrderId Order unique identifier
+	OrderId string `json:"order_id"`
+}
+
 // OrderItemRequest defines model for OrderItemRequest.
 type OrderItemRequest struct {
 	// ProductId Product unique identifier
@@ -105,12 +264,20 @@ type OrderItemResponse struct {
 	UnitPrice int `json:"unit_price"`
 }
 
+// OrderListResponse defines model for OrderListResponse.
+type OrderListResponse struct {
+	Orders []OrderResponse `json:"orders"`
+
+	// NextCursor Cursor for the next page of orders; omitted on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
 // OrderRequest defines model for OrderRequest.
 type OrderRequest struct {
 	// CustomerId Customer unique identifier
 	CustomerId string `json:"customer_id"`
 
-	// Items Order items
+	// Items Order items. An order has at most 20 lines; larger orders are rejected with 400.
 	Items []OrderItemRequest `json:"items"`
 }
 
@@ -128,7 +295,7 @@ type OrderResponse struct {
 	// Items Order items
 	Items []OrderItemResponse `json:"items"`
 
-	// Status Order status
+	// Status Order status; partially_refunded and returned are set by approving returns
 	Status OrderResponseStatus `json:"status"`
 
 	// TotalAmount Total order amount in cents
@@ -138,9 +305,59 @@ type OrderResponse struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
-// OrderResponseStatus Order status
+// OrderResponseStatus Order status; partially_refunded and returned are set by approving returns
 type OrderResponseStatus string
 
+// OrderStatusChangeResponse defines model for OrderStatusChangeResponse.
+type OrderStatusChangeResponse struct {
+	// Actor Who made the change; "system" for changes made by the application itself
+	Actor string `json:"actor"`
+
+	// ChangedAt Time of the change
+	ChangedAt time.Time `json:"changed_at"`
+
+	// From Previous status, omitted for the entry of the placed order
+	From *string `json:"from,omitempty"`
+
+	// Reason Why the change was made
+	Reason string `json:"reason"`
+
+	// To New status
+	To string `json:"to"`
+}
+
+// PaymentMethod How a payment was made
+type PaymentMethod string
+
+// PaymentRequest defines model for PaymentRequest.
+type PaymentRequest struct {
+	// Amount Payment amount in cents; must not exceed the outstanding balance
+	Amount int `json:"amount"`
+
+	// Method How a payment was made
+	Method PaymentMethod `json:"method"`
+}
+
+// PaymentResponse defines model for PaymentResponse.
+type PaymentResponse struct {
+	// Amount Payment amount in cents
+	Amount int `json:"amount"`
+
+	// Method How a payment was made
+	Method PaymentMethod `json:"method"`
+
+	// PaidAt Payment timestamp
+	PaidAt time.Time `json:"paid_at"`
+}
+
+// ProductListResponse defines model for ProductListResponse.
+type ProductListResponse struct {
+	Products []ProductResponse `json:"products"`
+
+	// NextCursor Cursor for the next page of products; omitted on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
 // ProductRequest defines model for ProductRequest.
 type ProductRequest struct {
 	// Description Product description
@@ -152,8 +369,10 @@ type ProductRequest struct {
 	// Price Product price in cents (e.g., 1999 = $19.99)
 	Price int `json:"price"`
 
-	// Stock Available stock quantity
-	Stock int `json:"stock"`
+	// Stock No longer accepted: requests that set it are rejected with 400. Stock is the total of the
+	// product's warehouse inventory, set with PUT /warehouses/{warehouseId}/inventory/{productId}.
+	// Deprecated: this property has been marked as deprecated upstream, but no `x-deprecated-reason` was set
+	Stock *int `json:"stock,omitempty"`
 }
 
 // ProductResponse defines model for ProductResponse.
@@ -173,13 +392,188 @@ type ProductResponse struct {
 	// Price Product price in cents
 	Price int `json:"price"`
 
-	// Stock Available stock quantity
+	// Stock Available stock quantity, the total of the product's inventory across all warehouses
 	Stock int `json:"stock"`
 
 	// UpdatedAt Product last update timestamp
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// ReturnApprovalRequest defines model for ReturnApprovalRequest.
+type ReturnApprovalRequest struct {
+	// Note Staff note on the decision
+	Note *string `json:"note,omitempty"`
+
+	// WarehouseId Warehouse receiving the returned goods
+	WarehouseId string `json:"warehouse_id"`
+}
+
+// ReturnItemRequest defines model for ReturnItemRequest.
+type ReturnItemRequest struct {
+	// ProductId Ordered product unique identifier
+	ProductId string `json:"product_id"`
+
+	// Quantity Quantity sent back
+	Quantity int `json:"quantity"`
+}
+
+// ReturnItemResponse defines model for ReturnItemResponse.
+type ReturnItemResponse struct {
+	// ProductId Product unique identifier
+	ProductId string `json:"product_id"`
+
+	// Quantity Quantity sent back
+	Quantity int `json:"quantity"`
+
+	// TotalPrice Amount refunded for this item in cents once approved
+	TotalPrice int `json:"total_price"`
+
+	// UnitPrice Price paid per unit in cents, taken from the order
+	UnitPrice int `json:"unit_price"`
+}
+
+// ReturnListResponse defines model for ReturnListResponse.
+type ReturnListResponse struct {
+	Returns []ReturnResponse `json:"returns"`
+
+	// NextCursor Cursor for the next page of return requests; omitted on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// ReturnRejectionRequest defines model for ReturnRejectionRequest.
+type ReturnRejectionRequest struct {
+	// Note Why the return is refused, shown to the customer
+	Note string `json:"note"`
+}
+
+// ReturnRequest defines model for ReturnRequest.
+type ReturnRequest struct {
+	// Items Delivered products to send back; together with earlier requests that were not rejected they must not exceed the order
+	Items []ReturnItemRequest `json:"items"`
+
+	// Reason Why the goods are sent back
+	Reason string `json:"reason"`
+}
+
+// ReturnResponse defines model for ReturnResponse.
+type ReturnResponse struct {
+	// CustomerId Customer who placed the order
+	CustomerId string `json:"customer_id"`
+
+	// DecidedAt Decision timestamp; omitted until approved or rejected
+	DecidedAt *time.Time `json:"decided_at,omitempty"`
+
+	// Id Return request unique identifier
+	Id string `json:"id"`
+
+	// Items Returned products
+	Items []ReturnItemResponse `json:"items"`
+
+	// Note Staff note on the decision; omitted until decided
+	Note *string `json:"note,omitempty"`
+
+	// OrderId Returned order identifier
+	OrderId string `json:"order_id"`
+
+	// Reason Why the goods are sent back
+	Reason string `json:"reason"`
+
+	// RefundAmount Refunded amount in cents; 0 until approved
+	RefundAmount int `json:"refund_amount"`
+
+	// RequestedAt Request timestamp
+	RequestedAt time.Time `json:"requested_at"`
+
+	// Status Return decision status
+	Status ReturnResponseStatus `json:"status"`
+
+	// UpdatedAt Return request last update timestamp
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// WarehouseId Warehouse receiving the goods; omitted until approved
+	WarehouseId *string `json:"warehouse_id,omitempty"`
+}
+
+// ReturnResponseStatus Return decision status
+type ReturnResponseStatus string
+
+// ShipmentItemRequest defines model for ShipmentItemRequest.
+type ShipmentItemRequest struct {
+	// ProductId Ordered product unique identifier
+	ProductId string `json:"product_id"`
+
+	// Quantity Quantity shipped
+	Quantity int `json:"quantity"`
+}
+
+// ShipmentItemResponse defines model for ShipmentItemResponse.
+type ShipmentItemResponse struct {
+	// ProductId Product unique identifier
+	ProductId string `json:"product_id"`
+
+	// Quantity Quantity shipped
+	Quantity int `json:"quantity"`
+}
+
+// ShipmentListResponse defines model for ShipmentListResponse.
+type ShipmentListResponse struct {
+	Shipments []ShipmentResponse `json:"shipments"`
+
+	// NextCursor Cursor for the next page of shipments; omitted on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// ShipmentRequest defines model for ShipmentRequest.
+type ShipmentRequest struct {
+	// Carrier Carrier name
+	Carrier string `json:"carrier"`
+
+	// Items Ordered products in the shipment; together with earlier shipments they must not exceed the order
+	Items []ShipmentItemRequest `json:"items"`
+
+	// TrackingNumber Carrier tracking number
+	TrackingNumber string `json:"tracking_number"`
+
+	// WarehouseId Warehouse the shipment leaves from
+	WarehouseId string `json:"warehouse_id"`
+}
+
+// ShipmentResponse defines model for ShipmentResponse.
+type ShipmentResponse struct {
+	// Carrier Carrier name
+	Carrier string `json:"carrier"`
+
+	// DeliveredAt Delivery timestamp; omitted until delivered
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+
+	// Id Shipment unique identifier
+	Id string `json:"id"`
+
+	// Items Shipped products
+	Items []ShipmentItemResponse `json:"items"`
+
+	// OrderId Shipped order identifier
+	OrderId string `json:"order_id"`
+
+	// ShippedAt Shipping timestamp
+	ShippedAt time.Time `json:"shipped_at"`
+
+	// Status Shipment delivery status
+	Status ShipmentResponseStatus `json:"status"`
+
+	// TrackingNumber Carrier tracking number
+	TrackingNumber string `json:"tracking_number"`
+
+	// UpdatedAt Shipment last update timestamp
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// WarehouseId Warehouse the shipment left from
+	WarehouseId string `json:"warehouse_id"`
+}
+
+// ShipmentResponseStatus Shipment delivery status
+type ShipmentResponseStatus string
+
 // ValidationError defines model for ValidationError.
 type ValidationError struct {
 	Code             string `json:"code"`
@@ -193,13 +587,61 @@ type ValidationError struct {
 	} `json:"validation_errors"`
 }
 
+// WarehouseRequest defines model for WarehouseRequest.
+type WarehouseRequest struct {
+	// Location Warehouse location
+	Location string `json:"location"`
+
+	// Name Warehouse name
+	Name string `json:"name"`
+}
+
+// WarehouseResponse defines model for WarehouseResponse.
+type WarehouseResponse struct {
+	// CreatedAt Warehouse creation timestamp
+	CreatedAt time.Time `json:"created_at"`
+
+	// Id Warehouse unique identifier
+	Id string `json:"id"`
+
+	// Location Warehouse location
+	Location string `json:"location"`
+
+	// Name Warehouse name
+	Name string `json:"name"`
+
+	// UpdatedAt Warehouse last update timestamp
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 // ListCustomersParams defines parameters for ListCustomers.
 type ListCustomersParams struct {
 	// Limit Maximum number of customers to return
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
-	// Offset Number of customers to skip
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	// Cursor Opaque cursor returned as next_cursor by the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// CreateCustomerParams defines parameters for CreateCustomer.
+type CreateCustomerParams struct {
+	// IdempotencyKey Key that makes a retry of this request safe. A repeat with the same body within 24 hours gets the stored response instead of running again.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// GetCustomerInvoicesParams defines parameters for GetCustomerInvoices.
+type GetCustomerInvoicesParams struct {
+	// From Earliest issue time to include; omit for no lower bound
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Latest issue time to include; omit for no upper bound
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit Maximum number of invoices to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor returned as next_cursor by the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // GetCustomerOrdersParams defines parameters for GetCustomerOrders.
@@ -207,8 +649,8 @@ type GetCustomerOrdersParams struct {
 	// Limit Maximum number of orders to return
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
-	// Offset Number of orders to skip
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	// Cursor Opaque cursor returned as next_cursor by the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // ListOrdersParams defines parameters for ListOrders.
@@ -219,12 +661,21 @@ type ListOrdersParams struct {
 	// Limit Maximum number of orders to return
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
-	// Offset Number of orders to skip
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	// Cursor Opaque cursor returned as next_cursor by the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// CreateOrderParams defines parameters for CreateOrder.
+type CreateOrderParams struct {
+	// IdempotencyKey Key that makes a retry of this request safe. A repeat with the same body within 24 hours gets the stored response instead of running again.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
 }
 
 // UpdateOrderStatusJSONBody defines parameters for UpdateOrderStatus.
 type UpdateOrderStatusJSONBody struct {
+	// Reason Why the status is changed, kept in the status history
+	Reason *string `json:"reason,omitempty"`
+
 	// Status New order status
 	Status UpdateOrderStatusJSONBodyStatus `json:"status"`
 }
@@ -237,8 +688,62 @@ type ListProductsParams struct {
 	// Limit Maximum number of products to return
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
-	// Offset Number of products to skip
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	// Cursor Opaque cursor returned as next_cursor by the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// CreateProductParams defines parameters for CreateProduct.
+type CreateProductParams struct {
+	// IdempotencyKey Key that makes a retry of this request safe. A repeat with the same body within 24 hours gets the stored response instead of running again.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// GetProductOrdersParams defines parameters for GetProductOrders.
+type GetProductOrdersParams struct {
+	// From Earliest order time to include; omit for no lower bound
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Latest order time to include; omit for no upper bound
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit Maximum number of orders to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor returned as next_cursor by the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListReturnsParams defines parameters for ListReturns.
+type ListReturnsParams struct {
+	// Status Return status to list
+	Status *ListReturnsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Limit Maximum number of return requests to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor returned as next_cursor by the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListReturnsParamsStatus defines parameters for ListReturns.
+type ListReturnsParamsStatus string
+
+// ListWarehouseInventoryParams defines parameters for ListWarehouseInventory.
+type ListWarehouseInventoryParams struct {
+	// Limit Maximum number of inventory entries to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor returned as next_cursor by the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListWarehouseShipmentsParams defines parameters for ListWarehouseShipments.
+type ListWarehouseShipmentsParams struct {
+	// Limit Maximum number of shipments to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor returned as next_cursor by the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // CreateCustomerJSONRequestBody defines body for CreateCustomer for application/json ContentType.
@@ -247,18 +752,42 @@ type CreateCustomerJSONRequestBody = CustomerRequest
 // UpdateCustomerJSONRequestBody defines body for UpdateCustomer for application/json ContentType.
 type UpdateCustomerJSONRequestBody = CustomerRequest
 
+// RecordPaymentJSONRequestBody defines body for RecordPayment for application/json ContentType.
+type RecordPaymentJSONRequestBody = PaymentRequest
+
 // CreateOrderJSONRequestBody defines body for CreateOrder for application/json ContentType.
 type CreateOrderJSONRequestBody = OrderRequest
 
 // UpdateOrderStatusJSONRequestBody defines body for UpdateOrderStatus for application/json ContentType.
 type UpdateOrderStatusJSONRequestBody UpdateOrderStatusJSONBody
 
+// CancelOrderJSONRequestBody defines body for CancelOrder for application/json ContentType.
+type CancelOrderJSONRequestBody = CancelOrderRequest
+
+// RequestReturnJSONRequestBody defines body for RequestReturn for application/json ContentType.
+type RequestReturnJSONRequestBody = ReturnRequest
+
+// CreateShipmentJSONRequestBody defines body for CreateShipment for application/json ContentType.
+type CreateShipmentJSONRequestBody = ShipmentRequest
+
 // CreateProductJSONRequestBody defines body for CreateProduct for application/json ContentType.
 type CreateProductJSONRequestBody = ProductRequest
 
 // UpdateProductJSONRequestBody defines body for UpdateProduct for application/json ContentType.
 type UpdateProductJSONRequestBody = ProductRequest
 
+// ApproveReturnJSONRequestBody defines body for ApproveReturn for application/json ContentType.
+type ApproveReturnJSONRequestBody = ReturnApprovalRequest
+
+// RejectReturnJSONRequestBody defines body for RejectReturn for application/json ContentType.
+type RejectReturnJSONRequestBody = ReturnRejectionRequest
+
+// CreateWarehouseJSONRequestBody defines body for CreateWarehouse for application/json ContentType.
+type CreateWarehouseJSONRequestBody = WarehouseRequest
+
+// SetInventoryJSONRequestBody defines body for SetInventory for application/json ContentType.
+type SetInventoryJSONRequestBody = InventoryRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// List all customers
@@ -266,7 +795,7 @@ type ServerInterface interface {
 	ListCustomers(ctx echo.Context, params ListCustomersParams) error
 	// Create a new customer
 	// (POST /customers)
-	CreateCustomer(ctx echo.Context) error
+	CreateCustomer(ctx echo.Context, params CreateCustomerParams) error
 	// Delete customer
 	// (DELETE /customers/{customerId})
 	DeleteCustomer(ctx echo.Context, customerId string) error
@@ -276,27 +805,60 @@ type ServerInterface interface {
 	// Update customer
 	// (PUT /customers/{customerId})
 	UpdateCustomer(ctx echo.Context, customerId string) error
+	// Get customer invoices
+	// (GET /customers/{customerId}/invoices)
+	GetCustomerInvoices(ctx echo.Context, customerId string, params GetCustomerInvoicesParams) error
 	// Get customer orders
 	// (GET /customers/{customerId}/orders)
 	GetCustomerOrders(ctx echo.Context, customerId string, params GetCustomerOrdersParams) error
+	// Get invoice by ID
+	// (GET /invoices/{invoiceId})
+	GetInvoice(ctx echo.Context, invoiceId string) error
+	// Record a payment
+	// (POST /invoices/{invoiceId}/payments)
+	RecordPayment(ctx echo.Context, invoiceId string) error
 	// List orders
 	// (GET /orders)
 	ListOrders(ctx echo.Context, params ListOrdersParams) error
 	// Create a new order
 	// (POST /orders)
-	CreateOrder(ctx echo.Context) error
+	CreateOrder(ctx echo.Context, params CreateOrderParams) error
 	// Get order by ID
 	// (GET /orders/{orderId})
 	GetOrder(ctx echo.Context, orderId string) error
 	// Update order status
 	// (PUT /orders/{orderId})
 	UpdateOrderStatus(ctx echo.Context, orderId string) error
+	// Cancel order
+	// (POST /orders/{orderId}/cancel)
+	CancelOrder(ctx echo.Context, orderId string) error
+	// Get order status history
+	// (GET /orders/{orderId}/history)
+	GetOrderHistory(ctx echo.Context, orderId string) error
+	// Get order invoice
+	// (GET /orders/{orderId}/invoice)
+	GetOrderInvoice(ctx echo.Context, orderId string) error
+	// Issue order invoice
+	// (POST /orders/{orderId}/invoice)
+	IssueInvoice(ctx echo.Context, orderId string) error
+	// List order returns
+	// (GET /orders/{orderId}/returns)
+	ListOrderReturns(ctx echo.Context, orderId string) error
+	// Request a return
+	// (POST /orders/{orderId}/returns)
+	RequestReturn(ctx echo.Context, orderId string) error
+	// List order shipments
+	// (GET /orders/{orderId}/shipments)
+	ListOrderShipments(ctx echo.Context, orderId string) error
+	// Ship order items
+	// (POST /orders/{orderId}/shipments)
+	CreateShipment(ctx echo.Context, orderId string) error
 	// List all products
 	// (GET /products)
 	ListProducts(ctx echo.Context, params ListProductsParams) error
 	// Create a new product
 	// (POST /products)
-	CreateProduct(ctx echo.Context) error
+	CreateProduct(ctx echo.Context, params CreateProductParams) error
 	// Delete product
 	// (DELETE /products/{productId})
 	DeleteProduct(ctx echo.Context, productId string) error
@@ -306,6 +868,45 @@ type ServerInterface interface {
 	// Update product
 	// (PUT /products/{productId})
 	UpdateProduct(ctx echo.Context, productId string) error
+	// Get product inventory
+	// (GET /products/{productId}/inventory)
+	GetProductInventory(ctx echo.Context, productId string) error
+	// Get product orders
+	// (GET /products/{productId}/orders)
+	GetProductOrders(ctx echo.Context, productId string, params GetProductOrdersParams) error
+	// List return requests
+	// (GET /returns)
+	ListReturns(ctx echo.Context, params ListReturnsParams) error
+	// Get return request by ID
+	// (GET /returns/{returnId})
+	GetReturn(ctx echo.Context, returnId string) error
+	// Approve return
+	// (POST /returns/{returnId}/approval)
+	ApproveReturn(ctx echo.Context, returnId string) error
+	// Reject return
+	// (POST /returns/{returnId}/rejection)
+	RejectReturn(ctx echo.Context, returnId string) error
+	// Get shipment by ID
+	// (GET /shipments/{shipmentId})
+	GetShipment(ctx echo.Context, shipmentId string) error
+	// Mark shipment delivered
+	// (POST /shipments/{shipmentId}/delivery)
+	DeliverShipment(ctx echo.Context, shipmentId string) error
+	// Create a new warehouse
+	// (POST /warehouses)
+	CreateWarehouse(ctx echo.Context) error
+	// Get warehouse by ID
+	// (GET /warehouses/{warehouseId})
+	GetWarehouse(ctx echo.Context, warehouseId string) error
+	// List warehouse inventory
+	// (GET /warehouses/{warehouseId}/inventory)
+	ListWarehouseInventory(ctx echo.Context, warehouseId string, params ListWarehouseInventoryParams) error
+	// Set product inventory in a warehouse
+	// (PUT /warehouses/{warehouseId}/inventory/{productId})
+	SetInventory(ctx echo.Context, warehouseId string, productId string) error
+	// List warehouse shipments
+	// (GET /warehouses/{warehouseId}/shipments)
+	ListWarehouseShipments(ctx echo.Context, warehouseId string, params ListWarehouseShipmentsParams) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -328,11 +929,11 @@ func (w *ServerInterfaceWrapper) ListCustomers(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Optional query parameter "cursor" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
@@ -346,8 +947,28 @@ func (w *ServerInterfaceWrapper) CreateCustomer(ctx echo.Context) error {
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateCustomerParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Idempotency-Key, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Idempotency-Key: %s", err))
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateCustomer(ctx)
+	err = w.Handler.CreateCustomer(ctx, params)
 	return err
 }
 
@@ -405,8 +1026,8 @@ func (w *ServerInterfaceWrapper) UpdateCustomer(ctx echo.Context) error {
 	return err
 }
 
-// GetCustomerOrders converts echo context to params.
-func (w *ServerInterfaceWrapper) GetCustomerOrders(ctx echo.Context) error {
+// GetCustomerInvoices converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCustomerInvoices(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "customerId" -------------
 	var customerId string
@@ -419,39 +1040,19 @@ func (w *ServerInterfaceWrapper) GetCustomerOrders(ctx echo.Context) error {
 	ctx.Set(BearerAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetCustomerOrdersParams
-	// ------------- Optional query parameter "limit" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
-	}
-
-	// ------------- Optional query parameter "offset" -------------
+	var params GetCustomerInvoicesParams
+	// ------------- Optional query parameter "from" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
 	}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetCustomerOrders(ctx, customerId, params)
-	return err
-}
-
-// ListOrders converts echo context to params.
-func (w *ServerInterfaceWrapper) ListOrders(ctx echo.Context) error {
-	var err error
+	// ------------- Optional query parameter "to" -------------
 
-	ctx.Set(BearerAuthScopes, []string{})
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListOrdersParams
-	// ------------- Optional query parameter "customer_id" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "customer_id", ctx.QueryParams(), &params.CustomerId)
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter customer_id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
 	}
 
 	// ------------- Optional query parameter "limit" -------------
@@ -461,11 +1062,115 @@ func (w *ServerInterfaceWrapper) ListOrders(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Optional query parameter "cursor" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetCustomerInvoices(ctx, customerId, params)
+	return err
+}
+
+// GetCustomerOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCustomerOrders(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "customerId" -------------
+	var customerId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customerId", ctx.Param("customerId"), &customerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter customerId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCustomerOrdersParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetCustomerOrders(ctx, customerId, params)
+	return err
+}
+
+// GetInvoice converts echo context to params.
+func (w *ServerInterfaceWrapper) GetInvoice(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "invoiceId" -------------
+	var invoiceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invoiceId", ctx.Param("invoiceId"), &invoiceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter invoiceId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetInvoice(ctx, invoiceId)
+	return err
+}
+
+// RecordPayment converts echo context to params.
+func (w *ServerInterfaceWrapper) RecordPayment(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "invoiceId" -------------
+	var invoiceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invoiceId", ctx.Param("invoiceId"), &invoiceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter invoiceId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RecordPayment(ctx, invoiceId)
+	return err
+}
+
+// ListOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) ListOrders(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListOrdersParams
+	// ------------- Optional query parameter "customer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "customer_id", ctx.QueryParams(), &params.CustomerId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter customer_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
@@ -479,8 +1184,28 @@ func (w *ServerInterfaceWrapper) CreateOrder(ctx echo.Context) error {
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateOrderParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Idempotency-Key, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Idempotency-Key: %s", err))
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateOrder(ctx)
+	err = w.Handler.CreateOrder(ctx, params)
 	return err
 }
 
@@ -520,6 +1245,150 @@ func (w *ServerInterfaceWrapper) UpdateOrderStatus(ctx echo.Context) error {
 	return err
 }
 
+// CancelOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) CancelOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CancelOrder(ctx, orderId)
+	return err
+}
+
+// GetOrderHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOrderHistory(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrderHistory(ctx, orderId)
+	return err
+}
+
+// GetOrderInvoice converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOrderInvoice(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrderInvoice(ctx, orderId)
+	return err
+}
+
+// IssueInvoice converts echo context to params.
+func (w *ServerInterfaceWrapper) IssueInvoice(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.IssueInvoice(ctx, orderId)
+	return err
+}
+
+// ListOrderReturns converts echo context to params.
+func (w *ServerInterfaceWrapper) ListOrderReturns(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListOrderReturns(ctx, orderId)
+	return err
+}
+
+// RequestReturn converts echo context to params.
+func (w *ServerInterfaceWrapper) RequestReturn(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RequestReturn(ctx, orderId)
+	return err
+}
+
+// ListOrderShipments converts echo context to params.
+func (w *ServerInterfaceWrapper) ListOrderShipments(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListOrderShipments(ctx, orderId)
+	return err
+}
+
+// CreateShipment converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateShipment(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateShipment(ctx, orderId)
+	return err
+}
+
 // ListProducts converts echo context to params.
 func (w *ServerInterfaceWrapper) ListProducts(ctx echo.Context) error {
 	var err error
@@ -535,11 +1404,11 @@ func (w *ServerInterfaceWrapper) ListProducts(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Optional query parameter "cursor" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
@@ -553,8 +1422,28 @@ func (w *ServerInterfaceWrapper) CreateProduct(ctx echo.Context) error {
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateProductParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Idempotency-Key, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Idempotency-Key: %s", err))
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateProduct(ctx)
+	err = w.Handler.CreateProduct(ctx, params)
 	return err
 }
 
@@ -612,6 +1501,319 @@ func (w *ServerInterfaceWrapper) UpdateProduct(ctx echo.Context) error {
 	return err
 }
 
+// GetProductInventory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProductInventory(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", ctx.Param("productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter productId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProductInventory(ctx, productId)
+	return err
+}
+
+// GetProductOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProductOrders(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", ctx.Param("productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter productId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductOrdersParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProductOrders(ctx, productId, params)
+	return err
+}
+
+// ListReturns converts echo context to params.
+func (w *ServerInterfaceWrapper) ListReturns(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListReturnsParams
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListReturns(ctx, params)
+	return err
+}
+
+// GetReturn converts echo context to params.
+func (w *ServerInterfaceWrapper) GetReturn(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "returnId" -------------
+	var returnId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "returnId", ctx.Param("returnId"), &returnId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter returnId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetReturn(ctx, returnId)
+	return err
+}
+
+// ApproveReturn converts echo context to params.
+func (w *ServerInterfaceWrapper) ApproveReturn(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "returnId" -------------
+	var returnId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "returnId", ctx.Param("returnId"), &returnId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter returnId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ApproveReturn(ctx, returnId)
+	return err
+}
+
+// RejectReturn converts echo context to params.
+func (w *ServerInterfaceWrapper) RejectReturn(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "returnId" -------------
+	var returnId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "returnId", ctx.Param("returnId"), &returnId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter returnId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RejectReturn(ctx, returnId)
+	return err
+}
+
+// GetShipment converts echo context to params.
+func (w *ServerInterfaceWrapper) GetShipment(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "shipmentId" -------------
+	var shipmentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "shipmentId", ctx.Param("shipmentId"), &shipmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter shipmentId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetShipment(ctx, shipmentId)
+	return err
+}
+
+// DeliverShipment converts echo context to params.
+func (w *ServerInterfaceWrapper) DeliverShipment(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "shipmentId" -------------
+	var shipmentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "shipmentId", ctx.Param("shipmentId"), &shipmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter shipmentId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeliverShipment(ctx, shipmentId)
+	return err
+}
+
+// CreateWarehouse converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateWarehouse(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateWarehouse(ctx)
+	return err
+}
+
+// GetWarehouse converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWarehouse(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "warehouseId" -------------
+	var warehouseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "warehouseId", ctx.Param("warehouseId"), &warehouseId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter warehouseId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWarehouse(ctx, warehouseId)
+	return err
+}
+
+// ListWarehouseInventory converts echo context to params.
+func (w *ServerInterfaceWrapper) ListWarehouseInventory(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "warehouseId" -------------
+	var warehouseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "warehouseId", ctx.Param("warehouseId"), &warehouseId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter warehouseId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWarehouseInventoryParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListWarehouseInventory(ctx, warehouseId, params)
+	return err
+}
+
+// SetInventory converts echo context to params.
+func (w *ServerInterfaceWrapper) SetInventory(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "warehouseId" -------------
+	var warehouseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "warehouseId", ctx.Param("warehouseId"), &warehouseId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter warehouseId: %s", err))
+	}
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", ctx.Param("productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter productId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SetInventory(ctx, warehouseId, productId)
+	return err
+}
+
+// ListWarehouseShipments converts echo context to params.
+func (w *ServerInterfaceWrapper) ListWarehouseShipments(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "warehouseId" -------------
+	var warehouseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "warehouseId", ctx.Param("warehouseId"), &warehouseId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter warehouseId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWarehouseShipmentsParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListWarehouseShipments(ctx, warehouseId, params)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -645,63 +1847,171 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.DELETE(baseURL+"/customers/:customerId", wrapper.DeleteCustomer)
 	router.GET(baseURL+"/customers/:customerId", wrapper.GetCustomer)
 	router.PUT(baseURL+"/customers/:customerId", wrapper.UpdateCustomer)
+	router.GET(baseURL+"/customers/:customerId/invoices", wrapper.GetCustomerInvoices)
 	router.GET(baseURL+"/customers/:customerId/orders", wrapper.GetCustomerOrders)
+	router.GET(baseURL+"/invoices/:invoiceId", wrapper.GetInvoice)
+	router.POST(baseURL+"/invoices/:invoiceId/payments", wrapper.RecordPayment)
 	router.GET(baseURL+"/orders", wrapper.ListOrders)
 	router.POST(baseURL+"/orders", wrapper.CreateOrder)
 	router.GET(baseURL+"/orders/:orderId", wrapper.GetOrder)
 	router.PUT(baseURL+"/orders/:orderId", wrapper.UpdateOrderStatus)
+	router.POST(baseURL+"/orders/:orderId/cancel", wrapper.CancelOrder)
+	router.GET(baseURL+"/orders/:orderId/history", wrapper.GetOrderHistory)
+	router.GET(baseURL+"/orders/:orderId/invoice", wrapper.GetOrderInvoice)
+	router.POST(baseURL+"/orders/:orderId/invoice", wrapper.IssueInvoice)
+	router.GET(baseURL+"/orders/:orderId/returns", wrapper.ListOrderReturns)
+	router.POST(baseURL+"/orders/:orderId/returns", wrapper.RequestReturn)
+	router.GET(baseURL+"/orders/:orderId/shipments", wrapper.ListOrderShipments)
+	router.POST(baseURL+"/orders/:orderId/shipments", wrapper.CreateShipment)
 	router.GET(baseURL+"/products", wrapper.ListProducts)
 	router.POST(baseURL+"/products", wrapper.CreateProduct)
 	router.DELETE(baseURL+"/products/:productId", wrapper.DeleteProduct)
 	router.GET(baseURL+"/products/:productId", wrapper.GetProduct)
 	router.PUT(baseURL+"/products/:productId", wrapper.UpdateProduct)
+	router.GET(baseURL+"/products/:productId/inventory", wrapper.GetProductInventory)
+	router.GET(baseURL+"/products/:productId/orders", wrapper.GetProductOrders)
+	router.GET(baseURL+"/returns", wrapper.ListReturns)
+	router.GET(baseURL+"/returns/:returnId", wrapper.GetReturn)
+	router.POST(baseURL+"/returns/:returnId/approval", wrapper.ApproveReturn)
+	router.POST(baseURL+"/returns/:returnId/rejection", wrapper.RejectReturn)
+	router.GET(baseURL+"/shipments/:shipmentId", wrapper.GetShipment)
+	router.POST(baseURL+"/shipments/:shipmentId/delivery", wrapper.DeliverShipment)
+	router.POST(baseURL+"/warehouses", wrapper.CreateWarehouse)
+	router.GET(baseURL+"/warehouses/:warehouseId", wrapper.GetWarehouse)
+	router.GET(baseURL+"/warehouses/:warehouseId/inventory", wrapper.ListWarehouseInventory)
+	router.PUT(baseURL+"/warehouses/:warehouseId/inventory/:productId", wrapper.SetInventory)
+	router.GET(baseURL+"/warehouses/:warehouseId/shipments", wrapper.ListWarehouseShipments)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xb/W7bOBJ/FYK3wLaAEjtp964xcMClTbebQ7fJtb3944ogoKWRzS1FKiSVrRH43Q/8",
-	"kCxZlOykiaPFBihQxyI5w5nffGp8g2OR5YID1wpPbrCK55AR+/FNobTIQH6EqwKUNl/lUuQgNQW7ADJC",
-	"mfmQgIolzTUVHE+qfcg+RyRJJCiFnmWF0mgKqOD0qoDnOMLwjWQ5AzzBsd/zL//VfiwyHOFUyIxoPPGk",
-	"IqwXuVmutKR8hpcR5iSDHhbSgjFk19Sp/VvMOToR5suMfHsPfKbneHIwHkc4o7z6u0VuGWEJVwWVkODJ",
-	"l4ope/5FtVpMf4dYG+ZWElS54AraIowlEA3JJdE9l7CLqOBI0wyUJlneuM3h+PDF3sHh3vjg88F4Mjb/",
-	"/leXXUI07JmtIfndSof3ozKa9NBz4EA0Aa5pSkG2aF6ODw5fvPzp7/94dTQm0ziB9CFw0TqwyJPNmmJE",
-	"aeRW3r+y1tBHExw1IRjV4dTgOATOt1IKGUCkSAKCs4uRfRaQTQKaUKba246ThJqPhCGwJ5QrA/xkoBSZ",
-	"BWj/UmSE70kgCZky8AeVqzdJybNcLg8J4kwmIE81ZJ2OLpciKWJ9GULuuXu2AbjmhO2Ae1UQrqletCn9",
-	"xz9BWiBheK5TOLSui2ZFVndclGuYgWxJpXahGsUNwunyYcOQjmUUElStaApnXSAR1kITdplLGgcw99k8",
-	"RPYhSoVEek4VohoyRDmKbbCsnf/i6OhViETBqe6icG7Pzp3L08FjD46Oju6oygbt5l07tdwJ/9LTX+7E",
-	"cxspqw79IvewtugHCSme4L+NVmnMyOcwo5ZhL62RnLq9KyshUpJF23PUbl0S7JHdXSK8u9NDhvcdqy7p",
-	"umM/BevNHhEdXnfLdUREWGmii056/mmEgRu/+wXnwBPDaIRjwVMqMzDYUXOa5/ZTAoxeGzdlVhAeA2OQ",
-	"GFTVfGF1ROvuzopJJgquu1yWFSVya27nq3ryG3fbnSc3IQtck0KlodvlPj4sdXq8xvW7Ylr927oUfqGz",
-	"+d5VQZiJ1USSKY0JikWaAqApEK5QKkWG3ggmsiklrSJkUxXSldyWfLXS2nMJGS0MRcvDa8PDbUufCHfG",
-	"MUfVxcoScegZ7M/2I2RCGPon+uHgaP/o6Hk7uPXlLEa3Iv4ayCqvCWU2F7QLgiG/vJE7fbwxjHqhNXVa",
-	"hk/HRy+Q7uL+S9E9ZADYMZK3Cgn3nRTejzl8H+A3523fD+fbOe2Sz5277S1M6Xbe+jfCaGItZEPNurrS",
-	"b8fvT0+OP5+efbh8+/Hj2ceQfmsFZ21jRQulhDIbqVs7r6tFl7YatUxUSUeTt5QCC9jAz+Zri02k50R7",
-	"Ymh18gaOm6fVuN5cHrfk258GNwvo0OXbOjNgh7iQVC8+mXTLiWIKRII8LkyE8Z1Gs8l9vWJ1rnWOl+YM",
-	"ylPh9Ms1iS3Gna3jM84oB/RpLnJ0fH6KPgPJWu4Ov2FAODqW8ZxqiHUhAU2JggTBXiyyDGQMdvcfVM/R",
-	"yYKTTJy8RlMSfwVuFM9oDN6re7q/nn62EqOaBdgw0gGpHPGD/fH+2CwWOXCSUzzBL/YP9sfGGIieW4mM",
-	"yhTH/jWDgBl/BC0pXINCBDGqNBIpIoyh1U5LQVqNnCZ4gt9Tpd/UnuZEkgy0JfJl/fhfyTcTIhEvsilI",
-	"c3h1MNICSdCFNFikZvFVAXJRWvgEM5pRm4LZjNqxnpKCaTw5HNv8wsf2RiwOdifW2foQZkd9pXkHMyJN",
-	"FXRwsykVuDCQdxHcKuJwPC5hBy7VJnnOaGxlPPpduVi6IhQul5teoa8UafWJA5WITXu7sv6A9nAw5Vm3",
-	"05bJvPcQW52zjPBPt5RH312dCw9QPuUaJCcMKZDXIJ0jc66kyDIiFyV36+jXZKbqBbvCFyZ6CxXq1Nqw",
-	"Y2yJwx/VKc4B+FY3T8qUoWlWbmupKuy8JCj9WiSLe5PO+juXZdMda1nAsgXWgwcgXwKxrafmawlIkCri",
-	"GJRKC8YsUl/eI1rWQ38QNzYiIa8OlBBNHBtHDw/atw4zTAJJFgi+UaWHZTEOtWt47zCaZVQLSKOb8uNp",
-	"snSGxEAHco8T+70xqcqcpguk50AlOj1pmZFbXjOj3vDU26KyYcDE0lUUWPGM1+2mHhnWk6J2AHjZ0y5z",
-	"kggB/+XDK73igguNUlHwZFB4c+rdhLRoc66jcohpSuPtUPUO9OAhNX4cN12+cnsCqAXoO9ANSFkwdaQQ",
-	"RQCj/7W1qkKEO3dP+aw670eFTMliymZXwTVB6rYOEacDSWQeyUJ8+2G4icyjWe1TDrWo7Pbu2dPIvpTZ",
-	"qsRn/g2Osm+dA1GwL/KdOTLD8CvR5k6Dv+kg2gwrXv4UPYYVorZ/13kP3YUaNu1ERN0k7tBsqKDor/OU",
-	"orRTFFEaddvnRCUMnPPZ3stUjUSvz2cid1NabIFSyrQdpJkuKiaeB3uM27mbn+15JaXaoS7zCplZ873v",
-	"k4v5C7qY7+xervzJsFqXLWOuDHi7hqWb7nCpQbN56RMFSKohmFD38syPDT5Ext+YIdtx33INfG0N1Sat",
-	"nhL9MrCgvHxFPsgw2GhdlgOvLcNZRb7Rjf3ftyu3bzA5o5ouENWqo7dU2k1vpOuedAtk1Z7VwbSUtrSg",
-	"XTeTHNXhpmkVdhptpLpX39BDskt/VH6UsByb8tODEVJzmkfIjw1GyM0MPu/oLFlhfSrn4QaA1bsFmWYa",
-	"0jWB+aEKh/c3hdk/3OLpXATzkN21tra0VA+ogfW2PFdaEq7sL0OePMmqwbQG52Co8yH79vMi1cZQKXe+",
-	"enjLaZHy2GGUWHVu/hRFVl2dW5VZ6wOndy+0aoD4nlKrOmaQcyL1S3pzqr7atugqs2RbZxkERfWB2cgN",
-	"gkZ2cKScbgzVXl5zD1R9rU2077j+aqGyrbbGsPNwa7BhVj95hZ0AiuthYXTjP209tFGiu7v+cWtX8O2N",
-	"EH2z3YHUsuL2/sc1VsPtjzitcT7oCtsPa/Si6zajGpux9A70sIE0fgyfuOuqetioNHV1DUmNyroZvLed",
-	"z/C7thrPGBw8h5ErPIpdPA1mDN1WfeXan5/Ufg1iLan+O5AvFwbljkLIzk7gGpjIM+Da84EjXEjmfycy",
-	"GY2YiAmbC6Unr8avxtgc55nonHzICCczsGdWLkC13z8ah9z5mz2iCROz4P5aSRTuu22gX769ulj+PwAA",
-	"//+aYshKnkcAAA==",
+	"H4sIAAAAAAAC/+x9e28cx5XvVyn0DWAbaJJDWsq1aFzg0pITM7EtXYqO7q4pUMXuGk6FPVXtqhpSswIB",
+	"j5SHd+Ngs3ktAmQRJ9jE3uzCwW72jw2cbD7MRLbzV77Cop79qu7poTjDlk1AgIYz3fU4dep3HnXOqQdB",
+	"RIcpJYgIHmw+CHg0QEOoPl6HJELJTRYjtoPeGiEu5LcpoyliAiP1DEOQUyI/xYhHDKcCyz+DO4MxEAME",
+	"qHwbYA4i1ViC4hAcoVQATNTvXEAx4mCAuaBsHIQBug+HaYKCzeD6iAs6RAxEA0gOUSyfxwwMMYmDMBhi",
+	"8ioih2IQbK6HgRin8hUuGCaHwelpGDD01ggzFAebb9pB3nXP0YOvo0gEp6Hr5FXMxQ7iKSUcVWcZmafU",
+	"H1igofrwBYb6wWbwv9YyCq4Z8q3Zdl2bp65zyBgcy78Jui/2oxHjlFUJeF19D/qUKTrJZ0EKDxGgfeCG",
+	"8yKgQywEigHV5Ewg148Fs2iSTamJLLXrjoYQJ75RmzVTvwMYxwxxDp4djrgABwiMCH5rhJ4rrLMdyf81",
+	"X61GdBiEQZ+yIRTBpumqMp8wIHCIGobQHyUJUM/ke/sKHRBwg8ovh/C+Y6Febz6WsoNS7TdTsJapGIIC",
+	"xftQNExCPYTl6uIh4gIO08JsNnobz6+sb6z01nfXe5s9+e+v87SLoUAr8lUf/eZaw/NZMhw39KeZA+AY",
+	"EYH7GLFKn/u99Y3nr1z94v9+4VoPHkQx6i+CLyoNjtJ49kqpraefPP/FKnEfjoOwyIJhnp0KI/Yx58uM",
+	"UebhSBp7CKceBuo3D21iJCBOePW1rTjG8iNMAFIt2Cc94xkiziVoVRp5ZTSEZIUhGMODBJmG7NMzIU4P",
+	"2T7uI8Q2OUZESp5m/Mf2sdb47xpeiABw43kCAZDNqZEwtRLgrREkAotxdeT/z/wiRyrHlDIajyIBBiiJ",
+	"rdw/gQwN6IgXNuEVDcJ4OBoGmz03KkwEOkSsMgM3ghkTqFtVM659HyjdMmNuxiTZQjtMWhC1yhRqxipH",
+	"k8WCVRi48Xppe8f+OoO6J4M2tC0xRW5RS+PILcJMgNwmxxRH6FVMUNf55yUstWqQm5trf8PHIIIK6JH6",
+	"cqpA/SaZLlLmQK6p569de8HLbgSL/ZThyAPebxAsgPrN2+T6tWvXZu7xwnLm1y/r106pcR1noLt8iM8D",
+	"7vKFRUG7GsyTIbueTwNF6qkBh3RExH4KfWy9qxjEIhUcDyVdwBDGCHAK+pCF3qXe6PW8YHUAE2kWepQH",
+	"NQjABU4SIKg0HeSAalp/3s+bVkv1blBDh9gZU0+udzZ0MwMHMDlu2QXno3pwVz2pRxYB6gkmiNfijzb0",
+	"9TPhXNuoALKeraRabl5D3XkNcfX7rchrOVrtA6e/3irsj6YZ3dLv52dTEgt2yxyhMYrBwdhuIrB9w6cY",
+	"a/dI/WLbt81zYYCI1J3eNIwSyCkxgWGSjPWOll/gOLibJ1DlkQpd+OigRmwUAEGtPjhAfcoQEPB+OJco",
+	"EfC+p314H2hE8rZ1xY8rAt7fZ1Agf3vyF9laiphssCCTenOITANSB1i7tQS8DzCJklGMaoDqSg1QzdDZ",
+	"1Eov3bx0264IpBYHckyRo7f66CRyWBAmGdznNppj8Ty2zVTPlE/yFe0zrJdkiAiGfZB1W3Vp/Io8BDSJ",
+	"ERegjxkXbcFLDUE3dF21czYIU83MkA6tAay0jLkVtKSoJea2QMNaU69jhpKgGvBLqq6zHNfPqFXOIE63",
+	"zQA10LntgDrVXcO6+tGoqZgDuS/OzTi4pdpONfOLZRgI5q/aVW42Es6s0CtWnUudN3jR3h4xRzR1+OOD",
+	"hQYoqIWBRn363L24buY+wFQ/roItYlS/AeQACjCkXICNnlZEXgQJZIeImRUAkCHAkJwqisEJFgNwpddb",
+	"nQvv8yh5qg4QtvW7G9pzZf5an7ECRXmqu29YjrMcIGgyLfL0YMncsGjZ2YbhzsAr9TpBnWqv+9O/vggy",
+	"3Zyh/ojEKAaQxIAhMWJE/sEQ4EhISwKmKaPHmByaX/PmQIpILKcZBhElfcyGyjbgA5ym6lOMEnyMmPrs",
+	"TmoL1oPtPggD23nJinBdVCir4VfrgrUmhJp2g6pfK2QalGdNy6Wrzr79XaJCTu+d5+CmXuus+nEi4RNV",
+	"dwZU+2ukENIa8ItgL+BjLtBwL1AyTH9t/DoH+iAfpmmCI40mWHCU9AsU5KM0pUyswENExMqVDS9iqGb9",
+	"K7WLh8iakfq5pgW6OhdS9Rkd+hQQdIzpiJutFjoBbaU4IoJlXvkEOkdD0JLtZ8VG6HmCE6gpXWjWOAoA",
+	"QxHCxyj2b6tq26+jk5wzIAPa3K5vZmBBg9DwjptBYel8bGlG+xoSA+oB6lfoCYDOV5Gfr4GniKEYi/0I",
+	"MjnAQ9x3n2PMI2VCqsO8AuAUX6pQx/liarSZOjiylC9B0YtAxTAQKgC6HyEdjgLoSHABFQeAzLot+T2b",
+	"DJMwGDqitfAsGQqXF820Ye3tpjWa5fhtTZA2/t0zzU27qLwoYYeyaBQv0zMbkpey2gRZkP1gDJw5LQj7",
+	"VmsbwkyitRXhOmigSO3eK8y+zmbOf5tf5lfw4WDlrRFMpC8AMniAIwgi2u8jBA4QJBxIwAfXaUKHBxhW",
+	"An1mRfrUBZDYcVVCR24xNMQj2aMaw0tyDPOGF4WBtk5rey2eo4Fn0erhagikiQz+D/jC+rXVa9eeqxrP",
+	"zdDDBY2OdJcpQxEUcmkFG6Gy2/p1ChJKpC0FowilAsWbgOnF5UAMoFA6KBY1Nha4LTsCmCu+FTmX8R4x",
+	"fPQMzw65s/CGULWr2rn1xi5Yc4/wtQfu83Z8uubeWHtgGtyOT1f3SDDTf2BWs8hs9Z6C8jaZyyiza7lI",
+	"s2zJW6uVoXbeXrDz2Z9PtgNnO6oK+6twbnAMcaLimdQDzmUXVrYHyHaH43AAI0Y5BzBJsi1THE5v7sgQ",
+	"O8elm0oN28/Sbz4LaUcZp1vKFoZJrQQi1Hc6dFvAfl/qeMgK2RhFmJe3iXLzAEx4qoAuBAxxlKglLeL+",
+	"1V6vQoX20TFa95eqpRyJM/kPKY35kwfKFEZRT8kzHwxYh3S6/AMCjuSxHIyO8l2sL+iEIE+mpyTSrI4+",
+	"cx4SmPNP55jyHxQASiJknFMoLvRYA5stjg0gjqtnB/II9ggRLaNc8sHFnChorliQSaChwGlg81kG+uX2",
+	"hoGeSWu7wDZfT5QdpR9iSuYEZ+s2MbPHXHEel/jLB/SEyHNB+bv1vhX2j+4anGAS0xN1XJBCzlHswet5",
+	"0gDUSJumWjPDGl/zDeuGdVafnBRHJFbb9UUg6CESA8S0TowgSzBiJVX8BDGk/BROERcDNPa7L8wGmYMR",
+	"ykcg9Ycesx1gSpIZF7YHkYI7jJJDwPHfoCdcJuuCbUgEKrH5GQ+/TgbUugq9ADTHiUeMIhzXqGs3jFaS",
+	"6WgZBoyIwInDW0CZ4wO/HvfF3d61eY0MHw12CqA0Q5wx9GSngDtWGbL75CxM3BC9Oad2WKa+Wbs5lEb/",
+	"EfB+PaHPMertXLepp3WpHtSe/uy4Y62yv7VXYuU2qoPhvpptY3BrlmVz9Qxmd91pntkVllGqgXpuxEEY",
+	"5Gbq9uzd4rbJHp4rS6m0Oecw864uMPy/aOAoNqvDsSe3d5qD2koSInc8V+TfEovNNEdvD3A6REQ8lWaU",
+	"Ox5euBFVJNPTYkZ56XPuNFmQEcFN83OaD+611gaEnUdrEyLrooks9WFKkDGMfNTQP1QdhH8Fh1BQsMsg",
+	"4SllYn7nfVPsSF6bt1nvZg51Cr2jwDmp7j4UmqW8CwajI0wO98loeNBETvsgMA/mKSt32orcaivXeusb",
+	"89O1rSTJExUkCB4j7ao+ZzdZ6JirSp+mQK7KFlgM03psCGNP1lgR6tdxvRWRDwvy6AbP765fPQ/TwZJn",
+	"BnjLBX4iq+G2Buy5jQavdJorzNv2fF4quxE93mVVfSml6vxPl+rUXLeCseWpakYK2ReSV7EoxJvdLeZB",
+	"5Z6pdL4YRJpLj3bz7EgCbQn3+uKcUK+sLM+NgjkFOserM9Xlr8EEx+psdEaJgmx+X9t6dfvG1u72zdf3",
+	"X97ZubnjI2euvkDuRdcX6EOc+I2qY/fQPpIjKqo9xbH1MUo8q/Ul+bXCb+2f052BrOUZIy62lhv17GoI",
+	"Ffo2hyUX6yX4Ju9bM8eNtRpZQnXgYBMnu2fyvPtVKujK0SgEu/RoTIvqw4b3bM1/NJx1UxGjqmVwA8sm",
+	"DkaKrtcREYgVe5u7QIzpyE1rBuXOEk6QzWqRAQWLz+APz5FBFssQc8mK3NAv6FQ9R7L2R+hSyqNoxLAY",
+	"35b6j+bDAwQZYlsjMcj++pId3Vfu7AblkKFXbm9c/SKYTv57OvnZdPIbsGP+fv+TP/zH4+99dzr50fTh",
+	"u9PJz8BX7uxO3354D91P74Hp5ANwj48O5Kd3H//xm39+753p5P3p25N7jCboHpg+/M300b9NH/1++ui9",
+	"6eRdcA/GQ0zk0x9OH70jv5cP/HY6+c108pPp5HfTya+mkw8//sF3//SHn04ffn/69mSPTCf/NH347p8+",
+	"+uXjf/7xX37/zj3rB7qXDfaTn04++dEv//L7v622a4f38Pt//vn7jz/8Bdi+IUc9nfzjdPKrT7/968fv",
+	"fGs6+VD/9vGjbz5+79+njz76+LcffPzjb08fffTpH3/4+AeTT37420//9TvTyQePf/Stxz+YTCcfPv77",
+	"h5988/3p5L3p5B+mD7//6c/fnU7emT78zvTth3ukOIJ3p5NfTyffmD766JNv/Pzx3/1uOvn14/f+8/H3",
+	"3pGDu9Jbn749+fiDf/nzT74nx64etdP/xp8++rEb23TyX/KBh7+Q7T78aPrwd/r95+XUPv3jDyX9ZOdB",
+	"qCvCoWDTrHrGgQMh0uBUMgwmfaqlMxEwUntB77vgJkkwQeD2gKZg69Y22EVwWE2Lvp4gSMAWiwZYoEiM",
+	"GAIHkKMYoJWIDoeIRUi9rYzkG2MCh/TGS8oRjYhOSY2QgU/T72vbu7IbgUXiGYaUbYhx3fn6am+1Jx+m",
+	"KSIwxcFm8Pzq+mpPRX2KgWL/tUIFuEPkd7EyjKThCUGCuZA+Fhmjk72pemBqR27HquQGF9dzv6aQwSES",
+	"qpM3y82/Bu9Lt5tRaQtV4OQZoT4UDeRSBJvBWyOkiukZYiR4iIVdSaiH3oejRJjEJd10JuQa/HsVD0cK",
+	"Jf5rB1QuLYWDnGPK5hCkNuTeOJd8Y9VvFAZbBry7YcCMwFTLsdHrWeZD+oghl6yw9nVztpG116ZuX8Hp",
+	"pni8XC6Fi8IiSAa6eo4D0fqvp+dtIhCTVbU4YseIaS1Qw/ZoOIRsbEdXZj4BD3mp8t9pGKSU+6qaKXEh",
+	"WZmgE9eK3n+mLByJrewscrV+9Xp2CN/I1l9FY60VD+GR6o8hl3WhTvj1gQWHfbQKtgBDKYImIlTZPlKr",
+	"PqDxWH2FCdi4AgZ0xDg4RNp7BrigDMXAcgzAhAsEY9kFGxEizUR4CDFZtQw5QFC71gxHbsdomFKBSDRe",
+	"+SoaF1gzr49evRr6WVVN4SUaj8+dS50vr6gFCDZCp5VNsr6A7us3SLGCIYoBH0UR4lxW35M01FRWQ3ME",
+	"Fis7KE3gGPk8KEioAA82QuBkgIgJBTGLKnNKmHlXubuhYRUUg6b1M+t1QKmUQHIep2Fw5Ry3cdmg9W5o",
+	"ZWc5Xo+hgIEaxrXFo8nLejMnDMF4DNB9zAUPgSGgHk9xs5WoCTA3VXowkX61Q4a4wsIrGxtLwMLSYCQb",
+	"2LmMuA1BhyDG/T5iOp/Keb87g9YaMUtYWwPYp2FOF1l7YD9ux6d6yyTIF7lwQ30v4dW+YEQyZrrwTBHC",
+	"9eNtIbwx11chqlSj8hLejjkog9Z8Uv9KQyCOpkQJddSmurL4RXejkGc2fToicaf4TS/vLE4LZ6u5PEUR",
+	"7uOoHVd9GYnOs1TvYmSkLY16yaCKQb+MRIGltm/U8mg68vDoG8q/wQEkWqJJHc++plIptF9Fe0aKTKpf",
+	"7SKfdkSLvKAdYlxWHjzviK52Ybv2wtREqSVmliHkYEhjyfsxiCiJRowhIpJxp4BF7+6z61hr+VKhM8Vj",
+	"AXL0e7o6o6rrC4EaC5OJ9ZUSZLWSc9sOoBvIVPEHvawiWLjIlaGUZpupi6fjC5SBRmRK6YnEd8XIfmeQ",
+	"OczM+m/nEK/UtoWi5ZBGaTpjSIKew4CqzjzHIJe+vHODCl/x3yZYdZv7nOXKTGmSAYHGVEW1zipkOIOg",
+	"KnqGuerDTUCaVTibBaNJYgt4ab9OxehogsubupuOgmUVBsxML0Hg3Pi+WtqvCQIMW17aYdVtT+1W8m16",
+	"86Pe8hYB1h6YT8Y71d6fYN4LjZCW9hsWHOSqxlZ2vAH7WVu9qR63Z6e7CXTGr1CpPe8VK2qOy/Yq2H67",
+	"y8xmOSs+hZLM8jHwWr42uP/QbAdFlMU8V+pKHSxxIR0RpqVneE3NqCJD66ZMuaPu8PT5+yBKVbqW7IJo",
+	"sZlyhdgkyHXGA2FY7GI391J8D7bnRhfDco6b3BULzhWiKhGY9Bq763WWBq8tENclVNRAk0FWPSi219hd",
+	"+It+BTxLU32RQjIGfZwIlRJzMHaqxXPeyJh2qvuXVHu2p1yjGuD9Cmk+8e9SXX/61PVXC/zVvcibiqrs",
+	"1ON28TbqcWPuFmNvjIaMYleQ2Bd8c9Pkhl1G3py7vlKokL7ksJtSmfcqg+aKfl8G3HT9EIcyl9O9fJ1q",
+	"66mI7iF81O/jCOsbjmSpPMVppuh/lsgXml95yhS+SHAWlIIhJONcjTwtpxV4y/lQgsAhDa329hmMJbI5",
+	"whUxlOlzaw/U/3N7aNRbUq/AgtcEe7SSQvU1/D2WqxlqZ3wxLQF52X4Y3Wt3vTCOdwo+mLyONCOoQz36",
+	"jK3cbmvhmhrnoUqIDG0mamhunn9udY/sDhAw+aWqjD0HME2Rqj5jaoeVWjbX0mcgqQqjq7jn2CjVe+T/",
+	"r2ypby2iakELnjU6/iaAKX5OxUvLFg7xMSJAlxnRBWp9ASi5Ov/d2EFn06SKuWyz6gwZomN7M1ccgiOU",
+	"Clc0obAmhfQpJ1RdcRaz6okamVyqdEDJXCnNrztFvJLKfLbbNO7OSt0y/XhSspbqFGuJamY5OhKY8wY5",
+	"IrIGYGHFLhBwl6LF6X474RdTCrVhiQxiuxj8U9rTrXSjNb2L613/19XvyvWvsUEd5FtwMH1KESDhnyul",
+	"yWiisdFdZTKblUKuXAwk8R4pXAAu31UrnpU6DR1AqhRzSXyo6mtKL1AOODWoru4R7VkzDgVTmELXeIsg",
+	"kVx8gICDrVWwO3B/whlyc4+0F5xgLrm5R2YJTr0EHVI6FxCwmU3xgg5M2rogLPd8ruM1P+9iQY8Dc0UC",
+	"Hy4q21sjzwAeI3CAELGI1C2rWvHzfPb0mlVUZ9vVKFcxx94KJbOHjTpTjA4NNaie2Hu8sFAYeTIY1xrh",
+	"rziV+TNui5evBJ6lvNolurTM85Z5xdBqx+/mvLAFv0vpj/PX1ccFx14tH7eM8Xmq+bjD8T033VWrhNr1",
+	"6ygLY8cpJd4txKfWncZtS54ssmmeP0N15bsUZhq4dZeqwESaSPsH3s9dTYtd9H/srqilJEKrFTZX3XaX",
+	"x9cvgscNPCxSjZypPOp13L7xOVEcB7mDD6WRWfZdvuKY2RFSxcms0/yIOoM+avvOhT9eMZq7n6OV2li8",
+	"DCSvN9aH1eyYPj7LgtRzA0stqxVp2NWIkty9zhWusj/VC7UtflS8TQRwOlQJJzLFwrmcJP9kdV91x9oH",
+	"pAuhq21Jh+lIXZVrbtqR1/ip7CUVDmfLTetBq+t/ZH8rVB7mKndUv28Lu6sHDd19Xh3j59ix4VWfSb9O",
+	"8bKYJUeVlO/3qXJp8fKALjl11LgufTpL9+loG0Bo9SAPFsWb6kx5e4x4tXJ5t4JgteyEWRhnA8B6pXah",
+	"LH47d495o53Evu3a/yzLbO+VB/VOHEeTjsprnlu0CkNlP9bL7CyjJM8teblNyzcMKJEMswMjLbtNyAWK",
+	"6BBxd+ijRHPOhlV137FQ2zvvi12tiTO1y/VZlcvlqyaWLJmrF2dUOdI+44/6vDjZ7Pj1AqQzZRn7f+5F",
+	"dWY0u13PXNn49CkS0pLTrXVtYt8bMVWK6fwt+HMVbXUv+gTyrezHOUu25m91vMwYOacMQk3TtjkjbmU7",
+	"Wa81x3eWud1XbbNHzAv6mFCuUZi/7z1UrjRlrufu5YexCuYYMDo6HPhu4n8xVxDSXvjPTeiKyhYp3/lf",
+	"ozKY1bpMTllIMq0m7gWpK673hmRaw5qXKSpdrgm7dVn69ULSNVKHjR7sz6szaw/Mp9ZlX83zDQkb+tmW",
+	"8Nx02aLHpHOjPf+Cr3YkF1rv9ZYvj6tr5V4buWueYq+zeenLSHSbkXoXIfSWHa7Rba6UsRo5TiqkAhVV",
+	"3rYVXs1bz/C2Sq/JMlms2qtH2rnt0A3l80L24WUp2RpsWIpyaXt+qgrGnkkzW3Mo0sr9lMGXew/AiFGu",
+	"ayBmGSAN4nbb9fhZl7tuprP8PnamGVFTlPMPd1Ym4txSVpgudyFnIwe2rtnjXL5c7kUBsXaAuNEo2XeC",
+	"mIxwhNFZChibdWhX1Wcp7Flfu1h7mTtVu7jFkC6sdvFlFaQLqYJ0s7Jfc7mDlzWMm8C1UqApf96TT3Jo",
+	"H4yZBb64MELrgjL5FHKb5uPgJBuikxnIqZmgVbSmfsz2Jqg60Krh7+xy5upmDFygVy7lPf+dCd2LFdpq",
+	"A8ib3D4bOcoEu4SQJYe/7lQDX5eKGoZZKZOUxET10UXwUAd0JW7NwUcxOMz8tfZAf5i7tk6xn2Y/W7vA",
+	"2OIyt1Wr7PA7FtLdnp+X7nIrdd9dz1uVxQoOuFn8vKZFAGyoiLClnjBntzZwWDewCrZrChbURY8e0nx9",
+	"hD1ixbWuDkDNexHCx1INcrZR6KruFOPfUsgEluVI93VMu85E3iOu65mBcfZJUx1Bp+wusy6CL1bekLyb",
+	"kLCoqPktw4kX5HdsDUlOafqceRqLfBbaDMrM157Xti6uIGJpO1BTUrgDYXylkVWy82IU4bhjqXAGiarh",
+	"9DMFizYoMCX1kmVHPeIXLDpPyRzKSMhWOYQy+jF71oX1lDON5GufL/DcsdTuOnraNf18o2cGS5cA+XQD",
+	"pN54s/DRhTOvPbAf5zYm7YvNZmTbTA77XFvIy4bduRyjVlkVyzYgXcfdNR3zDFUwGsvB937uXTPpguPZ",
+	"9+hIY8g+rfOSbTu+pKZcGqK03lRyVF+xvBuIrjIFGcPHvqSmG7qFy71QtxcMgZfvJbRDWGINDv9OXIqo",
+	"dV13SMi6MXnEa44tOoNUr0F2lPFNNsZ6uMqdqNeX+SwkWrg3slQL5XBKaOS/XFy/fse+FixGtXftX1Ae",
+	"QK7/elRxD3UtcbGs5nczVPwkx0OWoSshIdkXaw/c57kVyIzLGzXIPFc3is1s5VvKzdzQOyM45+TxZauR",
+	"d/zpr53SIwt8VVAkWzPyXBF2ppqbfr6c5wkGKDFBTfmtVT2Ld5RtHWq3NH5vd8u4mT8iki6XJ+4XEZJ4",
+	"pxpnvnSV+ik4bveE4z8ZSJTTpryZBbdtgqg5fjSWp3nThxPaGM3CdwUVMDE5AvpkkFsutslovuqPt5F4",
+	"CjDlac5QcOS9uNtmbf+NhS/1Q5d5CgVGv9Dr0rJFeTrSFm77Asmr6s38WDpPmStbZEQ9DzgiolKcKJTG",
+	"THPwo+OA1hWwuqRwZfO/VLSWXiUsY4RCpbBLPatOz/LVKyvkHRV8ZrIlFI0YFmO1Cw8QZIhtjcQg2Hzz",
+	"ruQA3bNvj95Axyihqi0zviAMRiwJNoOBEOnm2pp0oiUDysXmC70XesHpXTekB3U3ag4hgYdItelwhFfv",
+	"fZZMUKfWRFDAhB56389VjvEXOJvRv7utuB6wpPcwZ6N6GsktR7UhWzRbNmMvI/c14koQV5twfl7BYHSk",
+	"LuzwNJDfTzWxEXIMpmKsrwV70Hl69/R/BgA47BPGgu4AAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
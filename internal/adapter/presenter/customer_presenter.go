@@ -29,8 +29,8 @@ func (p *CustomerPresenter) PresentCustomer(ctx echo.Context, statusCode int, cu
 	return ctx.JSON(statusCode, response)
 }
 
-// PresentCustomers presents a list of customers
-func (p *CustomerPresenter) PresentCustomers(ctx echo.Context, statusCode int, customers []*entity.Customer) error {
+// PresentCustomers presents a page of customers with the cursor for the next page
+func (p *CustomerPresenter) PresentCustomers(ctx echo.Context, statusCode int, customers []*entity.Customer, nextCursor *string) error {
 	responses := make([]openapi.CustomerResponse, len(customers))
 
 	for i, customer := range customers {
@@ -43,7 +43,10 @@ func (p *CustomerPresenter) PresentCustomers(ctx echo.Context, statusCode int, c
 		}
 	}
 
-	return ctx.JSON(statusCode, responses)
+	return ctx.JSON(statusCode, openapi.CustomerListResponse{
+		Customers:  responses,
+		NextCursor: nextCursor,
+	})
 }
 
 // PresentError presents an error response
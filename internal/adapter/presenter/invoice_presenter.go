@@ -0,0 +1,84 @@
+package presenter
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/domain/entity"
+)
+
+// InvoicePresenter handles invoice response presentation
+type InvoicePresenter struct{}
+
+// NewInvoicePresenter creates a new invoice presenter
+func NewInvoicePresenter() *InvoicePresenter {
+	return &InvoicePresenter{}
+}
+
+// PresentInvoice presents a single invoice
+func (p *InvoicePresenter) PresentInvoice(ctx echo.Context, statusCode int, invoice *entity.Invoice) error {
+	return ctx.JSON(statusCode, toInvoiceResponse(invoice))
+}
+
+// PresentInvoices presents a list of invoices with the cursor for the next page
+func (p *InvoicePresenter) PresentInvoices(ctx echo.Context, statusCode int, invoices []*entity.Invoice, nextCursor *string) error {
+	responses := make([]openapi.InvoiceResponse, len(invoices))
+
+	for i, invoice := range invoices {
+		responses[i] = toInvoiceResponse(invoice)
+	}
+
+	return ctx.JSON(statusCode, openapi.InvoiceListResponse{
+		Invoices:   responses,
+		NextCursor: nextCursor,
+	})
+}
+
+// PresentError presents an error response
+func (p *InvoicePresenter) PresentError(ctx echo.Context, statusCode int, code, message string) error {
+	errorResponse := openapi.Error{
+		Code:    code,
+		Message: message,
+	}
+
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+// toInvoiceResponse converts an invoice entity to its response model
+func toInvoiceResponse(invoice *entity.Invoice) openapi.InvoiceResponse {
+	lines := make([]openapi.InvoiceLineResponse, 0, len(invoice.Lines()))
+	for _, line := range invoice.Lines() {
+		lines = append(lines, openapi.InvoiceLineResponse{
+			ProductId: line.ProductID.String(),
+			Quantity:  line.Quantity,
+			UnitPrice: int(line.UnitPrice.Cents()),
+			Total:     int(line.Total.Cents()),
+		})
+	}
+
+	payments := make(map[string]openapi.PaymentResponse, len(invoice.Payments()))
+	for paymentID, payment := range invoice.Payments() {
+		payments[paymentID.String()] = openapi.PaymentResponse{
+			Method: openapi.PaymentMethod(payment.Method),
+			Amount: int(payment.Amount.Cents()),
+			PaidAt: payment.PaidAt,
+		}
+	}
+
+	return openapi.InvoiceResponse{
+		Id:         invoice.ID().String(),
+		OrderId:    invoice.OrderID().String(),
+		CustomerId: invoice.CustomerID().String(),
+		Lines:      lines,
+		Subtotal:   int(invoice.Subtotal().Cents()),
+		TaxRate:    invoice.TaxRate(),
+		Tax:        int(invoice.Tax().Cents()),
+		Total:      int(invoice.Total().Cents()),
+		AmountPaid: int(invoice.AmountPaid().Cents()),
+		Balance:    int(invoice.Balance().Cents()),
+		Payments:   payments,
+		Status:     openapi.InvoiceResponseStatus(invoice.Status()),
+		IssuedAt:   invoice.IssuedAt(),
+		UpdatedAt:  invoice.UpdatedAt(),
+	}
+}
@@ -41,8 +41,8 @@ func (p *OrderPresenter) PresentOrder(ctx echo.Context, statusCode int, order *e
 	return ctx.JSON(statusCode, response)
 }
 
-// PresentOrders presents a list of orders
-func (p *OrderPresenter) PresentOrders(ctx echo.Context, statusCode int, orders []*entity.Order) error {
+// PresentOrders presents a page of orders with the cursor for the next page
+func (p *OrderPresenter) PresentOrders(ctx echo.Context, statusCode int, orders []*entity.Order, nextCursor *string) error {
 	responses := make([]openapi.OrderResponse, len(orders))
 
 	for i, order := range orders {
@@ -68,7 +68,32 @@ func (p *OrderPresenter) PresentOrders(ctx echo.Context, statusCode int, orders
 		}
 	}
 
-	return ctx.JSON(statusCode, responses)
+	return ctx.JSON(statusCode, openapi.OrderListResponse{
+		Orders:     responses,
+		NextCursor: nextCursor,
+	})
+}
+
+// PresentOrderHistory presents the status history of an order
+func (p *OrderPresenter) PresentOrderHistory(ctx echo.Context, statusCode int, orderID string, history []entity.OrderStatusChange) error {
+	entries := make([]openapi.OrderStatusChangeResponse, len(history))
+	for i, change := range history {
+		entries[i] = openapi.OrderStatusChangeResponse{
+			To:        string(change.To),
+			Actor:     change.Actor,
+			Reason:    change.Reason,
+			ChangedAt: change.ChangedAt,
+		}
+		if change.From != "" {
+			from := string(change.From)
+			entries[i].From = &from
+		}
+	}
+
+	return ctx.JSON(statusCode, openapi.OrderHistoryResponse{
+		OrderId: orderID,
+		Entries: entries,
+	})
 }
 
 // PresentError presents an error response
@@ -30,8 +30,8 @@ func (p *ProductPresenter) PresentProduct(ctx echo.Context, statusCode int, prod
 	return ctx.JSON(statusCode, response)
 }
 
-// PresentProducts presents a list of products
-func (p *ProductPresenter) PresentProducts(ctx echo.Context, statusCode int, products []*entity.Product) error {
+// PresentProducts presents a page of products with the cursor for the next page
+func (p *ProductPresenter) PresentProducts(ctx echo.Context, statusCode int, products []*entity.Product, nextCursor *string) error {
 	responses := make([]openapi.ProductResponse, len(products))
 
 	for i, product := range products {
@@ -46,7 +46,10 @@ func (p *ProductPresenter) PresentProducts(ctx echo.Context, statusCode int, pro
 		}
 	}
 
-	return ctx.JSON(statusCode, responses)
+	return ctx.JSON(statusCode, openapi.ProductListResponse{
+		Products:   responses,
+		NextCursor: nextCursor,
+	})
 }
 
 // PresentError presents an error response
@@ -0,0 +1,81 @@
+package presenter
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/domain/entity"
+)
+
+// ReturnPresenter handles return request response presentation
+type ReturnPresenter struct{}
+
+// NewReturnPresenter creates a new return presenter
+func NewReturnPresenter() *ReturnPresenter {
+	return &ReturnPresenter{}
+}
+
+// PresentReturn presents a single return request
+func (p *ReturnPresenter) PresentReturn(ctx echo.Context, statusCode int, request *entity.ReturnRequest) error {
+	return ctx.JSON(statusCode, toReturnResponse(request))
+}
+
+// PresentReturns presents a list of return requests with the cursor for the next page
+func (p *ReturnPresenter) PresentReturns(ctx echo.Context, statusCode int, requests []*entity.ReturnRequest, nextCursor *string) error {
+	responses := make([]openapi.ReturnResponse, len(requests))
+
+	for i, request := range requests {
+		responses[i] = toReturnResponse(request)
+	}
+
+	return ctx.JSON(statusCode, openapi.ReturnListResponse{
+		Returns:    responses,
+		NextCursor: nextCursor,
+	})
+}
+
+// PresentError presents an error response
+func (p *ReturnPresenter) PresentError(ctx echo.Context, statusCode int, code, message string) error {
+	errorResponse := openapi.Error{
+		Code:    code,
+		Message: message,
+	}
+
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+// toReturnResponse converts a return request entity to its response model
+func toReturnResponse(request *entity.ReturnRequest) openapi.ReturnResponse {
+	items := make([]openapi.ReturnItemResponse, 0, len(request.Items()))
+	for _, item := range request.Items() {
+		items = append(items, openapi.ReturnItemResponse{
+			ProductId:  item.ProductID.String(),
+			Quantity:   item.Quantity,
+			UnitPrice:  int(item.UnitPrice.Cents()),
+			TotalPrice: int(item.TotalPrice().Cents()),
+		})
+	}
+
+	response := openapi.ReturnResponse{
+		Id:           request.ID().String(),
+		OrderId:      request.OrderID().String(),
+		CustomerId:   request.CustomerID().String(),
+		Items:        items,
+		Reason:       request.Reason(),
+		Status:       openapi.ReturnResponseStatus(request.Status()),
+		RefundAmount: int(request.Refund().Cents()),
+		RequestedAt:  request.RequestedAt(),
+		UpdatedAt:    request.UpdatedAt(),
+	}
+	if warehouseID := request.WarehouseID().String(); warehouseID != "" {
+		response.WarehouseId = &warehouseID
+	}
+	if note := request.Note(); note != "" {
+		response.Note = &note
+	}
+	if decidedAt := request.DecidedAt(); !decidedAt.IsZero() {
+		response.DecidedAt = &decidedAt
+	}
+
+	return response
+}
@@ -0,0 +1,73 @@
+package presenter
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/domain/entity"
+)
+
+// ShipmentPresenter handles shipment response presentation
+type ShipmentPresenter struct{}
+
+// NewShipmentPresenter creates a new shipment presenter
+func NewShipmentPresenter() *ShipmentPresenter {
+	return &ShipmentPresenter{}
+}
+
+// PresentShipment presents a single shipment
+func (p *ShipmentPresenter) PresentShipment(ctx echo.Context, statusCode int, shipment *entity.Shipment) error {
+	return ctx.JSON(statusCode, toShipmentResponse(shipment))
+}
+
+// PresentShipments presents a list of shipments with the cursor for the next page
+func (p *ShipmentPresenter) PresentShipments(ctx echo.Context, statusCode int, shipments []*entity.Shipment, nextCursor *string) error {
+	responses := make([]openapi.ShipmentResponse, len(shipments))
+
+	for i, shipment := range shipments {
+		responses[i] = toShipmentResponse(shipment)
+	}
+
+	return ctx.JSON(statusCode, openapi.ShipmentListResponse{
+		Shipments:  responses,
+		NextCursor: nextCursor,
+	})
+}
+
+// PresentError presents an error response
+func (p *ShipmentPresenter) PresentError(ctx echo.Context, statusCode int, code, message string) error {
+	errorResponse := openapi.Error{
+		Code:    code,
+		Message: message,
+	}
+
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+// toShipmentResponse converts a shipment entity to its response model
+func toShipmentResponse(shipment *entity.Shipment) openapi.ShipmentResponse {
+	items := make([]openapi.ShipmentItemResponse, 0, len(shipment.Items()))
+	for _, item := range shipment.Items() {
+		items = append(items, openapi.ShipmentItemResponse{
+			ProductId: item.ProductID.String(),
+			Quantity:  item.Quantity,
+		})
+	}
+
+	response := openapi.ShipmentResponse{
+		Id:             shipment.ID().String(),
+		OrderId:        shipment.OrderID().String(),
+		WarehouseId:    shipment.WarehouseID().String(),
+		Carrier:        shipment.Carrier(),
+		TrackingNumber: shipment.TrackingNumber(),
+		Items:          items,
+		Status:         openapi.ShipmentResponseStatus(shipment.Status()),
+		ShippedAt:      shipment.ShippedAt(),
+		UpdatedAt:      shipment.UpdatedAt(),
+	}
+	if deliveredAt := shipment.DeliveredAt(); !deliveredAt.IsZero() {
+		response.DeliveredAt = &deliveredAt
+	}
+
+	return response
+}
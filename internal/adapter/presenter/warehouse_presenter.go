@@ -0,0 +1,68 @@
+package presenter
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/domain/entity"
+)
+
+// WarehousePresenter handles warehouse and inventory response presentation
+type WarehousePresenter struct{}
+
+// NewWarehousePresenter creates a new warehouse presenter
+func NewWarehousePresenter() *WarehousePresenter {
+	return &WarehousePresenter{}
+}
+
+// PresentWarehouse presents a single warehouse
+func (p *WarehousePresenter) PresentWarehouse(ctx echo.Context, statusCode int, warehouse *entity.Warehouse) error {
+	response := openapi.WarehouseResponse{
+		Id:        warehouse.ID().String(),
+		Name:      warehouse.Name(),
+		Location:  warehouse.Location(),
+		CreatedAt: warehouse.CreatedAt(),
+		UpdatedAt: warehouse.UpdatedAt(),
+	}
+
+	return ctx.JSON(statusCode, response)
+}
+
+// PresentInventory presents a product's inventory in a single warehouse
+func (p *WarehousePresenter) PresentInventory(ctx echo.Context, statusCode int, inventory *entity.Inventory) error {
+	return ctx.JSON(statusCode, toInventoryResponse(inventory))
+}
+
+// PresentInventories presents a list of inventory entries with the cursor for the next page
+func (p *WarehousePresenter) PresentInventories(ctx echo.Context, statusCode int, inventories []*entity.Inventory, nextCursor *string) error {
+	responses := make([]openapi.InventoryResponse, len(inventories))
+
+	for i, inventory := range inventories {
+		responses[i] = toInventoryResponse(inventory)
+	}
+
+	return ctx.JSON(statusCode, openapi.InventoryListResponse{
+		Inventory:  responses,
+		NextCursor: nextCursor,
+	})
+}
+
+// PresentError presents an error response
+func (p *WarehousePresenter) PresentError(ctx echo.Context, statusCode int, code, message string) error {
+	errorResponse := openapi.Error{
+		Code:    code,
+		Message: message,
+	}
+
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+// toInventoryResponse converts an inventory entity to its response model
+func toInventoryResponse(inventory *entity.Inventory) openapi.InventoryResponse {
+	return openapi.InventoryResponse{
+		ProductId:   inventory.ProductID().String(),
+		WarehouseId: inventory.WarehouseID().String(),
+		Quantity:    inventory.Quantity(),
+		UpdatedAt:   inventory.UpdatedAt(),
+	}
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/guregu/dynamo/v2"
+)
+
+// deleteCollection deletes every item stored under a partition key. A collection can hold more
+// items than one transaction may, so the items other than the header (the item whose SK is the
+// PK) are deleted in batches and the header goes last, on condition that its version is still
+// the one read here. A delete that stops part-way leaves the header in place to be retried.
+// When the header changed in the meantime the returned error satisfies dynamo.IsCondCheckFailed.
+// It reports whether there was anything to delete.
+func deleteCollection(ctx context.Context, table dynamo.Table, pk string) (bool, error) {
+	var items []struct {
+		PK      string `dynamo:"PK"`
+		SK      string `dynamo:"SK"`
+		Version int    `dynamo:"Version"`
+	}
+	err := table.Get("PK", pk).
+		Project("PK", "SK", "Version").
+		Consistent(true).
+		All(ctx, &items)
+	if err != nil {
+		return false, fmt.Errorf("failed to find items: %w", err)
+	}
+	if len(items) == 0 {
+		return false, nil
+	}
+
+	var others []dynamo.Keyed
+	header := -1
+	for i, item := range items {
+		if item.SK == pk {
+			header = i
+			continue
+		}
+		others = append(others, dynamo.Keys{item.PK, item.SK})
+	}
+
+	if len(others) > 0 {
+		if _, err := table.Batch("PK", "SK").Write().Delete(others...).Run(ctx); err != nil {
+			return true, fmt.Errorf("failed to delete items: %w", err)
+		}
+	}
+	if header < 0 {
+		return true, nil
+	}
+	return true, deleteIfVersion(table.Delete("PK", pk).Range("SK", pk), items[header].Version).Run(ctx)
+}
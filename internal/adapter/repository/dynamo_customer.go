@@ -2,12 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/guregu/dynamo/v2"
 
+	"dynamo-modeling/internal/domain"
 	"dynamo-modeling/internal/domain/entity"
 	"dynamo-modeling/internal/domain/value"
 	"dynamo-modeling/internal/infrastructure"
@@ -37,6 +39,32 @@ type CustomerItem struct {
 	Name      string    `dynamo:"Name"`      // Customer name
 	CreatedAt time.Time `dynamo:"CreatedAt"` // Creation timestamp
 	UpdatedAt time.Time `dynamo:"UpdatedAt"` // Last update timestamp
+	Version   int       `dynamo:"Version"`   // Optimistic locking version
+}
+
+// EmailSentinelItem reserves an email address for one customer. Writing it with
+// attribute_not_exists(PK) in the same transaction as the customer enforces uniqueness.
+type EmailSentinelItem struct {
+	PK         string `dynamo:"PK"`         // EMAIL#{Email}
+	SK         string `dynamo:"SK"`         // EMAIL#{Email}
+	Type       string `dynamo:"Type"`       // "EMAIL"
+	CustomerID string `dynamo:"CustomerID"` // Customer that owns the address
+}
+
+// emailSentinelKey returns the PK/SK of the sentinel item for an email address
+func emailSentinelKey(email string) string {
+	return fmt.Sprintf("EMAIL#%s", email)
+}
+
+// EmailSentinel returns the sentinel item that reserves the customer's email address
+func (item *CustomerItem) EmailSentinel() EmailSentinelItem {
+	key := emailSentinelKey(item.Email)
+	return EmailSentinelItem{
+		PK:         key,
+		SK:         key,
+		Type:       "EMAIL",
+		CustomerID: item.ID,
+	}
 }
 
 // ToEntity converts CustomerItem to Customer entity
@@ -51,10 +79,9 @@ func (item *CustomerItem) ToEntity() (*entity.Customer, error) {
 		return nil, fmt.Errorf("invalid email: %w", err)
 	}
 
-	customer := entity.NewCustomer(customerID, email, item.Name)
+	customer := entity.NewCustomerWithState(customerID, email, item.Name, item.CreatedAt, item.UpdatedAt)
+	customer.SetVersion(item.Version)
 
-	// Set timestamps using reflection or recreate entity with timestamps
-	// For now, we'll create a new instance and trust the timestamps are correct
 	return customer, nil
 }
 
@@ -73,26 +100,56 @@ func CustomerItemFromEntity(customer *entity.Customer) *CustomerItem {
 		Name:      customer.Name(),
 		CreatedAt: customer.CreatedAt(),
 		UpdatedAt: customer.UpdatedAt(),
+		Version:   customer.Version(),
 	}
 }
 
-// Save creates or updates a customer
+// Save creates or updates a customer together with its email sentinel in a single transaction
 func (r *DynamoCustomerRepository) Save(ctx context.Context, customer *entity.Customer) error {
 	slog.Info("Saving customer", "customerID", customer.ID().String())
 
-	// Check if email is already taken by another customer
-	if err := r.checkEmailUniqueness(ctx, customer); err != nil {
+	item := CustomerItemFromEntity(customer)
+	item.Version = customer.Version() + 1
+	table := r.client.GetTable()
+
+	// 現在のメールアドレスを取得（変更された場合は古いセンチネルを削除する）
+	previousEmail, err := r.storedEmail(ctx, item.PK)
+	if err != nil {
 		return err
 	}
 
-	item := CustomerItemFromEntity(customer)
-	table := r.client.GetTable()
+	tx := r.client.DB.WriteTx()
+
+	// 0: 顧客本体（楽観ロック）
+	tx.Put(putIfVersion(table.Put(item), customer.Version()))
+
+	// 1: 新しいメールアドレスのセンチネル（他の顧客が所有していない場合のみ）
+	tx.Put(table.Put(item.EmailSentinel()).If("attribute_not_exists('PK') OR 'CustomerID' = ?", item.ID))
 
-	err := table.Put(item).Run(ctx)
+	// 2: 古いメールアドレスのセンチネルを解放
+	if previousEmail != "" && previousEmail != item.Email {
+		oldKey := emailSentinelKey(previousEmail)
+		tx.Delete(table.Delete("PK", oldKey).
+			Range("SK", oldKey).
+			If("attribute_not_exists('PK') OR 'CustomerID' = ?", item.ID))
+	}
+
+	err = tx.Run(ctx)
 	if err != nil {
+		if i, ok := failedCondition(err); ok {
+			switch i {
+			case 0:
+				slog.Warn("Customer was modified concurrently", "customerID", customer.ID().String(), "version", customer.Version())
+				return domain.ConcurrentModificationError("Customer", customer.ID().String())
+			case 1:
+				slog.Warn("Email already taken by another customer", "customerID", customer.ID().String(), "email", item.Email)
+				return domain.CustomerAlreadyExistsError(item.Email)
+			}
+		}
 		slog.Error("Failed to save customer", "customerID", customer.ID().String(), "error", err)
 		return fmt.Errorf("failed to save customer: %w", err)
 	}
+	customer.SetVersion(item.Version)
 
 	slog.Info("Customer saved successfully", "customerID", customer.ID().String())
 	return nil
@@ -112,7 +169,7 @@ func (r *DynamoCustomerRepository) FindByID(ctx context.Context, id value.Custom
 	if err != nil {
 		if err == dynamo.ErrNotFound {
 			slog.Warn("Customer not found", "customerID", id.String())
-			return nil, fmt.Errorf("customer not found")
+			return nil, domain.CustomerNotFoundError(id.String())
 		}
 		slog.Error("Failed to find customer", "customerID", id.String(), "error", err)
 		return nil, fmt.Errorf("failed to find customer: %w", err)
@@ -140,7 +197,7 @@ func (r *DynamoCustomerRepository) FindByEmail(ctx context.Context, email value.
 	if err != nil {
 		if err == dynamo.ErrNotFound {
 			slog.Warn("Customer not found by email", "email", email.String())
-			return nil, fmt.Errorf("customer not found")
+			return nil, domain.CustomerNotFoundByEmailError(email.String())
 		}
 		slog.Error("Failed to find customer by email", "email", email.String(), "error", err)
 		return nil, fmt.Errorf("failed to find customer by email: %w", err)
@@ -155,17 +212,43 @@ func (r *DynamoCustomerRepository) FindByEmail(ctx context.Context, email value.
 	return customer, nil
 }
 
-// Delete removes a customer by their ID
+// Delete removes a customer by their ID and releases their email sentinel
 func (r *DynamoCustomerRepository) Delete(ctx context.Context, id value.CustomerID) error {
 	slog.Info("Deleting customer", "customerID", id.String())
 
+	email, err := r.storedEmail(ctx, fmt.Sprintf("CUSTOMER#%s", id.String()))
+	if err != nil {
+		return err
+	}
+	if email == "" {
+		slog.Info("Customer already deleted", "customerID", id.String())
+		return nil
+	}
+	return r.deleteWithEmail(ctx, id, email)
+}
+
+// deleteWithEmail deletes a customer and the sentinel of the email read before. The customer is
+// only deleted while it still has that email, so a concurrent email change cannot leave the
+// sentinel of the new address behind.
+func (r *DynamoCustomerRepository) deleteWithEmail(ctx context.Context, id value.CustomerID, email string) error {
 	pk := fmt.Sprintf("CUSTOMER#%s", id.String())
 	sk := fmt.Sprintf("CUSTOMER#%s", id.String())
 
 	table := r.client.GetTable()
 
-	err := table.Delete("PK", pk).Range("SK", sk).Run(ctx)
+	// 0: 顧客本体（読んだメールアドレスのままの場合のみ）、1: そのメールアドレスのセンチネル
+	sentinelKey := emailSentinelKey(email)
+	err := r.client.DB.WriteTx().
+		Delete(table.Delete("PK", pk).Range("SK", sk).If("'Email' = ?", email)).
+		Delete(table.Delete("PK", sentinelKey).
+			Range("SK", sentinelKey).
+			If("attribute_not_exists('PK') OR 'CustomerID' = ?", id.String())).
+		Run(ctx)
 	if err != nil {
+		if i, ok := failedCondition(err); ok && i == 0 {
+			slog.Warn("Customer was modified concurrently", "customerID", id.String(), "email", email)
+			return domain.ConcurrentModificationError("Customer", id.String())
+		}
 		slog.Error("Failed to delete customer", "customerID", id.String(), "error", err)
 		return fmt.Errorf("failed to delete customer: %w", err)
 	}
@@ -180,7 +263,7 @@ func (r *DynamoCustomerRepository) Exists(ctx context.Context, id value.Customer
 
 	_, err := r.FindByID(ctx, id)
 	if err != nil {
-		if err.Error() == "customer not found" {
+		if errors.Is(err, domain.ErrNotFound) {
 			return false, nil
 		}
 		return false, err
@@ -189,29 +272,32 @@ func (r *DynamoCustomerRepository) Exists(ctx context.Context, id value.Customer
 	return true, nil
 }
 
-// checkEmailUniqueness ensures that the email is not already taken by another customer
-func (r *DynamoCustomerRepository) checkEmailUniqueness(ctx context.Context, customer *entity.Customer) error {
-	existingCustomer, err := r.FindByEmail(ctx, customer.Email())
+// storedEmail returns the email currently stored for a customer item, or "" if it does not exist
+func (r *DynamoCustomerRepository) storedEmail(ctx context.Context, pk string) (string, error) {
+	var item CustomerItem
+	err := r.client.GetTable().
+		Get("PK", pk).
+		Range("SK", dynamo.Equal, pk).
+		Consistent(true).
+		One(ctx, &item)
 	if err != nil {
-		if err.Error() == "customer not found" {
-			// Email is available
-			return nil
+		if err == dynamo.ErrNotFound {
+			return "", nil
 		}
-		// Other error occurred
-		return err
-	}
-
-	// Check if the existing customer is the same as the one being saved
-	if existingCustomer.ID() != customer.ID() {
-		return fmt.Errorf("email already taken by another customer")
+		return "", fmt.Errorf("failed to load customer: %w", err)
 	}
-
-	return nil
+	return item.Email, nil
 }
 
-// ListWithLimit retrieves customers with optional limit
-func (r *DynamoCustomerRepository) ListWithLimit(ctx context.Context, limit *int) ([]*entity.Customer, error) {
-	slog.Info("Listing customers with limit", "limit", limit)
+// FindAll retrieves customers with optional pagination
+func (r *DynamoCustomerRepository) FindAll(ctx context.Context, limit int, lastKey *string) ([]*entity.Customer, *string, error) {
+	slog.Info("Listing customers", "limit", limit)
+
+	const scope = "customers:all"
+	start, err := startKey(r.client, scope, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	var items []CustomerItem
 	table := r.client.GetTable()
@@ -219,14 +305,17 @@ func (r *DynamoCustomerRepository) ListWithLimit(ctx context.Context, limit *int
 	query := table.Scan().
 		Filter("'Type' = ?", "CUSTOMER")
 
-	if limit != nil && *limit > 0 {
-		query = query.Limit(*limit)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if start != nil {
+		query = query.StartFrom(start)
 	}
 
-	err := query.All(ctx, &items)
+	lek, err := query.AllWithLastEvaluatedKey(ctx, &items)
 	if err != nil {
 		slog.Error("Failed to list customers", "error", err)
-		return nil, fmt.Errorf("failed to list customers: %w", err)
+		return nil, nil, fmt.Errorf("failed to list customers: %w", err)
 	}
 
 	customers := make([]*entity.Customer, 0, len(items))
@@ -239,6 +328,11 @@ func (r *DynamoCustomerRepository) ListWithLimit(ctx context.Context, limit *int
 		customers = append(customers, customer)
 	}
 
+	next, err := r.client.Cursors.Encode(scope, lek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
 	slog.Info("Listed customers successfully", "count", len(customers))
-	return customers, nil
+	return customers, next, nil
 }
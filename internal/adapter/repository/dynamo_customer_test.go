@@ -3,15 +3,54 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/domain"
 	"dynamo-modeling/internal/domain/entity"
 	"dynamo-modeling/internal/domain/value"
 	"dynamo-modeling/internal/infrastructure"
 )
 
+func TestCustomerItemConversion(t *testing.T) {
+	// Arrange
+	customerID, err := value.NewCustomerID("test-customer-123")
+	require.NoError(t, err)
+	email, err := value.NewEmail("test@example.com")
+	require.NoError(t, err)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := createdAt.Add(48 * time.Hour)
+
+	customer := entity.NewCustomerWithState(customerID, email, "Test Customer", createdAt, updatedAt)
+	customer.SetVersion(3)
+
+	// Act: Convert entity to item
+	item := CustomerItemFromEntity(customer)
+
+	// Assert: Check item structure
+	assert.Equal(t, "CUSTOMER#test-customer-123", item.PK)
+	assert.Equal(t, "CUSTOMER#test-customer-123", item.SK)
+	assert.Equal(t, "EMAIL#test@example.com", item.GSI1PK)
+	assert.Equal(t, "CUSTOMER#test-customer-123", item.GSI1SK)
+	assert.Equal(t, createdAt, item.CreatedAt)
+	assert.Equal(t, updatedAt, item.UpdatedAt)
+
+	// Act: Convert item back to entity
+	converted, err := item.ToEntity()
+	require.NoError(t, err)
+
+	// Assert: Every field survives the round trip
+	assert.Equal(t, customerID, converted.ID())
+	assert.Equal(t, email, converted.Email())
+	assert.Equal(t, "Test Customer", converted.Name())
+	assert.Equal(t, createdAt, converted.CreatedAt())
+	assert.Equal(t, updatedAt, converted.UpdatedAt())
+	assert.Equal(t, 3, converted.Version())
+}
+
 // TestDynamoCustomerRepository runs integration tests against DynamoDB Local
 func TestDynamoCustomerRepository(t *testing.T) {
 	// Skip if not running integration tests
@@ -22,11 +61,9 @@ func TestDynamoCustomerRepository(t *testing.T) {
 	ctx := context.Background()
 
 	// Initialize DynamoDB client for testing
-	client, err := infrastructure.NewDynamoDBClient(ctx, infrastructure.DynamoDBConfig{
-		Region:    "ap-northeast-1",
-		Endpoint:  "http://localhost:8000",
-		TableName: "OnlineShop",
-	})
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
 	require.NoError(t, err)
 
 	// Health check
@@ -51,6 +88,9 @@ func TestDynamoCustomerRepository(t *testing.T) {
 		assert.Equal(t, customer.ID(), found.ID())
 		assert.Equal(t, customer.Email(), found.Email())
 		assert.Equal(t, customer.Name(), found.Name())
+		assert.True(t, customer.CreatedAt().Equal(found.CreatedAt()))
+		assert.True(t, customer.UpdatedAt().Equal(found.UpdatedAt()))
+		assert.Equal(t, 1, found.Version())
 
 		// Find by email
 		found, err = repo.FindByEmail(ctx, email)
@@ -66,12 +106,12 @@ func TestDynamoCustomerRepository(t *testing.T) {
 		nonExistentID, _ := value.NewCustomerID("non-existent-123")
 		_, err := repo.FindByID(ctx, nonExistentID)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "not found")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
 
 		nonExistentEmail, _ := value.NewEmail("non@existent.com")
 		_, err = repo.FindByEmail(ctx, nonExistentEmail)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "not found")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
 	})
 
 	t.Run("duplicate email should fail", func(t *testing.T) {
@@ -88,13 +128,69 @@ func TestDynamoCustomerRepository(t *testing.T) {
 
 		// Try to save second customer with same email
 		err = repo.Save(ctx, customer2)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "email already taken")
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeCustomerAlreadyExists, domainErr.Code)
 
 		// Clean up
 		repo.Delete(ctx, customerID1)
 	})
 
+	t.Run("email change swaps the sentinel", func(t *testing.T) {
+		oldEmail, _ := value.NewEmail("sentinel-old@example.com")
+		newEmail, _ := value.NewEmail("sentinel-new@example.com")
+		customerID1, _ := value.NewCustomerID("sentinel-customer-1")
+		customerID2, _ := value.NewCustomerID("sentinel-customer-2")
+
+		customer1 := entity.NewCustomer(customerID1, oldEmail, "Customer 1")
+		require.NoError(t, repo.Save(ctx, customer1))
+
+		customer1.UpdateEmail(newEmail)
+		require.NoError(t, repo.Save(ctx, customer1))
+
+		// The old address is released and the new one is taken
+		customer2 := entity.NewCustomer(customerID2, oldEmail, "Customer 2")
+		require.NoError(t, repo.Save(ctx, customer2))
+
+		customer2.UpdateEmail(newEmail)
+		err := repo.Save(ctx, customer2)
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeCustomerAlreadyExists, domainErr.Code)
+
+		// Clean up
+		repo.Delete(ctx, customerID1)
+		repo.Delete(ctx, customerID2)
+	})
+
+	t.Run("delete racing an email change keeps the new sentinel", func(t *testing.T) {
+		oldEmail, _ := value.NewEmail("delete-race-old@example.com")
+		newEmail, _ := value.NewEmail("delete-race-new@example.com")
+		customerID, _ := value.NewCustomerID("delete-race-customer")
+
+		customer := entity.NewCustomer(customerID, oldEmail, "Customer")
+		require.NoError(t, repo.Save(ctx, customer))
+		customer.UpdateEmail(newEmail)
+		require.NoError(t, repo.Save(ctx, customer))
+
+		// A delete that read the old address before the change must not go through
+		err := repo.deleteWithEmail(ctx, customerID, oldEmail.String())
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeConcurrentModification, domainErr.Code)
+		found, err := repo.FindByEmail(ctx, newEmail)
+		require.NoError(t, err)
+		assert.Equal(t, customerID, found.ID())
+
+		// Deleting again releases the new address
+		require.NoError(t, repo.Delete(ctx, customerID))
+		other, _ := value.NewCustomerID("delete-race-other")
+		require.NoError(t, repo.Save(ctx, entity.NewCustomer(other, newEmail, "Other")))
+
+		// Clean up
+		repo.Delete(ctx, other)
+	})
+
 	t.Run("exists check", func(t *testing.T) {
 		customerID, _ := value.NewCustomerID("exists-test-123")
 		email, _ := value.NewEmail("exists-test@example.com")
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/guregu/dynamo/v2"
+
+	domainrepo "dynamo-modeling/internal/domain/repository"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// Idempotency item states
+const (
+	idempotencyPending   = "pending"
+	idempotencyCompleted = "completed"
+)
+
+// reserveAttempts bounds the retries of Reserve when the record holding a key expires between
+// the failed put and the read that follows it
+const reserveAttempts = 3
+
+// DynamoIdempotencyRepository implements IdempotencyRepository using DynamoDB
+type DynamoIdempotencyRepository struct {
+	client *infrastructure.DynamoDBClient
+}
+
+// NewDynamoIdempotencyRepository creates a new DynamoDB idempotency repository
+func NewDynamoIdempotencyRepository(client *infrastructure.DynamoDBClient) *DynamoIdempotencyRepository {
+	return &DynamoIdempotencyRepository{
+		client: client,
+	}
+}
+
+// IdempotencyItem represents an idempotency key item in DynamoDB. ExpiresAt is the table's TTL
+// attribute, so DynamoDB deletes expired keys on its own; until it does, they count as absent.
+type IdempotencyItem struct {
+	PK          string    `dynamo:"PK"`                 // IDEMPOTENCY#{Key}
+	SK          string    `dynamo:"SK"`                 // IDEMPOTENCY#{Key}
+	Type        string    `dynamo:"Type"`               // "IDEMPOTENCY"
+	Key         string    `dynamo:"Key"`                // Idempotency-Key header
+	State       string    `dynamo:"State"`              // pending or completed
+	RequestHash string    `dynamo:"RequestHash"`        // Hash of the method, route and body
+	StatusCode  int       `dynamo:"StatusCode"`         // Status of the stored response
+	ContentType string    `dynamo:"ContentType"`        // Content-Type of the stored response
+	Body        []byte    `dynamo:"Body"`               // Body of the stored response
+	CreatedAt   time.Time `dynamo:"CreatedAt"`          // Time of the first request
+	ExpiresAt   time.Time `dynamo:"ExpiresAt,unixtime"` // TTL in Unix seconds
+}
+
+// ToRecord converts IdempotencyItem to an IdempotencyRecord
+func (item *IdempotencyItem) ToRecord() *domainrepo.IdempotencyRecord {
+	return &domainrepo.IdempotencyRecord{
+		Key:         item.Key,
+		RequestHash: item.RequestHash,
+		Completed:   item.State == idempotencyCompleted,
+		StatusCode:  item.StatusCode,
+		ContentType: item.ContentType,
+		Body:        item.Body,
+		CreatedAt:   item.CreatedAt,
+		ExpiresAt:   item.ExpiresAt,
+	}
+}
+
+// IdempotencyItemFromRecord converts an IdempotencyRecord to IdempotencyItem
+func IdempotencyItemFromRecord(record *domainrepo.IdempotencyRecord) *IdempotencyItem {
+	state := idempotencyPending
+	if record.Completed {
+		state = idempotencyCompleted
+	}
+	return &IdempotencyItem{
+		PK:          idempotencyKey(record.Key),
+		SK:          idempotencyKey(record.Key),
+		Type:        "IDEMPOTENCY",
+		Key:         record.Key,
+		State:       state,
+		RequestHash: record.RequestHash,
+		StatusCode:  record.StatusCode,
+		ContentType: record.ContentType,
+		Body:        record.Body,
+		CreatedAt:   record.CreatedAt.UTC(),
+		ExpiresAt:   record.ExpiresAt.UTC(),
+	}
+}
+
+func idempotencyKey(key string) string {
+	return fmt.Sprintf("IDEMPOTENCY#%s", key)
+}
+
+// Reserve puts an in-flight record on condition that the key is absent or expired. Two requests
+// racing for the same key are decided by the condition, so only one of them gets to run.
+func (r *DynamoIdempotencyRepository) Reserve(ctx context.Context, record *domainrepo.IdempotencyRecord) (*domainrepo.IdempotencyRecord, error) {
+	item := IdempotencyItemFromRecord(record)
+	item.State = idempotencyPending
+	table := r.client.GetTable()
+
+	for attempt := 0; attempt < reserveAttempts; attempt++ {
+		err := table.Put(item).
+			If("attribute_not_exists('PK') OR 'ExpiresAt' < ?", time.Now().Unix()).
+			Run(ctx)
+		if err == nil {
+			return nil, nil
+		}
+		if !dynamo.IsCondCheckFailed(err) {
+			slog.Error("Failed to reserve idempotency key", "key", record.Key, "error", err)
+			return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+
+		var existing IdempotencyItem
+		err = table.Get("PK", item.PK).
+			Range("SK", dynamo.Equal, item.SK).
+			Consistent(true).
+			One(ctx, &existing)
+		if errors.Is(err, dynamo.ErrNotFound) {
+			continue // Expired and deleted in the meantime
+		}
+		if err != nil {
+			slog.Error("Failed to find idempotency key", "key", record.Key, "error", err)
+			return nil, fmt.Errorf("failed to find idempotency key: %w", err)
+		}
+		return existing.ToRecord(), nil
+	}
+	return nil, fmt.Errorf("failed to reserve idempotency key %s after %d attempts", record.Key, reserveAttempts)
+}
+
+// Complete stores the response on condition that the key is still held by the same request
+func (r *DynamoIdempotencyRepository) Complete(ctx context.Context, record *domainrepo.IdempotencyRecord) error {
+	item := IdempotencyItemFromRecord(record)
+	item.State = idempotencyCompleted
+	table := r.client.GetTable()
+
+	err := table.Put(item).
+		If("'State' = ? AND 'RequestHash' = ?", idempotencyPending, item.RequestHash).
+		Run(ctx)
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return fmt.Errorf("idempotency key %s is no longer reserved by this request", record.Key)
+		}
+		slog.Error("Failed to complete idempotency key", "key", record.Key, "error", err)
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release deletes the in-flight record of the key. A completed record is left in place.
+func (r *DynamoIdempotencyRepository) Release(ctx context.Context, key string) error {
+	table := r.client.GetTable()
+
+	err := table.Delete("PK", idempotencyKey(key)).
+		Range("SK", idempotencyKey(key)).
+		If("'State' = ?", idempotencyPending).
+		Run(ctx)
+	if err != nil && !dynamo.IsCondCheckFailed(err) {
+		slog.Error("Failed to release idempotency key", "key", key, "error", err)
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/config"
+	domainrepo "dynamo-modeling/internal/domain/repository"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+func TestIdempotencyItemConversion(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	record := &domainrepo.IdempotencyRecord{
+		Key:         "key-123",
+		RequestHash: "hash-123",
+		Completed:   true,
+		StatusCode:  201,
+		ContentType: "application/json",
+		Body:        []byte(`{"id":"order-123"}`),
+		CreatedAt:   createdAt,
+		ExpiresAt:   createdAt.Add(24 * time.Hour),
+	}
+
+	item := IdempotencyItemFromRecord(record)
+	assert.Equal(t, "IDEMPOTENCY#key-123", item.PK)
+	assert.Equal(t, "IDEMPOTENCY#key-123", item.SK)
+	assert.Equal(t, "IDEMPOTENCY", item.Type)
+	assert.Equal(t, "completed", item.State)
+
+	assert.Equal(t, record, item.ToRecord())
+
+	record.Completed = false
+	assert.Equal(t, "pending", IdempotencyItemFromRecord(record).State)
+}
+
+// TestDynamoIdempotencyRepository runs integration tests against DynamoDB Local
+func TestDynamoIdempotencyRepository(t *testing.T) {
+	// Skip if not running integration tests
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
+	require.NoError(t, err)
+	require.NoError(t, client.HealthCheck(ctx))
+
+	repo := NewDynamoIdempotencyRepository(client)
+
+	key := fmt.Sprintf("test-idempotency-%d", time.Now().UnixNano())
+	defer client.GetTable().Delete("PK", idempotencyKey(key)).Range("SK", idempotencyKey(key)).Run(ctx)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	record := &domainrepo.IdempotencyRecord{Key: key, RequestHash: "hash-1", CreatedAt: now, ExpiresAt: now.Add(time.Minute)}
+
+	existing, err := repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// A second request for the key sees the first one in flight
+	existing, err = repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.False(t, existing.Completed)
+
+	// Releasing lets the key be reserved again
+	require.NoError(t, repo.Release(ctx, key))
+	existing, err = repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	record.Completed = true
+	record.StatusCode = 201
+	record.ContentType = "application/json"
+	record.Body = []byte(`{"id":"order-1"}`)
+	record.ExpiresAt = now.Add(24 * time.Hour)
+	require.NoError(t, repo.Complete(ctx, record))
+
+	// A completed key is kept by Release and returned with its response
+	require.NoError(t, repo.Release(ctx, key))
+	existing, err = repo.Reserve(ctx, &domainrepo.IdempotencyRecord{Key: key, RequestHash: "hash-2", CreatedAt: now, ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.True(t, existing.Completed)
+	assert.Equal(t, "hash-1", existing.RequestHash)
+	assert.Equal(t, 201, existing.StatusCode)
+	assert.Equal(t, `{"id":"order-1"}`, string(existing.Body))
+}
@@ -0,0 +1,362 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/guregu/dynamo/v2"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// DynamoInvoiceRepository implements InvoiceRepository using DynamoDB
+type DynamoInvoiceRepository struct {
+	client *infrastructure.DynamoDBClient
+}
+
+// NewDynamoInvoiceRepository creates a new DynamoDB invoice repository
+func NewDynamoInvoiceRepository(client *infrastructure.DynamoDBClient) *DynamoInvoiceRepository {
+	return &DynamoInvoiceRepository{
+		client: client,
+	}
+}
+
+// InvoiceLineData represents one billed line in the Lines attribute of an invoice
+type InvoiceLineData struct {
+	ProductID string `dynamo:"ProductID"` // ProductID
+	Quantity  int    `dynamo:"Quantity"`  // Billed quantity
+	UnitPrice int64  `dynamo:"UnitPrice"` // Price in cents
+	Total     int64  `dynamo:"Total"`     // Line total in cents
+}
+
+// InvoicePaymentData represents one payment in the Payments map of an invoice
+type InvoicePaymentData struct {
+	Method string    `dynamo:"Method"` // Payment method
+	Amount int64     `dynamo:"Amount"` // Amount in cents
+	PaidAt time.Time `dynamo:"PaidAt"` // Payment timestamp
+}
+
+// InvoiceItem represents an invoice stored in the order item collection. Its sort key is the same
+// for every order, so an order can only ever hold one invoice.
+// GSI1 looks it up by invoice ID and GSI2 lists a customer's invoices by issue date.
+type InvoiceItem struct {
+	PK         string                        `dynamo:"PK"`         // ORDER#{OrderID}
+	SK         string                        `dynamo:"SK"`         // INVOICE
+	GSI1PK     string                        `dynamo:"GSI1PK"`     // INVOICE#{InvoiceID}
+	GSI1SK     string                        `dynamo:"GSI1SK"`     // INVOICE#{InvoiceID}
+	GSI2PK     string                        `dynamo:"GSI2PK"`     // CUSTOMER#{CustomerID}
+	GSI2SK     string                        `dynamo:"GSI2SK"`     // INVOICE#{IssuedAt in UTC}#{InvoiceID}
+	Type       string                        `dynamo:"Type"`       // "INVOICE"
+	ID         string                        `dynamo:"ID"`         // InvoiceID
+	OrderID    string                        `dynamo:"OrderID"`    // OrderID
+	CustomerID string                        `dynamo:"CustomerID"` // CustomerID
+	Lines      []InvoiceLineData             `dynamo:"Lines"`      // Billed lines
+	Subtotal   int64                         `dynamo:"Subtotal"`   // Subtotal in cents
+	TaxRate    int                           `dynamo:"TaxRate"`    // Tax rate in percent
+	Tax        int64                         `dynamo:"Tax"`        // Tax in cents
+	Total      int64                         `dynamo:"Total"`      // Total in cents
+	Payments   map[string]InvoicePaymentData `dynamo:"Payments"`   // Payments keyed by payment ID
+	Status     string                        `dynamo:"Status"`     // Invoice status
+	IssuedAt   time.Time                     `dynamo:"IssuedAt"`   // Issue timestamp
+	UpdatedAt  time.Time                     `dynamo:"UpdatedAt"`  // Last update timestamp
+	Version    int                           `dynamo:"Version"`    // Optimistic locking version
+}
+
+// ToEntity converts InvoiceItem to Invoice entity
+func (item *InvoiceItem) ToEntity() (*entity.Invoice, error) {
+	invoiceID, err := value.NewInvoiceID(item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice ID: %w", err)
+	}
+
+	orderID, err := value.NewOrderID(item.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	customerID, err := value.NewCustomerID(item.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer ID: %w", err)
+	}
+
+	lines := make([]entity.InvoiceLine, 0, len(item.Lines))
+	for _, data := range item.Lines {
+		productID, err := value.NewProductID(data.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID in invoice line: %w", err)
+		}
+		unitPrice, err := value.NewMoney(data.UnitPrice)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unit price in invoice line: %w", err)
+		}
+		total, err := value.NewMoney(data.Total)
+		if err != nil {
+			return nil, fmt.Errorf("invalid total in invoice line: %w", err)
+		}
+		lines = append(lines, entity.InvoiceLine{
+			ProductID: productID,
+			Quantity:  data.Quantity,
+			UnitPrice: unitPrice,
+			Total:     total,
+		})
+	}
+
+	payments := make(map[value.PaymentID]entity.Payment, len(item.Payments))
+	for id, data := range item.Payments {
+		paymentID, err := value.NewPaymentID(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payment ID: %w", err)
+		}
+		amount, err := value.NewMoney(data.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payment amount: %w", err)
+		}
+		payments[paymentID] = entity.Payment{
+			Method: entity.PaymentMethod(data.Method),
+			Amount: amount,
+			PaidAt: data.PaidAt,
+		}
+	}
+
+	subtotal, err := value.NewMoney(item.Subtotal)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subtotal: %w", err)
+	}
+	tax, err := value.NewMoney(item.Tax)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tax: %w", err)
+	}
+	total, err := value.NewMoney(item.Total)
+	if err != nil {
+		return nil, fmt.Errorf("invalid total amount: %w", err)
+	}
+
+	invoice, err := entity.NewInvoiceWithState(
+		invoiceID,
+		orderID,
+		customerID,
+		lines,
+		subtotal,
+		item.TaxRate,
+		tax,
+		total,
+		payments,
+		entity.InvoiceStatus(item.Status),
+		item.IssuedAt,
+		item.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invoice entity: %w", err)
+	}
+	invoice.SetVersion(item.Version)
+
+	return invoice, nil
+}
+
+// InvoiceItemFromEntity converts Invoice entity to InvoiceItem
+func InvoiceItemFromEntity(invoice *entity.Invoice) *InvoiceItem {
+	invoiceID := invoice.ID().String()
+	customerID := invoice.CustomerID().String()
+
+	lines := make([]InvoiceLineData, 0, len(invoice.Lines()))
+	for _, line := range invoice.Lines() {
+		lines = append(lines, InvoiceLineData{
+			ProductID: line.ProductID.String(),
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice.Cents(),
+			Total:     line.Total.Cents(),
+		})
+	}
+
+	payments := make(map[string]InvoicePaymentData, len(invoice.Payments()))
+	for paymentID, payment := range invoice.Payments() {
+		payments[paymentID.String()] = InvoicePaymentData{
+			Method: string(payment.Method),
+			Amount: payment.Amount.Cents(),
+			PaidAt: payment.PaidAt,
+		}
+	}
+
+	return &InvoiceItem{
+		PK:         fmt.Sprintf("ORDER#%s", invoice.OrderID().String()),
+		SK:         invoiceSK,
+		GSI1PK:     fmt.Sprintf("INVOICE#%s", invoiceID),
+		GSI1SK:     fmt.Sprintf("INVOICE#%s", invoiceID),
+		GSI2PK:     fmt.Sprintf("CUSTOMER#%s", customerID),
+		GSI2SK:     fmt.Sprintf("INVOICE#%s#%s", invoice.IssuedAt().UTC().Format(time.RFC3339), invoiceID),
+		Type:       "INVOICE",
+		ID:         invoiceID,
+		OrderID:    invoice.OrderID().String(),
+		CustomerID: customerID,
+		Lines:      lines,
+		Subtotal:   invoice.Subtotal().Cents(),
+		TaxRate:    invoice.TaxRate(),
+		Tax:        invoice.Tax().Cents(),
+		Total:      invoice.Total().Cents(),
+		Payments:   payments,
+		Status:     string(invoice.Status()),
+		IssuedAt:   invoice.IssuedAt(),
+		UpdatedAt:  invoice.UpdatedAt(),
+		Version:    invoice.Version(),
+	}
+}
+
+// invoiceSK is the sort key of the invoice in its order item collection
+const invoiceSK = "INVOICE"
+
+// invoiceDateRange returns the inclusive GSI2SK bounds of invoices issued between from and to.
+// Issue times are keyed in UTC at second precision so that they sort lexically; a zero time leaves that end open.
+func invoiceDateRange(from, to time.Time) (string, string) {
+	lower := "INVOICE#"
+	if !from.IsZero() {
+		lower += from.UTC().Format(time.RFC3339)
+	}
+	upper := "INVOICE#~"
+	if !to.IsZero() {
+		upper = "INVOICE#" + to.UTC().Format(time.RFC3339) + "#~"
+	}
+	return lower, upper
+}
+
+// Save creates or updates an invoice. Creating a second invoice for an order fails with
+// domain.InvoiceAlreadyExistsError, because both are written under the same key.
+func (r *DynamoInvoiceRepository) Save(ctx context.Context, invoice *entity.Invoice) error {
+	slog.Info("Saving invoice", "invoiceID", invoice.ID().String())
+
+	item := InvoiceItemFromEntity(invoice)
+	item.Version = invoice.Version() + 1
+	table := r.client.GetTable()
+
+	err := putIfVersion(table.Put(item), invoice.Version()).Run(ctx)
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) && invoice.Version() == 0 {
+			slog.Warn("Order is already invoiced", "orderID", item.OrderID, "invoiceID", invoice.ID().String())
+			return domain.InvoiceAlreadyExistsError(item.OrderID)
+		}
+		if dynamo.IsCondCheckFailed(err) {
+			slog.Warn("Invoice was modified concurrently", "invoiceID", invoice.ID().String(), "version", invoice.Version())
+			return domain.ConcurrentModificationError("Invoice", invoice.ID().String())
+		}
+		slog.Error("Failed to save invoice", "invoiceID", invoice.ID().String(), "error", err)
+		return fmt.Errorf("failed to save invoice: %w", err)
+	}
+	invoice.SetVersion(item.Version)
+
+	slog.Info("Invoice saved successfully", "invoiceID", invoice.ID().String())
+	return nil
+}
+
+// FindByID retrieves an invoice by its ID through GSI1
+func (r *DynamoInvoiceRepository) FindByID(ctx context.Context, id value.InvoiceID) (*entity.Invoice, error) {
+	slog.Info("Finding invoice by ID", "invoiceID", id.String())
+
+	var item InvoiceItem
+	table := r.client.GetTable()
+
+	key := fmt.Sprintf("INVOICE#%s", id.String())
+	err := table.Get("GSI1PK", key).
+		Range("GSI1SK", dynamo.Equal, key).
+		Index("GSI1").
+		One(ctx, &item)
+	if err != nil {
+		if err == dynamo.ErrNotFound {
+			slog.Info("Invoice not found", "invoiceID", id.String())
+			return nil, domain.InvoiceNotFoundError(id.String())
+		}
+		slog.Error("Failed to find invoice", "invoiceID", id.String(), "error", err)
+		return nil, fmt.Errorf("failed to find invoice: %w", err)
+	}
+
+	invoice, err := item.ToEntity()
+	if err != nil {
+		slog.Error("Failed to convert item to entity", "invoiceID", id.String(), "error", err)
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+
+	slog.Info("Invoice found successfully", "invoiceID", id.String())
+	return invoice, nil
+}
+
+// FindByOrderID retrieves the invoice for an order from the order item collection
+func (r *DynamoInvoiceRepository) FindByOrderID(ctx context.Context, orderID value.OrderID) (*entity.Invoice, error) {
+	slog.Info("Finding invoice by order ID", "orderID", orderID.String())
+
+	var item InvoiceItem
+	table := r.client.GetTable()
+
+	err := table.Get("PK", fmt.Sprintf("ORDER#%s", orderID.String())).
+		Range("SK", dynamo.Equal, invoiceSK).
+		Consistent(true).
+		One(ctx, &item)
+	if err == dynamo.ErrNotFound {
+		slog.Info("Order has no invoice", "orderID", orderID.String())
+		return nil, nil
+	}
+	if err != nil {
+		slog.Error("Failed to find invoice by order ID", "orderID", orderID.String(), "error", err)
+		return nil, fmt.Errorf("failed to find invoice by order ID: %w", err)
+	}
+
+	invoice, err := item.ToEntity()
+	if err != nil {
+		slog.Error("Failed to convert item to entity", "orderID", orderID.String(), "error", err)
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+
+	return invoice, nil
+}
+
+// FindByCustomerID retrieves a customer's invoices issued in a date range through GSI2, oldest first
+func (r *DynamoInvoiceRepository) FindByCustomerID(ctx context.Context, customerID value.CustomerID, from, to time.Time, limit int, lastKey *string) ([]*entity.Invoice, *string, error) {
+	slog.Info("Finding invoices by customer ID", "customerID", customerID.String(), "from", from, "to", to, "limit", limit)
+
+	lower, upper := invoiceDateRange(from, to)
+	scope := "invoices:customer:" + customerID.String() + ":" + lower + ":" + upper
+	start, err := startKey(r.client, scope, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []InvoiceItem
+	table := r.client.GetTable()
+
+	query := table.Get("GSI2PK", fmt.Sprintf("CUSTOMER#%s", customerID.String())).
+		Range("GSI2SK", dynamo.Between, lower, upper).
+		Index("GSI2")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if start != nil {
+		query = query.StartFrom(start)
+	}
+
+	lek, err := query.AllWithLastEvaluatedKey(ctx, &items)
+	if err != nil {
+		slog.Error("Failed to find invoices by customer ID", "customerID", customerID.String(), "error", err)
+		return nil, nil, fmt.Errorf("failed to find invoices by customer ID: %w", err)
+	}
+
+	invoices := make([]*entity.Invoice, 0, len(items))
+	for _, item := range items {
+		invoice, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "invoiceID", item.ID, "error", err)
+			continue // Skip invalid items
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	next, err := r.client.Cursors.Encode(scope, lek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	slog.Info("Found invoices by customer ID successfully", "customerID", customerID.String(), "count", len(invoices))
+	return invoices, next, nil
+}
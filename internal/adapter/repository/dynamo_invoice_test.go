@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+func TestInvoiceItemConversion(t *testing.T) {
+	orderID, _ := value.NewOrderID("order-123")
+	customerID, _ := value.NewCustomerID("customer-456")
+	productID, _ := value.NewProductID("product-1")
+	price, _ := value.NewMoney(1000)
+	orderItem, _ := entity.NewOrderItem(productID, 3, price)
+	order, err := entity.NewOrder(orderID, customerID, []entity.OrderItem{*orderItem})
+	require.NoError(t, err)
+
+	invoiceID, _ := value.NewInvoiceID("invoice-789")
+	invoice, err := entity.NewInvoice(invoiceID, order, entity.DefaultTaxRate)
+	require.NoError(t, err)
+	amount, _ := value.NewMoney(500)
+	require.NoError(t, invoice.AddPayment("payment-1", entity.PaymentMethodGiftCard, amount))
+
+	item := InvoiceItemFromEntity(invoice)
+
+	// Stored in the order item collection, looked up by ID on GSI1 and by customer and date on GSI2
+	issuedAt := invoice.IssuedAt().UTC().Format(time.RFC3339)
+	assert.Equal(t, "ORDER#order-123", item.PK)
+	assert.Equal(t, "INVOICE", item.SK)
+	assert.Equal(t, "INVOICE#invoice-789", item.GSI1PK)
+	assert.Equal(t, "INVOICE#invoice-789", item.GSI1SK)
+	assert.Equal(t, "CUSTOMER#customer-456", item.GSI2PK)
+	assert.Equal(t, "INVOICE#"+issuedAt+"#invoice-789", item.GSI2SK)
+	assert.Equal(t, "INVOICE", item.Type)
+	assert.Equal(t, int64(3000), item.Subtotal)
+	assert.Equal(t, int64(300), item.Tax)
+	assert.Equal(t, int64(3300), item.Total)
+	require.Len(t, item.Lines, 1)
+	assert.Equal(t, int64(3000), item.Lines[0].Total)
+	assert.Equal(t, "gift_card", item.Payments["payment-1"].Method)
+
+	converted, err := item.ToEntity()
+	require.NoError(t, err)
+	assert.Equal(t, invoiceID, converted.ID())
+	assert.Equal(t, orderID, converted.OrderID())
+	assert.Equal(t, customerID, converted.CustomerID())
+	assert.Equal(t, entity.InvoiceStatusPartiallyPaid, converted.Status())
+	assert.Equal(t, int64(2800), converted.Balance().Cents())
+	assert.Equal(t, invoice.Payments(), converted.Payments())
+	assert.Equal(t, invoice.Lines(), converted.Lines())
+}
+
+func TestInvoiceDateRange(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+
+	t.Run("open range covers every invoice", func(t *testing.T) {
+		lower, upper := invoiceDateRange(time.Time{}, time.Time{})
+		assert.Equal(t, "INVOICE#", lower)
+		assert.Equal(t, "INVOICE#~", upper)
+	})
+
+	t.Run("bounds are keyed in UTC and include the whole last second", func(t *testing.T) {
+		from := time.Date(2025, 6, 1, 9, 0, 0, 0, jst)
+		to := time.Date(2025, 6, 15, 9, 0, 0, 0, jst)
+
+		lower, upper := invoiceDateRange(from, to)
+		assert.Equal(t, "INVOICE#2025-06-01T00:00:00Z", lower)
+		assert.Equal(t, "INVOICE#2025-06-15T00:00:00Z#~", upper)
+		assert.True(t, "INVOICE#2025-06-15T00:00:00Z#invoice-1" <= upper)
+		assert.False(t, "INVOICE#2025-06-15T00:00:01Z#invoice-1" <= upper)
+	})
+}
+
+// TestDynamoInvoiceRepository runs integration tests against DynamoDB Local
+func TestDynamoInvoiceRepository(t *testing.T) {
+	// Skip if not running integration tests
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
+	require.NoError(t, err)
+	require.NoError(t, client.HealthCheck(ctx))
+
+	repo := NewDynamoInvoiceRepository(client)
+	orderRepo := NewDynamoOrderRepository(client)
+
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+	orderID, _ := value.NewOrderID("test-invoice-order-" + suffix)
+	customerID, _ := value.NewCustomerID("test-invoice-customer-" + suffix)
+	productID, _ := value.NewProductID("test-invoice-product-" + suffix)
+
+	price, _ := value.NewMoney(1200)
+	orderItem, _ := entity.NewOrderItem(productID, 2, price)
+	order, err := entity.NewOrder(orderID, customerID, []entity.OrderItem{*orderItem})
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Save(ctx, order))
+	defer orderRepo.Delete(ctx, orderID)
+
+	invoiceID, _ := value.NewInvoiceID("test-invoice-" + suffix)
+	invoice, err := entity.NewInvoice(invoiceID, order, entity.DefaultTaxRate)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, invoice))
+
+	t.Run("order lookup ignores the invoice in the collection", func(t *testing.T) {
+		found, err := orderRepo.FindByID(ctx, orderID)
+		require.NoError(t, err)
+		assert.Len(t, found.Items(), 1)
+	})
+
+	t.Run("find invoice by ID, order and customer", func(t *testing.T) {
+		amount, _ := value.NewMoney(640)
+		require.NoError(t, invoice.AddPayment("payment-1", entity.PaymentMethodCreditCard, amount))
+		require.NoError(t, repo.Save(ctx, invoice))
+
+		found, err := repo.FindByID(ctx, invoiceID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2640), found.Total().Cents())
+		assert.Equal(t, int64(2000), found.Balance().Cents())
+
+		found, err = repo.FindByOrderID(ctx, orderID)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, invoiceID, found.ID())
+
+		invoices, _, err := repo.FindByCustomerID(ctx, customerID, time.Now().Add(-time.Hour), time.Now().Add(time.Hour), 10, nil)
+		require.NoError(t, err)
+		require.Len(t, invoices, 1)
+		assert.Equal(t, invoiceID, invoices[0].ID())
+
+		invoices, _, err = repo.FindByCustomerID(ctx, customerID, time.Now().Add(time.Hour), time.Time{}, 10, nil)
+		require.NoError(t, err)
+		assert.Empty(t, invoices)
+	})
+}
@@ -3,12 +3,16 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/guregu/dynamo/v2"
 
+	"dynamo-modeling/internal/domain"
 	"dynamo-modeling/internal/domain/entity"
 	"dynamo-modeling/internal/domain/value"
 	"dynamo-modeling/internal/infrastructure"
@@ -26,31 +30,174 @@ func NewDynamoOrderRepository(client *infrastructure.DynamoDBClient) *DynamoOrde
 	}
 }
 
-// OrderItemData represents order item data for DynamoDB storage
+// OrderItemData represents order item data in the legacy JSON Items attribute
 type OrderItemData struct {
 	ProductID string `json:"productId"`
 	Quantity  int    `json:"quantity"`
 	UnitPrice int64  `json:"unitPrice"` // Price in cents
 }
 
-// OrderItem represents an order item in DynamoDB
+// OrderItem represents the order header item in DynamoDB
 type OrderItem struct {
-	PK         string    `dynamo:"PK"`         // ORDER#{OrderID}
-	SK         string    `dynamo:"SK"`         // ORDER#{OrderID}
-	GSI1PK     string    `dynamo:"GSI1PK"`     // CUSTOMER#{CustomerID}
-	GSI1SK     string    `dynamo:"GSI1SK"`     // ORDER#{CreatedAt}#{OrderID}
-	Type       string    `dynamo:"Type"`       // "ORDER"
-	ID         string    `dynamo:"ID"`         // OrderID
-	CustomerID string    `dynamo:"CustomerID"` // CustomerID
-	Items      string    `dynamo:"Items"`      // JSON array of OrderItemData
-	Status     string    `dynamo:"Status"`     // Order status
-	Total      int64     `dynamo:"Total"`      // Total price in cents
-	CreatedAt  time.Time `dynamo:"CreatedAt"`  // Creation timestamp
-	UpdatedAt  time.Time `dynamo:"UpdatedAt"`  // Last update timestamp
-}
-
-// ToEntity converts OrderItem to Order entity
-func (item *OrderItem) ToEntity() (*entity.Order, error) {
+	PK         string    `dynamo:"PK"`              // ORDER#{OrderID}
+	SK         string    `dynamo:"SK"`              // ORDER#{OrderID}
+	GSI1PK     string    `dynamo:"GSI1PK"`          // CUSTOMER#{CustomerID}
+	GSI1SK     string    `dynamo:"GSI1SK"`          // ORDER#{CreatedAt}#{OrderID}
+	GSI2PK     string    `dynamo:"GSI2PK"`          // STATUS#{Status}
+	GSI2SK     string    `dynamo:"GSI2SK"`          // {CreatedAt}#{OrderID}
+	Type       string    `dynamo:"Type"`            // "ORDER"
+	ID         string    `dynamo:"ID"`              // OrderID
+	CustomerID string    `dynamo:"CustomerID"`      // CustomerID
+	Items      string    `dynamo:"Items,omitempty"` // Legacy JSON array of OrderItemData (before LINE# items)
+	Status     string    `dynamo:"Status"`          // Order status
+	Total      int64     `dynamo:"Total"`           // Total price in cents
+	CreatedAt  time.Time `dynamo:"CreatedAt"`       // Creation timestamp
+	UpdatedAt  time.Time `dynamo:"UpdatedAt"`       // Last update timestamp
+	Version    int       `dynamo:"Version"`         // Optimistic locking version
+}
+
+// OrderLineItem represents one order line stored in the order item collection.
+// Lines are mirrored on GSI1 under their product so that a product's orders can be found by order date.
+type OrderLineItem struct {
+	PK        string `dynamo:"PK"`        // ORDER#{OrderID}
+	SK        string `dynamo:"SK"`        // LINE#{ProductID}
+	GSI1PK    string `dynamo:"GSI1PK"`    // PRODUCT#{ProductID}
+	GSI1SK    string `dynamo:"GSI1SK"`    // ORDER#{CreatedAt}#{OrderID}
+	Type      string `dynamo:"Type"`      // "ORDER_LINE"
+	OrderID   string `dynamo:"OrderID"`   // OrderID
+	ProductID string `dynamo:"ProductID"` // ProductID
+	Quantity  int    `dynamo:"Quantity"`  // Ordered quantity
+	UnitPrice int64  `dynamo:"UnitPrice"` // Price in cents
+}
+
+// OrderStatusItem is one entry of an order's status history, stored in the order item collection.
+// Entries are only ever added, so the history keeps who moved the order through each status.
+type OrderStatusItem struct {
+	PK        string    `dynamo:"PK"`             // ORDER#{OrderID}
+	SK        string    `dynamo:"SK"`             // STATUS#{ChangedAt}
+	Type      string    `dynamo:"Type"`           // "ORDER_STATUS"
+	OrderID   string    `dynamo:"OrderID"`        // OrderID
+	From      string    `dynamo:"From,omitempty"` // Previous status, empty for a placed order
+	To        string    `dynamo:"To"`             // New status
+	Actor     string    `dynamo:"Actor"`          // Who made the change
+	Reason    string    `dynamo:"Reason"`         // Why the change was made
+	ChangedAt time.Time `dynamo:"ChangedAt"`      // Time of the change
+}
+
+// statusTimeLayout formats the time in a status history key. Its fixed width keeps the keys
+// sorting in time order.
+const statusTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// OrderStatusItemsFromEntity converts the unsaved status changes of an order to history items
+func OrderStatusItemsFromEntity(order *entity.Order) []OrderStatusItem {
+	orderID := order.ID().String()
+	changes := order.UnsavedStatusChanges()
+	items := make([]OrderStatusItem, 0, len(changes))
+	for _, change := range changes {
+		items = append(items, OrderStatusItem{
+			PK:        fmt.Sprintf("ORDER#%s", orderID),
+			SK:        fmt.Sprintf("STATUS#%s", change.ChangedAt.UTC().Format(statusTimeLayout)),
+			Type:      "ORDER_STATUS",
+			OrderID:   orderID,
+			From:      string(change.From),
+			To:        string(change.To),
+			Actor:     change.Actor,
+			Reason:    change.Reason,
+			ChangedAt: change.ChangedAt,
+		})
+	}
+	return items
+}
+
+// ToEntity converts a history item to an order status change
+func (item *OrderStatusItem) ToEntity() entity.OrderStatusChange {
+	return entity.OrderStatusChange{
+		From:      entity.OrderStatus(item.From),
+		To:        entity.OrderStatus(item.To),
+		Actor:     item.Actor,
+		Reason:    item.Reason,
+		ChangedAt: item.ChangedAt,
+	}
+}
+
+// OrderReservationItem records the stock an order took from one warehouse, stored in the order
+// item collection so that a cancellation can give it back to the same warehouse
+type OrderReservationItem struct {
+	PK          string `dynamo:"PK"`          // ORDER#{OrderID}
+	SK          string `dynamo:"SK"`          // RESERVATION#{ProductID}#{WarehouseID}
+	Type        string `dynamo:"Type"`        // "ORDER_RESERVATION"
+	OrderID     string `dynamo:"OrderID"`     // OrderID
+	ProductID   string `dynamo:"ProductID"`   // ProductID
+	WarehouseID string `dynamo:"WarehouseID"` // Warehouse the stock was taken from
+	Quantity    int    `dynamo:"Quantity"`    // Quantity taken from the warehouse
+}
+
+// orderReservationItems converts the warehouse allocations of an order to reservation items
+func orderReservationItems(orderID string, productIDs []string, allocations map[string][]stockAllocation) []OrderReservationItem {
+	var items []OrderReservationItem
+	for _, productID := range productIDs {
+		for _, allocation := range allocations[productID] {
+			items = append(items, OrderReservationItem{
+				PK:          fmt.Sprintf("ORDER#%s", orderID),
+				SK:          fmt.Sprintf("RESERVATION#%s#%s", productID, allocation.item.WarehouseID),
+				Type:        "ORDER_RESERVATION",
+				OrderID:     orderID,
+				ProductID:   productID,
+				WarehouseID: allocation.item.WarehouseID,
+				Quantity:    allocation.quantity,
+			})
+		}
+	}
+	return items
+}
+
+// restockAllocations returns the warehouse inventories that take back the stock of a cancelled
+// order. Each reservation goes back to the warehouse it was taken from. Stock without a
+// reservation, as for orders placed before reservations were recorded, or whose warehouse no
+// longer holds the product, goes to the product's first warehouse; a product without any
+// warehouse inventory only gets its total back.
+func restockAllocations(productIDs []string, quantities map[string]int, reservations []OrderReservationItem, inventory map[string][]*InventoryItem) []stockAllocation {
+	var restocks []stockAllocation
+	for _, productID := range productIDs {
+		items := make([]*InventoryItem, len(inventory[productID]))
+		copy(items, inventory[productID])
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].WarehouseID < items[j].WarehouseID
+		})
+		byWarehouse := make(map[string]int, len(items)) // index into restocks
+		add := func(item *InventoryItem, quantity int) {
+			if i, ok := byWarehouse[item.WarehouseID]; ok {
+				restocks[i].quantity += quantity
+				return
+			}
+			byWarehouse[item.WarehouseID] = len(restocks)
+			restocks = append(restocks, stockAllocation{item: item, quantity: quantity})
+		}
+
+		remaining := quantities[productID]
+		for _, reservation := range reservations {
+			if reservation.ProductID != productID || remaining == 0 {
+				continue
+			}
+			for _, item := range items {
+				if item.WarehouseID == reservation.WarehouseID {
+					quantity := min(reservation.Quantity, remaining)
+					add(item, quantity)
+					remaining -= quantity
+					break
+				}
+			}
+		}
+		if remaining > 0 && len(items) > 0 {
+			add(items[0], remaining)
+		}
+	}
+	return restocks
+}
+
+// ToEntity converts the order header and its lines to an Order entity.
+// Orders written before lines became separate items are read from the legacy Items attribute.
+func (item *OrderItem) ToEntity(lines []OrderLineItem) (*entity.Order, error) {
 	orderID, err := value.NewOrderID(item.ID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid order ID: %w", err)
@@ -61,25 +208,26 @@ func (item *OrderItem) ToEntity() (*entity.Order, error) {
 		return nil, fmt.Errorf("invalid customer ID: %w", err)
 	}
 
-	// Parse order items
-	var itemsData []OrderItemData
-	if err := json.Unmarshal([]byte(item.Items), &itemsData); err != nil {
-		return nil, fmt.Errorf("failed to parse order items: %w", err)
+	if len(lines) == 0 && item.Items != "" {
+		lines, err = item.LegacyLines()
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	orderItems := make([]entity.OrderItem, 0, len(itemsData))
-	for _, data := range itemsData {
-		productID, err := value.NewProductID(data.ProductID)
+	orderItems := make([]entity.OrderItem, 0, len(lines))
+	for _, line := range lines {
+		productID, err := value.NewProductID(line.ProductID)
 		if err != nil {
 			return nil, fmt.Errorf("invalid product ID in order item: %w", err)
 		}
 
-		unitPrice, err := value.NewMoney(data.UnitPrice)
+		unitPrice, err := value.NewMoney(line.UnitPrice)
 		if err != nil {
 			return nil, fmt.Errorf("invalid unit price in order item: %w", err)
 		}
 
-		orderItem, err := entity.NewOrderItem(productID, data.Quantity, unitPrice)
+		orderItem, err := entity.NewOrderItem(productID, line.Quantity, unitPrice)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create order item: %w", err)
 		}
@@ -105,88 +253,450 @@ func (item *OrderItem) ToEntity() (*entity.Order, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order entity: %w", err)
 	}
+	order.SetVersion(item.Version)
 
 	return order, nil
 }
 
-// FromEntity converts Order entity to OrderItem
-func OrderItemFromEntity(order *entity.Order) (*OrderItem, error) {
+// LegacyLines converts the legacy JSON Items attribute of an order header to line items
+func (item *OrderItem) LegacyLines() ([]OrderLineItem, error) {
+	var itemsData []OrderItemData
+	if err := json.Unmarshal([]byte(item.Items), &itemsData); err != nil {
+		return nil, fmt.Errorf("failed to parse order items: %w", err)
+	}
+
+	lines := make([]OrderLineItem, 0, len(itemsData))
+	for _, data := range itemsData {
+		lines = append(lines, OrderLineItem{
+			PK:        item.PK,
+			SK:        fmt.Sprintf("LINE#%s", data.ProductID),
+			GSI1PK:    fmt.Sprintf("PRODUCT#%s", data.ProductID),
+			GSI1SK:    orderDateKey(item.CreatedAt, item.ID),
+			Type:      "ORDER_LINE",
+			OrderID:   item.ID,
+			ProductID: data.ProductID,
+			Quantity:  data.Quantity,
+			UnitPrice: data.UnitPrice,
+		})
+	}
+	return mergeOrderLines(lines)
+}
+
+// OrderItemFromEntity converts an Order entity to its header item and line items
+func OrderItemFromEntity(order *entity.Order) (*OrderItem, []OrderLineItem, error) {
 	orderID := order.ID().String()
 	customerID := order.CustomerID().String()
+	pk := fmt.Sprintf("ORDER#%s", orderID)
 
-	// Convert order items to JSON
-	itemsData := make([]OrderItemData, 0, len(order.Items()))
+	lines := make([]OrderLineItem, 0, len(order.Items()))
 	for _, item := range order.Items() {
-		itemsData = append(itemsData, OrderItemData{
+		lines = append(lines, OrderLineItem{
+			PK:        pk,
+			SK:        fmt.Sprintf("LINE#%s", item.ProductID.String()),
+			GSI1PK:    fmt.Sprintf("PRODUCT#%s", item.ProductID.String()),
+			GSI1SK:    orderDateKey(order.CreatedAt(), orderID),
+			Type:      "ORDER_LINE",
+			OrderID:   orderID,
 			ProductID: item.ProductID.String(),
 			Quantity:  item.Quantity,
 			UnitPrice: item.UnitPrice.Cents(),
 		})
 	}
 
-	itemsJSON, err := json.Marshal(itemsData)
+	lines, err := mergeOrderLines(lines)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal order items: %w", err)
+		return nil, nil, err
 	}
 
+	createdAt := order.CreatedAt().UTC().Format(time.RFC3339)
 	return &OrderItem{
-		PK:         fmt.Sprintf("ORDER#%s", orderID),
-		SK:         fmt.Sprintf("ORDER#%s", orderID),
+		PK:         pk,
+		SK:         pk,
 		GSI1PK:     fmt.Sprintf("CUSTOMER#%s", customerID),
-		GSI1SK:     fmt.Sprintf("ORDER#%s#%s", order.CreatedAt().Format(time.RFC3339), orderID),
+		GSI1SK:     orderDateKey(order.CreatedAt(), orderID),
+		GSI2PK:     fmt.Sprintf("STATUS#%s", order.Status()),
+		GSI2SK:     fmt.Sprintf("%s#%s", createdAt, orderID),
 		Type:       "ORDER",
 		ID:         orderID,
 		CustomerID: customerID,
-		Items:      string(itemsJSON),
 		Status:     string(order.Status()),
 		Total:      order.Total().Cents(),
 		CreatedAt:  order.CreatedAt(),
 		UpdatedAt:  order.UpdatedAt(),
-	}, nil
+		Version:    order.Version(),
+	}, lines, nil
+}
+
+// orderDateKey returns the GSI1SK of an order header and of its lines.
+// Order times are keyed in UTC at second precision so that orders sort lexically by date.
+func orderDateKey(createdAt time.Time, orderID string) string {
+	return fmt.Sprintf("ORDER#%s#%s", createdAt.UTC().Format(time.RFC3339), orderID)
+}
+
+// SetGSI1Keys sets the GSI1 keys of a line from its product and the creation time of its order
+func (line *OrderLineItem) SetGSI1Keys(orderCreatedAt time.Time) {
+	line.GSI1PK = fmt.Sprintf("PRODUCT#%s", line.ProductID)
+	line.GSI1SK = orderDateKey(orderCreatedAt, line.OrderID)
+}
+
+// orderDateRange returns the inclusive GSI1SK bounds of order lines for orders placed between from and to.
+// A zero time leaves that end open.
+func orderDateRange(from, to time.Time) (string, string) {
+	lower := "ORDER#"
+	if !from.IsZero() {
+		lower += from.UTC().Format(time.RFC3339)
+	}
+	upper := "ORDER#~"
+	if !to.IsZero() {
+		upper = "ORDER#" + to.UTC().Format(time.RFC3339) + "#~"
+	}
+	return lower, upper
+}
+
+// mergeOrderLines combines lines for the same product, since a product has a single LINE# item per order.
+// Lines for the same product must share a unit price.
+func mergeOrderLines(lines []OrderLineItem) ([]OrderLineItem, error) {
+	merged := make([]OrderLineItem, 0, len(lines))
+	index := make(map[string]int, len(lines))
+	for _, line := range lines {
+		i, ok := index[line.ProductID]
+		if !ok {
+			index[line.ProductID] = len(merged)
+			merged = append(merged, line)
+			continue
+		}
+		if merged[i].UnitPrice != line.UnitPrice {
+			return nil, fmt.Errorf("order has conflicting unit prices for product: %s", line.ProductID)
+		}
+		merged[i].Quantity += line.Quantity
+	}
+	return merged, nil
+}
+
+// splitOrderCollection separates the header and line items of an order item collection.
+// Other items in the collection are ignored.
+func splitOrderCollection(items []dynamo.Item) (*OrderItem, []OrderLineItem, error) {
+	var header *OrderItem
+	lines := make([]OrderLineItem, 0, len(items))
+	for _, raw := range items {
+		var key struct {
+			PK string `dynamo:"PK"`
+			SK string `dynamo:"SK"`
+		}
+		if err := dynamo.UnmarshalItem(raw, &key); err != nil {
+			return nil, nil, fmt.Errorf("failed to read item key: %w", err)
+		}
+
+		switch {
+		case key.SK == key.PK:
+			header = new(OrderItem)
+			if err := dynamo.UnmarshalItem(raw, header); err != nil {
+				return nil, nil, fmt.Errorf("failed to unmarshal order header: %w", err)
+			}
+		case strings.HasPrefix(key.SK, "LINE#"):
+			var line OrderLineItem
+			if err := dynamo.UnmarshalItem(raw, &line); err != nil {
+				return nil, nil, fmt.Errorf("failed to unmarshal order line: %w", err)
+			}
+			lines = append(lines, line)
+		}
+	}
+	return header, lines, nil
 }
 
-// Save creates or updates an order
+// Save creates or updates an order together with its lines
 func (r *DynamoOrderRepository) Save(ctx context.Context, order *entity.Order) error {
 	slog.Info("Saving order", "orderID", order.ID().String())
 
-	item, err := OrderItemFromEntity(order)
+	item, lines, err := OrderItemFromEntity(order)
 	if err != nil {
 		return fmt.Errorf("failed to convert order to item: %w", err)
 	}
+	item.Version = order.Version() + 1
 
 	table := r.client.GetTable()
+	tx := r.client.DB.WriteTx()
+
+	// 0: 注文ヘッダ（楽観ロック）、以降: 明細、ステータス履歴（追記のみ）
+	tx.Put(putIfVersion(table.Put(item), order.Version()))
+	for _, line := range lines {
+		tx.Put(table.Put(line))
+	}
+	for _, status := range OrderStatusItemsFromEntity(order) {
+		tx.Put(table.Put(status).If("attribute_not_exists('PK')"))
+	}
 
-	err = table.Put(item).Run(ctx)
+	err = tx.Run(ctx)
 	if err != nil {
+		if i, ok := failedCondition(err); ok && i == 0 {
+			slog.Warn("Order was modified concurrently", "orderID", order.ID().String(), "version", order.Version())
+			return domain.ConcurrentModificationError("Order", order.ID().String())
+		}
 		slog.Error("Failed to save order", "orderID", order.ID().String(), "error", err)
 		return fmt.Errorf("failed to save order: %w", err)
 	}
+	order.SetVersion(item.Version)
+	order.MarkStatusChangesSaved()
 
 	slog.Info("Order saved successfully", "orderID", order.ID().String())
 	return nil
 }
 
-// FindByID retrieves an order by ID
-func (r *DynamoOrderRepository) FindByID(ctx context.Context, id value.OrderID) (*entity.Order, error) {
-	slog.Info("Finding order by ID", "orderID", id.String())
+// PlaceOrder reserves stock for every order line from the product's warehouse inventories and
+// creates the order in a single transaction
+func (r *DynamoOrderRepository) PlaceOrder(ctx context.Context, order *entity.Order) error {
+	slog.Info("Placing order", "orderID", order.ID().String())
+
+	item, lines, err := OrderItemFromEntity(order)
+	if err != nil {
+		return fmt.Errorf("failed to convert order to item: %w", err)
+	}
+	item.Version = order.Version() + 1
+
+	table := r.client.GetTable()
+
+	// 各商品の倉庫別在庫を読み、引当先の倉庫を決める
+	productIDs, quantities := reservationQuantities(order.Items())
+	allocations := make(map[string][]stockAllocation, len(productIDs))
+	for _, productID := range productIDs {
+		inventory, err := productInventory(ctx, table, productID, true)
+		if err != nil {
+			slog.Error("Failed to load inventory", "orderID", order.ID().String(), "productID", productID, "error", err)
+			return fmt.Errorf("failed to place order: %w", err)
+		}
+		allocated, ok := allocateInventory(inventory, quantities[productID])
+		if !ok {
+			slog.Warn("Stock reservation failed", "orderID", order.ID().String(), "productID", productID)
+			return domain.InsufficientStockError(productID)
+		}
+		allocations[productID] = allocated
+	}
+
+	// 商品と倉庫ごとの減算、ヘッダ、明細、ステータス履歴、引当が 1 つのトランザクションに収まるか確かめる
+	statuses := OrderStatusItemsFromEntity(order)
+	writes := placeOrderWrites(len(lines), len(statuses), allocations)
+	if writes > maxTransactionItems {
+		slog.Warn("Order does not fit in one transaction", "orderID", order.ID().String(), "writes", writes)
+		return domain.RuleViolationError("order takes stock from too many warehouses to be placed at once; split it into smaller orders")
+	}
+
+	tx := r.client.DB.WriteTx()
+	// reservedProducts[i] は i 番目のトランザクション要素が引き当てる商品
+	reservedProducts := make([]string, 0, len(productIDs))
+
+	// 在庫合計の条件付き減算（同一商品の明細は1つの更新にまとめる）
+	for _, productID := range productIDs {
+		key := fmt.Sprintf("PRODUCT#%s", productID)
+		tx.Update(table.Update("PK", key).
+			Range("SK", key).
+			SetExpr("'Stock' = 'Stock' - ?", quantities[productID]).
+			Set("UpdatedAt", order.CreatedAt()).
+			Add("Version", 1).
+			If("attribute_exists('PK') AND 'Stock' >= ?", quantities[productID]))
+		reservedProducts = append(reservedProducts, productID)
+	}
+
+	// 倉庫別在庫の条件付き減算
+	for _, productID := range productIDs {
+		for _, allocation := range allocations[productID] {
+			tx.Update(table.Update("PK", allocation.item.PK).
+				Range("SK", allocation.item.SK).
+				SetExpr("'Quantity' = 'Quantity' - ?", allocation.quantity).
+				Set("UpdatedAt", order.CreatedAt()).
+				Add("Version", 1).
+				If("'Quantity' >= ?", allocation.quantity))
+			reservedProducts = append(reservedProducts, productID)
+		}
+	}
+
+	// 注文ヘッダと明細、最初のステータス履歴、倉庫ごとの引当の作成（既存の注文は上書きしない）
+	tx.Put(table.Put(item).If("attribute_not_exists('PK')"))
+	for _, line := range lines {
+		tx.Put(table.Put(line))
+	}
+	for _, status := range statuses {
+		tx.Put(table.Put(status).If("attribute_not_exists('PK')"))
+	}
+	for _, reservation := range orderReservationItems(order.ID().String(), productIDs, allocations) {
+		tx.Put(table.Put(reservation))
+	}
+
+	err = tx.Run(ctx)
+	if err != nil {
+		if productID, ok := failedReservation(err, reservedProducts); ok {
+			slog.Warn("Stock reservation failed", "orderID", order.ID().String(), "productID", productID)
+			return domain.InsufficientStockError(productID)
+		}
+		slog.Error("Failed to place order", "orderID", order.ID().String(), "error", err)
+		return fmt.Errorf("failed to place order: %w", err)
+	}
+
+	order.SetVersion(item.Version)
+	order.MarkStatusChangesSaved()
+
+	slog.Info("Order placed successfully", "orderID", order.ID().String())
+	return nil
+}
+
+// reservationQuantities sums the ordered quantity per product, keeping the order of first appearance
+func reservationQuantities(items []entity.OrderItem) ([]string, map[string]int) {
+	productIDs := make([]string, 0, len(items))
+	quantities := make(map[string]int, len(items))
+	for _, item := range items {
+		productID := item.ProductID.String()
+		if _, ok := quantities[productID]; !ok {
+			productIDs = append(productIDs, productID)
+		}
+		quantities[productID] += item.Quantity
+	}
+	return productIDs, quantities
+}
+
+// placeOrderWrites counts the items of the transaction that places an order: a stock update per
+// product, a stock update and a reservation per warehouse taken from, the header, the lines and
+// the status history
+func placeOrderWrites(lines, statuses int, allocations map[string][]stockAllocation) int {
+	writes := len(allocations) + 1 + lines + statuses
+	for _, allocated := range allocations {
+		writes += 2 * len(allocated)
+	}
+	return writes
+}
+
+// failedReservation returns the product whose stock condition cancelled the transaction.
+// The stock updates occupy the first len(productIDs) positions of the transaction, and
+// productIDs[i] names the product reserved at position i (a product total or one of its
+// warehouse inventories).
+func failedReservation(err error, productIDs []string) (string, bool) {
+	i, ok := failedCondition(err)
+	if !ok || i >= len(productIDs) {
+		return "", false
+	}
+	return productIDs[i], true
+}
+
+// CancelOrder saves a cancelled order and gives the stock reserved for it back to the given products
+// and their warehouse inventories in a single transaction
+func (r *DynamoOrderRepository) CancelOrder(ctx context.Context, order *entity.Order, products []*entity.Product) error {
+	slog.Info("Cancelling order", "orderID", order.ID().String())
+
+	item, _, err := OrderItemFromEntity(order)
+	if err != nil {
+		return fmt.Errorf("failed to convert order to item: %w", err)
+	}
+	item.Version = order.Version() + 1
 
-	var item OrderItem
 	table := r.client.GetTable()
 
-	err := table.Get("PK", fmt.Sprintf("ORDER#%s", id.String())).
-		Range("SK", dynamo.Equal, fmt.Sprintf("ORDER#%s", id.String())).
-		One(ctx, &item)
+	// 注文が引き当てた倉庫と、戻し先の商品の倉庫別在庫を読む
+	var reservations []OrderReservationItem
+	err = table.Get("PK", item.PK).
+		Range("SK", dynamo.BeginsWith, "RESERVATION#").
+		Consistent(true).
+		All(ctx, &reservations)
+	if err != nil {
+		slog.Error("Failed to load order reservations", "orderID", order.ID().String(), "error", err)
+		return fmt.Errorf("failed to cancel order: %w", err)
+	}
+	orderedIDs, quantities := reservationQuantities(order.Items())
+	productIDs := restockedProducts(orderedIDs, products)
+	inventory := make(map[string][]*InventoryItem, len(productIDs))
+	for _, productID := range productIDs {
+		inventory[productID], err = productInventory(ctx, table, productID, true)
+		if err != nil {
+			slog.Error("Failed to load inventory", "orderID", order.ID().String(), "productID", productID, "error", err)
+			return fmt.Errorf("failed to cancel order: %w", err)
+		}
+	}
+
+	tx := r.client.DB.WriteTx()
+
+	// 0: 注文ヘッダ（楽観ロック）、1〜len(productIDs): 在庫合計の加算
+	tx.Put(putIfVersion(table.Put(item), order.Version()))
+	for _, productID := range productIDs {
+		key := fmt.Sprintf("PRODUCT#%s", productID)
+		tx.Update(table.Update("PK", key).
+			Range("SK", key).
+			SetExpr("'Stock' = 'Stock' + ?", quantities[productID]).
+			Set("UpdatedAt", order.UpdatedAt()).
+			Add("Version", 1).
+			If("attribute_exists('PK')"))
+	}
+
+	// 倉庫別在庫の加算と引当の削除、ステータス履歴の追記
+	for _, restock := range restockAllocations(productIDs, quantities, reservations, inventory) {
+		tx.Update(table.Update("PK", restock.item.PK).
+			Range("SK", restock.item.SK).
+			SetExpr("'Quantity' = 'Quantity' + ?", restock.quantity).
+			Set("UpdatedAt", order.UpdatedAt()).
+			Add("Version", 1).
+			If("attribute_exists('PK')"))
+	}
+	for _, reservation := range reservations {
+		tx.Delete(table.Delete("PK", reservation.PK).Range("SK", reservation.SK))
+	}
+	for _, status := range OrderStatusItemsFromEntity(order) {
+		tx.Put(table.Put(status).If("attribute_not_exists('PK')"))
+	}
 
+	err = tx.Run(ctx)
 	if err != nil {
-		if err == dynamo.ErrNotFound {
-			slog.Info("Order not found", "orderID", id.String())
-			return nil, fmt.Errorf("order not found: %s", id.String())
+		if i, ok := failedCondition(err); ok && i == 0 {
+			slog.Warn("Order was modified concurrently", "orderID", order.ID().String(), "version", order.Version())
+			return domain.ConcurrentModificationError("Order", order.ID().String())
+		} else if ok && i <= len(productIDs) {
+			// 読んだ後に商品が削除された（再実行すればその商品を除いてキャンセルできる）
+			slog.Warn("Product of cancelled order was deleted concurrently", "orderID", order.ID().String(), "productID", productIDs[i-1])
+			return domain.ConcurrentModificationError("Product", productIDs[i-1])
 		}
+		slog.Error("Failed to cancel order", "orderID", order.ID().String(), "error", err)
+		return fmt.Errorf("failed to cancel order: %w", err)
+	}
+	order.SetVersion(item.Version)
+	order.MarkStatusChangesSaved()
+
+	slog.Info("Order cancelled successfully", "orderID", order.ID().String())
+	return nil
+}
+
+// restockedProducts keeps the IDs of productIDs whose product is among products, in the same order
+func restockedProducts(productIDs []string, products []*entity.Product) []string {
+	given := make(map[string]bool, len(products))
+	for _, product := range products {
+		given[product.ID().String()] = true
+	}
+	restocked := make([]string, 0, len(productIDs))
+	for _, productID := range productIDs {
+		if given[productID] {
+			restocked = append(restocked, productID)
+		}
+	}
+	return restocked
+}
+
+// FindByID retrieves an order and its lines with a single query on the order item collection
+func (r *DynamoOrderRepository) FindByID(ctx context.Context, id value.OrderID) (*entity.Order, error) {
+	slog.Info("Finding order by ID", "orderID", id.String())
+
+	var items []dynamo.Item
+	table := r.client.GetTable()
+
+	err := table.Get("PK", fmt.Sprintf("ORDER#%s", id.String())).All(ctx, &items)
+	if err != nil {
 		slog.Error("Failed to find order", "orderID", id.String(), "error", err)
 		return nil, fmt.Errorf("failed to find order: %w", err)
 	}
 
-	order, err := item.ToEntity()
+	header, lines, err := splitOrderCollection(items)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		slog.Info("Order not found", "orderID", id.String())
+		return nil, domain.OrderNotFoundError(id.String())
+	}
+
+	order, err := header.ToEntity(lines)
 	if err != nil {
 		slog.Error("Failed to convert item to entity", "orderID", id.String(), "error", err)
 		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
@@ -196,10 +706,40 @@ func (r *DynamoOrderRepository) FindByID(ctx context.Context, id value.OrderID)
 	return order, nil
 }
 
+// toEntities loads the lines of each order header on a page and converts them to entities
+func (r *DynamoOrderRepository) toEntities(ctx context.Context, items []OrderItem) ([]*entity.Order, error) {
+	table := r.client.GetTable()
+
+	orders := make([]*entity.Order, 0, len(items))
+	for _, item := range items {
+		var lines []OrderLineItem
+		err := table.Get("PK", item.PK).
+			Range("SK", dynamo.BeginsWith, "LINE#").
+			All(ctx, &lines)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load order lines: %w", err)
+		}
+
+		order, err := item.ToEntity(lines)
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "orderID", item.ID, "error", err)
+			continue // Skip invalid items
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
 // FindByCustomerID retrieves all orders for a customer
 func (r *DynamoOrderRepository) FindByCustomerID(ctx context.Context, customerID value.CustomerID, limit int, lastKey *string) ([]*entity.Order, *string, error) {
 	slog.Info("Finding orders by customer ID", "customerID", customerID.String(), "limit", limit)
 
+	scope := "orders:customer:" + customerID.String()
+	start, err := startKey(r.client, scope, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var items []OrderItem
 	table := r.client.GetTable()
 
@@ -209,85 +749,106 @@ func (r *DynamoOrderRepository) FindByCustomerID(ctx context.Context, customerID
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
+	if start != nil {
+		query = query.StartFrom(start)
+	}
 
-	err := query.All(ctx, &items)
+	lek, err := query.AllWithLastEvaluatedKey(ctx, &items)
 	if err != nil {
 		slog.Error("Failed to find orders by customer ID", "customerID", customerID.String(), "error", err)
 		return nil, nil, fmt.Errorf("failed to find orders by customer ID: %w", err)
 	}
 
-	orders := make([]*entity.Order, 0, len(items))
-	for _, item := range items {
-		order, err := item.ToEntity()
-		if err != nil {
-			slog.Error("Failed to convert item to entity", "orderID", item.ID, "error", err)
-			continue // Skip invalid items
-		}
-		orders = append(orders, order)
+	orders, err := r.toEntities(ctx, items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next, err := r.client.Cursors.Encode(scope, lek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cursor: %w", err)
 	}
 
 	slog.Info("Found orders successfully", "customerID", customerID.String(), "count", len(orders))
-	return orders, nil, nil // 簡易実装: lastKey は未実装
+	return orders, next, nil
 }
 
-// Delete removes an order
+// Delete removes an order together with every item in its collection
 func (r *DynamoOrderRepository) Delete(ctx context.Context, id value.OrderID) error {
 	slog.Info("Deleting order", "orderID", id.String())
 
-	table := r.client.GetTable()
-
-	err := table.Delete("PK", fmt.Sprintf("ORDER#%s", id.String())).
-		Range("SK", fmt.Sprintf("ORDER#%s", id.String())).
-		Run(ctx)
-
+	found, err := deleteCollection(ctx, r.client.GetTable(), fmt.Sprintf("ORDER#%s", id.String()))
 	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return domain.ConcurrentModificationError("Order", id.String())
+		}
 		slog.Error("Failed to delete order", "orderID", id.String(), "error", err)
 		return fmt.Errorf("failed to delete order: %w", err)
 	}
+	if !found {
+		slog.Info("Order already deleted", "orderID", id.String())
+		return nil
+	}
 
 	slog.Info("Order deleted successfully", "orderID", id.String())
 	return nil
 }
 
-// FindByStatus retrieves orders with a specific status
+// FindByStatus retrieves orders with a specific status, newest first
 func (r *DynamoOrderRepository) FindByStatus(ctx context.Context, status entity.OrderStatus, limit int, lastKey *string) ([]*entity.Order, *string, error) {
 	slog.Info("Finding orders by status", "status", string(status), "limit", limit)
 
+	scope := "orders:status:" + string(status)
+	start, err := startKey(r.client, scope, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var items []OrderItem
 	table := r.client.GetTable()
 
-	// Use scan with filter for status
-	scan := table.Scan().
-		Filter("'Type' = ? AND 'Status' = ?", "ORDER", string(status))
+	// Use GSI2 (STATUS#{Status}) sorted by creation time, newest first
+	query := table.Get("GSI2PK", fmt.Sprintf("STATUS#%s", status)).
+		Index("GSI2").
+		Order(dynamo.Descending)
 
 	if limit > 0 {
-		scan = scan.Limit(limit)
+		query = query.Limit(limit)
+	}
+	if start != nil {
+		query = query.StartFrom(start)
 	}
 
-	err := scan.All(ctx, &items)
+	lek, err := query.AllWithLastEvaluatedKey(ctx, &items)
 	if err != nil {
 		slog.Error("Failed to find orders by status", "status", string(status), "error", err)
 		return nil, nil, fmt.Errorf("failed to find orders by status: %w", err)
 	}
 
-	orders := make([]*entity.Order, 0, len(items))
-	for _, item := range items {
-		order, err := item.ToEntity()
-		if err != nil {
-			slog.Error("Failed to convert item to entity", "orderID", item.ID, "error", err)
-			continue // Skip invalid items
-		}
-		orders = append(orders, order)
+	orders, err := r.toEntities(ctx, items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next, err := r.client.Cursors.Encode(scope, lek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cursor: %w", err)
 	}
 
 	slog.Info("Found orders by status successfully", "status", string(status), "count", len(orders))
-	return orders, nil, nil // 簡易実装: lastKey は未実装
+	return orders, next, nil
 }
 
 // FindByCustomerAndStatus retrieves orders for a customer with a specific status
 func (r *DynamoOrderRepository) FindByCustomerAndStatus(ctx context.Context, customerID value.CustomerID, status entity.OrderStatus, limit int, lastKey *string) ([]*entity.Order, *string, error) {
 	slog.Info("Finding orders by customer and status", "customerID", customerID.String(), "status", string(status), "limit", limit)
 
+	scope := "orders:customer:" + customerID.String() + ":status:" + string(status)
+	start, err := startKey(r.client, scope, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var items []OrderItem
 	table := r.client.GetTable()
 
@@ -299,25 +860,133 @@ func (r *DynamoOrderRepository) FindByCustomerAndStatus(ctx context.Context, cus
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
+	if start != nil {
+		query = query.StartFrom(start)
+	}
 
-	err := query.All(ctx, &items)
+	lek, err := query.AllWithLastEvaluatedKey(ctx, &items)
 	if err != nil {
 		slog.Error("Failed to find orders by customer and status", "customerID", customerID.String(), "status", string(status), "error", err)
 		return nil, nil, fmt.Errorf("failed to find orders by customer and status: %w", err)
 	}
 
-	orders := make([]*entity.Order, 0, len(items))
-	for _, item := range items {
-		order, err := item.ToEntity()
+	orders, err := r.toEntities(ctx, items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next, err := r.client.Cursors.Encode(scope, lek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	slog.Info("Found orders by customer and status successfully", "customerID", customerID.String(), "status", string(status), "count", len(orders))
+	return orders, next, nil
+}
+
+// FindByProductAndDateRange retrieves the orders containing a product that were placed in a date range,
+// oldest first. The product's order lines are read through GSI1 and each page of lines is resolved to its orders.
+func (r *DynamoOrderRepository) FindByProductAndDateRange(ctx context.Context, productID value.ProductID, from, to time.Time, limit int, lastKey *string) ([]*entity.Order, *string, error) {
+	slog.Info("Finding orders by product and date range", "productID", productID.String(), "from", from, "to", to, "limit", limit)
+
+	lower, upper := orderDateRange(from, to)
+	scope := "orders:product:" + productID.String() + ":" + lower + ":" + upper
+	start, err := startKey(r.client, scope, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var lines []OrderLineItem
+	table := r.client.GetTable()
+
+	query := table.Get("GSI1PK", fmt.Sprintf("PRODUCT#%s", productID.String())).
+		Range("GSI1SK", dynamo.Between, lower, upper).
+		Index("GSI1")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if start != nil {
+		query = query.StartFrom(start)
+	}
+
+	lek, err := query.AllWithLastEvaluatedKey(ctx, &lines)
+	if err != nil {
+		slog.Error("Failed to find orders by product", "productID", productID.String(), "error", err)
+		return nil, nil, fmt.Errorf("failed to find orders by product: %w", err)
+	}
+
+	// 各明細の注文ヘッダと明細をまとめて取得する（商品ごとに明細は1注文1件）
+	orders := make([]*entity.Order, 0, len(lines))
+	for _, line := range lines {
+		var items []dynamo.Item
+		err := table.Get("PK", line.PK).All(ctx, &items)
 		if err != nil {
-			slog.Error("Failed to convert item to entity", "orderID", item.ID, "error", err)
+			return nil, nil, fmt.Errorf("failed to load order: %w", err)
+		}
+
+		header, orderLines, err := splitOrderCollection(items)
+		if err != nil {
+			return nil, nil, err
+		}
+		if header == nil {
+			continue // The order was deleted after the index was read
+		}
+
+		order, err := header.ToEntity(orderLines)
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "orderID", header.ID, "error", err)
 			continue // Skip invalid items
 		}
 		orders = append(orders, order)
 	}
 
-	slog.Info("Found orders by customer and status successfully", "customerID", customerID.String(), "status", string(status), "count", len(orders))
-	return orders, nil, nil // 簡易実装: lastKey は未実装
+	next, err := r.client.Cursors.Encode(scope, lek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	slog.Info("Found orders by product successfully", "productID", productID.String(), "count", len(orders))
+	return orders, next, nil
+}
+
+// FindStatusHistory retrieves the status history of an order, oldest first, with a single query on
+// the order item collection
+func (r *DynamoOrderRepository) FindStatusHistory(ctx context.Context, id value.OrderID) ([]entity.OrderStatusChange, error) {
+	slog.Info("Finding order status history", "orderID", id.String())
+
+	var items []dynamo.Item
+	table := r.client.GetTable()
+
+	pk := fmt.Sprintf("ORDER#%s", id.String())
+	err := table.Get("PK", pk).All(ctx, &items)
+	if err != nil {
+		slog.Error("Failed to find order status history", "orderID", id.String(), "error", err)
+		return nil, fmt.Errorf("failed to find order status history: %w", err)
+	}
+
+	// 履歴のない注文と存在しない注文を区別するため、ヘッダの有無も確認する
+	found := false
+	history := make([]entity.OrderStatusChange, 0, len(items))
+	for _, raw := range items {
+		var status OrderStatusItem
+		if err := dynamo.UnmarshalItem(raw, &status); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal order status: %w", err)
+		}
+		switch {
+		case status.SK == pk:
+			found = true
+		case strings.HasPrefix(status.SK, "STATUS#"):
+			history = append(history, status.ToEntity())
+		}
+	}
+	if !found {
+		slog.Info("Order not found", "orderID", id.String())
+		return nil, domain.OrderNotFoundError(id.String())
+	}
+
+	slog.Info("Found order status history successfully", "orderID", id.String(), "count", len(history))
+	return history, nil
 }
 
 // Exists checks if an order exists by its ID
@@ -326,7 +995,7 @@ func (r *DynamoOrderRepository) Exists(ctx context.Context, id value.OrderID) (b
 
 	_, err := r.FindByID(ctx, id)
 	if err != nil {
-		if err.Error() == "order not found: "+id.String() {
+		if errors.Is(err, domain.ErrNotFound) {
 			return false, nil
 		}
 		return false, err
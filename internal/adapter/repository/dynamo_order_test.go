@@ -2,12 +2,20 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/domain"
 	"dynamo-modeling/internal/domain/entity"
 	"dynamo-modeling/internal/domain/value"
 	"dynamo-modeling/internal/infrastructure"
@@ -42,8 +50,8 @@ func TestOrderItemConversion(t *testing.T) {
 	order, err := entity.NewOrder(orderID, customerID, []entity.OrderItem{*orderItem1, *orderItem2})
 	require.NoError(t, err)
 
-	// Act: Convert entity to item
-	item, err := OrderItemFromEntity(order)
+	// Act: Convert entity to items
+	item, lines, err := OrderItemFromEntity(order)
 	require.NoError(t, err)
 
 	// Assert: Check item structure
@@ -52,18 +60,29 @@ func TestOrderItemConversion(t *testing.T) {
 	assert.Equal(t, "CUSTOMER#test-customer-123", item.GSI1PK)
 	assert.Contains(t, item.GSI1SK, "ORDER#")
 	assert.Contains(t, item.GSI1SK, "test-order-123")
+	assert.Equal(t, "STATUS#pending", item.GSI2PK)
+	assert.Equal(t, strings.TrimPrefix(item.GSI1SK, "ORDER#"), item.GSI2SK)
 	assert.Equal(t, "ORDER", item.Type)
 	assert.Equal(t, "test-order-123", item.ID)
 	assert.Equal(t, "test-customer-123", item.CustomerID)
 	assert.Equal(t, string(order.Status()), item.Status)
 	assert.Equal(t, order.Total().Cents(), item.Total)
 
-	// Check Items JSON contains the products
-	assert.Contains(t, item.Items, "product-1")
-	assert.Contains(t, item.Items, "product-2")
-
-	// Act: Convert item back to entity
-	convertedOrder, err := item.ToEntity()
+	assert.Empty(t, item.Items)
+
+	// Check one LINE# item per product in the order item collection
+	require.Len(t, lines, 2)
+	assert.Equal(t, "ORDER#test-order-123", lines[0].PK)
+	assert.Equal(t, "LINE#product-1", lines[0].SK)
+	assert.Equal(t, "PRODUCT#product-1", lines[0].GSI1PK)
+	assert.Equal(t, "ORDER#"+order.CreatedAt().UTC().Format(time.RFC3339)+"#test-order-123", lines[0].GSI1SK)
+	assert.Equal(t, "ORDER_LINE", lines[0].Type)
+	assert.Equal(t, 2, lines[0].Quantity)
+	assert.Equal(t, int64(1299), lines[0].UnitPrice)
+	assert.Equal(t, "LINE#product-2", lines[1].SK)
+
+	// Act: Convert items back to entity
+	convertedOrder, err := item.ToEntity(lines)
 	require.NoError(t, err)
 
 	// Assert: Check entity structure
@@ -83,6 +102,261 @@ func TestOrderItemConversion(t *testing.T) {
 	assert.Equal(t, price2, items[1].UnitPrice)
 }
 
+func TestOrderKeysUseUTC(t *testing.T) {
+	orderID, _ := value.NewOrderID("order-jst")
+	customerID, _ := value.NewCustomerID("customer-1")
+	productID, _ := value.NewProductID("product-1")
+	price, _ := value.NewMoney(1000)
+	orderItem, err := entity.NewOrderItem(productID, 1, price)
+	require.NoError(t, err)
+	createdAt := time.Date(2024, 1, 2, 8, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	order, err := entity.NewOrderWithState(orderID, customerID, []entity.OrderItem{*orderItem},
+		entity.OrderStatusPending, price, createdAt, createdAt)
+	require.NoError(t, err)
+
+	item, lines, err := OrderItemFromEntity(order)
+	require.NoError(t, err)
+	assert.Equal(t, "ORDER#2024-01-01T23:00:00Z#order-jst", item.GSI1SK)
+	assert.Equal(t, "2024-01-01T23:00:00Z#order-jst", item.GSI2SK)
+	assert.Equal(t, item.GSI1SK, lines[0].GSI1SK)
+}
+
+func TestOrderDateRange(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+
+	t.Run("open range covers every order", func(t *testing.T) {
+		lower, upper := orderDateRange(time.Time{}, time.Time{})
+		assert.Equal(t, "ORDER#", lower)
+		assert.Equal(t, "ORDER#~", upper)
+	})
+
+	t.Run("bounds match order line keys written in UTC", func(t *testing.T) {
+		from := time.Date(2025, 6, 21, 9, 0, 0, 0, jst)
+		to := time.Date(2025, 6, 22, 8, 59, 59, 0, jst)
+
+		lower, upper := orderDateRange(from, to)
+		assert.Equal(t, "ORDER#2025-06-21T00:00:00Z", lower)
+		assert.Equal(t, "ORDER#2025-06-21T23:59:59Z#~", upper)
+
+		key := orderDateKey(time.Date(2025, 6, 22, 8, 59, 59, 0, jst), "order-1")
+		assert.Equal(t, "ORDER#2025-06-21T23:59:59Z#order-1", key)
+		assert.True(t, key >= lower && key <= upper)
+		assert.False(t, orderDateKey(to.Add(time.Second), "order-1") <= upper)
+	})
+}
+
+func TestOrderLinesMergeSameProduct(t *testing.T) {
+	lines, err := mergeOrderLines([]OrderLineItem{
+		{ProductID: "product-1", Quantity: 2, UnitPrice: 500},
+		{ProductID: "product-2", Quantity: 1, UnitPrice: 300},
+		{ProductID: "product-1", Quantity: 3, UnitPrice: 500},
+	})
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "product-1", lines[0].ProductID)
+	assert.Equal(t, 5, lines[0].Quantity)
+	assert.Equal(t, "product-2", lines[1].ProductID)
+
+	_, err = mergeOrderLines([]OrderLineItem{
+		{ProductID: "product-1", Quantity: 1, UnitPrice: 500},
+		{ProductID: "product-1", Quantity: 1, UnitPrice: 600},
+	})
+	assert.Error(t, err)
+}
+
+func TestLegacyOrderItemsConversion(t *testing.T) {
+	item := &OrderItem{
+		PK:         "ORDER#legacy-order",
+		SK:         "ORDER#legacy-order",
+		ID:         "legacy-order",
+		CustomerID: "customer-1",
+		Items:      `[{"productId":"product-1","quantity":2,"unitPrice":1299}]`,
+		Status:     "pending",
+		Total:      2598,
+		CreatedAt:  time.Date(2020, 6, 21, 12, 0, 0, 0, time.UTC),
+	}
+
+	lines, err := item.LegacyLines()
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	assert.Equal(t, OrderLineItem{
+		PK:        "ORDER#legacy-order",
+		SK:        "LINE#product-1",
+		GSI1PK:    "PRODUCT#product-1",
+		GSI1SK:    "ORDER#2020-06-21T12:00:00Z#legacy-order",
+		Type:      "ORDER_LINE",
+		OrderID:   "legacy-order",
+		ProductID: "product-1",
+		Quantity:  2,
+		UnitPrice: 1299,
+	}, lines[0])
+
+	// Lines written without GSI1 keys get the same keys from the order's creation time
+	line := OrderLineItem{OrderID: "legacy-order", ProductID: "product-1"}
+	line.SetGSI1Keys(item.CreatedAt)
+	assert.Equal(t, lines[0].GSI1PK, line.GSI1PK)
+	assert.Equal(t, lines[0].GSI1SK, line.GSI1SK)
+
+	// Unconverted orders are still readable
+	order, err := item.ToEntity(nil)
+	require.NoError(t, err)
+	require.Len(t, order.Items(), 1)
+	assert.Equal(t, 2, order.Items()[0].Quantity)
+}
+
+func TestSplitOrderCollection(t *testing.T) {
+	header, err := dynamo.MarshalItem(OrderItem{PK: "ORDER#o1", SK: "ORDER#o1", Type: "ORDER", ID: "o1"})
+	require.NoError(t, err)
+	line, err := dynamo.MarshalItem(OrderLineItem{PK: "ORDER#o1", SK: "LINE#p1", Type: "ORDER_LINE", ProductID: "p1", Quantity: 1})
+	require.NoError(t, err)
+	other, err := dynamo.MarshalItem(map[string]string{"PK": "ORDER#o1", "SK": "INVOICE#i1"})
+	require.NoError(t, err)
+
+	item, lines, err := splitOrderCollection([]dynamo.Item{header, line, other})
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, "o1", item.ID)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "p1", lines[0].ProductID)
+
+	item, _, err = splitOrderCollection(nil)
+	require.NoError(t, err)
+	assert.Nil(t, item)
+}
+
+func TestOrderStatusItemsFromEntity(t *testing.T) {
+	customerID, _ := value.NewCustomerID("customer-1")
+	productID, _ := value.NewProductID("product-1")
+	price, _ := value.NewMoney(1000)
+	item, err := entity.NewOrderItem(productID, 1, price)
+	require.NoError(t, err)
+	createdAt := time.Date(2025, 6, 14, 3, 0, 0, 0, time.UTC)
+	order, err := entity.NewOrderWithState(value.OrderID("o1"), customerID, []entity.OrderItem{*item}, entity.OrderStatusPending, price, createdAt, createdAt)
+	require.NoError(t, err)
+	assert.Empty(t, OrderStatusItemsFromEntity(order))
+
+	require.NoError(t, order.Confirm())
+	require.NoError(t, order.Cancel())
+	order.AttributeStatusChanges("clerk", "duplicate order")
+
+	items := OrderStatusItemsFromEntity(order)
+	require.Len(t, items, 2)
+	assert.Equal(t, "ORDER#o1", items[0].PK)
+	assert.Equal(t, "ORDER_STATUS", items[0].Type)
+	assert.Equal(t, "pending", items[0].From)
+	assert.Equal(t, "confirmed", items[0].To)
+	assert.Equal(t, "clerk", items[0].Actor)
+	assert.Equal(t, "duplicate order", items[0].Reason)
+	assert.Equal(t, "cancelled", items[1].To)
+
+	// Keys have a fixed width, so they sort in time order
+	assert.Regexp(t, `^STATUS#\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{9}Z$`, items[0].SK)
+	assert.Len(t, items[1].SK, len(items[0].SK))
+	assert.Less(t, items[0].SK, items[1].SK)
+	assert.Equal(t, order.UnsavedStatusChanges()[1], items[1].ToEntity())
+}
+
+func TestReservationQuantities(t *testing.T) {
+	price, err := value.NewMoney(500)
+	require.NoError(t, err)
+
+	items := []entity.OrderItem{
+		{ProductID: value.ProductID("product-b"), Quantity: 2, UnitPrice: price},
+		{ProductID: value.ProductID("product-a"), Quantity: 1, UnitPrice: price},
+		{ProductID: value.ProductID("product-b"), Quantity: 3, UnitPrice: price},
+	}
+
+	productIDs, quantities := reservationQuantities(items)
+
+	assert.Equal(t, []string{"product-b", "product-a"}, productIDs)
+	assert.Equal(t, 5, quantities["product-b"])
+	assert.Equal(t, 1, quantities["product-a"])
+}
+
+func TestPlaceOrderWrites(t *testing.T) {
+	// 20 lines of products each held by four warehouses: 20 + 160 + 1 + 20 + 1 items
+	allocations := make(map[string][]stockAllocation)
+	for i := range 20 {
+		allocations[fmt.Sprintf("product-%d", i)] = make([]stockAllocation, 4)
+	}
+
+	writes := placeOrderWrites(20, 1, allocations)
+
+	assert.Equal(t, 202, writes)
+	assert.Greater(t, writes, maxTransactionItems)
+}
+
+func TestRestockAllocations(t *testing.T) {
+	inventory := func(productID, warehouseID string) *InventoryItem {
+		return &InventoryItem{PK: "PRODUCT#" + productID, SK: "WAREHOUSE#" + warehouseID, ProductID: productID, WarehouseID: warehouseID}
+	}
+	a1, a2, b2 := inventory("product-a", "w1"), inventory("product-a", "w2"), inventory("product-b", "w2")
+	inventories := map[string][]*InventoryItem{
+		"product-a": {a2, a1},
+		"product-b": {b2},
+	}
+
+	t.Run("reservations go back to their warehouses", func(t *testing.T) {
+		reservations := []OrderReservationItem{
+			{ProductID: "product-a", WarehouseID: "w2", Quantity: 4},
+			{ProductID: "product-a", WarehouseID: "w1", Quantity: 1},
+			{ProductID: "product-b", WarehouseID: "w2", Quantity: 2},
+		}
+
+		restocks := restockAllocations([]string{"product-a", "product-b"}, map[string]int{"product-a": 5, "product-b": 2}, reservations, inventories)
+
+		assert.Equal(t, []stockAllocation{{item: a2, quantity: 4}, {item: a1, quantity: 1}, {item: b2, quantity: 2}}, restocks)
+	})
+
+	t.Run("unreserved stock goes to the first warehouse", func(t *testing.T) {
+		// w3 no longer holds product-a, and product-c has no warehouse at all
+		reservations := []OrderReservationItem{
+			{ProductID: "product-a", WarehouseID: "w3", Quantity: 2},
+			{ProductID: "product-a", WarehouseID: "w1", Quantity: 1},
+		}
+
+		restocks := restockAllocations([]string{"product-a", "product-c"}, map[string]int{"product-a": 3, "product-c": 1}, reservations, inventories)
+
+		assert.Equal(t, []stockAllocation{{item: a1, quantity: 3}}, restocks)
+	})
+}
+
+func TestFailedReservation(t *testing.T) {
+	productIDs := []string{"product-a", "product-b"}
+
+	t.Run("stock condition failed", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", &types.TransactionCanceledException{
+			CancellationReasons: []types.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+				{Code: aws.String("None")},
+			},
+		})
+
+		productID, ok := failedReservation(err, productIDs)
+		assert.True(t, ok)
+		assert.Equal(t, "product-b", productID)
+	})
+
+	t.Run("order put condition failed", func(t *testing.T) {
+		err := &types.TransactionCanceledException{
+			CancellationReasons: []types.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+			},
+		}
+
+		_, ok := failedReservation(err, productIDs)
+		assert.False(t, ok)
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		_, ok := failedReservation(errors.New("network error"), productIDs)
+		assert.False(t, ok)
+	})
+}
+
 // TestDynamoOrderRepository runs integration tests against DynamoDB Local
 func TestDynamoOrderRepository(t *testing.T) {
 	// Skip if not running integration tests
@@ -93,11 +367,9 @@ func TestDynamoOrderRepository(t *testing.T) {
 	ctx := context.Background()
 
 	// Initialize DynamoDB client for testing
-	client, err := infrastructure.NewDynamoDBClient(ctx, infrastructure.DynamoDBConfig{
-		Region:    "ap-northeast-1",
-		Endpoint:  "http://localhost:8000",
-		TableName: "OnlineShop",
-	})
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
 	require.NoError(t, err)
 
 	// Health check
@@ -179,7 +451,7 @@ func TestDynamoOrderRepository(t *testing.T) {
 		}
 
 		// Find by customer ID
-		found, err := repo.FindByCustomerID(ctx, customerID)
+		found, _, err := repo.FindByCustomerID(ctx, customerID, 0, nil)
 		require.NoError(t, err)
 		assert.Len(t, found, 2)
 
@@ -199,6 +471,92 @@ func TestDynamoOrderRepository(t *testing.T) {
 		}
 	})
 
+	t.Run("find by product and date range", func(t *testing.T) {
+		customerID, err := value.NewCustomerID("test-customer-product")
+		require.NoError(t, err)
+		productID, err := value.NewProductID("test-product-range")
+		require.NoError(t, err)
+		price, err := value.NewMoney(500)
+		require.NoError(t, err)
+
+		orderItem, err := entity.NewOrderItem(productID, 1, price)
+		require.NoError(t, err)
+
+		// Orders on three days; only the middle one is in range
+		orderIDs := make([]value.OrderID, 3)
+		for i, day := range []int{20, 21, 22} {
+			orderIDs[i] = value.OrderID(fmt.Sprintf("test-product-range-order-%d", i))
+			createdAt := time.Date(2025, 6, day, 12, 0, 0, 0, time.UTC)
+			order, err := entity.NewOrderWithState(orderIDs[i], customerID, []entity.OrderItem{*orderItem},
+				entity.OrderStatusPending, price, createdAt, createdAt)
+			require.NoError(t, err)
+			require.NoError(t, repo.Save(ctx, order))
+		}
+
+		from := time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 6, 21, 23, 59, 0, 0, time.UTC)
+		found, _, err := repo.FindByProductAndDateRange(ctx, productID, from, to, 0, nil)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, orderIDs[1], found[0].ID())
+
+		// Open range, oldest first, one order per page
+		found, next, err := repo.FindByProductAndDateRange(ctx, productID, time.Time{}, time.Time{}, 2, nil)
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, orderIDs[0], found[0].ID())
+		require.NotNil(t, next)
+
+		found, _, err = repo.FindByProductAndDateRange(ctx, productID, time.Time{}, time.Time{}, 2, next)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, orderIDs[2], found[0].ID())
+
+		// Clean up
+		for _, id := range orderIDs {
+			repo.Delete(ctx, id)
+		}
+	})
+
+	t.Run("status history", func(t *testing.T) {
+		orderID, err := value.NewOrderID("test-history-order")
+		require.NoError(t, err)
+		customerID, err := value.NewCustomerID("test-customer-history")
+		require.NoError(t, err)
+		productID, err := value.NewProductID("test-product-history")
+		require.NoError(t, err)
+		price, err := value.NewMoney(500)
+		require.NoError(t, err)
+		orderItem, err := entity.NewOrderItem(productID, 1, price)
+		require.NoError(t, err)
+		order, err := entity.NewOrder(orderID, customerID, []entity.OrderItem{*orderItem})
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Save(ctx, order))
+		require.NoError(t, order.Confirm())
+		order.AttributeStatusChanges("clerk", "payment received")
+		require.NoError(t, repo.Save(ctx, order))
+
+		// The order read back has no unsaved changes, so saving it adds no entries
+		found, err := repo.FindByID(ctx, orderID)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, found))
+
+		history, err := repo.FindStatusHistory(ctx, orderID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, entity.OrderStatus(""), history[0].From)
+		assert.Equal(t, entity.OrderStatusPending, history[0].To)
+		assert.Equal(t, entity.OrderStatusConfirmed, history[1].To)
+		assert.Equal(t, "clerk", history[1].Actor)
+		assert.Equal(t, "payment received", history[1].Reason)
+
+		// Clean up
+		require.NoError(t, repo.Delete(ctx, orderID))
+		_, err = repo.FindStatusHistory(ctx, orderID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
 	t.Run("find by ID not found", func(t *testing.T) {
 		orderID, err := value.NewOrderID("non-existent-order")
 		require.NoError(t, err)
@@ -209,7 +567,7 @@ func TestDynamoOrderRepository(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Nil(t, order)
-		assert.Contains(t, err.Error(), "order not found")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
 	})
 
 	t.Run("delete order", func(t *testing.T) {
@@ -248,6 +606,48 @@ func TestDynamoOrderRepository(t *testing.T) {
 		deleted, err := repo.FindByID(ctx, orderID)
 		assert.Error(t, err)
 		assert.Nil(t, deleted)
-		assert.Contains(t, err.Error(), "order not found")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("delete order with more items than a transaction holds", func(t *testing.T) {
+		orderID, err := value.NewOrderID("test-delete-large-order")
+		require.NoError(t, err)
+		customerID, err := value.NewCustomerID("test-customer-delete")
+		require.NoError(t, err)
+		productID, err := value.NewProductID("test-product-delete")
+		require.NoError(t, err)
+		price, err := value.NewMoney(799)
+		require.NoError(t, err)
+		orderItem, err := entity.NewOrderItem(productID, 1, price)
+		require.NoError(t, err)
+		order, err := entity.NewOrder(orderID, customerID, []entity.OrderItem{*orderItem})
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, order))
+
+		pk := "ORDER#" + orderID.String()
+		putFillerItems(ctx, t, client.GetTable(), pk, 120)
+
+		// Act
+		require.NoError(t, repo.Delete(ctx, orderID))
+
+		// Assert: Nothing is left of the collection
+		count, err := client.GetTable().Get("PK", pk).Consistent(true).Count(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
 	})
 }
+
+// putFillerItems writes n bare items into the item collection under pk
+func putFillerItems(ctx context.Context, t *testing.T, table dynamo.Table, pk string, n int) {
+	t.Helper()
+	type filler struct {
+		PK string `dynamo:"PK"`
+		SK string `dynamo:"SK"`
+	}
+	items := make([]any, 0, n)
+	for i := range n {
+		items = append(items, filler{PK: pk, SK: fmt.Sprintf("FILLER#%03d", i)})
+	}
+	_, err := table.Batch("PK", "SK").Write().Put(items...).Run(ctx)
+	require.NoError(t, err)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/guregu/dynamo/v2"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// startKey decodes a caller-supplied cursor for the given query scope.
+// A cursor that fails verification is reported as invalid input.
+func startKey(client *infrastructure.DynamoDBClient, scope string, cursor *string) (dynamo.PagingKey, error) {
+	key, err := client.Cursors.Decode(scope, cursor)
+	if err != nil {
+		return nil, domain.NewDomainError(domain.ErrCodeInvalidInput, "invalid pagination cursor", err)
+	}
+	return key, nil
+}
@@ -2,12 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/guregu/dynamo/v2"
 
+	"dynamo-modeling/internal/domain"
 	"dynamo-modeling/internal/domain/entity"
 	"dynamo-modeling/internal/domain/value"
 	"dynamo-modeling/internal/infrastructure"
@@ -39,6 +41,7 @@ type ProductItem struct {
 	Stock       int       `dynamo:"Stock"`       // Stock quantity
 	CreatedAt   time.Time `dynamo:"CreatedAt"`   // Creation timestamp
 	UpdatedAt   time.Time `dynamo:"UpdatedAt"`   // Last update timestamp
+	Version     int       `dynamo:"Version"`     // Optimistic locking version
 }
 
 // ToEntity converts ProductItem to Product entity
@@ -53,10 +56,11 @@ func (item *ProductItem) ToEntity() (*entity.Product, error) {
 		return nil, fmt.Errorf("invalid price: %w", err)
 	}
 
-	product, err := entity.NewProduct(productID, item.Name, item.Description, price, item.Stock)
+	product, err := entity.NewProductWithState(productID, item.Name, item.Description, price, item.Stock, item.CreatedAt, item.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create product entity: %w", err)
 	}
+	product.SetVersion(item.Version)
 
 	return product, nil
 }
@@ -78,6 +82,7 @@ func ProductItemFromEntity(product *entity.Product) *ProductItem {
 		Stock:       product.Stock(),
 		CreatedAt:   product.CreatedAt(),
 		UpdatedAt:   product.UpdatedAt(),
+		Version:     product.Version(),
 	}
 }
 
@@ -86,13 +91,19 @@ func (r *DynamoProductRepository) Save(ctx context.Context, product *entity.Prod
 	slog.Info("Saving product", "productID", product.ID().String())
 
 	item := ProductItemFromEntity(product)
+	item.Version = product.Version() + 1
 	table := r.client.GetTable()
 
-	err := table.Put(item).Run(ctx)
+	err := putIfVersion(table.Put(item), product.Version()).Run(ctx)
 	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			slog.Warn("Product was modified concurrently", "productID", product.ID().String(), "version", product.Version())
+			return domain.ConcurrentModificationError("Product", product.ID().String())
+		}
 		slog.Error("Failed to save product", "productID", product.ID().String(), "error", err)
 		return fmt.Errorf("failed to save product: %w", err)
 	}
+	product.SetVersion(item.Version)
 
 	slog.Info("Product saved successfully", "productID", product.ID().String())
 	return nil
@@ -112,7 +123,7 @@ func (r *DynamoProductRepository) FindByID(ctx context.Context, id value.Product
 	if err != nil {
 		if err == dynamo.ErrNotFound {
 			slog.Info("Product not found", "productID", id.String())
-			return nil, fmt.Errorf("product not found: %s", id.String())
+			return nil, domain.ProductNotFoundError(id.String())
 		}
 		slog.Error("Failed to find product", "productID", id.String(), "error", err)
 		return nil, fmt.Errorf("failed to find product: %w", err)
@@ -132,6 +143,12 @@ func (r *DynamoProductRepository) FindByID(ctx context.Context, id value.Product
 func (r *DynamoProductRepository) FindAll(ctx context.Context, limit int, lastKey *string) ([]*entity.Product, *string, error) {
 	slog.Info("Finding all products", "limit", limit)
 
+	const scope = "products:all"
+	start, err := startKey(r.client, scope, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var items []ProductItem
 	table := r.client.GetTable()
 
@@ -141,8 +158,11 @@ func (r *DynamoProductRepository) FindAll(ctx context.Context, limit int, lastKe
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
+	if start != nil {
+		query = query.StartFrom(start)
+	}
 
-	err := query.All(ctx, &items)
+	lek, err := query.AllWithLastEvaluatedKey(ctx, &items)
 	if err != nil {
 		slog.Error("Failed to find all products", "error", err)
 		return nil, nil, fmt.Errorf("failed to find all products: %w", err)
@@ -158,26 +178,41 @@ func (r *DynamoProductRepository) FindAll(ctx context.Context, limit int, lastKe
 		products = append(products, product)
 	}
 
+	next, err := r.client.Cursors.Encode(scope, lek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
 	slog.Info("Found products successfully", "count", len(products))
-	return products, nil, nil // 簡易実装: lastKey は未実装
+	return products, next, nil
 }
 
 // FindInStock retrieves products that are currently in stock
 func (r *DynamoProductRepository) FindInStock(ctx context.Context, limit int, lastKey *string) ([]*entity.Product, *string, error) {
 	slog.Info("Finding products in stock", "limit", limit)
 
+	const scope = "products:in-stock"
+	start, err := startKey(r.client, scope, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var items []ProductItem
 	table := r.client.GetTable()
 
-	// Use scan with filter for stock > 0
-	scan := table.Scan().
-		Filter("'Type' = ? AND 'Stock' > ?", "PRODUCT", 0)
+	// Query the product listing index and filter for stock > 0
+	query := table.Get("GSI1PK", "PRODUCT#ALL").
+		Index("GSI1").
+		Filter("'Stock' > ?", 0)
 
 	if limit > 0 {
-		scan = scan.Limit(limit)
+		query = query.Limit(limit)
+	}
+	if start != nil {
+		query = query.StartFrom(start)
 	}
 
-	err := scan.All(ctx, &items)
+	lek, err := query.AllWithLastEvaluatedKey(ctx, &items)
 	if err != nil {
 		slog.Error("Failed to find products in stock", "error", err)
 		return nil, nil, fmt.Errorf("failed to find products in stock: %w", err)
@@ -193,24 +228,31 @@ func (r *DynamoProductRepository) FindInStock(ctx context.Context, limit int, la
 		products = append(products, product)
 	}
 
+	next, err := r.client.Cursors.Encode(scope, lek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
 	slog.Info("Found products in stock successfully", "count", len(products))
-	return products, nil, nil // 簡易実装: lastKey は未実装
+	return products, next, nil
 }
 
-// Delete removes a product
+// Delete removes a product together with its warehouse inventory
 func (r *DynamoProductRepository) Delete(ctx context.Context, id value.ProductID) error {
 	slog.Info("Deleting product", "productID", id.String())
 
-	table := r.client.GetTable()
-
-	err := table.Delete("PK", fmt.Sprintf("PRODUCT#%s", id.String())).
-		Range("SK", fmt.Sprintf("PRODUCT#%s", id.String())).
-		Run(ctx)
-
+	found, err := deleteCollection(ctx, r.client.GetTable(), fmt.Sprintf("PRODUCT#%s", id.String()))
 	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return domain.ConcurrentModificationError("Product", id.String())
+		}
 		slog.Error("Failed to delete product", "productID", id.String(), "error", err)
 		return fmt.Errorf("failed to delete product: %w", err)
 	}
+	if !found {
+		slog.Info("Product already deleted", "productID", id.String())
+		return nil
+	}
 
 	slog.Info("Product deleted successfully", "productID", id.String())
 	return nil
@@ -222,7 +264,7 @@ func (r *DynamoProductRepository) Exists(ctx context.Context, id value.ProductID
 
 	_, err := r.FindByID(ctx, id)
 	if err != nil {
-		if err.Error() == "product not found" {
+		if errors.Is(err, domain.ErrNotFound) {
 			return false, nil
 		}
 		return false, err
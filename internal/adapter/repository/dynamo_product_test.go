@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/domain"
 	"dynamo-modeling/internal/domain/entity"
 	"dynamo-modeling/internal/domain/value"
 	"dynamo-modeling/internal/infrastructure"
@@ -52,6 +54,9 @@ func TestProductItemConversion(t *testing.T) {
 	assert.Equal(t, "A test product", convertedProduct.Description())
 	assert.Equal(t, price, convertedProduct.Price())
 	assert.Equal(t, 10, convertedProduct.Stock())
+	assert.Equal(t, product.CreatedAt(), convertedProduct.CreatedAt())
+	assert.Equal(t, product.UpdatedAt(), convertedProduct.UpdatedAt())
+	assert.Equal(t, item.Version, convertedProduct.Version())
 }
 
 // TestDynamoProductRepository runs integration tests against DynamoDB Local
@@ -64,11 +69,9 @@ func TestDynamoProductRepository(t *testing.T) {
 	ctx := context.Background()
 
 	// Initialize DynamoDB client for testing
-	client, err := infrastructure.NewDynamoDBClient(ctx, infrastructure.DynamoDBConfig{
-		Region:    "ap-northeast-1",
-		Endpoint:  "http://localhost:8000",
-		TableName: "OnlineShop",
-	})
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
 	require.NoError(t, err)
 
 	// Health check
@@ -100,6 +103,18 @@ func TestDynamoProductRepository(t *testing.T) {
 		assert.Equal(t, product.Description(), found.Description())
 		assert.Equal(t, product.Price(), found.Price())
 		assert.Equal(t, product.Stock(), found.Stock())
+		assert.True(t, product.CreatedAt().Equal(found.CreatedAt()))
+		assert.True(t, product.UpdatedAt().Equal(found.UpdatedAt()))
+		assert.Equal(t, 1, found.Version())
+
+		// Updating the found product keeps its creation time
+		require.NoError(t, found.UpdateStock(7))
+		require.NoError(t, repo.Save(ctx, found))
+		updated, err := repo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 7, updated.Stock())
+		assert.True(t, product.CreatedAt().Equal(updated.CreatedAt()))
+		assert.True(t, updated.UpdatedAt().After(product.UpdatedAt()))
 
 		// Clean up
 		err = repo.Delete(ctx, productID)
@@ -116,7 +131,7 @@ func TestDynamoProductRepository(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Nil(t, product)
-		assert.Contains(t, err.Error(), "product not found")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
 	})
 
 	t.Run("find all products", func(t *testing.T) {
@@ -146,7 +161,7 @@ func TestDynamoProductRepository(t *testing.T) {
 		}
 
 		// Act
-		found, err := repo.FindAll(ctx)
+		found, _, err := repo.FindAll(ctx, 0, nil)
 
 		// Assert
 		require.NoError(t, err)
@@ -195,6 +210,66 @@ func TestDynamoProductRepository(t *testing.T) {
 		deleted, err := repo.FindByID(ctx, productID)
 		assert.Error(t, err)
 		assert.Nil(t, deleted)
-		assert.Contains(t, err.Error(), "product not found")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("delete product with more items than a transaction holds", func(t *testing.T) {
+		productID, err := value.NewProductID("test-delete-large-product")
+		require.NoError(t, err)
+		price, err := value.NewMoney(799)
+		require.NoError(t, err)
+		product, err := entity.NewProduct(productID, "Large Delete Test Product", "Test product for delete operation", price, 3)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, product))
+
+		pk := "PRODUCT#" + productID.String()
+		putFillerItems(ctx, t, client.GetTable(), pk, 120)
+
+		// Act
+		require.NoError(t, repo.Delete(ctx, productID))
+
+		// Assert: Nothing is left of the collection
+		count, err := client.GetTable().Get("PK", pk).Consistent(true).Count(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("stale version is rejected", func(t *testing.T) {
+		productID, err := value.NewProductID("test-version-product")
+		require.NoError(t, err)
+
+		price, err := value.NewMoney(1200)
+		require.NoError(t, err)
+
+		product, err := entity.NewProduct(productID, "Version Test Product", "Test product for optimistic locking", price, 5)
+		require.NoError(t, err)
+
+		err = repo.Save(ctx, product)
+		require.NoError(t, err)
+		assert.Equal(t, 1, product.Version())
+
+		// Two readers load the same version
+		first, err := repo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		second, err := repo.FindByID(ctx, productID)
+		require.NoError(t, err)
+
+		// The first writer wins
+		first.UpdateName("First Writer")
+		err = repo.Save(ctx, first)
+		require.NoError(t, err)
+		assert.Equal(t, 2, first.Version())
+
+		// The second writer is rejected instead of silently overwriting
+		second.UpdateName("Second Writer")
+		err = repo.Save(ctx, second)
+		assert.ErrorIs(t, err, domain.ErrConcurrentModification)
+
+		found, err := repo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, "First Writer", found.Name())
+
+		// Clean up
+		repo.Delete(ctx, productID)
 	})
 }
@@ -0,0 +1,384 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/guregu/dynamo/v2"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// DynamoReturnRepository implements ReturnRepository using DynamoDB
+type DynamoReturnRepository struct {
+	client *infrastructure.DynamoDBClient
+}
+
+// NewDynamoReturnRepository creates a new DynamoDB return repository
+func NewDynamoReturnRepository(client *infrastructure.DynamoDBClient) *DynamoReturnRepository {
+	return &DynamoReturnRepository{
+		client: client,
+	}
+}
+
+// ReturnItemData represents one returned product in the Items attribute of a return request
+type ReturnItemData struct {
+	ProductID string `dynamo:"ProductID"` // ProductID
+	Quantity  int    `dynamo:"Quantity"`  // Returned quantity
+	UnitPrice int64  `dynamo:"UnitPrice"` // Price paid in cents
+}
+
+// ReturnRequestItem represents a return request stored in the order item collection.
+// GSI1 looks it up by return ID and GSI2 lists the requests with a status by request date.
+type ReturnRequestItem struct {
+	PK          string           `dynamo:"PK"`                    // ORDER#{OrderID}
+	SK          string           `dynamo:"SK"`                    // RETURN#{ReturnID}
+	GSI1PK      string           `dynamo:"GSI1PK"`                // RETURN#{ReturnID}
+	GSI1SK      string           `dynamo:"GSI1SK"`                // RETURN#{ReturnID}
+	GSI2PK      string           `dynamo:"GSI2PK"`                // RETURN_STATUS#{Status}
+	GSI2SK      string           `dynamo:"GSI2SK"`                // {RequestedAt in UTC}#{ReturnID}
+	Type        string           `dynamo:"Type"`                  // "RETURN"
+	ID          string           `dynamo:"ID"`                    // ReturnID
+	OrderID     string           `dynamo:"OrderID"`               // OrderID
+	CustomerID  string           `dynamo:"CustomerID"`            // CustomerID
+	Items       []ReturnItemData `dynamo:"Items"`                 // Returned products
+	Reason      string           `dynamo:"Reason"`                // Why the customer returns the goods
+	Status      string           `dynamo:"Status"`                // Return status
+	Refund      int64            `dynamo:"Refund"`                // Refund in cents, 0 until approved
+	WarehouseID string           `dynamo:"WarehouseID,omitempty"` // Warehouse receiving the goods
+	Note        string           `dynamo:"Note,omitempty"`        // Staff note on the decision
+	RequestedAt time.Time        `dynamo:"RequestedAt"`           // Request timestamp
+	DecidedAt   time.Time        `dynamo:"DecidedAt,omitempty"`   // Decision timestamp
+	UpdatedAt   time.Time        `dynamo:"UpdatedAt"`             // Last update timestamp
+	Version     int              `dynamo:"Version"`               // Optimistic locking version
+}
+
+// ToEntity converts ReturnRequestItem to ReturnRequest entity
+func (item *ReturnRequestItem) ToEntity() (*entity.ReturnRequest, error) {
+	returnID, err := value.NewReturnID(item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid return ID: %w", err)
+	}
+
+	orderID, err := value.NewOrderID(item.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	customerID, err := value.NewCustomerID(item.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer ID: %w", err)
+	}
+
+	items := make([]entity.ReturnItem, 0, len(item.Items))
+	for _, data := range item.Items {
+		productID, err := value.NewProductID(data.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID in return item: %w", err)
+		}
+		unitPrice, err := value.NewMoney(data.UnitPrice)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unit price in return item: %w", err)
+		}
+		items = append(items, entity.ReturnItem{
+			ProductID: productID,
+			Quantity:  data.Quantity,
+			UnitPrice: unitPrice,
+		})
+	}
+
+	refund, err := value.NewMoney(item.Refund)
+	if err != nil {
+		return nil, fmt.Errorf("invalid refund amount: %w", err)
+	}
+
+	request, err := entity.NewReturnRequestWithState(
+		returnID,
+		orderID,
+		customerID,
+		items,
+		item.Reason,
+		entity.ReturnStatus(item.Status),
+		refund,
+		value.WarehouseID(item.WarehouseID),
+		item.Note,
+		item.RequestedAt,
+		item.DecidedAt,
+		item.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create return request entity: %w", err)
+	}
+	request.SetVersion(item.Version)
+
+	return request, nil
+}
+
+// ReturnRequestItemFromEntity converts ReturnRequest entity to ReturnRequestItem
+func ReturnRequestItemFromEntity(request *entity.ReturnRequest) *ReturnRequestItem {
+	returnID := request.ID().String()
+
+	items := make([]ReturnItemData, 0, len(request.Items()))
+	for _, item := range request.Items() {
+		items = append(items, ReturnItemData{
+			ProductID: item.ProductID.String(),
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice.Cents(),
+		})
+	}
+
+	return &ReturnRequestItem{
+		PK:          fmt.Sprintf("ORDER#%s", request.OrderID().String()),
+		SK:          fmt.Sprintf("RETURN#%s", returnID),
+		GSI1PK:      fmt.Sprintf("RETURN#%s", returnID),
+		GSI1SK:      fmt.Sprintf("RETURN#%s", returnID),
+		GSI2PK:      fmt.Sprintf("RETURN_STATUS#%s", request.Status()),
+		GSI2SK:      fmt.Sprintf("%s#%s", request.RequestedAt().UTC().Format(time.RFC3339), returnID),
+		Type:        "RETURN",
+		ID:          returnID,
+		OrderID:     request.OrderID().String(),
+		CustomerID:  request.CustomerID().String(),
+		Items:       items,
+		Reason:      request.Reason(),
+		Status:      string(request.Status()),
+		Refund:      request.Refund().Cents(),
+		WarehouseID: request.WarehouseID().String(),
+		Note:        request.Note(),
+		RequestedAt: request.RequestedAt(),
+		DecidedAt:   request.DecidedAt(),
+		UpdatedAt:   request.UpdatedAt(),
+		Version:     request.Version(),
+	}
+}
+
+// returnedQuantities sums the returned quantity per product, keeping the order of first appearance
+func returnedQuantities(items []entity.ReturnItem) ([]string, map[string]int) {
+	productIDs := make([]string, 0, len(items))
+	quantities := make(map[string]int, len(items))
+	for _, item := range items {
+		productID := item.ProductID.String()
+		if _, ok := quantities[productID]; !ok {
+			productIDs = append(productIDs, productID)
+		}
+		quantities[productID] += item.Quantity
+	}
+	return productIDs, quantities
+}
+
+// Save creates or updates a return request and writes its order header in the same transaction
+func (r *DynamoReturnRepository) Save(ctx context.Context, request *entity.ReturnRequest, order *entity.Order) error {
+	slog.Info("Saving return request", "returnID", request.ID().String(), "orderID", order.ID().String())
+	return r.save(ctx, request, order, nil, false)
+}
+
+// Approve saves an approved return request and its order, and puts the returned quantities back
+// into the given products' totals and the receiving warehouse, in a single transaction
+func (r *DynamoReturnRepository) Approve(ctx context.Context, request *entity.ReturnRequest, order *entity.Order, products []*entity.Product) error {
+	slog.Info("Approving return request", "returnID", request.ID().String(), "orderID", order.ID().String())
+	if !request.IsApproved() {
+		return fmt.Errorf("return request is not approved: %s", request.ID().String())
+	}
+	return r.save(ctx, request, order, products, true)
+}
+
+func (r *DynamoReturnRepository) save(ctx context.Context, request *entity.ReturnRequest, order *entity.Order, products []*entity.Product, restock bool) error {
+	item := ReturnRequestItemFromEntity(request)
+	item.Version = request.Version() + 1
+
+	header, lines, err := OrderItemFromEntity(order)
+	if err != nil {
+		return fmt.Errorf("failed to convert order to item: %w", err)
+	}
+	header.Version = order.Version() + 1
+
+	table := r.client.GetTable()
+
+	// 承認時は返品された商品のうち渡された商品に戻す
+	productIDs := []string{}
+	var quantities map[string]int
+	if restock {
+		var returnedIDs []string
+		returnedIDs, quantities = returnedQuantities(request.Items())
+		productIDs = restockedProducts(returnedIDs, products)
+	}
+
+	tx := r.client.DB.WriteTx()
+
+	// 0: 返品（楽観ロック）、1: 注文ヘッダ（楽観ロック）
+	tx.Put(putIfVersion(table.Put(item), request.Version()))
+	tx.Put(putIfVersion(table.Put(header), order.Version()))
+
+	// 承認時のみ 2〜: 在庫合計の加算、続いて受け入れ倉庫の在庫数の加算（在庫アイテムがなければ作る）
+	if restock {
+		warehouseID := request.WarehouseID().String()
+		for _, productID := range productIDs {
+			key := fmt.Sprintf("PRODUCT#%s", productID)
+			tx.Update(table.Update("PK", key).
+				Range("SK", key).
+				SetExpr("'Stock' = 'Stock' + ?", quantities[productID]).
+				Set("UpdatedAt", request.UpdatedAt()).
+				Add("Version", 1).
+				If("attribute_exists('PK')"))
+		}
+		for _, productID := range productIDs {
+			tx.Update(table.Update("PK", fmt.Sprintf("PRODUCT#%s", productID)).
+				Range("SK", fmt.Sprintf("WAREHOUSE#%s", warehouseID)).
+				SetExpr("'Quantity' = if_not_exists('Quantity', ?) + ?", 0, quantities[productID]).
+				Set("GSI2PK", fmt.Sprintf("WAREHOUSE#%s", warehouseID)).
+				Set("GSI2SK", fmt.Sprintf("PRODUCT#%s", productID)).
+				Set("Type", "INVENTORY").
+				Set("ProductID", productID).
+				Set("WarehouseID", warehouseID).
+				Set("UpdatedAt", request.UpdatedAt()).
+				Add("Version", 1))
+		}
+	}
+
+	// 以降: 注文明細、ステータス履歴（追記のみ）
+	for _, line := range lines {
+		tx.Put(table.Put(line))
+	}
+	for _, status := range OrderStatusItemsFromEntity(order) {
+		tx.Put(table.Put(status).If("attribute_not_exists('PK')"))
+	}
+
+	err = tx.Run(ctx)
+	if err != nil {
+		if i, ok := failedCondition(err); ok {
+			switch {
+			case i == 0:
+				slog.Warn("Return request was modified concurrently", "returnID", request.ID().String(), "version", request.Version())
+				return domain.ConcurrentModificationError("ReturnRequest", request.ID().String())
+			case i == 1:
+				slog.Warn("Order was modified concurrently", "orderID", order.ID().String(), "version", order.Version())
+				return domain.ConcurrentModificationError("Order", order.ID().String())
+			case i-2 < len(productIDs):
+				// 読んだ後に商品が削除された（再実行すればその商品を除いて承認できる）
+				slog.Warn("Returned product was deleted concurrently", "returnID", request.ID().String(), "productID", productIDs[i-2])
+				return domain.ConcurrentModificationError("Product", productIDs[i-2])
+			}
+		}
+		slog.Error("Failed to save return request", "returnID", request.ID().String(), "error", err)
+		return fmt.Errorf("failed to save return request: %w", err)
+	}
+	request.SetVersion(item.Version)
+	order.SetVersion(header.Version)
+	order.MarkStatusChangesSaved()
+
+	slog.Info("Return request saved successfully", "returnID", request.ID().String())
+	return nil
+}
+
+// FindByID retrieves a return request by its ID through GSI1
+func (r *DynamoReturnRepository) FindByID(ctx context.Context, id value.ReturnID) (*entity.ReturnRequest, error) {
+	slog.Info("Finding return request by ID", "returnID", id.String())
+
+	var item ReturnRequestItem
+	table := r.client.GetTable()
+
+	key := fmt.Sprintf("RETURN#%s", id.String())
+	err := table.Get("GSI1PK", key).
+		Range("GSI1SK", dynamo.Equal, key).
+		Index("GSI1").
+		One(ctx, &item)
+	if err != nil {
+		if err == dynamo.ErrNotFound {
+			slog.Info("Return request not found", "returnID", id.String())
+			return nil, domain.ReturnNotFoundError(id.String())
+		}
+		slog.Error("Failed to find return request", "returnID", id.String(), "error", err)
+		return nil, fmt.Errorf("failed to find return request: %w", err)
+	}
+
+	request, err := item.ToEntity()
+	if err != nil {
+		slog.Error("Failed to convert item to entity", "returnID", id.String(), "error", err)
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+
+	slog.Info("Return request found successfully", "returnID", id.String())
+	return request, nil
+}
+
+// FindByOrderID retrieves all return requests of an order from the order item collection
+func (r *DynamoReturnRepository) FindByOrderID(ctx context.Context, orderID value.OrderID) ([]*entity.ReturnRequest, error) {
+	slog.Info("Finding return requests by order ID", "orderID", orderID.String())
+
+	var items []ReturnRequestItem
+	table := r.client.GetTable()
+
+	err := table.Get("PK", fmt.Sprintf("ORDER#%s", orderID.String())).
+		Range("SK", dynamo.BeginsWith, "RETURN#").
+		Consistent(true).
+		All(ctx, &items)
+	if err != nil {
+		slog.Error("Failed to find return requests by order ID", "orderID", orderID.String(), "error", err)
+		return nil, fmt.Errorf("failed to find return requests by order ID: %w", err)
+	}
+
+	requests := make([]*entity.ReturnRequest, 0, len(items))
+	for _, item := range items {
+		request, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "returnID", item.ID, "error", err)
+			return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	slog.Info("Found return requests by order ID successfully", "orderID", orderID.String(), "count", len(requests))
+	return requests, nil
+}
+
+// FindByStatus retrieves the return requests with a status through GSI2, oldest first
+func (r *DynamoReturnRepository) FindByStatus(ctx context.Context, status entity.ReturnStatus, limit int, lastKey *string) ([]*entity.ReturnRequest, *string, error) {
+	slog.Info("Finding return requests by status", "status", string(status), "limit", limit)
+
+	scope := "returns:status:" + string(status)
+	start, err := startKey(r.client, scope, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []ReturnRequestItem
+	table := r.client.GetTable()
+
+	query := table.Get("GSI2PK", fmt.Sprintf("RETURN_STATUS#%s", status)).
+		Index("GSI2")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if start != nil {
+		query = query.StartFrom(start)
+	}
+
+	lek, err := query.AllWithLastEvaluatedKey(ctx, &items)
+	if err != nil {
+		slog.Error("Failed to find return requests by status", "status", string(status), "error", err)
+		return nil, nil, fmt.Errorf("failed to find return requests by status: %w", err)
+	}
+
+	requests := make([]*entity.ReturnRequest, 0, len(items))
+	for _, item := range items {
+		request, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "returnID", item.ID, "error", err)
+			continue // Skip invalid items
+		}
+		requests = append(requests, request)
+	}
+
+	next, err := r.client.Cursors.Encode(scope, lek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	slog.Info("Found return requests by status successfully", "status", string(status), "count", len(requests))
+	return requests, next, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+func TestReturnRequestItemConversion(t *testing.T) {
+	orderID, _ := value.NewOrderID("order-123")
+	customerID, _ := value.NewCustomerID("customer-123")
+	warehouseID, _ := value.NewWarehouseID("warehouse-456")
+	productID, _ := value.NewProductID("product-1")
+	price, _ := value.NewMoney(1500)
+
+	orderItem, _ := entity.NewOrderItem(productID, 3, price)
+	total, _ := value.NewMoney(4500)
+	order, err := entity.NewOrderWithState(orderID, customerID, []entity.OrderItem{*orderItem},
+		entity.OrderStatusDelivered, total, time.Now(), time.Now())
+	require.NoError(t, err)
+
+	request, err := entity.NewReturnRequest("return-789", order,
+		[]entity.ReturnItem{{ProductID: productID, Quantity: 2}}, "Damaged", nil)
+	require.NoError(t, err)
+
+	item := ReturnRequestItemFromEntity(request)
+
+	// Stored in the order item collection, looked up by ID on GSI1 and by status on GSI2
+	requestedAt := request.RequestedAt().UTC().Format(time.RFC3339)
+	assert.Equal(t, "ORDER#order-123", item.PK)
+	assert.Equal(t, "RETURN#return-789", item.SK)
+	assert.Equal(t, "RETURN#return-789", item.GSI1PK)
+	assert.Equal(t, "RETURN#return-789", item.GSI1SK)
+	assert.Equal(t, "RETURN_STATUS#requested", item.GSI2PK)
+	assert.Equal(t, requestedAt+"#return-789", item.GSI2SK)
+	assert.Equal(t, "RETURN", item.Type)
+	require.Len(t, item.Items, 1)
+	assert.Equal(t, int64(1500), item.Items[0].UnitPrice)
+
+	require.NoError(t, request.Approve(warehouseID, "Resellable"))
+	item = ReturnRequestItemFromEntity(request)
+	assert.Equal(t, "RETURN_STATUS#approved", item.GSI2PK)
+
+	converted, err := item.ToEntity()
+	require.NoError(t, err)
+	assert.Equal(t, request.ID(), converted.ID())
+	assert.Equal(t, orderID, converted.OrderID())
+	assert.Equal(t, customerID, converted.CustomerID())
+	assert.Equal(t, entity.ReturnStatusApproved, converted.Status())
+	assert.Equal(t, int64(3000), converted.Refund().Cents())
+	assert.Equal(t, warehouseID, converted.WarehouseID())
+	assert.Equal(t, "Resellable", converted.Note())
+	assert.Equal(t, request.DecidedAt(), converted.DecidedAt())
+	assert.Equal(t, request.Items(), converted.Items())
+}
+
+// TestDynamoReturnRepository runs integration tests against DynamoDB Local
+func TestDynamoReturnRepository(t *testing.T) {
+	// Skip if not running integration tests
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
+	require.NoError(t, err)
+	require.NoError(t, client.HealthCheck(ctx))
+
+	repo := NewDynamoReturnRepository(client)
+	orderRepo := NewDynamoOrderRepository(client)
+	productRepo := NewDynamoProductRepository(client)
+	warehouseRepo := NewDynamoWarehouseRepository(client)
+
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+	orderID, _ := value.NewOrderID("test-return-order-" + suffix)
+	customerID, _ := value.NewCustomerID("test-return-customer-" + suffix)
+	productID, _ := value.NewProductID("test-return-product-" + suffix)
+	warehouseID, _ := value.NewWarehouseID("test-return-warehouse-" + suffix)
+
+	price, _ := value.NewMoney(1200)
+	product, err := entity.NewProduct(productID, "Widget", "", price, 5)
+	require.NoError(t, err)
+	require.NoError(t, productRepo.Save(ctx, product))
+	defer productRepo.Delete(ctx, productID)
+
+	orderItem, _ := entity.NewOrderItem(productID, 2, price)
+	total, _ := value.NewMoney(2400)
+	order, err := entity.NewOrderWithState(orderID, customerID, []entity.OrderItem{*orderItem},
+		entity.OrderStatusDelivered, total, time.Now(), time.Now())
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Save(ctx, order))
+	defer orderRepo.Delete(ctx, orderID)
+
+	returnID, _ := value.NewReturnID("test-return-" + suffix)
+	request, err := entity.NewReturnRequest(returnID, order,
+		[]entity.ReturnItem{{ProductID: productID, Quantity: 1}}, "Damaged", nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, request, order))
+
+	t.Run("find return request by ID and order", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, returnID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ReturnStatusRequested, found.Status())
+
+		requests, err := repo.FindByOrderID(ctx, orderID)
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+
+		// Return requests in the order item collection do not disturb reading the order
+		storedOrder, err := orderRepo.FindByID(ctx, orderID)
+		require.NoError(t, err)
+		assert.Len(t, storedOrder.Items(), 1)
+	})
+
+	t.Run("approve restocks in the same write", func(t *testing.T) {
+		require.NoError(t, request.Approve(warehouseID, ""))
+		require.NoError(t, order.ApplyReturns([]*entity.ReturnRequest{request}))
+		require.NoError(t, repo.Approve(ctx, request, order, []*entity.Product{product}))
+
+		storedProduct, err := productRepo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 6, storedProduct.Stock())
+
+		inventory, err := warehouseRepo.FindInventory(ctx, warehouseID, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, inventory.Quantity())
+
+		storedOrder, err := orderRepo.FindByID(ctx, orderID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusPartiallyRefunded, storedOrder.Status())
+	})
+}
@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/guregu/dynamo/v2"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// DynamoShipmentRepository implements ShipmentRepository using DynamoDB
+type DynamoShipmentRepository struct {
+	client *infrastructure.DynamoDBClient
+}
+
+// NewDynamoShipmentRepository creates a new DynamoDB shipment repository
+func NewDynamoShipmentRepository(client *infrastructure.DynamoDBClient) *DynamoShipmentRepository {
+	return &DynamoShipmentRepository{
+		client: client,
+	}
+}
+
+// ShipmentItemData represents one shipped product in the Items attribute of a shipment
+type ShipmentItemData struct {
+	ProductID string `dynamo:"ProductID"` // ProductID
+	Quantity  int    `dynamo:"Quantity"`  // Shipped quantity
+}
+
+// ShipmentItem represents a shipment stored in the order item collection.
+// GSI1 looks it up by shipment ID and GSI2 lists a warehouse's shipments by shipping date.
+type ShipmentItem struct {
+	PK             string             `dynamo:"PK"`                    // ORDER#{OrderID}
+	SK             string             `dynamo:"SK"`                    // SHIPMENT#{ShipmentID}
+	GSI1PK         string             `dynamo:"GSI1PK"`                // SHIPMENT#{ShipmentID}
+	GSI1SK         string             `dynamo:"GSI1SK"`                // SHIPMENT#{ShipmentID}
+	GSI2PK         string             `dynamo:"GSI2PK"`                // WAREHOUSE#{WarehouseID}
+	GSI2SK         string             `dynamo:"GSI2SK"`                // SHIPMENT#{ShippedAt in UTC}#{ShipmentID}
+	Type           string             `dynamo:"Type"`                  // "SHIPMENT"
+	ID             string             `dynamo:"ID"`                    // ShipmentID
+	OrderID        string             `dynamo:"OrderID"`               // OrderID
+	WarehouseID    string             `dynamo:"WarehouseID"`           // WarehouseID
+	Carrier        string             `dynamo:"Carrier"`               // Carrier name
+	TrackingNumber string             `dynamo:"TrackingNumber"`        // Carrier tracking number
+	Items          []ShipmentItemData `dynamo:"Items"`                 // Shipped products
+	Status         string             `dynamo:"Status"`                // Shipment status
+	ShippedAt      time.Time          `dynamo:"ShippedAt"`             // Shipping timestamp
+	DeliveredAt    time.Time          `dynamo:"DeliveredAt,omitempty"` // Delivery timestamp
+	UpdatedAt      time.Time          `dynamo:"UpdatedAt"`             // Last update timestamp
+	Version        int                `dynamo:"Version"`               // Optimistic locking version
+}
+
+// ToEntity converts ShipmentItem to Shipment entity
+func (item *ShipmentItem) ToEntity() (*entity.Shipment, error) {
+	shipmentID, err := value.NewShipmentID(item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shipment ID: %w", err)
+	}
+
+	orderID, err := value.NewOrderID(item.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	warehouseID, err := value.NewWarehouseID(item.WarehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warehouse ID: %w", err)
+	}
+
+	items := make([]entity.ShipmentItem, 0, len(item.Items))
+	for _, data := range item.Items {
+		productID, err := value.NewProductID(data.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID in shipment item: %w", err)
+		}
+		items = append(items, entity.ShipmentItem{
+			ProductID: productID,
+			Quantity:  data.Quantity,
+		})
+	}
+
+	shipment, err := entity.NewShipmentWithState(
+		shipmentID,
+		orderID,
+		warehouseID,
+		item.Carrier,
+		item.TrackingNumber,
+		items,
+		entity.ShipmentStatus(item.Status),
+		item.ShippedAt,
+		item.DeliveredAt,
+		item.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shipment entity: %w", err)
+	}
+	shipment.SetVersion(item.Version)
+
+	return shipment, nil
+}
+
+// ShipmentItemFromEntity converts Shipment entity to ShipmentItem
+func ShipmentItemFromEntity(shipment *entity.Shipment) *ShipmentItem {
+	shipmentID := shipment.ID().String()
+	warehouseID := shipment.WarehouseID().String()
+
+	items := make([]ShipmentItemData, 0, len(shipment.Items()))
+	for _, item := range shipment.Items() {
+		items = append(items, ShipmentItemData{
+			ProductID: item.ProductID.String(),
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return &ShipmentItem{
+		PK:             fmt.Sprintf("ORDER#%s", shipment.OrderID().String()),
+		SK:             fmt.Sprintf("SHIPMENT#%s", shipmentID),
+		GSI1PK:         fmt.Sprintf("SHIPMENT#%s", shipmentID),
+		GSI1SK:         fmt.Sprintf("SHIPMENT#%s", shipmentID),
+		GSI2PK:         fmt.Sprintf("WAREHOUSE#%s", warehouseID),
+		GSI2SK:         fmt.Sprintf("SHIPMENT#%s#%s", shipment.ShippedAt().UTC().Format(time.RFC3339), shipmentID),
+		Type:           "SHIPMENT",
+		ID:             shipmentID,
+		OrderID:        shipment.OrderID().String(),
+		WarehouseID:    warehouseID,
+		Carrier:        shipment.Carrier(),
+		TrackingNumber: shipment.TrackingNumber(),
+		Items:          items,
+		Status:         string(shipment.Status()),
+		ShippedAt:      shipment.ShippedAt(),
+		DeliveredAt:    shipment.DeliveredAt(),
+		UpdatedAt:      shipment.UpdatedAt(),
+		Version:        shipment.Version(),
+	}
+}
+
+// Save creates or updates a shipment and writes its order header in the same transaction
+func (r *DynamoShipmentRepository) Save(ctx context.Context, shipment *entity.Shipment, order *entity.Order) error {
+	slog.Info("Saving shipment", "shipmentID", shipment.ID().String(), "orderID", order.ID().String())
+
+	item := ShipmentItemFromEntity(shipment)
+	item.Version = shipment.Version() + 1
+
+	header, lines, err := OrderItemFromEntity(order)
+	if err != nil {
+		return fmt.Errorf("failed to convert order to item: %w", err)
+	}
+	header.Version = order.Version() + 1
+
+	table := r.client.GetTable()
+	tx := r.client.DB.WriteTx()
+
+	// 0: 出荷（楽観ロック）、1: 注文ヘッダ（楽観ロック）、以降: 注文明細、ステータス履歴（追記のみ）
+	tx.Put(putIfVersion(table.Put(item), shipment.Version()))
+	tx.Put(putIfVersion(table.Put(header), order.Version()))
+	for _, line := range lines {
+		tx.Put(table.Put(line))
+	}
+	for _, status := range OrderStatusItemsFromEntity(order) {
+		tx.Put(table.Put(status).If("attribute_not_exists('PK')"))
+	}
+
+	err = tx.Run(ctx)
+	if err != nil {
+		if i, ok := failedCondition(err); ok {
+			switch i {
+			case 0:
+				slog.Warn("Shipment was modified concurrently", "shipmentID", shipment.ID().String(), "version", shipment.Version())
+				return domain.ConcurrentModificationError("Shipment", shipment.ID().String())
+			case 1:
+				slog.Warn("Order was modified concurrently", "orderID", order.ID().String(), "version", order.Version())
+				return domain.ConcurrentModificationError("Order", order.ID().String())
+			}
+		}
+		slog.Error("Failed to save shipment", "shipmentID", shipment.ID().String(), "error", err)
+		return fmt.Errorf("failed to save shipment: %w", err)
+	}
+	shipment.SetVersion(item.Version)
+	order.SetVersion(header.Version)
+	order.MarkStatusChangesSaved()
+
+	slog.Info("Shipment saved successfully", "shipmentID", shipment.ID().String())
+	return nil
+}
+
+// FindByID retrieves a shipment by its ID through GSI1
+func (r *DynamoShipmentRepository) FindByID(ctx context.Context, id value.ShipmentID) (*entity.Shipment, error) {
+	slog.Info("Finding shipment by ID", "shipmentID", id.String())
+
+	var item ShipmentItem
+	table := r.client.GetTable()
+
+	key := fmt.Sprintf("SHIPMENT#%s", id.String())
+	err := table.Get("GSI1PK", key).
+		Range("GSI1SK", dynamo.Equal, key).
+		Index("GSI1").
+		One(ctx, &item)
+	if err != nil {
+		if err == dynamo.ErrNotFound {
+			slog.Info("Shipment not found", "shipmentID", id.String())
+			return nil, domain.ShipmentNotFoundError(id.String())
+		}
+		slog.Error("Failed to find shipment", "shipmentID", id.String(), "error", err)
+		return nil, fmt.Errorf("failed to find shipment: %w", err)
+	}
+
+	shipment, err := item.ToEntity()
+	if err != nil {
+		slog.Error("Failed to convert item to entity", "shipmentID", id.String(), "error", err)
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+
+	slog.Info("Shipment found successfully", "shipmentID", id.String())
+	return shipment, nil
+}
+
+// FindByOrderID retrieves all shipments of an order from the order item collection
+func (r *DynamoShipmentRepository) FindByOrderID(ctx context.Context, orderID value.OrderID) ([]*entity.Shipment, error) {
+	slog.Info("Finding shipments by order ID", "orderID", orderID.String())
+
+	var items []ShipmentItem
+	table := r.client.GetTable()
+
+	err := table.Get("PK", fmt.Sprintf("ORDER#%s", orderID.String())).
+		Range("SK", dynamo.BeginsWith, "SHIPMENT#").
+		Consistent(true).
+		All(ctx, &items)
+	if err != nil {
+		slog.Error("Failed to find shipments by order ID", "orderID", orderID.String(), "error", err)
+		return nil, fmt.Errorf("failed to find shipments by order ID: %w", err)
+	}
+
+	shipments := make([]*entity.Shipment, 0, len(items))
+	for _, item := range items {
+		shipment, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "shipmentID", item.ID, "error", err)
+			return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+		}
+		shipments = append(shipments, shipment)
+	}
+
+	slog.Info("Found shipments by order ID successfully", "orderID", orderID.String(), "count", len(shipments))
+	return shipments, nil
+}
+
+// FindByWarehouseID retrieves the shipments sent from a warehouse through GSI2, newest first
+func (r *DynamoShipmentRepository) FindByWarehouseID(ctx context.Context, warehouseID value.WarehouseID, limit int, lastKey *string) ([]*entity.Shipment, *string, error) {
+	slog.Info("Finding shipments by warehouse ID", "warehouseID", warehouseID.String(), "limit", limit)
+
+	scope := "shipments:warehouse:" + warehouseID.String()
+	start, err := startKey(r.client, scope, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []ShipmentItem
+	table := r.client.GetTable()
+
+	// GSI2 の倉庫パーティションには在庫（PRODUCT#）も同居するので SHIPMENT# で絞る
+	query := table.Get("GSI2PK", fmt.Sprintf("WAREHOUSE#%s", warehouseID.String())).
+		Range("GSI2SK", dynamo.BeginsWith, "SHIPMENT#").
+		Index("GSI2").
+		Order(dynamo.Descending)
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if start != nil {
+		query = query.StartFrom(start)
+	}
+
+	lek, err := query.AllWithLastEvaluatedKey(ctx, &items)
+	if err != nil {
+		slog.Error("Failed to find shipments by warehouse ID", "warehouseID", warehouseID.String(), "error", err)
+		return nil, nil, fmt.Errorf("failed to find shipments by warehouse ID: %w", err)
+	}
+
+	shipments := make([]*entity.Shipment, 0, len(items))
+	for _, item := range items {
+		shipment, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "shipmentID", item.ID, "error", err)
+			continue // Skip invalid items
+		}
+		shipments = append(shipments, shipment)
+	}
+
+	next, err := r.client.Cursors.Encode(scope, lek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	slog.Info("Found shipments by warehouse ID successfully", "warehouseID", warehouseID.String(), "count", len(shipments))
+	return shipments, next, nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+func TestShipmentItemConversion(t *testing.T) {
+	shipmentID, _ := value.NewShipmentID("shipment-789")
+	orderID, _ := value.NewOrderID("order-123")
+	warehouseID, _ := value.NewWarehouseID("warehouse-456")
+	productID, _ := value.NewProductID("product-1")
+
+	shipment, err := entity.NewShipment(shipmentID, orderID, warehouseID, "Yamato", "TRACK-1",
+		[]entity.ShipmentItem{{ProductID: productID, Quantity: 3}})
+	require.NoError(t, err)
+
+	item := ShipmentItemFromEntity(shipment)
+
+	// Stored in the order item collection, looked up by ID on GSI1 and by warehouse on GSI2
+	shippedAt := shipment.ShippedAt().UTC().Format(time.RFC3339)
+	assert.Equal(t, "ORDER#order-123", item.PK)
+	assert.Equal(t, "SHIPMENT#shipment-789", item.SK)
+	assert.Equal(t, "SHIPMENT#shipment-789", item.GSI1PK)
+	assert.Equal(t, "SHIPMENT#shipment-789", item.GSI1SK)
+	assert.Equal(t, "WAREHOUSE#warehouse-456", item.GSI2PK)
+	assert.Equal(t, "SHIPMENT#"+shippedAt+"#shipment-789", item.GSI2SK)
+	assert.Equal(t, "SHIPMENT", item.Type)
+	require.Len(t, item.Items, 1)
+	assert.Equal(t, 3, item.Items[0].Quantity)
+
+	require.NoError(t, shipment.Deliver())
+	converted, err := ShipmentItemFromEntity(shipment).ToEntity()
+	require.NoError(t, err)
+	assert.Equal(t, shipmentID, converted.ID())
+	assert.Equal(t, orderID, converted.OrderID())
+	assert.Equal(t, warehouseID, converted.WarehouseID())
+	assert.Equal(t, "TRACK-1", converted.TrackingNumber())
+	assert.Equal(t, entity.ShipmentStatusDelivered, converted.Status())
+	assert.Equal(t, shipment.DeliveredAt(), converted.DeliveredAt())
+	assert.Equal(t, shipment.Items(), converted.Items())
+}
+
+// TestDynamoShipmentRepository runs integration tests against DynamoDB Local
+func TestDynamoShipmentRepository(t *testing.T) {
+	// Skip if not running integration tests
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
+	require.NoError(t, err)
+	require.NoError(t, client.HealthCheck(ctx))
+
+	repo := NewDynamoShipmentRepository(client)
+	orderRepo := NewDynamoOrderRepository(client)
+
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+	orderID, _ := value.NewOrderID("test-shipment-order-" + suffix)
+	customerID, _ := value.NewCustomerID("test-shipment-customer-" + suffix)
+	productID, _ := value.NewProductID("test-shipment-product-" + suffix)
+	warehouseID, _ := value.NewWarehouseID("test-shipment-warehouse-" + suffix)
+
+	price, _ := value.NewMoney(1200)
+	orderItem, _ := entity.NewOrderItem(productID, 2, price)
+	order, err := entity.NewOrder(orderID, customerID, []entity.OrderItem{*orderItem})
+	require.NoError(t, err)
+	require.NoError(t, order.Confirm())
+	require.NoError(t, orderRepo.Save(ctx, order))
+	defer orderRepo.Delete(ctx, orderID)
+
+	shipmentID, _ := value.NewShipmentID("test-shipment-" + suffix)
+	shipment, err := entity.NewShipment(shipmentID, orderID, warehouseID, "Yamato", "TRACK-"+suffix,
+		[]entity.ShipmentItem{{ProductID: productID, Quantity: 2}})
+	require.NoError(t, err)
+	require.NoError(t, order.ApplyShipments([]*entity.Shipment{shipment}))
+	require.NoError(t, repo.Save(ctx, shipment, order))
+
+	t.Run("order is shipped in the same write", func(t *testing.T) {
+		found, err := orderRepo.FindByID(ctx, orderID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusShipped, found.Status())
+		assert.Len(t, found.Items(), 1)
+	})
+
+	t.Run("find shipment by ID, order and warehouse", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, shipmentID)
+		require.NoError(t, err)
+		assert.Equal(t, warehouseID, found.WarehouseID())
+
+		shipments, err := repo.FindByOrderID(ctx, orderID)
+		require.NoError(t, err)
+		require.Len(t, shipments, 1)
+
+		shipments, _, err = repo.FindByWarehouseID(ctx, warehouseID, 10, nil)
+		require.NoError(t, err)
+		require.Len(t, shipments, 1)
+		assert.Equal(t, shipmentID, shipments[0].ID())
+	})
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// maxTransactionItems is the most items DynamoDB accepts in one transaction
+const maxTransactionItems = 100
+
+// failedCondition returns the position of the first transaction item whose condition
+// check cancelled the transaction. Cancellation reasons are index-aligned with the
+// items in the order they were added to the transaction.
+func failedCondition(err error) (int, bool) {
+	var txErr *types.TransactionCanceledException
+	if !errors.As(err, &txErr) {
+		return 0, false
+	}
+	for i, reason := range txErr.CancellationReasons {
+		if reason.Code != nil && *reason.Code == "ConditionalCheckFailed" {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"github.com/guregu/dynamo/v2"
+)
+
+// putIfVersion makes a put conditional on the version the entity was read at.
+// Version 0 means the entity has never been persisted (or was written before
+// versioning existed), so the stored item must not carry a Version attribute yet.
+func putIfVersion(put *dynamo.Put, version int) *dynamo.Put {
+	if version == 0 {
+		return put.If("attribute_not_exists('Version')")
+	}
+	return put.If("'Version' = ?", version)
+}
+
+// updateIfVersion makes an update conditional on the version the item was read at,
+// with the same handling of unversioned items as putIfVersion.
+func updateIfVersion(update *dynamo.Update, version int) *dynamo.Update {
+	if version == 0 {
+		return update.If("attribute_not_exists('Version')")
+	}
+	return update.If("'Version' = ?", version)
+}
+
+// deleteIfVersion makes a delete conditional on the version the item was read at,
+// with the same handling of unversioned items as putIfVersion.
+func deleteIfVersion(del *dynamo.Delete, version int) *dynamo.Delete {
+	if version == 0 {
+		return del.If("attribute_not_exists('Version')")
+	}
+	return del.If("'Version' = ?", version)
+}
@@ -0,0 +1,411 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/guregu/dynamo/v2"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// DynamoWarehouseRepository implements WarehouseRepository using DynamoDB
+type DynamoWarehouseRepository struct {
+	client *infrastructure.DynamoDBClient
+}
+
+// NewDynamoWarehouseRepository creates a new DynamoDB warehouse repository
+func NewDynamoWarehouseRepository(client *infrastructure.DynamoDBClient) *DynamoWarehouseRepository {
+	return &DynamoWarehouseRepository{
+		client: client,
+	}
+}
+
+// WarehouseItem represents a warehouse item in DynamoDB
+type WarehouseItem struct {
+	PK        string    `dynamo:"PK"`        // WAREHOUSE#{WarehouseID}
+	SK        string    `dynamo:"SK"`        // WAREHOUSE#{WarehouseID}
+	Type      string    `dynamo:"Type"`      // "WAREHOUSE"
+	ID        string    `dynamo:"ID"`        // WarehouseID
+	Name      string    `dynamo:"Name"`      // Warehouse name
+	Location  string    `dynamo:"Location"`  // Warehouse location
+	CreatedAt time.Time `dynamo:"CreatedAt"` // Creation timestamp
+	UpdatedAt time.Time `dynamo:"UpdatedAt"` // Last update timestamp
+	Version   int       `dynamo:"Version"`   // Optimistic locking version
+}
+
+// InventoryItem represents a product's inventory in one warehouse. It lives in the product's
+// item collection and is mirrored on GSI2 under the warehouse.
+type InventoryItem struct {
+	PK          string    `dynamo:"PK"`          // PRODUCT#{ProductID}
+	SK          string    `dynamo:"SK"`          // WAREHOUSE#{WarehouseID}
+	GSI2PK      string    `dynamo:"GSI2PK"`      // WAREHOUSE#{WarehouseID}
+	GSI2SK      string    `dynamo:"GSI2SK"`      // PRODUCT#{ProductID}
+	Type        string    `dynamo:"Type"`        // "INVENTORY"
+	ProductID   string    `dynamo:"ProductID"`   // ProductID
+	WarehouseID string    `dynamo:"WarehouseID"` // WarehouseID
+	Quantity    int       `dynamo:"Quantity"`    // Quantity held in the warehouse
+	UpdatedAt   time.Time `dynamo:"UpdatedAt"`   // Last update timestamp
+	Version     int       `dynamo:"Version"`     // Optimistic locking version
+}
+
+// ToEntity converts WarehouseItem to Warehouse entity
+func (item *WarehouseItem) ToEntity() (*entity.Warehouse, error) {
+	warehouseID, err := value.NewWarehouseID(item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warehouse ID: %w", err)
+	}
+
+	warehouse, err := entity.NewWarehouseWithState(warehouseID, item.Name, item.Location, item.CreatedAt, item.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create warehouse entity: %w", err)
+	}
+	warehouse.SetVersion(item.Version)
+
+	return warehouse, nil
+}
+
+// WarehouseItemFromEntity converts Warehouse entity to WarehouseItem
+func WarehouseItemFromEntity(warehouse *entity.Warehouse) *WarehouseItem {
+	warehouseID := warehouse.ID().String()
+
+	return &WarehouseItem{
+		PK:        fmt.Sprintf("WAREHOUSE#%s", warehouseID),
+		SK:        fmt.Sprintf("WAREHOUSE#%s", warehouseID),
+		Type:      "WAREHOUSE",
+		ID:        warehouseID,
+		Name:      warehouse.Name(),
+		Location:  warehouse.Location(),
+		CreatedAt: warehouse.CreatedAt(),
+		UpdatedAt: warehouse.UpdatedAt(),
+		Version:   warehouse.Version(),
+	}
+}
+
+// ToEntity converts InventoryItem to Inventory entity
+func (item *InventoryItem) ToEntity() (*entity.Inventory, error) {
+	productID, err := value.NewProductID(item.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	warehouseID, err := value.NewWarehouseID(item.WarehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warehouse ID: %w", err)
+	}
+
+	inventory, err := entity.NewInventoryWithState(productID, warehouseID, item.Quantity, item.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inventory entity: %w", err)
+	}
+	inventory.SetVersion(item.Version)
+
+	return inventory, nil
+}
+
+// InventoryItemFromEntity converts Inventory entity to InventoryItem
+func InventoryItemFromEntity(inventory *entity.Inventory) *InventoryItem {
+	productID := inventory.ProductID().String()
+	warehouseID := inventory.WarehouseID().String()
+
+	return &InventoryItem{
+		PK:          fmt.Sprintf("PRODUCT#%s", productID),
+		SK:          fmt.Sprintf("WAREHOUSE#%s", warehouseID),
+		GSI2PK:      fmt.Sprintf("WAREHOUSE#%s", warehouseID),
+		GSI2SK:      fmt.Sprintf("PRODUCT#%s", productID),
+		Type:        "INVENTORY",
+		ProductID:   productID,
+		WarehouseID: warehouseID,
+		Quantity:    inventory.Quantity(),
+		UpdatedAt:   inventory.UpdatedAt(),
+		Version:     inventory.Version(),
+	}
+}
+
+// inventoryName identifies an inventory item in logs and errors
+func inventoryName(warehouseID value.WarehouseID, productID value.ProductID) string {
+	return fmt.Sprintf("%s/%s", warehouseID.String(), productID.String())
+}
+
+// stockAllocation is the quantity taken from one inventory item to fill an order
+type stockAllocation struct {
+	item     *InventoryItem
+	quantity int
+}
+
+// allocateInventory picks the warehouses that fill quantity, largest holdings first so an
+// order is split across as few warehouses as possible. It reports false when the
+// inventories together hold less than quantity.
+func allocateInventory(items []*InventoryItem, quantity int) ([]stockAllocation, bool) {
+	sorted := make([]*InventoryItem, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Quantity != sorted[j].Quantity {
+			return sorted[i].Quantity > sorted[j].Quantity
+		}
+		return sorted[i].WarehouseID < sorted[j].WarehouseID
+	})
+
+	var allocations []stockAllocation
+	remaining := quantity
+	for _, item := range sorted {
+		if remaining == 0 {
+			break
+		}
+		if item.Quantity <= 0 {
+			continue
+		}
+		take := min(item.Quantity, remaining)
+		allocations = append(allocations, stockAllocation{item: item, quantity: take})
+		remaining -= take
+	}
+	return allocations, remaining == 0
+}
+
+// productInventory reads a product's inventory items from its item collection
+func productInventory(ctx context.Context, table dynamo.Table, productID string, consistent bool) ([]*InventoryItem, error) {
+	var items []*InventoryItem
+	err := table.Get("PK", fmt.Sprintf("PRODUCT#%s", productID)).
+		Range("SK", dynamo.BeginsWith, "WAREHOUSE#").
+		Consistent(consistent).
+		All(ctx, &items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product inventory: %w", err)
+	}
+	return items, nil
+}
+
+// Save creates or updates a warehouse
+func (r *DynamoWarehouseRepository) Save(ctx context.Context, warehouse *entity.Warehouse) error {
+	slog.Info("Saving warehouse", "warehouseID", warehouse.ID().String())
+
+	item := WarehouseItemFromEntity(warehouse)
+	item.Version = warehouse.Version() + 1
+	table := r.client.GetTable()
+
+	err := putIfVersion(table.Put(item), warehouse.Version()).Run(ctx)
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			slog.Warn("Warehouse was modified concurrently", "warehouseID", warehouse.ID().String(), "version", warehouse.Version())
+			return domain.ConcurrentModificationError("Warehouse", warehouse.ID().String())
+		}
+		slog.Error("Failed to save warehouse", "warehouseID", warehouse.ID().String(), "error", err)
+		return fmt.Errorf("failed to save warehouse: %w", err)
+	}
+	warehouse.SetVersion(item.Version)
+
+	slog.Info("Warehouse saved successfully", "warehouseID", warehouse.ID().String())
+	return nil
+}
+
+// FindByID retrieves a warehouse by its ID
+func (r *DynamoWarehouseRepository) FindByID(ctx context.Context, id value.WarehouseID) (*entity.Warehouse, error) {
+	slog.Info("Finding warehouse by ID", "warehouseID", id.String())
+
+	var item WarehouseItem
+	table := r.client.GetTable()
+
+	err := table.Get("PK", fmt.Sprintf("WAREHOUSE#%s", id.String())).
+		Range("SK", dynamo.Equal, fmt.Sprintf("WAREHOUSE#%s", id.String())).
+		One(ctx, &item)
+	if err != nil {
+		if err == dynamo.ErrNotFound {
+			slog.Info("Warehouse not found", "warehouseID", id.String())
+			return nil, domain.WarehouseNotFoundError(id.String())
+		}
+		slog.Error("Failed to find warehouse", "warehouseID", id.String(), "error", err)
+		return nil, fmt.Errorf("failed to find warehouse: %w", err)
+	}
+
+	warehouse, err := item.ToEntity()
+	if err != nil {
+		slog.Error("Failed to convert item to entity", "warehouseID", id.String(), "error", err)
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+
+	slog.Info("Warehouse found successfully", "warehouseID", id.String())
+	return warehouse, nil
+}
+
+// Exists checks if a warehouse exists by its ID
+func (r *DynamoWarehouseRepository) Exists(ctx context.Context, id value.WarehouseID) (bool, error) {
+	slog.Info("Checking if warehouse exists", "warehouseID", id.String())
+
+	var item WarehouseItem
+	err := r.client.GetTable().
+		Get("PK", fmt.Sprintf("WAREHOUSE#%s", id.String())).
+		Range("SK", dynamo.Equal, fmt.Sprintf("WAREHOUSE#%s", id.String())).
+		One(ctx, &item)
+	if err != nil {
+		if err == dynamo.ErrNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to find warehouse: %w", err)
+	}
+
+	return true, nil
+}
+
+// SaveInventory writes a product's inventory in a warehouse and adjusts the product's total
+// stock by the change in one transaction
+func (r *DynamoWarehouseRepository) SaveInventory(ctx context.Context, inventory *entity.Inventory) error {
+	name := inventoryName(inventory.WarehouseID(), inventory.ProductID())
+	slog.Info("Saving inventory", "inventory", name, "quantity", inventory.Quantity())
+
+	item := InventoryItemFromEntity(inventory)
+	table := r.client.GetTable()
+
+	// 現在の数量を強い整合性で読み、商品の在庫合計に加える差分を求める
+	var stored InventoryItem
+	err := table.Get("PK", item.PK).
+		Range("SK", dynamo.Equal, item.SK).
+		Consistent(true).
+		One(ctx, &stored)
+	if err != nil && err != dynamo.ErrNotFound {
+		slog.Error("Failed to load inventory", "inventory", name, "error", err)
+		return fmt.Errorf("failed to load inventory: %w", err)
+	}
+	if stored.Version != inventory.Version() {
+		slog.Warn("Inventory was modified concurrently", "inventory", name, "version", inventory.Version())
+		return domain.ConcurrentModificationError("Inventory", name)
+	}
+	delta := item.Quantity - stored.Quantity
+
+	item.Version = inventory.Version() + 1
+	tx := r.client.DB.WriteTx()
+
+	// 0: 在庫アイテム（楽観ロック）、1: 商品の在庫合計
+	tx.Put(putIfVersion(table.Put(item), inventory.Version()))
+	tx.Update(table.Update("PK", item.PK).
+		Range("SK", item.PK).
+		Add("Stock", delta).
+		Set("UpdatedAt", item.UpdatedAt).
+		Add("Version", 1).
+		If("attribute_exists('PK')"))
+
+	err = tx.Run(ctx)
+	if err != nil {
+		if i, ok := failedCondition(err); ok {
+			if i == 0 {
+				slog.Warn("Inventory was modified concurrently", "inventory", name, "version", inventory.Version())
+				return domain.ConcurrentModificationError("Inventory", name)
+			}
+			slog.Info("Product not found", "productID", inventory.ProductID().String())
+			return domain.ProductNotFoundError(inventory.ProductID().String())
+		}
+		slog.Error("Failed to save inventory", "inventory", name, "error", err)
+		return fmt.Errorf("failed to save inventory: %w", err)
+	}
+	inventory.SetVersion(item.Version)
+
+	slog.Info("Inventory saved successfully", "inventory", name)
+	return nil
+}
+
+// FindInventory retrieves a product's inventory in a warehouse
+func (r *DynamoWarehouseRepository) FindInventory(ctx context.Context, warehouseID value.WarehouseID, productID value.ProductID) (*entity.Inventory, error) {
+	name := inventoryName(warehouseID, productID)
+	slog.Info("Finding inventory", "inventory", name)
+
+	var item InventoryItem
+	table := r.client.GetTable()
+
+	err := table.Get("PK", fmt.Sprintf("PRODUCT#%s", productID.String())).
+		Range("SK", dynamo.Equal, fmt.Sprintf("WAREHOUSE#%s", warehouseID.String())).
+		One(ctx, &item)
+	if err != nil {
+		if err == dynamo.ErrNotFound {
+			slog.Info("Inventory not found", "inventory", name)
+			return nil, domain.InventoryNotFoundError(warehouseID.String(), productID.String())
+		}
+		slog.Error("Failed to find inventory", "inventory", name, "error", err)
+		return nil, fmt.Errorf("failed to find inventory: %w", err)
+	}
+
+	inventory, err := item.ToEntity()
+	if err != nil {
+		slog.Error("Failed to convert item to entity", "inventory", name, "error", err)
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+
+	return inventory, nil
+}
+
+// FindInventoryByWarehouse retrieves the inventory of all products in a warehouse through GSI2
+func (r *DynamoWarehouseRepository) FindInventoryByWarehouse(ctx context.Context, warehouseID value.WarehouseID, limit int, lastKey *string) ([]*entity.Inventory, *string, error) {
+	slog.Info("Finding inventory by warehouse", "warehouseID", warehouseID.String(), "limit", limit)
+
+	scope := "inventory:warehouse:" + warehouseID.String()
+	start, err := startKey(r.client, scope, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []InventoryItem
+	table := r.client.GetTable()
+
+	query := table.Get("GSI2PK", fmt.Sprintf("WAREHOUSE#%s", warehouseID.String())).
+		Range("GSI2SK", dynamo.BeginsWith, "PRODUCT#").
+		Index("GSI2")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if start != nil {
+		query = query.StartFrom(start)
+	}
+
+	lek, err := query.AllWithLastEvaluatedKey(ctx, &items)
+	if err != nil {
+		slog.Error("Failed to find inventory by warehouse", "warehouseID", warehouseID.String(), "error", err)
+		return nil, nil, fmt.Errorf("failed to find inventory by warehouse: %w", err)
+	}
+
+	inventories := make([]*entity.Inventory, 0, len(items))
+	for _, item := range items {
+		inventory, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "productID", item.ProductID, "warehouseID", item.WarehouseID, "error", err)
+			continue // Skip invalid items
+		}
+		inventories = append(inventories, inventory)
+	}
+
+	next, err := r.client.Cursors.Encode(scope, lek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	slog.Info("Found inventory by warehouse successfully", "warehouseID", warehouseID.String(), "count", len(inventories))
+	return inventories, next, nil
+}
+
+// FindInventoryByProduct retrieves a product's inventory across all warehouses from its item collection
+func (r *DynamoWarehouseRepository) FindInventoryByProduct(ctx context.Context, productID value.ProductID) ([]*entity.Inventory, error) {
+	slog.Info("Finding inventory by product", "productID", productID.String())
+
+	items, err := productInventory(ctx, r.client.GetTable(), productID.String(), false)
+	if err != nil {
+		slog.Error("Failed to find inventory by product", "productID", productID.String(), "error", err)
+		return nil, err
+	}
+
+	inventories := make([]*entity.Inventory, 0, len(items))
+	for _, item := range items {
+		inventory, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "productID", item.ProductID, "warehouseID", item.WarehouseID, "error", err)
+			continue // Skip invalid items
+		}
+		inventories = append(inventories, inventory)
+	}
+
+	slog.Info("Found inventory by product successfully", "productID", productID.String(), "count", len(inventories))
+	return inventories, nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+func TestWarehouseItemConversion(t *testing.T) {
+	warehouseID, _ := value.NewWarehouseID("warehouse-123")
+	warehouse, err := entity.NewWarehouse(warehouseID, "Tokyo DC", "Tokyo")
+	require.NoError(t, err)
+
+	item := WarehouseItemFromEntity(warehouse)
+	assert.Equal(t, "WAREHOUSE#warehouse-123", item.PK)
+	assert.Equal(t, "WAREHOUSE#warehouse-123", item.SK)
+	assert.Equal(t, "WAREHOUSE", item.Type)
+
+	converted, err := item.ToEntity()
+	require.NoError(t, err)
+	assert.Equal(t, warehouseID, converted.ID())
+	assert.Equal(t, "Tokyo", converted.Location())
+	assert.Equal(t, warehouse.CreatedAt(), converted.CreatedAt())
+}
+
+func TestInventoryItemConversion(t *testing.T) {
+	productID, _ := value.NewProductID("product-1")
+	warehouseID, _ := value.NewWarehouseID("warehouse-123")
+	inventory, err := entity.NewInventory(productID, warehouseID, 7)
+	require.NoError(t, err)
+
+	item := InventoryItemFromEntity(inventory)
+
+	// Stored in the product's item collection and mirrored on GSI2 under the warehouse
+	assert.Equal(t, "PRODUCT#product-1", item.PK)
+	assert.Equal(t, "WAREHOUSE#warehouse-123", item.SK)
+	assert.Equal(t, "WAREHOUSE#warehouse-123", item.GSI2PK)
+	assert.Equal(t, "PRODUCT#product-1", item.GSI2SK)
+	assert.Equal(t, "INVENTORY", item.Type)
+	assert.Equal(t, 7, item.Quantity)
+
+	converted, err := item.ToEntity()
+	require.NoError(t, err)
+	assert.Equal(t, productID, converted.ProductID())
+	assert.Equal(t, warehouseID, converted.WarehouseID())
+	assert.Equal(t, 7, converted.Quantity())
+}
+
+func TestAllocateInventory(t *testing.T) {
+	items := []*InventoryItem{
+		{SK: "WAREHOUSE#a", WarehouseID: "a", Quantity: 2},
+		{SK: "WAREHOUSE#b", WarehouseID: "b", Quantity: 5},
+		{SK: "WAREHOUSE#c", WarehouseID: "c", Quantity: 0},
+	}
+
+	t.Run("largest holding first", func(t *testing.T) {
+		allocations, ok := allocateInventory(items, 4)
+		require.True(t, ok)
+		require.Len(t, allocations, 1)
+		assert.Equal(t, "b", allocations[0].item.WarehouseID)
+		assert.Equal(t, 4, allocations[0].quantity)
+	})
+
+	t.Run("split across warehouses", func(t *testing.T) {
+		allocations, ok := allocateInventory(items, 6)
+		require.True(t, ok)
+		require.Len(t, allocations, 2)
+		assert.Equal(t, 5, allocations[0].quantity)
+		assert.Equal(t, "a", allocations[1].item.WarehouseID)
+		assert.Equal(t, 1, allocations[1].quantity)
+	})
+
+	t.Run("insufficient total", func(t *testing.T) {
+		_, ok := allocateInventory(items, 8)
+		assert.False(t, ok)
+	})
+}
+
+// TestDynamoWarehouseRepository runs integration tests against DynamoDB Local
+func TestDynamoWarehouseRepository(t *testing.T) {
+	// Skip if not running integration tests
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
+	require.NoError(t, err)
+	require.NoError(t, client.HealthCheck(ctx))
+
+	repo := NewDynamoWarehouseRepository(client)
+	productRepo := NewDynamoProductRepository(client)
+	orderRepo := NewDynamoOrderRepository(client)
+
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+	productID, _ := value.NewProductID("test-inventory-product-" + suffix)
+	tokyo, _ := value.NewWarehouseID("test-tokyo-" + suffix)
+	osaka, _ := value.NewWarehouseID("test-osaka-" + suffix)
+
+	price, _ := value.NewMoney(500)
+	product, err := entity.NewProduct(productID, "Inventory Test Product", "Test product for inventory", price, 0)
+	require.NoError(t, err)
+	require.NoError(t, productRepo.Save(ctx, product))
+	defer productRepo.Delete(ctx, productID)
+
+	t.Run("save and find warehouse", func(t *testing.T) {
+		warehouse, err := entity.NewWarehouse(tokyo, "Tokyo DC", "Tokyo")
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, warehouse))
+
+		found, err := repo.FindByID(ctx, tokyo)
+		require.NoError(t, err)
+		assert.Equal(t, "Tokyo DC", found.Name())
+
+		exists, err := repo.Exists(ctx, osaka)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("inventory adjusts stock and order placement draws from warehouses", func(t *testing.T) {
+		first, _ := entity.NewInventory(productID, tokyo, 3)
+		require.NoError(t, repo.SaveInventory(ctx, first))
+		second, _ := entity.NewInventory(productID, osaka, 2)
+		require.NoError(t, repo.SaveInventory(ctx, second))
+
+		found, err := productRepo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, found.Stock())
+
+		inventories, _, err := repo.FindInventoryByWarehouse(ctx, tokyo, 0, nil)
+		require.NoError(t, err)
+		require.Len(t, inventories, 1)
+		assert.Equal(t, productID, inventories[0].ProductID())
+
+		customerID, _ := value.NewCustomerID("test-inventory-customer-" + suffix)
+		orderID, _ := value.NewOrderID("test-inventory-order-" + suffix)
+		line, _ := entity.NewOrderItem(productID, 4, price)
+		order, err := entity.NewOrder(orderID, customerID, []entity.OrderItem{*line})
+		require.NoError(t, err)
+		require.NoError(t, orderRepo.PlaceOrder(ctx, order))
+		defer orderRepo.Delete(ctx, orderID)
+
+		inventories, err = repo.FindInventoryByProduct(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, entity.TotalStock(inventories))
+
+		found, err = productRepo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, found.Stock())
+	})
+}
@@ -12,6 +12,7 @@ import (
 "github.com/stretchr/testify/assert"
 "github.com/stretchr/testify/require"
 
+"dynamo-modeling/internal/config"
 "dynamo-modeling/internal/domain/entity"
 "dynamo-modeling/internal/domain/value"
 "dynamo-modeling/internal/infrastructure"
@@ -27,11 +28,9 @@ func TestRepositoriesIntegration(t *testing.T) {
 	ctx := context.Background()
 
 	// Initialize DynamoDB client for testing
-	client, err := infrastructure.NewDynamoDBClient(ctx, infrastructure.DynamoDBConfig{
-Region:    "ap-northeast-1",
-Endpoint:  "http://localhost:8000",
-TableName: "OnlineShop",
-})
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
 	require.NoError(t, err)
 
 	// Health check
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// MemoryCustomerRepository implements CustomerRepository on top of a MemoryStore
+type MemoryCustomerRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryCustomerRepository creates a new in-memory customer repository
+func NewMemoryCustomerRepository(store *MemoryStore) *MemoryCustomerRepository {
+	return &MemoryCustomerRepository{
+		store: store,
+	}
+}
+
+// Save creates or updates a customer
+func (r *MemoryCustomerRepository) Save(ctx context.Context, customer *entity.Customer) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Email uniqueness (the Dynamo repository enforces this with EMAIL# sentinel items)
+	for _, existing := range r.store.customers {
+		if existing.Email == customer.Email().String() && existing.ID != customer.ID().String() {
+			return domain.CustomerAlreadyExistsError(customer.Email().String())
+		}
+	}
+
+	item := CustomerItemFromEntity(customer)
+	// Optimistic locking: the stored version (0 when absent) must match the version the entity was read at
+	storedVersion := 0
+	if current, ok := r.store.customers[item.PK]; ok {
+		storedVersion = current.Version
+	}
+	if storedVersion != customer.Version() {
+		slog.Warn("Customer was modified concurrently", "customerID", customer.ID().String(), "version", customer.Version())
+		return domain.ConcurrentModificationError("Customer", customer.ID().String())
+	}
+
+	item.Version = customer.Version() + 1
+	r.store.customers[item.PK] = item
+	customer.SetVersion(item.Version)
+
+	return nil
+}
+
+// FindByID retrieves a customer by their ID
+func (r *MemoryCustomerRepository) FindByID(ctx context.Context, id value.CustomerID) (*entity.Customer, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	item, ok := r.store.customers[fmt.Sprintf("CUSTOMER#%s", id.String())]
+	if !ok {
+		return nil, domain.CustomerNotFoundError(id.String())
+	}
+
+	customer, err := item.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+	return customer, nil
+}
+
+// FindByEmail retrieves a customer by their email address
+func (r *MemoryCustomerRepository) FindByEmail(ctx context.Context, email value.Email) (*entity.Customer, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, item := range r.store.customers {
+		if item.Email == email.String() {
+			customer, err := item.ToEntity()
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+			}
+			return customer, nil
+		}
+	}
+	return nil, domain.CustomerNotFoundByEmailError(email.String())
+}
+
+// Delete removes a customer by their ID (deleting a missing customer is not an error)
+func (r *MemoryCustomerRepository) Delete(ctx context.Context, id value.CustomerID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.customers, fmt.Sprintf("CUSTOMER#%s", id.String()))
+	return nil
+}
+
+// Exists checks if a customer exists by their ID
+func (r *MemoryCustomerRepository) Exists(ctx context.Context, id value.CustomerID) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	_, ok := r.store.customers[fmt.Sprintf("CUSTOMER#%s", id.String())]
+	return ok, nil
+}
+
+// FindAll retrieves customers ordered by ID with optional pagination
+func (r *MemoryCustomerRepository) FindAll(ctx context.Context, limit int, lastKey *string) ([]*entity.Customer, *string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	items := make([]*CustomerItem, 0, len(r.store.customers))
+	for _, item := range r.store.customers {
+		items = append(items, item)
+	}
+
+	page, next, err := memoryPage(r.store, "customers:all", items, func(item *CustomerItem) string {
+		return item.PK
+	}, false, limit, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	customers := make([]*entity.Customer, 0, len(page))
+	for _, item := range page {
+		customer, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "customerID", item.ID, "error", err)
+			continue // Skip invalid items
+		}
+		customers = append(customers, customer)
+	}
+	return customers, next, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestMemoryCustomerRepository(t *testing.T) {
+	ctx := context.Background()
+
+	newRepo := func(t *testing.T) *MemoryCustomerRepository {
+		store, err := NewMemoryStore()
+		require.NoError(t, err)
+		return NewMemoryCustomerRepository(store)
+	}
+
+	t.Run("save and find customer", func(t *testing.T) {
+		repo := newRepo(t)
+		customerID, _ := value.NewCustomerID("customer-1")
+		email, _ := value.NewEmail("test@example.com")
+		customer := entity.NewCustomer(customerID, email, "Test Customer")
+
+		require.NoError(t, repo.Save(ctx, customer))
+		assert.Equal(t, 1, customer.Version())
+
+		found, err := repo.FindByID(ctx, customerID)
+		require.NoError(t, err)
+		assert.Equal(t, customer.Email(), found.Email())
+		assert.Equal(t, customer.Name(), found.Name())
+		assert.Equal(t, customer.CreatedAt(), found.CreatedAt())
+		assert.Equal(t, customer.UpdatedAt(), found.UpdatedAt())
+		assert.Equal(t, 1, found.Version())
+
+		found, err = repo.FindByEmail(ctx, email)
+		require.NoError(t, err)
+		assert.Equal(t, customerID, found.ID())
+
+		exists, err := repo.Exists(ctx, customerID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		require.NoError(t, repo.Delete(ctx, customerID))
+		_, err = repo.FindByID(ctx, customerID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("duplicate email should fail", func(t *testing.T) {
+		repo := newRepo(t)
+		email, _ := value.NewEmail("duplicate@example.com")
+		customerID1, _ := value.NewCustomerID("customer-1")
+		customerID2, _ := value.NewCustomerID("customer-2")
+
+		require.NoError(t, repo.Save(ctx, entity.NewCustomer(customerID1, email, "First")))
+		err := repo.Save(ctx, entity.NewCustomer(customerID2, email, "Second"))
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeCustomerAlreadyExists, domainErr.Code)
+	})
+
+	t.Run("stale version is rejected", func(t *testing.T) {
+		repo := newRepo(t)
+		customerID, _ := value.NewCustomerID("customer-1")
+		email, _ := value.NewEmail("test@example.com")
+		require.NoError(t, repo.Save(ctx, entity.NewCustomer(customerID, email, "Test Customer")))
+
+		first, err := repo.FindByID(ctx, customerID)
+		require.NoError(t, err)
+		second, err := repo.FindByID(ctx, customerID)
+		require.NoError(t, err)
+
+		first.UpdateName("First Writer")
+		require.NoError(t, repo.Save(ctx, first))
+
+		second.UpdateName("Second Writer")
+		assert.ErrorIs(t, repo.Save(ctx, second), domain.ErrConcurrentModification)
+	})
+
+	t.Run("find all pages by cursor", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, id := range []string{"customer-3", "customer-1", "customer-2"} {
+			customerID, _ := value.NewCustomerID(id)
+			email, _ := value.NewEmail(id + "@example.com")
+			require.NoError(t, repo.Save(ctx, entity.NewCustomer(customerID, email, id)))
+		}
+
+		page, next, err := repo.FindAll(ctx, 2, nil)
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		require.NotNil(t, next)
+		assert.Equal(t, "customer-1", page[0].ID().String())
+		assert.Equal(t, "customer-2", page[1].ID().String())
+
+		page, next, err = repo.FindAll(ctx, 2, next)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Nil(t, next)
+		assert.Equal(t, "customer-3", page[0].ID().String())
+
+		invalid := "invalid"
+		_, _, err = repo.FindAll(ctx, 2, &invalid)
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeInvalidInput, domainErr.Code)
+	})
+}
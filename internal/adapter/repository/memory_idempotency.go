@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainrepo "dynamo-modeling/internal/domain/repository"
+)
+
+// MemoryIdempotencyRepository implements IdempotencyRepository on top of a MemoryStore
+type MemoryIdempotencyRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryIdempotencyRepository creates a new in-memory idempotency repository
+func NewMemoryIdempotencyRepository(store *MemoryStore) *MemoryIdempotencyRepository {
+	return &MemoryIdempotencyRepository{
+		store: store,
+	}
+}
+
+// Reserve stores an in-flight record unless an unexpired record holds the key
+func (r *MemoryIdempotencyRepository) Reserve(ctx context.Context, record *domainrepo.IdempotencyRecord) (*domainrepo.IdempotencyRecord, error) {
+	item := IdempotencyItemFromRecord(record)
+	item.State = idempotencyPending
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if existing, ok := r.store.idempotent[item.PK]; ok && !existing.ExpiresAt.Before(time.Now()) {
+		return existing.ToRecord(), nil
+	}
+	r.store.idempotent[item.PK] = item
+	return nil, nil
+}
+
+// Complete stores the response if the key is still held by the same request
+func (r *MemoryIdempotencyRepository) Complete(ctx context.Context, record *domainrepo.IdempotencyRecord) error {
+	item := IdempotencyItemFromRecord(record)
+	item.State = idempotencyCompleted
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.idempotent[item.PK]
+	if !ok || existing.State != idempotencyPending || existing.RequestHash != item.RequestHash {
+		return fmt.Errorf("idempotency key %s is no longer reserved by this request", record.Key)
+	}
+	r.store.idempotent[item.PK] = item
+	return nil
+}
+
+// Release deletes the in-flight record of the key. A completed record is left in place.
+func (r *MemoryIdempotencyRepository) Release(ctx context.Context, key string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	pk := idempotencyKey(key)
+	if existing, ok := r.store.idempotent[pk]; ok && existing.State == idempotencyPending {
+		delete(r.store.idempotent, pk)
+	}
+	return nil
+}
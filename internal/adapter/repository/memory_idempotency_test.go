@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainrepo "dynamo-modeling/internal/domain/repository"
+)
+
+func TestMemoryIdempotencyRepository(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewMemoryStore()
+	require.NoError(t, err)
+	repo := NewMemoryIdempotencyRepository(store)
+
+	// newRecord returns an in-flight record that holds its key for a minute
+	newRecord := func(key, hash string) *domainrepo.IdempotencyRecord {
+		now := time.Now().UTC()
+		return &domainrepo.IdempotencyRecord{Key: key, RequestHash: hash, CreatedAt: now, ExpiresAt: now.Add(time.Minute)}
+	}
+
+	t.Run("reserve, complete and replay", func(t *testing.T) {
+		record := newRecord("key-1", "hash-1")
+		existing, err := repo.Reserve(ctx, record)
+		require.NoError(t, err)
+		assert.Nil(t, existing)
+
+		existing, err = repo.Reserve(ctx, newRecord("key-1", "hash-1"))
+		require.NoError(t, err)
+		require.NotNil(t, existing)
+		assert.False(t, existing.Completed)
+
+		record.Completed = true
+		record.StatusCode = 201
+		record.ContentType = "application/json"
+		record.Body = []byte(`{"id":"order-1"}`)
+		require.NoError(t, repo.Complete(ctx, record))
+
+		existing, err = repo.Reserve(ctx, newRecord("key-1", "hash-2"))
+		require.NoError(t, err)
+		require.NotNil(t, existing)
+		assert.True(t, existing.Completed)
+		assert.Equal(t, "hash-1", existing.RequestHash)
+		assert.Equal(t, 201, existing.StatusCode)
+		assert.Equal(t, `{"id":"order-1"}`, string(existing.Body))
+	})
+
+	t.Run("release frees an in-flight key only", func(t *testing.T) {
+		_, err := repo.Reserve(ctx, newRecord("key-2", "hash-1"))
+		require.NoError(t, err)
+		require.NoError(t, repo.Release(ctx, "key-2"))
+
+		existing, err := repo.Reserve(ctx, newRecord("key-2", "hash-1"))
+		require.NoError(t, err)
+		assert.Nil(t, existing)
+
+		// key-1 is completed and stays
+		require.NoError(t, repo.Release(ctx, "key-1"))
+		existing, err = repo.Reserve(ctx, newRecord("key-1", "hash-1"))
+		require.NoError(t, err)
+		assert.NotNil(t, existing)
+	})
+
+	t.Run("expired key is free again", func(t *testing.T) {
+		record := newRecord("key-3", "hash-1")
+		record.ExpiresAt = time.Now().Add(-time.Second)
+		_, err := repo.Reserve(ctx, record)
+		require.NoError(t, err)
+
+		existing, err := repo.Reserve(ctx, newRecord("key-3", "hash-2"))
+		require.NoError(t, err)
+		assert.Nil(t, existing)
+	})
+
+	t.Run("complete fails once the key is held by another request", func(t *testing.T) {
+		record := newRecord("key-4", "hash-1")
+		record.Completed = true
+		assert.Error(t, repo.Complete(ctx, record))
+	})
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// MemoryInvoiceRepository implements InvoiceRepository on top of a MemoryStore
+type MemoryInvoiceRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryInvoiceRepository creates a new in-memory invoice repository
+func NewMemoryInvoiceRepository(store *MemoryStore) *MemoryInvoiceRepository {
+	return &MemoryInvoiceRepository{
+		store: store,
+	}
+}
+
+// Save creates or updates an invoice. An order holds at most one invoice.
+func (r *MemoryInvoiceRepository) Save(ctx context.Context, invoice *entity.Invoice) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	item := InvoiceItemFromEntity(invoice)
+
+	// Optimistic locking: the stored version (0 when absent) must match the version the entity was read at
+	storedVersion := 0
+	if current, ok := r.store.invoices[item.PK][item.SK]; ok {
+		storedVersion = current.Version
+	}
+	if storedVersion != 0 && invoice.Version() == 0 {
+		slog.Warn("Order is already invoiced", "orderID", item.OrderID, "invoiceID", invoice.ID().String())
+		return domain.InvoiceAlreadyExistsError(item.OrderID)
+	}
+	if storedVersion != invoice.Version() {
+		slog.Warn("Invoice was modified concurrently", "invoiceID", invoice.ID().String(), "version", invoice.Version())
+		return domain.ConcurrentModificationError("Invoice", invoice.ID().String())
+	}
+
+	item.Version = invoice.Version() + 1
+	if r.store.invoices[item.PK] == nil {
+		r.store.invoices[item.PK] = make(map[string]*InvoiceItem)
+	}
+	r.store.invoices[item.PK][item.SK] = item
+	invoice.SetVersion(item.Version)
+
+	return nil
+}
+
+// FindByID retrieves an invoice by its ID
+func (r *MemoryInvoiceRepository) FindByID(ctx context.Context, id value.InvoiceID) (*entity.Invoice, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, collection := range r.store.invoices {
+		if item, ok := collection[invoiceSK]; ok && item.ID == id.String() {
+			invoice, err := item.ToEntity()
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+			}
+			return invoice, nil
+		}
+	}
+	return nil, domain.InvoiceNotFoundError(id.String())
+}
+
+// FindByOrderID retrieves the invoice for an order, or nil when the order has not been invoiced
+func (r *MemoryInvoiceRepository) FindByOrderID(ctx context.Context, orderID value.OrderID) (*entity.Invoice, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	item, ok := r.store.invoices[fmt.Sprintf("ORDER#%s", orderID.String())][invoiceSK]
+	if !ok {
+		return nil, nil
+	}
+	invoice, err := item.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+	return invoice, nil
+}
+
+// FindByCustomerID retrieves a customer's invoices issued in a date range, oldest first like GSI2
+func (r *MemoryInvoiceRepository) FindByCustomerID(ctx context.Context, customerID value.CustomerID, from, to time.Time, limit int, lastKey *string) ([]*entity.Invoice, *string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	lower, upper := invoiceDateRange(from, to)
+	customerKey := fmt.Sprintf("CUSTOMER#%s", customerID.String())
+	var items []*InvoiceItem
+	for _, collection := range r.store.invoices {
+		for _, item := range collection {
+			if item.GSI2PK == customerKey && item.GSI2SK >= lower && item.GSI2SK <= upper {
+				items = append(items, item)
+			}
+		}
+	}
+
+	scope := "invoices:customer:" + customerID.String() + ":" + lower + ":" + upper
+	page, next, err := memoryPage(r.store, scope, items, func(item *InvoiceItem) string {
+		return item.GSI2SK
+	}, false, limit, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	invoices := make([]*entity.Invoice, 0, len(page))
+	for _, item := range page {
+		invoice, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "invoiceID", item.ID, "error", err)
+			continue // Skip invalid items
+		}
+		invoices = append(invoices, invoice)
+	}
+	return invoices, next, nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestMemoryInvoiceRepository(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewMemoryStore()
+	require.NoError(t, err)
+	orderRepo := NewMemoryOrderRepository(store)
+	repo := NewMemoryInvoiceRepository(store)
+
+	customerID, _ := value.NewCustomerID("customer-1")
+	productID, _ := value.NewProductID("product-1")
+	price, _ := value.NewMoney(1000)
+
+	// newInvoice saves an order and returns an invoice for it issued at the given time
+	newInvoice := func(t *testing.T, id string, issuedAt time.Time) *entity.Invoice {
+		orderID, _ := value.NewOrderID("order-" + id)
+		orderItem, _ := entity.NewOrderItem(productID, 1, price)
+		order, err := entity.NewOrder(orderID, customerID, []entity.OrderItem{*orderItem})
+		require.NoError(t, err)
+		require.NoError(t, orderRepo.Save(ctx, order))
+
+		issued, err := entity.NewInvoice(value.InvoiceID("invoice-"+id), order, entity.DefaultTaxRate)
+		require.NoError(t, err)
+		invoice, err := entity.NewInvoiceWithState(issued.ID(), issued.OrderID(), issued.CustomerID(), issued.Lines(),
+			issued.Subtotal(), issued.TaxRate(), issued.Tax(), issued.Total(), issued.Payments(), issued.Status(), issuedAt, issuedAt)
+		require.NoError(t, err)
+		return invoice
+	}
+
+	t.Run("save and find invoice by ID and by order", func(t *testing.T) {
+		invoice := newInvoice(t, "1", time.Now())
+
+		found, err := repo.FindByOrderID(ctx, invoice.OrderID())
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		require.NoError(t, repo.Save(ctx, invoice))
+		assert.Equal(t, 1, invoice.Version())
+
+		found, err = repo.FindByID(ctx, invoice.ID())
+		require.NoError(t, err)
+		assert.Equal(t, invoice.OrderID(), found.OrderID())
+		assert.Equal(t, int64(1100), found.Total().Cents())
+
+		found, err = repo.FindByOrderID(ctx, invoice.OrderID())
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, invoice.ID(), found.ID())
+
+		_, err = repo.FindByID(ctx, "missing")
+		assert.Error(t, err)
+	})
+
+	t.Run("stale version is rejected", func(t *testing.T) {
+		invoice := newInvoice(t, "2", time.Now())
+		require.NoError(t, repo.Save(ctx, invoice))
+
+		stale, err := repo.FindByID(ctx, invoice.ID())
+		require.NoError(t, err)
+
+		amount, _ := value.NewMoney(100)
+		require.NoError(t, invoice.AddPayment("payment-1", entity.PaymentMethodCreditCard, amount))
+		require.NoError(t, repo.Save(ctx, invoice))
+
+		require.NoError(t, stale.AddPayment("payment-2", entity.PaymentMethodCreditCard, amount))
+		err = repo.Save(ctx, stale)
+		assert.ErrorIs(t, err, domain.ErrConcurrentModification)
+	})
+
+	t.Run("find by customer in a date range", func(t *testing.T) {
+		for i, day := range []int{1, 10, 20} {
+			invoice := newInvoice(t, string(rune('a'+i)), time.Date(2025, 6, day, 12, 0, 0, 0, time.UTC))
+			require.NoError(t, repo.Save(ctx, invoice))
+		}
+
+		from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+		invoices, next, err := repo.FindByCustomerID(ctx, customerID, from, to, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, invoices, 1)
+		assert.Equal(t, value.InvoiceID("invoice-a"), invoices[0].ID())
+		require.NotNil(t, next)
+
+		invoices, next, err = repo.FindByCustomerID(ctx, customerID, from, to, 1, next)
+		require.NoError(t, err)
+		require.Len(t, invoices, 1)
+		assert.Equal(t, value.InvoiceID("invoice-b"), invoices[0].ID())
+		assert.Nil(t, next)
+
+		// The cursor is bound to the date range it was issued for
+		_, cursor, err := repo.FindByCustomerID(ctx, customerID, from, to, 1, nil)
+		require.NoError(t, err)
+		_, _, err = repo.FindByCustomerID(ctx, customerID, from, time.Time{}, 1, cursor)
+		assert.Error(t, err)
+
+		invoices, _, err = repo.FindByCustomerID(ctx, customerID, time.Time{}, time.Time{}, 0, nil)
+		require.NoError(t, err)
+		assert.Len(t, invoices, 5)
+	})
+
+	t.Run("deleting the order removes its invoice", func(t *testing.T) {
+		invoice := newInvoice(t, "3", time.Now())
+		require.NoError(t, repo.Save(ctx, invoice))
+
+		require.NoError(t, orderRepo.Delete(ctx, invoice.OrderID()))
+
+		_, err := repo.FindByID(ctx, invoice.ID())
+		assert.Error(t, err)
+	})
+
+	t.Run("second invoice for an order is rejected", func(t *testing.T) {
+		invoice := newInvoice(t, "4", time.Now())
+		require.NoError(t, repo.Save(ctx, invoice))
+
+		second, err := entity.NewInvoiceWithState("invoice-4b", invoice.OrderID(), invoice.CustomerID(), invoice.Lines(),
+			invoice.Subtotal(), invoice.TaxRate(), invoice.Tax(), invoice.Total(), nil, invoice.Status(), time.Now(), time.Now())
+		require.NoError(t, err)
+		err = repo.Save(ctx, second)
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+
+		found, err := repo.FindByOrderID(ctx, invoice.OrderID())
+		require.NoError(t, err)
+		assert.Equal(t, invoice.ID(), found.ID())
+	})
+}
@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// MemoryOrderRepository implements OrderRepository on top of a MemoryStore
+type MemoryOrderRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryOrderRepository creates a new in-memory order repository
+func NewMemoryOrderRepository(store *MemoryStore) *MemoryOrderRepository {
+	return &MemoryOrderRepository{
+		store: store,
+	}
+}
+
+// Save creates or updates an order
+func (r *MemoryOrderRepository) Save(ctx context.Context, order *entity.Order) error {
+	item, lines, err := OrderItemFromEntity(order)
+	if err != nil {
+		return fmt.Errorf("failed to convert order to item: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Optimistic locking: the stored version (0 when absent) must match the version the entity was read at
+	storedVersion := 0
+	if current, ok := r.store.orders[item.PK]; ok {
+		storedVersion = current.Version
+	}
+	if storedVersion != order.Version() {
+		slog.Warn("Order was modified concurrently", "orderID", order.ID().String(), "version", order.Version())
+		return domain.ConcurrentModificationError("Order", order.ID().String())
+	}
+
+	item.Version = order.Version() + 1
+	r.store.orders[item.PK] = item
+	r.store.lines[item.PK] = lines
+	r.store.appendOrderHistory(item.PK, order)
+	order.SetVersion(item.Version)
+	order.MarkStatusChangesSaved()
+
+	return nil
+}
+
+// PlaceOrder reserves stock for every order line from the warehouse inventories and creates the order under a single lock
+func (r *MemoryOrderRepository) PlaceOrder(ctx context.Context, order *entity.Order) error {
+	item, lines, err := OrderItemFromEntity(order)
+	if err != nil {
+		return fmt.Errorf("failed to convert order to item: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// 在庫条件の確認と倉庫の引当（すべて満たす場合のみ書き込む）
+	productIDs, quantities := reservationQuantities(order.Items())
+	allocations := make(map[string][]stockAllocation, len(productIDs))
+	for _, productID := range productIDs {
+		pk := fmt.Sprintf("PRODUCT#%s", productID)
+		product, ok := r.store.products[pk]
+		if !ok || product.Stock < quantities[productID] {
+			return domain.InsufficientStockError(productID)
+		}
+		inventory := make([]*InventoryItem, 0, len(r.store.inventory[pk]))
+		for _, item := range r.store.inventory[pk] {
+			inventory = append(inventory, item)
+		}
+		allocated, ok := allocateInventory(inventory, quantities[productID])
+		if !ok {
+			return domain.InsufficientStockError(productID)
+		}
+		allocations[productID] = allocated
+	}
+
+	// 既存の注文は上書きしない
+	if _, ok := r.store.orders[item.PK]; ok {
+		return fmt.Errorf("failed to place order: order already exists: %s", order.ID().String())
+	}
+
+	for _, productID := range productIDs {
+		product := r.store.products[fmt.Sprintf("PRODUCT#%s", productID)]
+		product.Stock -= quantities[productID]
+		product.UpdatedAt = order.CreatedAt()
+		product.Version++
+
+		for _, allocation := range allocations[productID] {
+			allocation.item.Quantity -= allocation.quantity
+			allocation.item.UpdatedAt = order.CreatedAt()
+			allocation.item.Version++
+		}
+	}
+
+	item.Version = order.Version() + 1
+	r.store.orders[item.PK] = item
+	r.store.lines[item.PK] = lines
+	r.store.reserved[item.PK] = orderReservationItems(order.ID().String(), productIDs, allocations)
+	r.store.appendOrderHistory(item.PK, order)
+	order.SetVersion(item.Version)
+	order.MarkStatusChangesSaved()
+
+	return nil
+}
+
+// CancelOrder saves a cancelled order and gives its reserved stock back to the given products and
+// their warehouse inventories under a single lock
+func (r *MemoryOrderRepository) CancelOrder(ctx context.Context, order *entity.Order, products []*entity.Product) error {
+	item, lines, err := OrderItemFromEntity(order)
+	if err != nil {
+		return fmt.Errorf("failed to convert order to item: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	storedVersion := 0
+	if current, ok := r.store.orders[item.PK]; ok {
+		storedVersion = current.Version
+	}
+	if storedVersion != order.Version() {
+		slog.Warn("Order was modified concurrently", "orderID", order.ID().String(), "version", order.Version())
+		return domain.ConcurrentModificationError("Order", order.ID().String())
+	}
+
+	// 戻し先の商品と倉庫別在庫（読んだ後に削除された商品があれば何も書かない）
+	orderedIDs, quantities := reservationQuantities(order.Items())
+	productIDs := restockedProducts(orderedIDs, products)
+	inventory := make(map[string][]*InventoryItem, len(productIDs))
+	for _, productID := range productIDs {
+		pk := fmt.Sprintf("PRODUCT#%s", productID)
+		if _, ok := r.store.products[pk]; !ok {
+			slog.Warn("Product of cancelled order was deleted concurrently", "orderID", order.ID().String(), "productID", productID)
+			return domain.ConcurrentModificationError("Product", productID)
+		}
+		for _, item := range r.store.inventory[pk] {
+			inventory[productID] = append(inventory[productID], item)
+		}
+	}
+
+	for _, productID := range productIDs {
+		product := r.store.products[fmt.Sprintf("PRODUCT#%s", productID)]
+		product.Stock += quantities[productID]
+		product.UpdatedAt = order.UpdatedAt()
+		product.Version++
+	}
+	for _, restock := range restockAllocations(productIDs, quantities, r.store.reserved[item.PK], inventory) {
+		restock.item.Quantity += restock.quantity
+		restock.item.UpdatedAt = order.UpdatedAt()
+		restock.item.Version++
+	}
+
+	item.Version = order.Version() + 1
+	r.store.orders[item.PK] = item
+	r.store.lines[item.PK] = lines
+	delete(r.store.reserved, item.PK)
+	r.store.appendOrderHistory(item.PK, order)
+	order.SetVersion(item.Version)
+	order.MarkStatusChangesSaved()
+
+	return nil
+}
+
+// FindByID retrieves an order by ID
+func (r *MemoryOrderRepository) FindByID(ctx context.Context, id value.OrderID) (*entity.Order, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	item, ok := r.store.orders[fmt.Sprintf("ORDER#%s", id.String())]
+	if !ok {
+		return nil, domain.OrderNotFoundError(id.String())
+	}
+
+	order, err := item.ToEntity(r.store.lines[item.PK])
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+	return order, nil
+}
+
+// FindByCustomerID retrieves a customer's orders, oldest first
+func (r *MemoryOrderRepository) FindByCustomerID(ctx context.Context, customerID value.CustomerID, limit int, lastKey *string) ([]*entity.Order, *string, error) {
+	return r.find(limit, lastKey, "orders:customer:"+customerID.String(), func(item *OrderItem) bool {
+		return item.CustomerID == customerID.String()
+	}, orderByCustomerPosition, false)
+}
+
+// FindByStatus retrieves orders with a specific status, newest first like GSI2
+func (r *MemoryOrderRepository) FindByStatus(ctx context.Context, status entity.OrderStatus, limit int, lastKey *string) ([]*entity.Order, *string, error) {
+	return r.find(limit, lastKey, "orders:status:"+string(status), func(item *OrderItem) bool {
+		return item.GSI2PK == "STATUS#"+string(status)
+	}, func(item *OrderItem) string {
+		return item.GSI2SK
+	}, true)
+}
+
+// FindByCustomerAndStatus retrieves a customer's orders with a specific status, oldest first
+func (r *MemoryOrderRepository) FindByCustomerAndStatus(ctx context.Context, customerID value.CustomerID, status entity.OrderStatus, limit int, lastKey *string) ([]*entity.Order, *string, error) {
+	scope := "orders:customer:" + customerID.String() + ":status:" + string(status)
+	return r.find(limit, lastKey, scope, func(item *OrderItem) bool {
+		return item.CustomerID == customerID.String() && item.Status == string(status)
+	}, orderByCustomerPosition, false)
+}
+
+// FindByProductAndDateRange retrieves the orders containing a product that were placed in a date range,
+// oldest first like GSI1
+func (r *MemoryOrderRepository) FindByProductAndDateRange(ctx context.Context, productID value.ProductID, from, to time.Time, limit int, lastKey *string) ([]*entity.Order, *string, error) {
+	lower, upper := orderDateRange(from, to)
+	productKey := fmt.Sprintf("PRODUCT#%s", productID.String())
+
+	// productLine returns the GSI1SK of the order's line for the product ("" when the order has none)
+	productLine := func(item *OrderItem) string {
+		for _, line := range r.store.lines[item.PK] {
+			if line.GSI1PK == productKey {
+				return line.GSI1SK
+			}
+		}
+		return ""
+	}
+
+	scope := "orders:product:" + productID.String() + ":" + lower + ":" + upper
+	return r.find(limit, lastKey, scope, func(item *OrderItem) bool {
+		key := productLine(item)
+		return key != "" && key >= lower && key <= upper
+	}, productLine, false)
+}
+
+// Delete removes an order together with its invoice, shipments and returns (deleting a missing order is not an error)
+func (r *MemoryOrderRepository) Delete(ctx context.Context, id value.OrderID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	pk := fmt.Sprintf("ORDER#%s", id.String())
+	delete(r.store.orders, pk)
+	delete(r.store.lines, pk)
+	delete(r.store.history, pk)
+	delete(r.store.reserved, pk)
+	delete(r.store.invoices, pk)
+	delete(r.store.shipments, pk)
+	delete(r.store.returns, pk)
+	return nil
+}
+
+// FindStatusHistory retrieves the status history of an order, oldest first
+func (r *MemoryOrderRepository) FindStatusHistory(ctx context.Context, id value.OrderID) ([]entity.OrderStatusChange, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	pk := fmt.Sprintf("ORDER#%s", id.String())
+	if _, ok := r.store.orders[pk]; !ok {
+		return nil, domain.OrderNotFoundError(id.String())
+	}
+
+	items := r.store.history[pk]
+	history := make([]entity.OrderStatusChange, 0, len(items))
+	for _, item := range items {
+		history = append(history, item.ToEntity())
+	}
+	return history, nil
+}
+
+// Exists checks if an order exists by its ID
+func (r *MemoryOrderRepository) Exists(ctx context.Context, id value.OrderID) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	_, ok := r.store.orders[fmt.Sprintf("ORDER#%s", id.String())]
+	return ok, nil
+}
+
+// orderByCustomerPosition orders a customer's orders like GSI1 (creation time, then order ID)
+func orderByCustomerPosition(item *OrderItem) string {
+	return item.GSI1SK
+}
+
+// find returns a page of the orders matching filter, ordered by position
+func (r *MemoryOrderRepository) find(limit int, lastKey *string, scope string, filter func(*OrderItem) bool, position func(*OrderItem) string, descending bool) ([]*entity.Order, *string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	items := make([]*OrderItem, 0, len(r.store.orders))
+	for _, item := range r.store.orders {
+		if filter(item) {
+			items = append(items, item)
+		}
+	}
+
+	page, next, err := memoryPage(r.store, scope, items, position, descending, limit, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orders := make([]*entity.Order, 0, len(page))
+	for _, item := range page {
+		order, err := item.ToEntity(r.store.lines[item.PK])
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "orderID", item.ID, "error", err)
+			continue // Skip invalid items
+		}
+		orders = append(orders, order)
+	}
+	return orders, next, nil
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestMemoryOrderRepository(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewMemoryStore()
+	require.NoError(t, err)
+	productRepo := NewMemoryProductRepository(store)
+	warehouseRepo := NewMemoryWarehouseRepository(store)
+	repo := NewMemoryOrderRepository(store)
+
+	customerID, _ := value.NewCustomerID("customer-1")
+	productID, _ := value.NewProductID("product-1")
+	price, _ := value.NewMoney(1000)
+	product, err := entity.NewProduct(productID, "Product", "description", price, 0)
+	require.NoError(t, err)
+	require.NoError(t, productRepo.Save(ctx, product))
+	warehouseID, _ := value.NewWarehouseID("warehouse-1")
+	inventory, err := entity.NewInventory(productID, warehouseID, 5)
+	require.NoError(t, err)
+	require.NoError(t, warehouseRepo.SaveInventory(ctx, inventory))
+
+	newOrder := func(t *testing.T, id string, quantity int) *entity.Order {
+		orderID, _ := value.NewOrderID(id)
+		item, err := entity.NewOrderItem(productID, quantity, price)
+		require.NoError(t, err)
+		order, err := entity.NewOrder(orderID, customerID, []entity.OrderItem{*item})
+		require.NoError(t, err)
+		return order
+	}
+
+	t.Run("place order reserves stock", func(t *testing.T) {
+		order := newOrder(t, "order-1", 3)
+		require.NoError(t, repo.PlaceOrder(ctx, order))
+		assert.Equal(t, 1, order.Version())
+
+		found, err := productRepo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.Stock())
+		assert.Equal(t, 3, found.Version())
+
+		stored, err := warehouseRepo.FindInventory(ctx, warehouseID, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stored.Quantity())
+
+		// Placing the same order again must not reserve stock twice
+		assert.Error(t, repo.PlaceOrder(ctx, newOrder(t, "order-1", 1)))
+	})
+
+	t.Run("place order with insufficient stock changes nothing", func(t *testing.T) {
+		err := repo.PlaceOrder(ctx, newOrder(t, "order-2", 3))
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeInsufficientStock, domainErr.Code)
+
+		orderID, _ := value.NewOrderID("order-2")
+		exists, err := repo.Exists(ctx, orderID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		found, err := productRepo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.Stock())
+	})
+
+	t.Run("find by customer and status", func(t *testing.T) {
+		order := newOrder(t, "order-3", 1)
+		require.NoError(t, repo.Save(ctx, order))
+		require.NoError(t, order.UpdateStatus(entity.OrderStatusConfirmed))
+		require.NoError(t, repo.Save(ctx, order))
+
+		orders, next, err := repo.FindByCustomerID(ctx, customerID, 0, nil)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Len(t, orders, 2)
+
+		orders, _, err = repo.FindByCustomerAndStatus(ctx, customerID, entity.OrderStatusConfirmed, 0, nil)
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "order-3", orders[0].ID().String())
+
+		orders, _, err = repo.FindByStatus(ctx, entity.OrderStatusPending, 0, nil)
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "order-1", orders[0].ID().String())
+	})
+
+	t.Run("find by status follows status changes newest first", func(t *testing.T) {
+		older := newOrder(t, "order-4", 1)
+		newer := newOrder(t, "order-5", 1)
+		require.NoError(t, repo.Save(ctx, older))
+		require.NoError(t, repo.Save(ctx, newer))
+		// Same creation second: make the order explicit through the GSI2 sort key
+		store.orders["ORDER#order-4"].GSI2SK = "2099-01-01T00:00:00Z#order-4"
+		store.orders["ORDER#order-5"].GSI2SK = "2099-01-02T00:00:00Z#order-5"
+
+		orders, next, err := repo.FindByStatus(ctx, entity.OrderStatusPending, 2, nil)
+		require.NoError(t, err)
+		require.Len(t, orders, 2)
+		assert.Equal(t, "order-5", orders[0].ID().String())
+		assert.Equal(t, "order-4", orders[1].ID().String())
+		require.NotNil(t, next)
+
+		orders, next, err = repo.FindByStatus(ctx, entity.OrderStatusPending, 2, next)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "order-1", orders[0].ID().String())
+
+		require.NoError(t, older.UpdateStatus(entity.OrderStatusConfirmed))
+		require.NoError(t, repo.Save(ctx, older))
+
+		orders, _, err = repo.FindByStatus(ctx, entity.OrderStatusConfirmed, 0, nil)
+		require.NoError(t, err)
+		require.Len(t, orders, 2)
+		assert.Equal(t, "order-4", orders[0].ID().String())
+		assert.Equal(t, "order-3", orders[1].ID().String())
+
+		orders, _, err = repo.FindByStatus(ctx, entity.OrderStatusPending, 0, nil)
+		require.NoError(t, err)
+		assert.Len(t, orders, 2)
+	})
+
+	t.Run("status history is appended on every save", func(t *testing.T) {
+		order := newOrder(t, "order-6", 1)
+		order.AttributeStatusChanges("customer-1", "")
+		require.NoError(t, repo.PlaceOrder(ctx, order))
+		assert.Empty(t, order.UnsavedStatusChanges())
+
+		require.NoError(t, order.UpdateStatus(entity.OrderStatusConfirmed))
+		order.AttributeStatusChanges("clerk", "payment received")
+		require.NoError(t, repo.Save(ctx, order))
+		require.NoError(t, order.UpdateStatus(entity.OrderStatusCancelled))
+		require.NoError(t, repo.Save(ctx, order))
+
+		history, err := repo.FindStatusHistory(ctx, order.ID())
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.Equal(t, entity.OrderStatusChange{To: entity.OrderStatusPending, Actor: "customer-1", Reason: "order placed", ChangedAt: order.CreatedAt()}, history[0])
+		assert.Equal(t, entity.OrderStatusPending, history[1].From)
+		assert.Equal(t, entity.OrderStatusConfirmed, history[1].To)
+		assert.Equal(t, "clerk", history[1].Actor)
+		assert.Equal(t, "payment received", history[1].Reason)
+		assert.Equal(t, entity.OrderStatusCancelled, history[2].To)
+		assert.Equal(t, entity.SystemActor, history[2].Actor)
+		assert.True(t, history[2].ChangedAt.After(history[1].ChangedAt))
+
+		missing, _ := value.NewOrderID("order-missing")
+		_, err = repo.FindStatusHistory(ctx, missing)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		require.NoError(t, repo.Delete(ctx, order.ID()))
+		_, err = repo.FindStatusHistory(ctx, order.ID())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("find by product and date range", func(t *testing.T) {
+		otherID, _ := value.NewProductID("product-2")
+		otherItem, err := entity.NewOrderItem(otherID, 1, price)
+		require.NoError(t, err)
+		item, err := entity.NewOrderItem(productID, 1, price)
+		require.NoError(t, err)
+
+		// newDatedOrder saves an order placed on the given day of June 2020
+		newDatedOrder := func(t *testing.T, id string, day int, items ...entity.OrderItem) {
+			orderID, _ := value.NewOrderID(id)
+			createdAt := time.Date(2020, 6, day, 12, 0, 0, 0, time.UTC)
+			order, err := entity.NewOrderWithState(orderID, customerID, items, entity.OrderStatusPending, price, createdAt, createdAt)
+			require.NoError(t, err)
+			require.NoError(t, repo.Save(ctx, order))
+		}
+		newDatedOrder(t, "dated-1", 20, *item)
+		newDatedOrder(t, "dated-2", 21, *otherItem, *item)
+		newDatedOrder(t, "dated-3", 21, *otherItem)
+		newDatedOrder(t, "dated-4", 22, *item)
+
+		from := time.Date(2020, 6, 21, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2020, 6, 22, 23, 59, 0, 0, time.UTC)
+		orders, next, err := repo.FindByProductAndDateRange(ctx, productID, from, to, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "dated-2", orders[0].ID().String())
+		require.NotNil(t, next)
+
+		orders, next, err = repo.FindByProductAndDateRange(ctx, productID, from, to, 1, next)
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "dated-4", orders[0].ID().String())
+
+		orders, _, err = repo.FindByProductAndDateRange(ctx, otherID, time.Time{}, time.Time{}, 0, nil)
+		require.NoError(t, err)
+		require.Len(t, orders, 2)
+		assert.Equal(t, "dated-2", orders[0].ID().String())
+		assert.Equal(t, "dated-3", orders[1].ID().String())
+	})
+
+	t.Run("cancel order gives reserved stock back to its warehouses", func(t *testing.T) {
+		splitID, _ := value.NewProductID("product-3")
+		splitProduct, err := entity.NewProduct(splitID, "Split", "description", price, 0)
+		require.NoError(t, err)
+		require.NoError(t, productRepo.Save(ctx, splitProduct))
+		otherWarehouseID, _ := value.NewWarehouseID("warehouse-2")
+		for _, held := range []struct {
+			warehouseID value.WarehouseID
+			quantity    int
+		}{{warehouseID, 4}, {otherWarehouseID, 3}} {
+			inventory, err := entity.NewInventory(splitID, held.warehouseID, held.quantity)
+			require.NoError(t, err)
+			require.NoError(t, warehouseRepo.SaveInventory(ctx, inventory))
+		}
+		// quantities returns the product total and the quantity held by each warehouse
+		quantities := func(t *testing.T) (int, int, int) {
+			product, err := productRepo.FindByID(ctx, splitID)
+			require.NoError(t, err)
+			first, err := warehouseRepo.FindInventory(ctx, warehouseID, splitID)
+			require.NoError(t, err)
+			second, err := warehouseRepo.FindInventory(ctx, otherWarehouseID, splitID)
+			require.NoError(t, err)
+			return product.Stock(), first.Quantity(), second.Quantity()
+		}
+
+		orderID, _ := value.NewOrderID("order-7")
+		item, err := entity.NewOrderItem(splitID, 6, price)
+		require.NoError(t, err)
+		order, err := entity.NewOrder(orderID, customerID, []entity.OrderItem{*item})
+		require.NoError(t, err)
+		require.NoError(t, repo.PlaceOrder(ctx, order))
+		total, first, second := quantities(t)
+		assert.Equal(t, []int{1, 0, 1}, []int{total, first, second})
+
+		stale, err := repo.FindByID(ctx, orderID)
+		require.NoError(t, err)
+		require.NoError(t, order.Cancel())
+		require.NoError(t, repo.CancelOrder(ctx, order, []*entity.Product{splitProduct}))
+		assert.Empty(t, order.UnsavedStatusChanges())
+
+		total, first, second = quantities(t)
+		assert.Equal(t, []int{7, 4, 3}, []int{total, first, second})
+
+		// A cancellation based on an outdated read must not give the stock back twice
+		require.NoError(t, stale.Cancel())
+		err = repo.CancelOrder(ctx, stale, []*entity.Product{splitProduct})
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeConcurrentModification, domainErr.Code)
+		total, first, second = quantities(t)
+		assert.Equal(t, []int{7, 4, 3}, []int{total, first, second})
+
+		history, err := repo.FindStatusHistory(ctx, orderID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, entity.OrderStatusCancelled, history[1].To)
+	})
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// MemoryProductRepository implements ProductRepository on top of a MemoryStore
+type MemoryProductRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryProductRepository creates a new in-memory product repository
+func NewMemoryProductRepository(store *MemoryStore) *MemoryProductRepository {
+	return &MemoryProductRepository{
+		store: store,
+	}
+}
+
+// Save creates or updates a product
+func (r *MemoryProductRepository) Save(ctx context.Context, product *entity.Product) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	item := ProductItemFromEntity(product)
+
+	// Optimistic locking: the stored version (0 when absent) must match the version the entity was read at
+	storedVersion := 0
+	if current, ok := r.store.products[item.PK]; ok {
+		storedVersion = current.Version
+	}
+	if storedVersion != product.Version() {
+		slog.Warn("Product was modified concurrently", "productID", product.ID().String(), "version", product.Version())
+		return domain.ConcurrentModificationError("Product", product.ID().String())
+	}
+
+	item.Version = product.Version() + 1
+	r.store.products[item.PK] = item
+	product.SetVersion(item.Version)
+
+	return nil
+}
+
+// FindByID retrieves a product by its ID
+func (r *MemoryProductRepository) FindByID(ctx context.Context, id value.ProductID) (*entity.Product, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	item, ok := r.store.products[fmt.Sprintf("PRODUCT#%s", id.String())]
+	if !ok {
+		return nil, domain.ProductNotFoundError(id.String())
+	}
+
+	product, err := item.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+	return product, nil
+}
+
+// FindAll retrieves all products ordered by ID with optional pagination
+func (r *MemoryProductRepository) FindAll(ctx context.Context, limit int, lastKey *string) ([]*entity.Product, *string, error) {
+	return r.find(limit, lastKey, "products:all", func(item *ProductItem) bool {
+		return true
+	})
+}
+
+// FindInStock retrieves products that are currently in stock
+func (r *MemoryProductRepository) FindInStock(ctx context.Context, limit int, lastKey *string) ([]*entity.Product, *string, error) {
+	return r.find(limit, lastKey, "products:in-stock", func(item *ProductItem) bool {
+		return item.Stock > 0
+	})
+}
+
+// Delete removes a product and its warehouse inventory (deleting a missing product is not an error)
+func (r *MemoryProductRepository) Delete(ctx context.Context, id value.ProductID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	pk := fmt.Sprintf("PRODUCT#%s", id.String())
+	delete(r.store.products, pk)
+	delete(r.store.inventory, pk)
+	return nil
+}
+
+// Exists checks if a product exists by its ID
+func (r *MemoryProductRepository) Exists(ctx context.Context, id value.ProductID) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	_, ok := r.store.products[fmt.Sprintf("PRODUCT#%s", id.String())]
+	return ok, nil
+}
+
+// find returns a page of the products matching filter in GSI1 (product listing) order
+func (r *MemoryProductRepository) find(limit int, lastKey *string, scope string, filter func(*ProductItem) bool) ([]*entity.Product, *string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	items := make([]*ProductItem, 0, len(r.store.products))
+	for _, item := range r.store.products {
+		if filter(item) {
+			items = append(items, item)
+		}
+	}
+
+	page, next, err := memoryPage(r.store, scope, items, func(item *ProductItem) string {
+		return item.GSI1SK
+	}, false, limit, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	products := make([]*entity.Product, 0, len(page))
+	for _, item := range page {
+		product, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "productID", item.ID, "error", err)
+			continue // Skip invalid items
+		}
+		products = append(products, product)
+	}
+	return products, next, nil
+}
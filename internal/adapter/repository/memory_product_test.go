@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestMemoryProductRepository(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewMemoryStore()
+	require.NoError(t, err)
+	repo := NewMemoryProductRepository(store)
+
+	price, _ := value.NewMoney(1000)
+	for id, stock := range map[string]int{"product-1": 5, "product-2": 0, "product-3": 7} {
+		productID, _ := value.NewProductID(id)
+		product, err := entity.NewProduct(productID, id, "description", price, stock)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, product))
+	}
+
+	t.Run("find by id", func(t *testing.T) {
+		productID, _ := value.NewProductID("product-1")
+		found, err := repo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, found.Stock())
+
+		missingID, _ := value.NewProductID("missing")
+		_, err = repo.FindByID(ctx, missingID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("every field survives a save and find", func(t *testing.T) {
+		productID, _ := value.NewProductID("product-restored")
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		product, err := entity.NewProductWithState(productID, "Restored", "restored product", price, 3, createdAt, createdAt.Add(time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, product))
+
+		found, err := repo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, product.Name(), found.Name())
+		assert.Equal(t, product.Description(), found.Description())
+		assert.Equal(t, product.Price(), found.Price())
+		assert.Equal(t, product.Stock(), found.Stock())
+		assert.Equal(t, createdAt, found.CreatedAt())
+		assert.Equal(t, product.UpdatedAt(), found.UpdatedAt())
+		assert.Equal(t, 1, found.Version())
+
+		// Saving the found product again keeps its creation time
+		found.UpdatePrice(price)
+		require.NoError(t, repo.Save(ctx, found))
+		updated, err := repo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, createdAt, updated.CreatedAt())
+
+		require.NoError(t, repo.Delete(ctx, productID))
+	})
+
+	t.Run("find all pages by cursor", func(t *testing.T) {
+		page, next, err := repo.FindAll(ctx, 2, nil)
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, "product-1", page[0].ID().String())
+		assert.Equal(t, "product-2", page[1].ID().String())
+
+		page, next, err = repo.FindAll(ctx, 2, next)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, "product-3", page[0].ID().String())
+		assert.Nil(t, next)
+	})
+
+	t.Run("find in stock skips sold out products", func(t *testing.T) {
+		page, next, err := repo.FindInStock(ctx, 0, nil)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		require.Len(t, page, 2)
+		assert.Equal(t, "product-1", page[0].ID().String())
+		assert.Equal(t, "product-3", page[1].ID().String())
+	})
+
+	t.Run("cursor is bound to its query", func(t *testing.T) {
+		_, next, err := repo.FindAll(ctx, 1, nil)
+		require.NoError(t, err)
+		require.NotNil(t, next)
+
+		_, _, err = repo.FindInStock(ctx, 1, next)
+		assert.Error(t, err)
+	})
+}
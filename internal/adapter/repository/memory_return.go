@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// MemoryReturnRepository implements ReturnRepository on top of a MemoryStore
+type MemoryReturnRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryReturnRepository creates a new in-memory return repository
+func NewMemoryReturnRepository(store *MemoryStore) *MemoryReturnRepository {
+	return &MemoryReturnRepository{
+		store: store,
+	}
+}
+
+// Save creates or updates a return request and its order under a single lock
+func (r *MemoryReturnRepository) Save(ctx context.Context, request *entity.ReturnRequest, order *entity.Order) error {
+	return r.save(request, order, nil, false)
+}
+
+// Approve saves an approved return request and its order, and puts the returned quantities back
+// into the given products' totals and the receiving warehouse under a single lock
+func (r *MemoryReturnRepository) Approve(ctx context.Context, request *entity.ReturnRequest, order *entity.Order, products []*entity.Product) error {
+	if !request.IsApproved() {
+		return fmt.Errorf("return request is not approved: %s", request.ID().String())
+	}
+	return r.save(request, order, products, true)
+}
+
+func (r *MemoryReturnRepository) save(request *entity.ReturnRequest, order *entity.Order, products []*entity.Product, restock bool) error {
+	header, lines, err := OrderItemFromEntity(order)
+	if err != nil {
+		return fmt.Errorf("failed to convert order to item: %w", err)
+	}
+	item := ReturnRequestItemFromEntity(request)
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Optimistic locking on both the return request and the order (0 when absent)
+	storedVersion := 0
+	if current, ok := r.store.returns[item.PK][item.SK]; ok {
+		storedVersion = current.Version
+	}
+	if storedVersion != request.Version() {
+		slog.Warn("Return request was modified concurrently", "returnID", request.ID().String(), "version", request.Version())
+		return domain.ConcurrentModificationError("ReturnRequest", request.ID().String())
+	}
+
+	storedVersion = 0
+	if current, ok := r.store.orders[header.PK]; ok {
+		storedVersion = current.Version
+	}
+	if storedVersion != order.Version() {
+		slog.Warn("Order was modified concurrently", "orderID", order.ID().String(), "version", order.Version())
+		return domain.ConcurrentModificationError("Order", order.ID().String())
+	}
+
+	// 承認時は返品された商品のうち渡された商品に戻す（読んだ後に削除された商品があれば何も書かない）
+	productIDs := []string{}
+	var quantities map[string]int
+	if restock {
+		var returnedIDs []string
+		returnedIDs, quantities = returnedQuantities(request.Items())
+		productIDs = restockedProducts(returnedIDs, products)
+		for _, productID := range productIDs {
+			if _, ok := r.store.products[fmt.Sprintf("PRODUCT#%s", productID)]; !ok {
+				slog.Warn("Returned product was deleted concurrently", "returnID", request.ID().String(), "productID", productID)
+				return domain.ConcurrentModificationError("Product", productID)
+			}
+		}
+
+		warehouseID := request.WarehouseID().String()
+		for _, productID := range productIDs {
+			pk := fmt.Sprintf("PRODUCT#%s", productID)
+			product := r.store.products[pk]
+			product.Stock += quantities[productID]
+			product.UpdatedAt = request.UpdatedAt()
+			product.Version++
+
+			// 受け入れ倉庫の在庫アイテムがなければ作る
+			sk := fmt.Sprintf("WAREHOUSE#%s", warehouseID)
+			inventory, ok := r.store.inventory[pk][sk]
+			if !ok {
+				inventory = &InventoryItem{
+					PK:          pk,
+					SK:          sk,
+					GSI2PK:      fmt.Sprintf("WAREHOUSE#%s", warehouseID),
+					GSI2SK:      pk,
+					Type:        "INVENTORY",
+					ProductID:   productID,
+					WarehouseID: warehouseID,
+				}
+				if r.store.inventory[pk] == nil {
+					r.store.inventory[pk] = make(map[string]*InventoryItem)
+				}
+				r.store.inventory[pk][sk] = inventory
+			}
+			inventory.Quantity += quantities[productID]
+			inventory.UpdatedAt = request.UpdatedAt()
+			inventory.Version++
+		}
+	}
+
+	item.Version = request.Version() + 1
+	if r.store.returns[item.PK] == nil {
+		r.store.returns[item.PK] = make(map[string]*ReturnRequestItem)
+	}
+	r.store.returns[item.PK][item.SK] = item
+	request.SetVersion(item.Version)
+
+	header.Version = order.Version() + 1
+	r.store.orders[header.PK] = header
+	r.store.lines[header.PK] = lines
+	r.store.appendOrderHistory(header.PK, order)
+	order.SetVersion(header.Version)
+	order.MarkStatusChangesSaved()
+
+	return nil
+}
+
+// FindByID retrieves a return request by its ID
+func (r *MemoryReturnRepository) FindByID(ctx context.Context, id value.ReturnID) (*entity.ReturnRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	key := fmt.Sprintf("RETURN#%s", id.String())
+	for _, collection := range r.store.returns {
+		if item, ok := collection[key]; ok {
+			request, err := item.ToEntity()
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+			}
+			return request, nil
+		}
+	}
+	return nil, domain.ReturnNotFoundError(id.String())
+}
+
+// FindByOrderID retrieves all return requests of an order, ordered by SK like the order item collection
+func (r *MemoryReturnRepository) FindByOrderID(ctx context.Context, orderID value.OrderID) ([]*entity.ReturnRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	collection := r.store.returns[fmt.Sprintf("ORDER#%s", orderID.String())]
+	items := make([]*ReturnRequestItem, 0, len(collection))
+	for _, item := range collection {
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].SK < items[j].SK
+	})
+
+	requests := make([]*entity.ReturnRequest, 0, len(items))
+	for _, item := range items {
+		request, err := item.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// FindByStatus retrieves the return requests with a status, oldest first like GSI2
+func (r *MemoryReturnRepository) FindByStatus(ctx context.Context, status entity.ReturnStatus, limit int, lastKey *string) ([]*entity.ReturnRequest, *string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	statusKey := fmt.Sprintf("RETURN_STATUS#%s", status)
+	var items []*ReturnRequestItem
+	for _, collection := range r.store.returns {
+		for _, item := range collection {
+			if item.GSI2PK == statusKey {
+				items = append(items, item)
+			}
+		}
+	}
+
+	page, next, err := memoryPage(r.store, "returns:status:"+string(status), items, func(item *ReturnRequestItem) string {
+		return item.GSI2SK
+	}, false, limit, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requests := make([]*entity.ReturnRequest, 0, len(page))
+	for _, item := range page {
+		request, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "returnID", item.ID, "error", err)
+			continue // Skip invalid items
+		}
+		requests = append(requests, request)
+	}
+	return requests, next, nil
+}
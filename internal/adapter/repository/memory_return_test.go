@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestMemoryReturnRepository(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewMemoryStore()
+	require.NoError(t, err)
+	orderRepo := NewMemoryOrderRepository(store)
+	productRepo := NewMemoryProductRepository(store)
+	warehouseRepo := NewMemoryWarehouseRepository(store)
+	repo := NewMemoryReturnRepository(store)
+
+	customerID, _ := value.NewCustomerID("customer-1")
+	productID, _ := value.NewProductID("product-1")
+	warehouseID, _ := value.NewWarehouseID("warehouse-1")
+	price, _ := value.NewMoney(1000)
+
+	product, err := entity.NewProduct(productID, "Widget", "", price, 8)
+	require.NoError(t, err)
+	require.NoError(t, productRepo.Save(ctx, product))
+
+	// newOrder saves a delivered order for two units of the product
+	newOrder := func(t *testing.T, id string) *entity.Order {
+		orderID, _ := value.NewOrderID("order-" + id)
+		orderItem, _ := entity.NewOrderItem(productID, 2, price)
+		total, _ := value.NewMoney(2000)
+		order, err := entity.NewOrderWithState(orderID, customerID, []entity.OrderItem{*orderItem},
+			entity.OrderStatusDelivered, total, time.Now(), time.Now())
+		require.NoError(t, err)
+		require.NoError(t, orderRepo.Save(ctx, order))
+		return order
+	}
+
+	// newReturn returns a requested return of the given quantity
+	newReturn := func(t *testing.T, id string, order *entity.Order, quantity int) *entity.ReturnRequest {
+		request, err := entity.NewReturnRequest(value.ReturnID("return-"+id), order,
+			[]entity.ReturnItem{{ProductID: productID, Quantity: quantity}}, "Damaged", nil)
+		require.NoError(t, err)
+		return request
+	}
+
+	t.Run("save return request with its order", func(t *testing.T) {
+		order := newOrder(t, "1")
+		request := newReturn(t, "1", order, 1)
+
+		require.NoError(t, repo.Save(ctx, request, order))
+		assert.Equal(t, 1, request.Version())
+		assert.Equal(t, 2, order.Version())
+
+		found, err := repo.FindByID(ctx, request.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.ReturnStatusRequested, found.Status())
+		assert.Equal(t, int64(1000), found.Items()[0].UnitPrice.Cents())
+
+		requests, err := repo.FindByOrderID(ctx, order.ID())
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+
+		_, err = repo.FindByID(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("approve restocks the product and the receiving warehouse", func(t *testing.T) {
+		order := newOrder(t, "2")
+		request := newReturn(t, "2", order, 2)
+		require.NoError(t, repo.Save(ctx, request, order))
+
+		require.NoError(t, request.Approve(warehouseID, ""))
+		require.NoError(t, order.ApplyReturns([]*entity.ReturnRequest{request}))
+		require.NoError(t, repo.Approve(ctx, request, order, []*entity.Product{product}))
+
+		storedProduct, err := productRepo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 10, storedProduct.Stock())
+
+		inventory, err := warehouseRepo.FindInventory(ctx, warehouseID, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, inventory.Quantity())
+
+		storedOrder, err := orderRepo.FindByID(ctx, order.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusReturned, storedOrder.Status())
+
+		found, err := repo.FindByID(ctx, request.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2000), found.Refund().Cents())
+		assert.Equal(t, warehouseID, found.WarehouseID())
+	})
+
+	t.Run("approving a return of a deleted product skips the restock", func(t *testing.T) {
+		otherID, _ := value.NewProductID("product-deleted")
+		orderItem, _ := entity.NewOrderItem(otherID, 1, price)
+		order, err := entity.NewOrderWithState("order-3", customerID, []entity.OrderItem{*orderItem},
+			entity.OrderStatusDelivered, price, time.Now(), time.Now())
+		require.NoError(t, err)
+		require.NoError(t, orderRepo.Save(ctx, order))
+
+		request, err := entity.NewReturnRequest("return-3", order,
+			[]entity.ReturnItem{{ProductID: otherID, Quantity: 1}}, "Damaged", nil)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, request, order))
+		require.NoError(t, request.Approve(warehouseID, ""))
+		require.NoError(t, order.ApplyReturns([]*entity.ReturnRequest{request}))
+
+		// The product was read before it was deleted
+		deleted, err := entity.NewProduct(otherID, "Deleted", "", price, 0)
+		require.NoError(t, err)
+		err = repo.Approve(ctx, request, order, []*entity.Product{deleted})
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeConcurrentModification, domainErr.Code)
+		found, err := repo.FindByID(ctx, request.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.ReturnStatusRequested, found.Status())
+
+		require.NoError(t, repo.Approve(ctx, request, order, nil))
+
+		found, err = repo.FindByID(ctx, request.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.ReturnStatusApproved, found.Status())
+		// No inventory item is created for the deleted product
+		_, err = warehouseRepo.FindInventory(ctx, warehouseID, otherID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("stale order version is rejected", func(t *testing.T) {
+		order := newOrder(t, "4")
+		stale, err := orderRepo.FindByID(ctx, order.ID())
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Save(ctx, newReturn(t, "4a", order, 1), order))
+
+		err = repo.Save(ctx, newReturn(t, "4b", stale, 1), stale)
+		assert.ErrorIs(t, err, domain.ErrConcurrentModification)
+
+		requests, err := repo.FindByOrderID(ctx, order.ID())
+		require.NoError(t, err)
+		assert.Len(t, requests, 1)
+	})
+
+	t.Run("find by status, oldest first", func(t *testing.T) {
+		requests, next, err := repo.FindByStatus(ctx, entity.ReturnStatusRequested, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		require.NotNil(t, next)
+
+		for next != nil {
+			var page []*entity.ReturnRequest
+			page, next, err = repo.FindByStatus(ctx, entity.ReturnStatusRequested, 1, next)
+			require.NoError(t, err)
+			requests = append(requests, page...)
+		}
+		require.Len(t, requests, 2)
+		assert.Equal(t, value.ReturnID("return-1"), requests[0].ID())
+
+		approved, _, err := repo.FindByStatus(ctx, entity.ReturnStatusApproved, 10, nil)
+		require.NoError(t, err)
+		require.Len(t, approved, 2)
+		assert.Equal(t, value.ReturnID("return-2"), approved[0].ID())
+		assert.Equal(t, value.ReturnID("return-3"), approved[1].ID())
+	})
+
+	t.Run("deleting the order removes its returns", func(t *testing.T) {
+		order := newOrder(t, "5")
+		request := newReturn(t, "5", order, 1)
+		require.NoError(t, repo.Save(ctx, request, order))
+
+		require.NoError(t, orderRepo.Delete(ctx, order.ID()))
+
+		_, err := repo.FindByID(ctx, request.ID())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// MemoryShipmentRepository implements ShipmentRepository on top of a MemoryStore
+type MemoryShipmentRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryShipmentRepository creates a new in-memory shipment repository
+func NewMemoryShipmentRepository(store *MemoryStore) *MemoryShipmentRepository {
+	return &MemoryShipmentRepository{
+		store: store,
+	}
+}
+
+// Save creates or updates a shipment and its order under a single lock
+func (r *MemoryShipmentRepository) Save(ctx context.Context, shipment *entity.Shipment, order *entity.Order) error {
+	header, lines, err := OrderItemFromEntity(order)
+	if err != nil {
+		return fmt.Errorf("failed to convert order to item: %w", err)
+	}
+	item := ShipmentItemFromEntity(shipment)
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Optimistic locking on both the shipment and the order (0 when absent)
+	storedVersion := 0
+	if current, ok := r.store.shipments[item.PK][item.SK]; ok {
+		storedVersion = current.Version
+	}
+	if storedVersion != shipment.Version() {
+		slog.Warn("Shipment was modified concurrently", "shipmentID", shipment.ID().String(), "version", shipment.Version())
+		return domain.ConcurrentModificationError("Shipment", shipment.ID().String())
+	}
+
+	storedVersion = 0
+	if current, ok := r.store.orders[header.PK]; ok {
+		storedVersion = current.Version
+	}
+	if storedVersion != order.Version() {
+		slog.Warn("Order was modified concurrently", "orderID", order.ID().String(), "version", order.Version())
+		return domain.ConcurrentModificationError("Order", order.ID().String())
+	}
+
+	item.Version = shipment.Version() + 1
+	if r.store.shipments[item.PK] == nil {
+		r.store.shipments[item.PK] = make(map[string]*ShipmentItem)
+	}
+	r.store.shipments[item.PK][item.SK] = item
+	shipment.SetVersion(item.Version)
+
+	header.Version = order.Version() + 1
+	r.store.orders[header.PK] = header
+	r.store.lines[header.PK] = lines
+	r.store.appendOrderHistory(header.PK, order)
+	order.SetVersion(header.Version)
+	order.MarkStatusChangesSaved()
+
+	return nil
+}
+
+// FindByID retrieves a shipment by its ID
+func (r *MemoryShipmentRepository) FindByID(ctx context.Context, id value.ShipmentID) (*entity.Shipment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	key := fmt.Sprintf("SHIPMENT#%s", id.String())
+	for _, collection := range r.store.shipments {
+		if item, ok := collection[key]; ok {
+			shipment, err := item.ToEntity()
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+			}
+			return shipment, nil
+		}
+	}
+	return nil, domain.ShipmentNotFoundError(id.String())
+}
+
+// FindByOrderID retrieves all shipments of an order, ordered by SK like the order item collection
+func (r *MemoryShipmentRepository) FindByOrderID(ctx context.Context, orderID value.OrderID) ([]*entity.Shipment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	collection := r.store.shipments[fmt.Sprintf("ORDER#%s", orderID.String())]
+	items := make([]*ShipmentItem, 0, len(collection))
+	for _, item := range collection {
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].SK < items[j].SK
+	})
+
+	shipments := make([]*entity.Shipment, 0, len(items))
+	for _, item := range items {
+		shipment, err := item.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+		}
+		shipments = append(shipments, shipment)
+	}
+	return shipments, nil
+}
+
+// FindByWarehouseID retrieves the shipments sent from a warehouse, newest first like GSI2
+func (r *MemoryShipmentRepository) FindByWarehouseID(ctx context.Context, warehouseID value.WarehouseID, limit int, lastKey *string) ([]*entity.Shipment, *string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	warehouseKey := fmt.Sprintf("WAREHOUSE#%s", warehouseID.String())
+	var items []*ShipmentItem
+	for _, collection := range r.store.shipments {
+		for _, item := range collection {
+			if item.GSI2PK == warehouseKey {
+				items = append(items, item)
+			}
+		}
+	}
+
+	page, next, err := memoryPage(r.store, "shipments:warehouse:"+warehouseID.String(), items, func(item *ShipmentItem) string {
+		return item.GSI2SK
+	}, true, limit, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shipments := make([]*entity.Shipment, 0, len(page))
+	for _, item := range page {
+		shipment, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "shipmentID", item.ID, "error", err)
+			continue // Skip invalid items
+		}
+		shipments = append(shipments, shipment)
+	}
+	return shipments, next, nil
+}
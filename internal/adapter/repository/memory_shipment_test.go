@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestMemoryShipmentRepository(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewMemoryStore()
+	require.NoError(t, err)
+	orderRepo := NewMemoryOrderRepository(store)
+	repo := NewMemoryShipmentRepository(store)
+
+	customerID, _ := value.NewCustomerID("customer-1")
+	productID, _ := value.NewProductID("product-1")
+	warehouseID, _ := value.NewWarehouseID("warehouse-1")
+	price, _ := value.NewMoney(1000)
+
+	// newOrder saves a confirmed order for two units of the product
+	newOrder := func(t *testing.T, id string) *entity.Order {
+		orderID, _ := value.NewOrderID("order-" + id)
+		orderItem, _ := entity.NewOrderItem(productID, 2, price)
+		order, err := entity.NewOrder(orderID, customerID, []entity.OrderItem{*orderItem})
+		require.NoError(t, err)
+		require.NoError(t, order.Confirm())
+		require.NoError(t, orderRepo.Save(ctx, order))
+		return order
+	}
+
+	// newShipment returns a shipment of the given quantity shipped at the given time
+	newShipment := func(t *testing.T, id string, order *entity.Order, quantity int, shippedAt time.Time) *entity.Shipment {
+		items := []entity.ShipmentItem{{ProductID: productID, Quantity: quantity}}
+		shipment, err := entity.NewShipmentWithState(value.ShipmentID("shipment-"+id), order.ID(), warehouseID,
+			"Yamato", "TRACK-"+id, items, entity.ShipmentStatusInTransit, shippedAt, time.Time{}, shippedAt)
+		require.NoError(t, err)
+		return shipment
+	}
+
+	t.Run("save shipment with its order", func(t *testing.T) {
+		order := newOrder(t, "1")
+		shipment := newShipment(t, "1", order, 2, time.Now())
+		require.NoError(t, order.ApplyShipments([]*entity.Shipment{shipment}))
+
+		require.NoError(t, repo.Save(ctx, shipment, order))
+		assert.Equal(t, 1, shipment.Version())
+		assert.Equal(t, 2, order.Version())
+
+		found, err := repo.FindByID(ctx, shipment.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "TRACK-1", found.TrackingNumber())
+
+		shipments, err := repo.FindByOrderID(ctx, order.ID())
+		require.NoError(t, err)
+		require.Len(t, shipments, 1)
+
+		storedOrder, err := orderRepo.FindByID(ctx, order.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusShipped, storedOrder.Status())
+
+		_, err = repo.FindByID(ctx, "missing")
+		assert.Error(t, err)
+	})
+
+	t.Run("stale order version is rejected", func(t *testing.T) {
+		order := newOrder(t, "2")
+		stale, err := orderRepo.FindByID(ctx, order.ID())
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Save(ctx, newShipment(t, "2a", order, 1, time.Now()), order))
+
+		err = repo.Save(ctx, newShipment(t, "2b", stale, 1, time.Now()), stale)
+		assert.ErrorIs(t, err, domain.ErrConcurrentModification)
+
+		shipments, err := repo.FindByOrderID(ctx, order.ID())
+		require.NoError(t, err)
+		assert.Len(t, shipments, 1)
+	})
+
+	t.Run("find by warehouse, newest first", func(t *testing.T) {
+		order := newOrder(t, "3")
+		for i, day := range []int{1, 10} {
+			shipment := newShipment(t, string(rune('a'+i)), order, 1, time.Date(2025, 6, day, 12, 0, 0, 0, time.UTC))
+			require.NoError(t, repo.Save(ctx, shipment, order))
+		}
+
+		shipments, next, err := repo.FindByWarehouseID(ctx, warehouseID, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, shipments, 1)
+		require.NotNil(t, next)
+
+		// Shipments saved by earlier subtests were shipped now, after the June shipments
+		for next != nil {
+			var page []*entity.Shipment
+			page, next, err = repo.FindByWarehouseID(ctx, warehouseID, 1, next)
+			require.NoError(t, err)
+			shipments = append(shipments, page...)
+		}
+		require.Len(t, shipments, 4)
+		assert.Equal(t, value.ShipmentID("shipment-b"), shipments[2].ID())
+		assert.Equal(t, value.ShipmentID("shipment-a"), shipments[3].ID())
+	})
+
+	t.Run("deleting the order removes its shipments", func(t *testing.T) {
+		order := newOrder(t, "4")
+		shipment := newShipment(t, "4", order, 1, time.Now())
+		require.NoError(t, repo.Save(ctx, shipment, order))
+
+		require.NoError(t, orderRepo.Delete(ctx, order.ID()))
+
+		_, err := repo.FindByID(ctx, shipment.ID())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// MemoryStore holds the items shared by the in-memory repositories.
+// Items are stored in their DynamoDB item form so that the memory repositories
+// reuse the same entity conversions as the Dynamo ones.
+type MemoryStore struct {
+	mu         sync.RWMutex
+	customers  map[string]*CustomerItem                 // keyed by PK
+	products   map[string]*ProductItem                  // keyed by PK
+	orders     map[string]*OrderItem                    // keyed by PK
+	lines      map[string][]OrderLineItem               // keyed by order PK
+	history    map[string][]OrderStatusItem             // keyed by order PK, oldest first
+	reserved   map[string][]OrderReservationItem        // keyed by order PK
+	warehouses map[string]*WarehouseItem                // keyed by PK
+	inventory  map[string]map[string]*InventoryItem     // keyed by product PK, then SK
+	invoices   map[string]map[string]*InvoiceItem       // keyed by order PK, then SK
+	shipments  map[string]map[string]*ShipmentItem      // keyed by order PK, then SK
+	returns    map[string]map[string]*ReturnRequestItem // keyed by order PK, then SK
+	idempotent map[string]*IdempotencyItem              // keyed by PK
+	cursors    *infrastructure.CursorCodec
+}
+
+// NewMemoryStore creates an empty in-memory store.
+// Data and pagination cursors live only as long as the process.
+func NewMemoryStore() (*MemoryStore, error) {
+	cursors, err := infrastructure.NewRandomCursorCodec()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemoryStore{
+		customers:  make(map[string]*CustomerItem),
+		products:   make(map[string]*ProductItem),
+		orders:     make(map[string]*OrderItem),
+		lines:      make(map[string][]OrderLineItem),
+		history:    make(map[string][]OrderStatusItem),
+		reserved:   make(map[string][]OrderReservationItem),
+		warehouses: make(map[string]*WarehouseItem),
+		inventory:  make(map[string]map[string]*InventoryItem),
+		invoices:   make(map[string]map[string]*InvoiceItem),
+		shipments:  make(map[string]map[string]*ShipmentItem),
+		returns:    make(map[string]map[string]*ReturnRequestItem),
+		idempotent: make(map[string]*IdempotencyItem),
+		cursors:    cursors,
+	}, nil
+}
+
+// appendOrderHistory appends the unsaved status changes of an order to its history.
+// The caller must hold the write lock.
+func (s *MemoryStore) appendOrderHistory(pk string, order *entity.Order) {
+	s.history[pk] = append(s.history[pk], OrderStatusItemsFromEntity(order)...)
+}
+
+// memoryPage sorts items by their position key (descending when requested) and returns the page
+// following the cursor. The cursor records the position of the last returned item, so a page stays stable
+// even when items before it are deleted between requests.
+func memoryPage[T any](s *MemoryStore, scope string, items []T, position func(T) string, descending bool, limit int, cursor *string) ([]T, *string, error) {
+	key, err := s.cursors.Decode(scope, cursor)
+	if err != nil {
+		return nil, nil, domain.NewDomainError(domain.ErrCodeInvalidInput, "invalid pagination cursor", err)
+	}
+
+	// before reports whether position a comes before position b in page order
+	before := func(a, b string) bool {
+		if descending {
+			return a > b
+		}
+		return a < b
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return before(position(items[i]), position(items[j]))
+	})
+
+	start := 0
+	if key != nil {
+		after, ok := key["Position"].(*types.AttributeValueMemberS)
+		if !ok {
+			return nil, nil, domain.NewDomainError(domain.ErrCodeInvalidInput, "invalid pagination cursor", infrastructure.ErrInvalidCursor)
+		}
+		start = sort.Search(len(items), func(i int) bool {
+			return before(after.Value, position(items[i]))
+		})
+	}
+
+	items = items[start:]
+	if limit <= 0 || len(items) <= limit {
+		return items, nil, nil
+	}
+
+	page := items[:limit]
+	next, err := s.cursors.Encode(scope, dynamo.PagingKey{
+		"Position": &types.AttributeValueMemberS{Value: position(page[len(page)-1])},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return page, next, nil
+}
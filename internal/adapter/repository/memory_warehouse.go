@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// MemoryWarehouseRepository implements WarehouseRepository on top of a MemoryStore
+type MemoryWarehouseRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryWarehouseRepository creates a new in-memory warehouse repository
+func NewMemoryWarehouseRepository(store *MemoryStore) *MemoryWarehouseRepository {
+	return &MemoryWarehouseRepository{
+		store: store,
+	}
+}
+
+// Save creates or updates a warehouse
+func (r *MemoryWarehouseRepository) Save(ctx context.Context, warehouse *entity.Warehouse) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	item := WarehouseItemFromEntity(warehouse)
+
+	// Optimistic locking: the stored version (0 when absent) must match the version the entity was read at
+	storedVersion := 0
+	if current, ok := r.store.warehouses[item.PK]; ok {
+		storedVersion = current.Version
+	}
+	if storedVersion != warehouse.Version() {
+		slog.Warn("Warehouse was modified concurrently", "warehouseID", warehouse.ID().String(), "version", warehouse.Version())
+		return domain.ConcurrentModificationError("Warehouse", warehouse.ID().String())
+	}
+
+	item.Version = warehouse.Version() + 1
+	r.store.warehouses[item.PK] = item
+	warehouse.SetVersion(item.Version)
+
+	return nil
+}
+
+// FindByID retrieves a warehouse by its ID
+func (r *MemoryWarehouseRepository) FindByID(ctx context.Context, id value.WarehouseID) (*entity.Warehouse, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	item, ok := r.store.warehouses[fmt.Sprintf("WAREHOUSE#%s", id.String())]
+	if !ok {
+		return nil, domain.WarehouseNotFoundError(id.String())
+	}
+
+	warehouse, err := item.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+	return warehouse, nil
+}
+
+// Exists checks if a warehouse exists by its ID
+func (r *MemoryWarehouseRepository) Exists(ctx context.Context, id value.WarehouseID) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	_, ok := r.store.warehouses[fmt.Sprintf("WAREHOUSE#%s", id.String())]
+	return ok, nil
+}
+
+// SaveInventory writes a product's inventory in a warehouse and adjusts the product's total stock by the change
+func (r *MemoryWarehouseRepository) SaveInventory(ctx context.Context, inventory *entity.Inventory) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	item := InventoryItemFromEntity(inventory)
+	name := inventoryName(inventory.WarehouseID(), inventory.ProductID())
+
+	// Optimistic locking: the stored version (0 when absent) must match the version the entity was read at
+	storedVersion, storedQuantity := 0, 0
+	if current, ok := r.store.inventory[item.PK][item.SK]; ok {
+		storedVersion, storedQuantity = current.Version, current.Quantity
+	}
+	if storedVersion != inventory.Version() {
+		slog.Warn("Inventory was modified concurrently", "inventory", name, "version", inventory.Version())
+		return domain.ConcurrentModificationError("Inventory", name)
+	}
+
+	product, ok := r.store.products[item.PK]
+	if !ok {
+		return domain.ProductNotFoundError(inventory.ProductID().String())
+	}
+	product.Stock += item.Quantity - storedQuantity
+	product.UpdatedAt = item.UpdatedAt
+	product.Version++
+
+	item.Version = inventory.Version() + 1
+	if r.store.inventory[item.PK] == nil {
+		r.store.inventory[item.PK] = make(map[string]*InventoryItem)
+	}
+	r.store.inventory[item.PK][item.SK] = item
+	inventory.SetVersion(item.Version)
+
+	return nil
+}
+
+// FindInventory retrieves a product's inventory in a warehouse
+func (r *MemoryWarehouseRepository) FindInventory(ctx context.Context, warehouseID value.WarehouseID, productID value.ProductID) (*entity.Inventory, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	item, ok := r.store.inventory[fmt.Sprintf("PRODUCT#%s", productID.String())][fmt.Sprintf("WAREHOUSE#%s", warehouseID.String())]
+	if !ok {
+		return nil, domain.InventoryNotFoundError(warehouseID.String(), productID.String())
+	}
+
+	inventory, err := item.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert item to entity: %w", err)
+	}
+	return inventory, nil
+}
+
+// FindInventoryByWarehouse retrieves the inventory of all products in a warehouse, ordered like GSI2
+func (r *MemoryWarehouseRepository) FindInventoryByWarehouse(ctx context.Context, warehouseID value.WarehouseID, limit int, lastKey *string) ([]*entity.Inventory, *string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	warehouseKey := fmt.Sprintf("WAREHOUSE#%s", warehouseID.String())
+	var items []*InventoryItem
+	for _, collection := range r.store.inventory {
+		if item, ok := collection[warehouseKey]; ok {
+			items = append(items, item)
+		}
+	}
+
+	page, next, err := memoryPage(r.store, "inventory:warehouse:"+warehouseID.String(), items, func(item *InventoryItem) string {
+		return item.GSI2SK
+	}, false, limit, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r.toEntities(page), next, nil
+}
+
+// FindInventoryByProduct retrieves a product's inventory across all warehouses, ordered by warehouse ID
+func (r *MemoryWarehouseRepository) FindInventoryByProduct(ctx context.Context, productID value.ProductID) ([]*entity.Inventory, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	collection := r.store.inventory[fmt.Sprintf("PRODUCT#%s", productID.String())]
+	items := make([]*InventoryItem, 0, len(collection))
+	for _, item := range collection {
+		items = append(items, item)
+	}
+
+	page, _, err := memoryPage(r.store, "inventory:product:"+productID.String(), items, func(item *InventoryItem) string {
+		return item.SK
+	}, false, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toEntities(page), nil
+}
+
+// toEntities converts inventory items, skipping invalid ones
+func (r *MemoryWarehouseRepository) toEntities(items []*InventoryItem) []*entity.Inventory {
+	inventories := make([]*entity.Inventory, 0, len(items))
+	for _, item := range items {
+		inventory, err := item.ToEntity()
+		if err != nil {
+			slog.Error("Failed to convert item to entity", "productID", item.ProductID, "warehouseID", item.WarehouseID, "error", err)
+			continue // Skip invalid items
+		}
+		inventories = append(inventories, inventory)
+	}
+	return inventories
+}
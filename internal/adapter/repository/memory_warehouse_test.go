@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestMemoryWarehouseRepository(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewMemoryStore()
+	require.NoError(t, err)
+	productRepo := NewMemoryProductRepository(store)
+	repo := NewMemoryWarehouseRepository(store)
+
+	price, _ := value.NewMoney(1000)
+	for _, id := range []string{"product-1", "product-2"} {
+		productID, _ := value.NewProductID(id)
+		product, err := entity.NewProduct(productID, id, "description", price, 0)
+		require.NoError(t, err)
+		require.NoError(t, productRepo.Save(ctx, product))
+	}
+	productID, _ := value.NewProductID("product-1")
+	tokyo, _ := value.NewWarehouseID("warehouse-tokyo")
+	osaka, _ := value.NewWarehouseID("warehouse-osaka")
+
+	t.Run("save and find warehouse", func(t *testing.T) {
+		warehouse, err := entity.NewWarehouse(tokyo, "Tokyo DC", "Tokyo")
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, warehouse))
+		assert.Equal(t, 1, warehouse.Version())
+
+		found, err := repo.FindByID(ctx, tokyo)
+		require.NoError(t, err)
+		assert.Equal(t, "Tokyo DC", found.Name())
+		assert.Equal(t, warehouse.CreatedAt(), found.CreatedAt())
+
+		exists, err := repo.Exists(ctx, osaka)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("inventory changes adjust product stock", func(t *testing.T) {
+		first, _ := entity.NewInventory(productID, tokyo, 10)
+		require.NoError(t, repo.SaveInventory(ctx, first))
+		second, _ := entity.NewInventory(productID, osaka, 4)
+		require.NoError(t, repo.SaveInventory(ctx, second))
+
+		product, err := productRepo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 14, product.Stock())
+
+		require.NoError(t, first.SetQuantity(6))
+		require.NoError(t, repo.SaveInventory(ctx, first))
+
+		product, err = productRepo.FindByID(ctx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 10, product.Stock())
+
+		inventories, err := repo.FindInventoryByProduct(ctx, productID)
+		require.NoError(t, err)
+		require.Len(t, inventories, 2)
+		assert.Equal(t, 10, entity.TotalStock(inventories))
+	})
+
+	t.Run("stale inventory write is rejected", func(t *testing.T) {
+		stale, _ := entity.NewInventory(productID, tokyo, 1)
+		err := repo.SaveInventory(ctx, stale)
+		assert.ErrorIs(t, err, domain.ErrConcurrentModification)
+	})
+
+	t.Run("inventory for a missing product is rejected", func(t *testing.T) {
+		missingID, _ := value.NewProductID("missing")
+		inventory, _ := entity.NewInventory(missingID, tokyo, 1)
+		err := repo.SaveInventory(ctx, inventory)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("find inventory by warehouse pages by cursor", func(t *testing.T) {
+		otherID, _ := value.NewProductID("product-2")
+		other, _ := entity.NewInventory(otherID, tokyo, 3)
+		require.NoError(t, repo.SaveInventory(ctx, other))
+
+		page, next, err := repo.FindInventoryByWarehouse(ctx, tokyo, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, "product-1", page[0].ProductID().String())
+		require.NotNil(t, next)
+
+		page, next, err = repo.FindInventoryByWarehouse(ctx, tokyo, 1, next)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, "product-2", page[0].ProductID().String())
+		assert.Nil(t, next)
+	})
+}
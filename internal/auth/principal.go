@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Roles carried in the role claim of a token
+const (
+	RoleAdmin    = "admin"    // Back office, may call every operation
+	RoleCustomer = "customer" // A customer, whose ID is the subject of the token
+)
+
+// roleClaim names the claim that holds the role of the caller
+const roleClaim = "role"
+
+// Principal is the caller a verified token identifies
+type Principal struct {
+	Subject string
+	Role    string
+	Claims  jwt.MapClaims
+}
+
+// IsAdmin reports whether the caller may act on every resource
+func (p *Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+// CanActFor reports whether the caller may act for a customer: admins act for every customer
+// and customers only for themselves
+func (p *Principal) CanActFor(customerID string) bool {
+	return p.IsAdmin() || p.Subject == customerID
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx that carries the principal
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal of the request, or nil when the request was not authenticated
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minHMACKeySize is the shortest HS256 key accepted, the size of the SHA-256 output (RFC 7518 3.2)
+const minHMACKeySize = 32
+
+// leeway absorbs clock skew between the token issuer and the server
+const leeway = 30 * time.Second
+
+// ErrNoKey is returned by NewVerifier when no verification key is configured
+var ErrNoKey = errors.New("no token verification key configured")
+
+// Config names where the verification keys come from and what the tokens must be issued for.
+// Keys from all files are used together; JWKS keys are chosen by the kid header of a token.
+type Config struct {
+	HMACKeyFile      string // Shared secret for HS256
+	RSAPublicKeyFile string // PEM public key or certificate for RS256
+	JWKSFile         string // JSON Web Key Set with RSA and oct keys
+	Issuer           string // Required iss claim, empty to accept any
+	Audience         string // Required aud claim, empty to accept any
+}
+
+// Verifier checks the signature and the registered claims of bearer tokens
+type Verifier struct {
+	hmacKeys map[string][]byte // keyed by kid, "" for the key file
+	rsaKeys  map[string]*rsa.PublicKey
+	parser   *jwt.Parser
+}
+
+// NewVerifier loads the keys named by cfg. Tokens must carry an expiry and a subject and may only
+// be signed with an algorithm for which a key was loaded.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	v := &Verifier{
+		hmacKeys: make(map[string][]byte),
+		rsaKeys:  make(map[string]*rsa.PublicKey),
+	}
+
+	if cfg.HMACKeyFile != "" {
+		data, err := os.ReadFile(cfg.HMACKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read HMAC key: %w", err)
+		}
+		key := bytes.TrimSpace(data)
+		if len(key) < minHMACKeySize {
+			return nil, fmt.Errorf("HMAC key in %s must be at least %d bytes", cfg.HMACKeyFile, minHMACKeySize)
+		}
+		v.hmacKeys[""] = key
+	}
+	if cfg.RSAPublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.RSAPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read RSA public key: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA public key in %s: %w", cfg.RSAPublicKeyFile, err)
+		}
+		v.rsaKeys[""] = key
+	}
+	if cfg.JWKSFile != "" {
+		if err := v.loadJWKS(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var methods []string
+	if len(v.hmacKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(v.rsaKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, ErrNoKey
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+	v.parser = jwt.NewParser(options...)
+	return v, nil
+}
+
+// jsonWebKey is the part of a JWK (RFC 7517) needed for RSA and oct signing keys
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"` // RSA modulus
+	E   string `json:"e"` // RSA exponent
+	K   string `json:"k"` // Symmetric key
+}
+
+// loadJWKS adds the signing keys of a JWK Set file. Keys for encryption, for other algorithms or
+// of other types, e.g. EC keys published by the same issuer, are skipped.
+func (v *Verifier) loadJWKS(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read JWKS: %w", err)
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("invalid JWKS in %s: %w", path, err)
+	}
+
+	for i, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		switch {
+		case jwk.Kty == "RSA" && (jwk.Alg == "" || jwk.Alg == jwt.SigningMethodRS256.Alg()):
+			if _, ok := v.rsaKeys[jwk.Kid]; ok {
+				return fmt.Errorf("duplicate RSA key with kid %q in %s", jwk.Kid, path)
+			}
+			key, err := rsaPublicKey(jwk)
+			if err != nil {
+				return fmt.Errorf("invalid key %d in %s: %w", i, path, err)
+			}
+			v.rsaKeys[jwk.Kid] = key
+		case jwk.Kty == "oct" && (jwk.Alg == "" || jwk.Alg == jwt.SigningMethodHS256.Alg()):
+			if _, ok := v.hmacKeys[jwk.Kid]; ok {
+				return fmt.Errorf("duplicate HMAC key with kid %q in %s", jwk.Kid, path)
+			}
+			key, err := base64.RawURLEncoding.DecodeString(jwk.K)
+			if err != nil {
+				return fmt.Errorf("invalid key %d in %s: %w", i, path, err)
+			}
+			if len(key) < minHMACKeySize {
+				return fmt.Errorf("invalid key %d in %s: HMAC key must be at least %d bytes", i, path, minHMACKeySize)
+			}
+			v.hmacKeys[jwk.Kid] = key
+		}
+	}
+	return nil
+}
+
+// rsaPublicKey decodes the base64url modulus and exponent of an RSA JWK
+func rsaPublicKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid modulus or exponent")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+// Verify checks a token and returns the principal it identifies. Tokens without a role claim
+// are treated as customer tokens.
+func (v *Verifier) Verify(token string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.key); err != nil {
+		return nil, err
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil {
+		return nil, err
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("%w: sub claim is required", jwt.ErrTokenRequiredClaimMissing)
+	}
+	role, _ := claims[roleClaim].(string)
+	if role == "" {
+		role = RoleCustomer
+	}
+
+	return &Principal{
+		Subject: subject,
+		Role:    role,
+		Claims:  claims,
+	}, nil
+}
+
+// key picks the key for a token by its algorithm and kid. A kid that matches no JWKS key falls
+// back to the key loaded from a key file.
+func (v *Verifier) key(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return pickKey(v.hmacKeys, kid)
+	case jwt.SigningMethodRS256.Alg():
+		return pickKey(v.rsaKeys, kid)
+	default:
+		return nil, fmt.Errorf("unsupported signing method %s", token.Method.Alg())
+	}
+}
+
+func pickKey[K any](keys map[string]K, kid string) (K, error) {
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	if key, ok := keys[""]; ok {
+		return key, nil
+	}
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+	var zero K
+	return zero, fmt.Errorf("no key for kid %q", kid)
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+// sign issues a token for the claims, adding a kid header when one is given
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+// validClaims returns the claims of a customer token that expires in an hour
+func validClaims(subject string) jwt.MapClaims {
+	return jwt.MapClaims{"sub": subject, "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+func TestVerifier(t *testing.T) {
+	hmacKey := []byte("0123456789abcdef0123456789abcdef")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	t.Run("HS256 token from a key file", func(t *testing.T) {
+		verifier, err := NewVerifier(Config{HMACKeyFile: writeFile(t, "hmac.key", append(hmacKey, '\n'))})
+		require.NoError(t, err)
+
+		claims := validClaims("customer-1")
+		claims["email"] = "a@example.com"
+		principal, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, hmacKey, "", claims))
+		require.NoError(t, err)
+		assert.Equal(t, "customer-1", principal.Subject)
+		assert.Equal(t, RoleCustomer, principal.Role)
+		assert.Equal(t, "a@example.com", principal.Claims["email"])
+		assert.False(t, principal.IsAdmin())
+		assert.True(t, principal.CanActFor("customer-1"))
+		assert.False(t, principal.CanActFor("customer-2"))
+	})
+
+	t.Run("RS256 token from a PEM file", func(t *testing.T) {
+		verifier, err := NewVerifier(Config{RSAPublicKeyFile: writeFile(t, "public.pem", publicPEM)})
+		require.NoError(t, err)
+
+		claims := validClaims("staff-1")
+		claims["role"] = RoleAdmin
+		principal, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, "", claims))
+		require.NoError(t, err)
+		assert.True(t, principal.IsAdmin())
+		assert.True(t, principal.CanActFor("customer-2"))
+	})
+
+	t.Run("JWKS keys are chosen by kid", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		jwk := func(kid string, key *rsa.PublicKey) map[string]string {
+			return map[string]string{
+				"kty": "RSA", "kid": kid, "use": "sig", "alg": "RS256",
+				"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}
+		}
+		set, err := json.Marshal(map[string]any{"keys": []any{
+			jwk("rsa-1", &rsaKey.PublicKey),
+			jwk("rsa-2", &otherKey.PublicKey),
+			map[string]string{"kty": "oct", "kid": "hmac-1", "k": base64.RawURLEncoding.EncodeToString(hmacKey)},
+			map[string]string{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": "AA", "y": "AA"},
+		}})
+		require.NoError(t, err)
+		verifier, err := NewVerifier(Config{JWKSFile: writeFile(t, "jwks.json", set)})
+		require.NoError(t, err)
+
+		for _, token := range []string{
+			sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", validClaims("customer-1")),
+			sign(t, jwt.SigningMethodRS256, otherKey, "rsa-2", validClaims("customer-1")),
+			sign(t, jwt.SigningMethodHS256, hmacKey, "hmac-1", validClaims("customer-1")),
+		} {
+			_, err := verifier.Verify(token)
+			assert.NoError(t, err)
+		}
+
+		_, err = verifier.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-2", validClaims("customer-1")))
+		assert.Error(t, err, "signed with another key than the kid names")
+		_, err = verifier.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, "", validClaims("customer-1")))
+		assert.Error(t, err, "no kid with several RSA keys")
+	})
+
+	t.Run("invalid tokens are rejected", func(t *testing.T) {
+		verifier, err := NewVerifier(Config{
+			RSAPublicKeyFile: writeFile(t, "public.pem", publicPEM),
+			Issuer:           "https://issuer.example.com",
+			Audience:         "shop-api",
+		})
+		require.NoError(t, err)
+
+		claims := func(change func(jwt.MapClaims)) jwt.MapClaims {
+			c := validClaims("customer-1")
+			c["iss"] = "https://issuer.example.com"
+			c["aud"] = "shop-api"
+			change(c)
+			return c
+		}
+		_, err = verifier.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, "", claims(func(jwt.MapClaims) {})))
+		require.NoError(t, err)
+
+		tests := []struct {
+			name  string
+			token string
+		}{
+			{"expired", sign(t, jwt.SigningMethodRS256, rsaKey, "", claims(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }))},
+			{"no expiry", sign(t, jwt.SigningMethodRS256, rsaKey, "", claims(func(c jwt.MapClaims) { delete(c, "exp") }))},
+			{"no subject", sign(t, jwt.SigningMethodRS256, rsaKey, "", claims(func(c jwt.MapClaims) { delete(c, "sub") }))},
+			{"other issuer", sign(t, jwt.SigningMethodRS256, rsaKey, "", claims(func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }))},
+			{"other audience", sign(t, jwt.SigningMethodRS256, rsaKey, "", claims(func(c jwt.MapClaims) { c["aud"] = "other-api" }))},
+			// HS256 signed with the public key must not pass as RS256
+			{"algorithm confusion", sign(t, jwt.SigningMethodHS256, publicPEM, "", claims(func(jwt.MapClaims) {}))},
+			{"unsigned", sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", claims(func(jwt.MapClaims) {}))},
+			{"garbage", "not-a-token"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := verifier.Verify(tt.token)
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("key configuration errors", func(t *testing.T) {
+		_, err := NewVerifier(Config{})
+		assert.ErrorIs(t, err, ErrNoKey)
+
+		_, err = NewVerifier(Config{HMACKeyFile: writeFile(t, "short.key", []byte("short"))})
+		assert.ErrorContains(t, err, "at least 32 bytes")
+
+		_, err = NewVerifier(Config{RSAPublicKeyFile: writeFile(t, "bad.pem", []byte("not a key"))})
+		assert.ErrorContains(t, err, "invalid RSA public key")
+
+		_, err = NewVerifier(Config{JWKSFile: writeFile(t, "empty.json", []byte(`{"keys":[]}`))})
+		assert.ErrorIs(t, err, ErrNoKey)
+
+		_, err = NewVerifier(Config{JWKSFile: filepath.Join(t.TempDir(), "missing.json")})
+		assert.ErrorContains(t, err, "failed to read JWKS")
+	})
+}
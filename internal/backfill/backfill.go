@@ -0,0 +1,357 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+	"golang.org/x/time/rate"
+
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// Backfill rewrites the existing items of one Type, e.g. to add the attributes of a new GSI
+// to items written before it existed
+type Backfill struct {
+	Name        string // snake_case, e.g. "order_line_gsi1_keys"
+	Type        string // Type attribute of the items to rewrite, e.g. "ORDER_LINE"
+	Description string
+	Transform   TransformFunc
+}
+
+// TransformFunc returns what should be written for one scanned item. It must not modify item;
+// rewrite a Clone instead. Returning a zero Change leaves the item as it is, so a transform
+// has to recognize items it has already rewritten.
+type TransformFunc func(ctx context.Context, table dynamo.Table, item dynamo.Item) (Change, error)
+
+// Change is what a transform writes for one item
+type Change struct {
+	Item dynamo.Item   // Replacement for the scanned item with the same PK and SK, nil to keep it
+	Puts []dynamo.Item // New items written in the same transaction, e.g. split out of the item
+}
+
+// Clone returns a copy of an item that can be changed without touching the original
+func Clone(item dynamo.Item) dynamo.Item {
+	clone := make(dynamo.Item, len(item))
+	for name, value := range item {
+		clone[name] = value
+	}
+	return clone
+}
+
+// Options control how a backfill runs
+type Options struct {
+	Segments int     // Parallel scan segments, 4 when zero
+	PageSize int     // Items evaluated per Scan request, 100 when zero
+	Rate     float64 // Items per second across all segments, unlimited when zero
+	DryRun   bool    // Report diffs without writing items or checkpoints
+	Reset    bool    // Discard the checkpoints of an earlier run and start over
+}
+
+// Report counts what a run did. Items skipped because they changed after being scanned are
+// conflicts; the writer that changed them is expected to have written the new form.
+type Report struct {
+	Scanned   int
+	Changed   int
+	Conflicts int
+	Diffs     []Diff // Dry runs only
+}
+
+// Runner runs backfills against the table of a DynamoDB client
+type Runner struct {
+	client    *infrastructure.DynamoDBClient
+	backfills []Backfill
+}
+
+// NewRunner creates a runner for the given backfills
+func NewRunner(client *infrastructure.DynamoDBClient, backfills []Backfill) (*Runner, error) {
+	sorted := make([]Backfill, len(backfills))
+	copy(sorted, backfills)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for i, b := range sorted {
+		if b.Name == "" || b.Type == "" || b.Transform == nil {
+			return nil, fmt.Errorf("backfill %q: name, type and transform are required", b.Name)
+		}
+		if i > 0 && sorted[i-1].Name == b.Name {
+			return nil, fmt.Errorf("duplicate backfill name: %s", b.Name)
+		}
+	}
+
+	return &Runner{client: client, backfills: sorted}, nil
+}
+
+// Backfills returns the registered backfills sorted by name
+func (r *Runner) Backfills() []Backfill {
+	return r.backfills
+}
+
+// Run scans the items of the backfill's Type with a parallel Scan and writes each change with
+// a condition that the item is still as scanned. Unless it is a dry run, every segment saves a
+// checkpoint after each page, so running the same backfill again resumes where it stopped.
+func (r *Runner) Run(ctx context.Context, name string, opts Options) (*Report, error) {
+	// 1. バックフィルとオプションの確認
+	var backfill *Backfill
+	for i := range r.backfills {
+		if r.backfills[i].Name == name {
+			backfill = &r.backfills[i]
+		}
+	}
+	if backfill == nil {
+		return nil, fmt.Errorf("unknown backfill: %s", name)
+	}
+	if opts.Segments <= 0 {
+		opts.Segments = 4
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = 100
+	}
+	limit := rate.Inf
+	if opts.Rate > 0 {
+		limit = rate.Limit(opts.Rate)
+	}
+
+	// 2. チェックポイントの読み込み（ドライランでは使わない）
+	checkpoints := newCheckpointStore(r.client.GetTable(), name)
+	resume := make(map[int]*checkpoint)
+	if !opts.DryRun {
+		if opts.Reset {
+			if err := checkpoints.reset(ctx); err != nil {
+				return nil, err
+			}
+		}
+		saved, err := checkpoints.load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, cp := range saved {
+			if cp.TotalSegments != opts.Segments {
+				return nil, fmt.Errorf("backfill %s was started with %d segments, resume with the same number or reset it", name, cp.TotalSegments)
+			}
+			resume[cp.Segment] = cp
+		}
+	}
+
+	// 3. セグメントごとに並列スキャン（最初のエラーで全セグメントを止める）
+	run := &run{
+		runner:      r,
+		backfill:    backfill,
+		opts:        opts,
+		limiter:     rate.NewLimiter(limit, 1),
+		checkpoints: checkpoints,
+		report:      &Report{},
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, opts.Segments)
+	for segment := 0; segment < opts.Segments; segment++ {
+		cp := resume[segment]
+		if cp == nil {
+			cp = &checkpoint{Segment: segment, TotalSegments: opts.Segments}
+		}
+		if cp.Done {
+			continue
+		}
+
+		wg.Add(1)
+		go func(cp *checkpoint) {
+			defer wg.Done()
+			if err := run.segment(ctx, cp); err != nil {
+				errs[cp.Segment] = fmt.Errorf("segment %d: %w", cp.Segment, err)
+				cancel()
+			}
+		}(cp)
+	}
+	wg.Wait()
+
+	slog.Info("Backfill finished", "backfill", name, "dryRun", opts.DryRun,
+		"scanned", run.report.Scanned, "changed", run.report.Changed, "conflicts", run.report.Conflicts)
+	if err := errors.Join(errs...); err != nil {
+		return run.report, fmt.Errorf("backfill %s failed: %w", name, err)
+	}
+	return run.report, nil
+}
+
+// run is the state shared by the segments of one Run
+type run struct {
+	runner      *Runner
+	backfill    *Backfill
+	opts        Options
+	limiter     *rate.Limiter
+	checkpoints *checkpointStore
+
+	mu     sync.Mutex
+	report *Report
+}
+
+// segment scans one segment page by page from its checkpoint
+func (r *run) segment(ctx context.Context, cp *checkpoint) error {
+	table := r.runner.client.GetTable()
+	for {
+		scan := table.Scan().
+			Filter("'Type' = ?", r.backfill.Type).
+			Segment(cp.Segment, cp.TotalSegments).
+			SearchLimit(r.opts.PageSize)
+		if len(cp.LastKey) > 0 {
+			scan = scan.StartFrom(pagingKey(cp.LastKey))
+		}
+
+		var items []dynamo.Item
+		lek, err := scan.AllWithLastEvaluatedKey(ctx, &items)
+		if err != nil {
+			return fmt.Errorf("failed to scan: %w", err)
+		}
+
+		for _, item := range items {
+			if err := r.limiter.Wait(ctx); err != nil {
+				return err
+			}
+			outcome, err := r.item(ctx, item)
+			if err != nil {
+				return err
+			}
+			cp.count(outcome)
+			r.mu.Lock()
+			r.report.count(outcome)
+			r.mu.Unlock()
+		}
+
+		cp.Done = lek == nil
+		if cp.LastKey, err = checkpointKey(lek); err != nil {
+			return err
+		}
+		if !r.opts.DryRun {
+			if err := r.checkpoints.save(ctx, cp); err != nil {
+				return err
+			}
+		}
+		if cp.Done {
+			return nil
+		}
+	}
+}
+
+// outcome is what happened to one scanned item
+type outcome int
+
+const (
+	unchanged outcome = iota
+	changed
+	conflict
+)
+
+func (r *Report) count(o outcome) {
+	r.Scanned++
+	switch o {
+	case changed:
+		r.Changed++
+	case conflict:
+		r.Conflicts++
+	}
+}
+
+// item transforms one scanned item and writes the change
+func (r *run) item(ctx context.Context, item dynamo.Item) (outcome, error) {
+	change, err := r.backfill.Transform(ctx, r.runner.client.GetTable(), item)
+	if err != nil {
+		return unchanged, fmt.Errorf("failed to transform %s: %w", describeKey(item), err)
+	}
+	if change.Item != nil && !sameKey(item, change.Item) {
+		return unchanged, fmt.Errorf("transform changed the key of %s", describeKey(item))
+	}
+
+	diff := diffChange(item, change)
+	if diff.empty() {
+		return unchanged, nil
+	}
+
+	if r.opts.DryRun {
+		r.mu.Lock()
+		r.report.Diffs = append(r.report.Diffs, diff)
+		r.mu.Unlock()
+		return changed, nil
+	}
+
+	err = r.write(ctx, item, change)
+	if dynamo.IsCondCheckFailed(err) || isTxConditionFailed(err) {
+		slog.Warn("Item changed during backfill, skipping", "backfill", r.backfill.Name, "key", describeKey(item))
+		return conflict, nil
+	}
+	if err != nil {
+		return unchanged, fmt.Errorf("failed to write %s: %w", describeKey(item), err)
+	}
+	return changed, nil
+}
+
+// write puts the change on condition that the scanned item has not changed since and that
+// the new items do not exist yet
+func (r *run) write(ctx context.Context, original dynamo.Item, change Change) error {
+	table := r.runner.client.GetTable()
+	item := change.Item
+	if item == nil {
+		item = original
+	}
+	expr, args := unchangedCondition(original)
+	put := table.Put(item).If(expr, args...)
+	if len(change.Puts) == 0 {
+		return put.Run(ctx)
+	}
+
+	tx := r.runner.client.DB.WriteTx()
+	tx.Put(put)
+	for _, newItem := range change.Puts {
+		tx.Put(table.Put(newItem).If("attribute_not_exists(PK)"))
+	}
+	return tx.Run(ctx)
+}
+
+// unchangedCondition returns a condition that every attribute of an item still has the value
+// it was scanned with. Writes by the application bump Version, so a concurrent update to a
+// versioned item always fails it.
+func unchangedCondition(item dynamo.Item) (string, []any) {
+	names := make([]string, 0, len(item))
+	for name := range item {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	clauses := make([]string, 0, len(names))
+	args := make([]any, 0, len(names)*2)
+	for _, name := range names {
+		clauses = append(clauses, "$ = ?")
+		args = append(args, name, item[name])
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+// isTxConditionFailed reports whether a transaction was cancelled by a failed condition
+func isTxConditionFailed(err error) bool {
+	var txErr *types.TransactionCanceledException
+	if !errors.As(err, &txErr) {
+		return false
+	}
+	for _, reason := range txErr.CancellationReasons {
+		if reason.Code != nil && *reason.Code == "ConditionalCheckFailed" {
+			return true
+		}
+	}
+	return false
+}
+
+// sameKey reports whether two items have the same PK and SK
+func sameKey(a, b dynamo.Item) bool {
+	return formatValue(a["PK"]) == formatValue(b["PK"]) && formatValue(a["SK"]) == formatValue(b["SK"])
+}
+
+// describeKey formats the key of an item for logs and errors
+func describeKey(item dynamo.Item) string {
+	return fmt.Sprintf("PK=%s SK=%s", formatValue(item["PK"]), formatValue(item["SK"]))
+}
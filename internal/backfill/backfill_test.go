@@ -0,0 +1,313 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+func s(value string) types.AttributeValue {
+	return &types.AttributeValueMemberS{Value: value}
+}
+
+func TestNewRunner(t *testing.T) {
+	noop := func(ctx context.Context, table dynamo.Table, item dynamo.Item) (Change, error) {
+		return Change{}, nil
+	}
+
+	_, err := NewRunner(nil, All())
+	assert.NoError(t, err)
+
+	_, err = NewRunner(nil, []Backfill{{Name: "a", Type: "X", Transform: noop}, {Name: "a", Type: "Y", Transform: noop}})
+	assert.ErrorContains(t, err, "duplicate backfill name: a")
+
+	_, err = NewRunner(nil, []Backfill{{Name: "a", Transform: noop}})
+	assert.ErrorContains(t, err, "name, type and transform are required")
+}
+
+func TestDiffChange(t *testing.T) {
+	original := dynamo.Item{
+		"PK":    s("ORDER#1"),
+		"SK":    s("ORDER#1"),
+		"Items": s(`[{"productId":"p-1"}]`),
+		"Total": &types.AttributeValueMemberN{Value: "100"},
+	}
+
+	t.Run("added, changed and removed attributes", func(t *testing.T) {
+		updated := Clone(original)
+		delete(updated, "Items")
+		updated["Total"] = &types.AttributeValueMemberN{Value: "200"}
+		updated["GSI1PK"] = s("PRODUCT#p-1")
+
+		diff := diffChange(original, Change{Item: updated, Puts: []dynamo.Item{{"PK": s("ORDER#1"), "SK": s("LINE#p-1")}}})
+		assert.Equal(t, `PK="ORDER#1" SK="ORDER#1"`, diff.Key)
+		assert.Equal(t, []AttributeChange{
+			{Name: "GSI1PK", New: `"PRODUCT#p-1"`},
+			{Name: "Items", Old: `"[{\"productId\":\"p-1\"}]"`},
+			{Name: "Total", Old: "100", New: "200"},
+		}, diff.Changes)
+		assert.Equal(t, []string{`PK="ORDER#1" SK="LINE#p-1"`}, diff.Puts)
+		assert.Contains(t, diff.String(), "  - Total: 100\n  + Total: 200\n")
+		assert.Contains(t, diff.String(), `  + new item PK="ORDER#1" SK="LINE#p-1"`)
+
+		// Clone left the original alone
+		assert.Contains(t, original, "Items")
+	})
+
+	t.Run("an identical item is no change", func(t *testing.T) {
+		assert.True(t, diffChange(original, Change{Item: Clone(original)}).empty())
+		assert.True(t, diffChange(original, Change{}).empty())
+	})
+}
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "", formatValue(nil))
+	assert.Equal(t, "true", formatValue(&types.AttributeValueMemberBOOL{Value: true}))
+	assert.Equal(t, "null", formatValue(&types.AttributeValueMemberNULL{Value: true}))
+	assert.Equal(t, `<<"a", "b">>`, formatValue(&types.AttributeValueMemberSS{Value: []string{"b", "a"}}))
+	assert.Equal(t, `[1, "x"]`, formatValue(&types.AttributeValueMemberL{Value: []types.AttributeValue{
+		&types.AttributeValueMemberN{Value: "1"}, s("x"),
+	}}))
+	assert.Equal(t, `{a: "1", b: "2"}`, formatValue(&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"b": s("2"), "a": s("1"),
+	}}))
+}
+
+func TestUnchangedCondition(t *testing.T) {
+	expr, args := unchangedCondition(dynamo.Item{"SK": s("B"), "PK": s("A")})
+	assert.Equal(t, "$ = ? AND $ = ?", expr)
+	assert.Equal(t, []any{"PK", s("A"), "SK", s("B")}, args)
+}
+
+func TestCheckpointKey(t *testing.T) {
+	key, err := checkpointKey(dynamo.PagingKey{"PK": s("ORDER#1"), "SK": s("LINE#p-1")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"PK": "ORDER#1", "SK": "LINE#p-1"}, key)
+	assert.Equal(t, dynamo.PagingKey{"PK": s("ORDER#1"), "SK": s("LINE#p-1")}, pagingKey(key))
+
+	key, err = checkpointKey(nil)
+	require.NoError(t, err)
+	assert.Nil(t, key)
+
+	_, err = checkpointKey(dynamo.PagingKey{"PK": &types.AttributeValueMemberN{Value: "1"}})
+	assert.ErrorContains(t, err, "unsupported key attribute PK")
+}
+
+func TestOrderLinesFromJSON(t *testing.T) {
+	header, err := dynamo.MarshalItem(repository.OrderItem{
+		PK:        "ORDER#legacy-order",
+		SK:        "ORDER#legacy-order",
+		Type:      "ORDER",
+		ID:        "legacy-order",
+		Items:     `[{"productId":"product-1","quantity":2,"unitPrice":1299}]`,
+		Status:    "pending",
+		CreatedAt: time.Date(2020, 6, 21, 12, 0, 0, 0, time.UTC),
+		Version:   3,
+	})
+	require.NoError(t, err)
+
+	change, err := orderLinesFromJSON(context.Background(), dynamo.Table{}, header)
+	require.NoError(t, err)
+	assert.NotContains(t, change.Item, "Items")
+	assert.Equal(t, header["Version"], change.Item["Version"])
+	require.Len(t, change.Puts, 1)
+
+	var line repository.OrderLineItem
+	require.NoError(t, dynamo.UnmarshalItem(change.Puts[0], &line))
+	assert.Equal(t, "LINE#product-1", line.SK)
+	assert.Equal(t, "ORDER#2020-06-21T12:00:00Z#legacy-order", line.GSI1SK)
+	assert.Equal(t, 2, line.Quantity)
+
+	// Converted orders are left alone
+	change, err = orderLinesFromJSON(context.Background(), dynamo.Table{}, change.Item)
+	require.NoError(t, err)
+	assert.Nil(t, change.Item)
+	assert.Empty(t, change.Puts)
+}
+
+func TestDynamoBackfill(t *testing.T) {
+	// Skip if not running integration tests
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+
+	// Backfill a throwaway table so the shared one is left alone
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	dynamoCfg := cfg.DynamoDB.Infrastructure()
+	dynamoCfg.TableName = fmt.Sprintf("BackfillTest-%d", time.Now().UnixNano())
+	client, err := infrastructure.NewDynamoDBClient(ctx, dynamoCfg)
+	require.NoError(t, err)
+	require.NoError(t, client.DB.CreateTable(dynamoCfg.TableName, struct {
+		PK string `dynamo:"PK,hash"`
+		SK string `dynamo:"SK,range"`
+	}{}).OnDemand(true).Wait(ctx))
+	t.Cleanup(func() {
+		_ = client.GetTable().DeleteTable().Run(context.Background())
+	})
+
+	// Orders with lines written before GSI1 keys existed
+	table := client.GetTable()
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < 25; i++ {
+		orderID := fmt.Sprintf("order-%02d", i)
+		pk := "ORDER#" + orderID
+		require.NoError(t, table.Put(repository.OrderItem{PK: pk, SK: pk, Type: "ORDER", ID: orderID, CreatedAt: createdAt}).Run(ctx))
+		require.NoError(t, table.Put(struct {
+			PK, SK, Type, OrderID, ProductID string
+		}{pk, "LINE#product-1", "ORDER_LINE", orderID, "product-1"}).Run(ctx))
+	}
+
+	runner, err := NewRunner(client, All())
+	require.NoError(t, err)
+	opts := Options{Segments: 3, PageSize: 5, Rate: 1000}
+
+	t.Run("dry run reports diffs without writing", func(t *testing.T) {
+		dryRun := opts
+		dryRun.DryRun = true
+		report, err := runner.Run(ctx, "order_line_gsi1_keys", dryRun)
+		require.NoError(t, err)
+		assert.Equal(t, 25, report.Scanned)
+		assert.Equal(t, 25, report.Changed)
+		require.Len(t, report.Diffs, 25)
+		assert.Contains(t, report.Diffs[0].String(), `+ GSI1SK: "ORDER#2024-01-02T03:04:05Z#order-`)
+
+		var line repository.OrderLineItem
+		require.NoError(t, table.Get("PK", "ORDER#order-00").Range("SK", dynamo.Equal, "LINE#product-1").One(ctx, &line))
+		assert.Empty(t, line.GSI1PK)
+	})
+
+	t.Run("run rewrites items and completes its checkpoints", func(t *testing.T) {
+		report, err := runner.Run(ctx, "order_line_gsi1_keys", opts)
+		require.NoError(t, err)
+		assert.Equal(t, 25, report.Changed)
+
+		var line repository.OrderLineItem
+		require.NoError(t, table.Get("PK", "ORDER#order-07").Range("SK", dynamo.Equal, "LINE#product-1").One(ctx, &line))
+		assert.Equal(t, "PRODUCT#product-1", line.GSI1PK)
+		assert.Equal(t, "ORDER#2024-01-02T03:04:05Z#order-07", line.GSI1SK)
+
+		checkpoints, err := newCheckpointStore(table, "order_line_gsi1_keys").load(ctx)
+		require.NoError(t, err)
+		require.Len(t, checkpoints, 3)
+		for _, cp := range checkpoints {
+			assert.True(t, cp.Done)
+		}
+
+		// Finished segments are not scanned again
+		report, err = runner.Run(ctx, "order_line_gsi1_keys", opts)
+		require.NoError(t, err)
+		assert.Zero(t, report.Scanned)
+
+		_, err = runner.Run(ctx, "order_line_gsi1_keys", Options{Segments: 2})
+		assert.ErrorContains(t, err, "started with 3 segments")
+	})
+
+	t.Run("reset scans again and finds nothing to change", func(t *testing.T) {
+		reset := opts
+		reset.Reset = true
+		report, err := runner.Run(ctx, "order_line_gsi1_keys", reset)
+		require.NoError(t, err)
+		assert.Equal(t, 25, report.Scanned)
+		assert.Zero(t, report.Changed)
+	})
+
+	t.Run("email sentinels are written for customers without one", func(t *testing.T) {
+		customer := func(id, email string) repository.CustomerItem {
+			pk := "CUSTOMER#" + id
+			item := repository.CustomerItem{PK: pk, SK: pk, Type: "CUSTOMER", ID: id, Email: email, Version: 1}
+			require.NoError(t, table.Put(item).Run(ctx))
+			return item
+		}
+		legacy := customer("customer-legacy", "legacy@example.com")
+		saved := customer("customer-saved", "saved@example.com")
+		require.NoError(t, table.Put(saved.EmailSentinel()).Run(ctx))
+		// 導入前に同じアドレスで登録された 2 人目は、センチネルを奪わない
+		customer("customer-duplicate", "saved@example.com")
+
+		report, err := runner.Run(ctx, "email_sentinels", opts)
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Scanned)
+		assert.Equal(t, 1, report.Changed)
+
+		var sentinel repository.EmailSentinelItem
+		require.NoError(t, table.Get("PK", "EMAIL#legacy@example.com").Range("SK", dynamo.Equal, "EMAIL#legacy@example.com").One(ctx, &sentinel))
+		assert.Equal(t, legacy.ID, sentinel.CustomerID)
+		require.NoError(t, table.Get("PK", "EMAIL#saved@example.com").Range("SK", dynamo.Equal, "EMAIL#saved@example.com").One(ctx, &sentinel))
+		assert.Equal(t, saved.ID, sentinel.CustomerID)
+	})
+
+	t.Run("product stock that no warehouse holds moves to the default warehouse", func(t *testing.T) {
+		product := func(id string, stock int, held map[string]int) {
+			pk := "PRODUCT#" + id
+			require.NoError(t, table.Put(repository.ProductItem{PK: pk, SK: pk, Type: "PRODUCT", ID: id, Stock: stock, Version: 1}).Run(ctx))
+			for warehouseID, quantity := range held {
+				require.NoError(t, table.Put(repository.InventoryItem{
+					PK: pk, SK: "WAREHOUSE#" + warehouseID, Type: "INVENTORY",
+					ProductID: id, WarehouseID: warehouseID, Quantity: quantity, Version: 1,
+				}).Run(ctx))
+			}
+		}
+		product("product-legacy", 10, nil)
+		// 倉庫在庫の登録で古い在庫合計に差分が加算された商品
+		product("product-mixed", 15, map[string]int{"warehouse-1": 5})
+		product("product-current", 5, map[string]int{"warehouse-1": 5})
+
+		report, err := runner.Run(ctx, "product_stock_to_inventory", opts)
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Scanned)
+		assert.Equal(t, 2, report.Changed)
+
+		for id, quantity := range map[string]int{"product-legacy": 10, "product-mixed": 10} {
+			var inventory repository.InventoryItem
+			require.NoError(t, table.Get("PK", "PRODUCT#"+id).Range("SK", dynamo.Equal, "WAREHOUSE#"+LegacyStockWarehouseID).One(ctx, &inventory))
+			assert.Equal(t, quantity, inventory.Quantity, id)
+			assert.Equal(t, "WAREHOUSE#"+LegacyStockWarehouseID, inventory.GSI2PK)
+		}
+		err = table.Get("PK", "PRODUCT#product-current").Range("SK", dynamo.Equal, "WAREHOUSE#"+LegacyStockWarehouseID).One(ctx, &repository.InventoryItem{})
+		assert.ErrorIs(t, err, dynamo.ErrNotFound)
+	})
+
+	t.Run("items changed after the scan are conflicts", func(t *testing.T) {
+		concurrent := Backfill{
+			Name: "concurrent_update",
+			Type: "ORDER",
+			Transform: func(ctx context.Context, table dynamo.Table, item dynamo.Item) (Change, error) {
+				// Another writer updates the order between the scan and the write
+				var order repository.OrderItem
+				if err := dynamo.UnmarshalItem(item, &order); err != nil {
+					return Change{}, err
+				}
+				if err := table.Update("PK", order.PK).Range("SK", order.SK).Set("Version", order.Version+1).Run(ctx); err != nil {
+					return Change{}, err
+				}
+				change := Change{Item: Clone(item)}
+				change.Item["Status"] = s("overwritten")
+				return change, nil
+			},
+		}
+		runner, err := NewRunner(client, []Backfill{concurrent})
+		require.NoError(t, err)
+
+		report, err := runner.Run(ctx, "concurrent_update", opts)
+		require.NoError(t, err)
+		assert.Equal(t, 25, report.Conflicts)
+		assert.Zero(t, report.Changed)
+
+		var order repository.OrderItem
+		require.NoError(t, table.Get("PK", "ORDER#order-00").Range("SK", dynamo.Equal, "ORDER#order-00").One(ctx, &order))
+		assert.NotEqual(t, "overwritten", order.Status)
+	})
+}
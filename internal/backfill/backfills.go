@@ -0,0 +1,196 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// LegacyStockWarehouseID is the warehouse that takes over the stock products had before
+// warehouses existed. The migration that runs product_stock_to_inventory creates it.
+const LegacyStockWarehouseID = "default"
+
+// All returns every registered backfill
+func All() []Backfill {
+	return []Backfill{
+		{
+			Name:        "order_lines_from_json",
+			Type:        "ORDER",
+			Description: "Move order lines from the JSON Items attribute of order headers to LINE# items",
+			Transform:   orderLinesFromJSON,
+		},
+		{
+			Name:        "order_line_gsi1_keys",
+			Type:        "ORDER_LINE",
+			Description: "Add GSI1 keys to order lines written without them, for orders by product",
+			Transform:   orderLineGSI1Keys,
+		},
+		{
+			Name:        "email_sentinels",
+			Type:        "CUSTOMER",
+			Description: "Reserve the email address of customers saved before EMAIL# sentinels existed",
+			Transform:   emailSentinels,
+		},
+		{
+			Name:        "product_stock_to_inventory",
+			Type:        "PRODUCT",
+			Description: "Move product stock that no warehouse holds into the inventory of the default warehouse",
+			Transform:   productStockToInventory,
+		},
+	}
+}
+
+// orderLinesFromJSON splits the legacy Items attribute of an order header into LINE# items
+// and removes it from the header in the same transaction
+func orderLinesFromJSON(ctx context.Context, table dynamo.Table, item dynamo.Item) (Change, error) {
+	if _, ok := item["Items"]; !ok {
+		return Change{}, nil
+	}
+
+	var header repository.OrderItem
+	if err := dynamo.UnmarshalItem(item, &header); err != nil {
+		return Change{}, fmt.Errorf("failed to decode order: %w", err)
+	}
+	lines, err := header.LegacyLines()
+	if err != nil {
+		return Change{}, err
+	}
+
+	change := Change{Item: Clone(item)}
+	delete(change.Item, "Items")
+	for _, line := range lines {
+		put, err := dynamo.MarshalItem(line)
+		if err != nil {
+			return Change{}, fmt.Errorf("failed to encode order line: %w", err)
+		}
+		change.Puts = append(change.Puts, put)
+	}
+	return change, nil
+}
+
+// orderLineGSI1Keys sets the GSI1 keys of an order line from the creation time of its order
+func orderLineGSI1Keys(ctx context.Context, table dynamo.Table, item dynamo.Item) (Change, error) {
+	if key, ok := item["GSI1PK"].(*types.AttributeValueMemberS); ok && key.Value != "" {
+		return Change{}, nil
+	}
+
+	var line repository.OrderLineItem
+	if err := dynamo.UnmarshalItem(item, &line); err != nil {
+		return Change{}, fmt.Errorf("failed to decode order line: %w", err)
+	}
+	// 明細だけ残った注文はキーを決められないので、そのままにしておく
+	var header repository.OrderItem
+	err := table.Get("PK", line.PK).Range("SK", dynamo.Equal, line.PK).One(ctx, &header)
+	if errors.Is(err, dynamo.ErrNotFound) {
+		slog.Warn("Order line without an order, skipping", "orderID", line.OrderID, "productID", line.ProductID)
+		return Change{}, nil
+	}
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to read order %s: %w", line.OrderID, err)
+	}
+	line.SetGSI1Keys(header.CreatedAt)
+
+	keys, err := dynamo.MarshalItem(struct {
+		GSI1PK string `dynamo:"GSI1PK"`
+		GSI1SK string `dynamo:"GSI1SK"`
+	}{line.GSI1PK, line.GSI1SK})
+	if err != nil {
+		return Change{}, err
+	}
+
+	change := Change{Item: Clone(item)}
+	for name, value := range keys {
+		change.Item[name] = value
+	}
+	return change, nil
+}
+
+// emailSentinels writes the EMAIL# sentinel of a customer that has none. The runner puts it only
+// if it still does not exist and the customer is unchanged, so a concurrent Save wins.
+func emailSentinels(ctx context.Context, table dynamo.Table, item dynamo.Item) (Change, error) {
+	var customer repository.CustomerItem
+	if err := dynamo.UnmarshalItem(item, &customer); err != nil {
+		return Change{}, fmt.Errorf("failed to decode customer: %w", err)
+	}
+	sentinel := customer.EmailSentinel()
+
+	var existing repository.EmailSentinelItem
+	err := table.Get("PK", sentinel.PK).Range("SK", dynamo.Equal, sentinel.SK).Consistent(true).One(ctx, &existing)
+	switch {
+	case errors.Is(err, dynamo.ErrNotFound):
+	case err != nil:
+		return Change{}, fmt.Errorf("failed to read email sentinel of customer %s: %w", customer.ID, err)
+	case existing.CustomerID == customer.ID:
+		return Change{}, nil
+	default:
+		// 導入前に同じアドレスで登録された顧客は自動では直せないので、ログに残して手で直す
+		slog.Warn("Email already reserved by another customer, skipping",
+			"customerID", customer.ID, "email", customer.Email, "owner", existing.CustomerID)
+		return Change{}, nil
+	}
+
+	put, err := dynamo.MarshalItem(sentinel)
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to encode email sentinel: %w", err)
+	}
+	return Change{Puts: []dynamo.Item{put}}, nil
+}
+
+// productStockToInventory puts the part of a product's Stock that its warehouse inventories do
+// not account for, i.e. stock set before warehouses existed, into the default warehouse. Orders
+// reserve stock from inventories only, so without it that stock cannot be ordered.
+func productStockToInventory(ctx context.Context, table dynamo.Table, item dynamo.Item) (Change, error) {
+	var product repository.ProductItem
+	if err := dynamo.UnmarshalItem(item, &product); err != nil {
+		return Change{}, fmt.Errorf("failed to decode product: %w", err)
+	}
+
+	var inventory []repository.InventoryItem
+	err := table.Get("PK", product.PK).
+		Range("SK", dynamo.BeginsWith, "WAREHOUSE#").
+		Consistent(true).
+		All(ctx, &inventory)
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to read inventory of product %s: %w", product.ID, err)
+	}
+	unassigned := product.Stock
+	moved := false
+	for _, held := range inventory {
+		unassigned -= held.Quantity
+		moved = moved || held.WarehouseID == LegacyStockWarehouseID
+	}
+	if unassigned == 0 {
+		return Change{}, nil
+	}
+	// 在庫合計が倉庫在庫より少ない、または移動済みなのにずれている商品は自動では直せない
+	if unassigned < 0 || moved {
+		slog.Warn("Product stock does not match its inventory, skipping",
+			"productID", product.ID, "stock", product.Stock, "unassigned", unassigned)
+		return Change{}, nil
+	}
+
+	productID, err := value.NewProductID(product.ID)
+	if err != nil {
+		return Change{}, fmt.Errorf("invalid product ID: %w", err)
+	}
+	held, err := entity.NewInventoryWithState(productID, value.WarehouseID(LegacyStockWarehouseID), unassigned, product.UpdatedAt)
+	if err != nil {
+		return Change{}, err
+	}
+	inventoryItem := repository.InventoryItemFromEntity(held)
+	inventoryItem.Version = 1
+
+	put, err := dynamo.MarshalItem(inventoryItem)
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to encode inventory: %w", err)
+	}
+	return Change{Puts: []dynamo.Item{put}}, nil
+}
@@ -0,0 +1,104 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+)
+
+// checkpoint records how far one scan segment of a backfill has got. Checkpoints of a
+// backfill share its BACKFILL# partition so that a run loads them with a single query.
+type checkpoint struct {
+	PK            string            `dynamo:"PK"`            // BACKFILL#{Name}
+	SK            string            `dynamo:"SK"`            // SEGMENT#{Segment:0000}
+	Type          string            `dynamo:"Type"`          // "BACKFILL_CHECKPOINT"
+	Segment       int               `dynamo:"Segment"`       // Scan segment
+	TotalSegments int               `dynamo:"TotalSegments"` // Segments of the run that wrote it
+	LastKey       map[string]string `dynamo:"LastKey"`       // Key to resume the scan after, empty before the first page
+	Done          bool              `dynamo:"Done"`          // Segment has been scanned to the end
+	Scanned       int               `dynamo:"Scanned"`       // Items scanned so far
+	Changed       int               `dynamo:"Changed"`       // Items rewritten so far
+	Conflicts     int               `dynamo:"Conflicts"`     // Items skipped because they changed after the scan
+	UpdatedAt     time.Time         `dynamo:"UpdatedAt"`     // Last save
+}
+
+func (cp *checkpoint) count(o outcome) {
+	cp.Scanned++
+	switch o {
+	case changed:
+		cp.Changed++
+	case conflict:
+		cp.Conflicts++
+	}
+}
+
+// checkpointStore reads and writes the checkpoints of one backfill
+type checkpointStore struct {
+	table dynamo.Table
+	pk    string
+}
+
+func newCheckpointStore(table dynamo.Table, name string) *checkpointStore {
+	return &checkpointStore{table: table, pk: fmt.Sprintf("BACKFILL#%s", name)}
+}
+
+func (s *checkpointStore) load(ctx context.Context) ([]*checkpoint, error) {
+	var checkpoints []*checkpoint
+	if err := s.table.Get("PK", s.pk).All(ctx, &checkpoints); err != nil {
+		return nil, fmt.Errorf("failed to load checkpoints: %w", err)
+	}
+	return checkpoints, nil
+}
+
+func (s *checkpointStore) save(ctx context.Context, cp *checkpoint) error {
+	cp.PK = s.pk
+	cp.SK = fmt.Sprintf("SEGMENT#%04d", cp.Segment)
+	cp.Type = "BACKFILL_CHECKPOINT"
+	cp.UpdatedAt = time.Now().UTC()
+	if err := s.table.Put(cp).Run(ctx); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (s *checkpointStore) reset(ctx context.Context) error {
+	checkpoints, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+	for _, cp := range checkpoints {
+		if err := s.table.Delete("PK", cp.PK).Range("SK", cp.SK).Run(ctx); err != nil {
+			return fmt.Errorf("failed to delete checkpoint: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkpointKey converts the key a scan stopped at to its checkpoint form. The table's keys
+// are strings, so a map of strings is enough and reads back without custom decoding.
+func checkpointKey(key dynamo.PagingKey) (map[string]string, error) {
+	if len(key) == 0 {
+		return nil, nil
+	}
+	converted := make(map[string]string, len(key))
+	for name, value := range key {
+		s, ok := value.(*types.AttributeValueMemberS)
+		if !ok {
+			return nil, fmt.Errorf("unsupported key attribute %s of type %T", name, value)
+		}
+		converted[name] = s.Value
+	}
+	return converted, nil
+}
+
+// pagingKey converts a checkpoint key back to the key to resume a scan from
+func pagingKey(key map[string]string) dynamo.PagingKey {
+	converted := make(dynamo.PagingKey, len(key))
+	for name, value := range key {
+		converted[name] = &types.AttributeValueMemberS{Value: value}
+	}
+	return converted
+}
@@ -0,0 +1,132 @@
+package backfill
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+)
+
+// Diff is what a dry run would have written for one item
+type Diff struct {
+	Key     string            // PK and SK of the scanned item
+	Changes []AttributeChange // Attributes the transform added, changed or removed
+	Puts    []string          // Keys of the new items
+}
+
+// AttributeChange is one changed attribute. Old is empty for an added attribute and New is
+// empty for a removed one.
+type AttributeChange struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// String formats the diff as lines prefixed with + for additions and - for removals
+func (d Diff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", d.Key)
+	for _, c := range d.Changes {
+		if c.Old != "" {
+			fmt.Fprintf(&b, "  - %s: %s\n", c.Name, c.Old)
+		}
+		if c.New != "" {
+			fmt.Fprintf(&b, "  + %s: %s\n", c.Name, c.New)
+		}
+	}
+	for _, key := range d.Puts {
+		fmt.Fprintf(&b, "  + new item %s\n", key)
+	}
+	return b.String()
+}
+
+func (d Diff) empty() bool {
+	return len(d.Changes) == 0 && len(d.Puts) == 0
+}
+
+// diffChange compares a scanned item with what a transform wants written for it
+func diffChange(original dynamo.Item, change Change) Diff {
+	diff := Diff{Key: describeKey(original)}
+
+	if change.Item != nil {
+		names := make(map[string]bool, len(original)+len(change.Item))
+		for name := range original {
+			names[name] = true
+		}
+		for name := range change.Item {
+			names[name] = true
+		}
+		sorted := make([]string, 0, len(names))
+		for name := range names {
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+
+		for _, name := range sorted {
+			old, updated := formatValue(original[name]), formatValue(change.Item[name])
+			if old != updated {
+				diff.Changes = append(diff.Changes, AttributeChange{Name: name, Old: old, New: updated})
+			}
+		}
+	}
+
+	for _, item := range change.Puts {
+		diff.Puts = append(diff.Puts, describeKey(item))
+	}
+	return diff
+}
+
+// formatValue renders an attribute value for diffs and key descriptions. Sets are sorted so
+// that equal values always render the same. A missing value renders as an empty string.
+func formatValue(value types.AttributeValue) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case *types.AttributeValueMemberS:
+		return fmt.Sprintf("%q", v.Value)
+	case *types.AttributeValueMemberN:
+		return v.Value
+	case *types.AttributeValueMemberBOOL:
+		return fmt.Sprintf("%t", v.Value)
+	case *types.AttributeValueMemberNULL:
+		return "null"
+	case *types.AttributeValueMemberB:
+		return fmt.Sprintf("<%d bytes>", len(v.Value))
+	case *types.AttributeValueMemberSS:
+		return formatSet(v.Value, func(s string) string { return fmt.Sprintf("%q", s) })
+	case *types.AttributeValueMemberNS:
+		return formatSet(v.Value, func(s string) string { return s })
+	case *types.AttributeValueMemberBS:
+		return fmt.Sprintf("<set of %d binaries>", len(v.Value))
+	case *types.AttributeValueMemberL:
+		parts := make([]string, 0, len(v.Value))
+		for _, elem := range v.Value {
+			parts = append(parts, formatValue(elem))
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case *types.AttributeValueMemberM:
+		keys := make([]string, 0, len(v.Value))
+		for k := range v.Value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf("%s: %s", k, formatValue(v.Value[k])))
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func formatSet(values []string, format func(string) string) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, format(value))
+	}
+	sort.Strings(parts)
+	return "<<" + strings.Join(parts, ", ") + ">>"
+}
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"dynamo-modeling/internal/auth"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// Storage backends the server can run on
+const (
+	StorageDynamoDB = "dynamodb"
+	StorageMemory   = "memory"
+)
+
+// Environment variables read by Load
+const (
+	EnvConfigFile       = "CONFIG_FILE"
+	EnvStorage          = "STORAGE"
+	EnvServerAddr       = "SERVER_ADDR"
+	EnvShutdownTimeout  = "SHUTDOWN_TIMEOUT"
+	EnvDynamoDBRegion   = "DYNAMODB_REGION"
+	EnvDynamoDBEndpoint = "DYNAMODB_ENDPOINT"
+	EnvDynamoDBTable    = "DYNAMODB_TABLE"
+	EnvCursorSecret     = "CURSOR_SECRET"
+	EnvAuthDisabled     = "AUTH_DISABLED"
+	EnvAuthHMACKeyFile  = "AUTH_HMAC_KEY_FILE"
+	EnvAuthRSAKeyFile   = "AUTH_RSA_PUBLIC_KEY_FILE"
+	EnvAuthJWKSFile     = "AUTH_JWKS_FILE"
+	EnvAuthIssuer       = "AUTH_ISSUER"
+	EnvAuthAudience     = "AUTH_AUDIENCE"
+)
+
+// Config holds the settings of the server and the tools that share its DynamoDB table
+type Config struct {
+	Storage  string         `yaml:"storage"`
+	Server   ServerConfig   `yaml:"server"`
+	DynamoDB DynamoDBConfig `yaml:"dynamodb"`
+	Auth     AuthConfig     `yaml:"auth"`
+}
+
+// ServerConfig holds the settings of the HTTP server
+type ServerConfig struct {
+	Addr            string        `yaml:"addr"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// DynamoDBConfig holds the settings of the DynamoDB connection
+type DynamoDBConfig struct {
+	Region       string `yaml:"region"`
+	Endpoint     string `yaml:"endpoint"` // Empty for AWS, set for DynamoDB Local
+	TableName    string `yaml:"table_name"`
+	CursorSecret string `yaml:"cursor_secret"`
+}
+
+// AuthConfig holds the settings of bearer token verification. The server needs at least one key
+// file unless authentication is disabled; the other tools ignore these settings.
+type AuthConfig struct {
+	Disabled         bool   `yaml:"disabled"`            // Accept every request, for local development only
+	HMACKeyFile      string `yaml:"hmac_key_file"`       // Shared secret for HS256
+	RSAPublicKeyFile string `yaml:"rsa_public_key_file"` // PEM public key for RS256
+	JWKSFile         string `yaml:"jwks_file"`           // JSON Web Key Set with RSA and oct keys
+	Issuer           string `yaml:"issuer"`              // Required iss claim, empty to accept any
+	Audience         string `yaml:"audience"`            // Required aud claim, empty to accept any
+}
+
+// Default returns the settings for local development against DynamoDB Local
+func Default() Config {
+	return Config{
+		Storage: StorageDynamoDB,
+		Server: ServerConfig{
+			Addr:            ":8080",
+			ShutdownTimeout: 30 * time.Second,
+		},
+		DynamoDB: DynamoDBConfig{
+			Region:    "ap-northeast-1",
+			Endpoint:  "http://localhost:8000",
+			TableName: "OnlineShop",
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, an optional YAML file, environment variables
+// and command line flags, each overriding the previous one. The YAML file is named by the -config
+// flag or the CONFIG_FILE variable. A setting given as an empty string, e.g. DYNAMODB_ENDPOINT=""
+// to use AWS instead of DynamoDB Local, still overrides the earlier sources.
+func Load(args []string) (*Config, error) {
+	return LoadWithFlags(flag.NewFlagSet(os.Args[0], flag.ContinueOnError), args)
+}
+
+// LoadWithFlags is Load for a tool with flags of its own: it adds the configuration flags to fs,
+// which may already define the tool's flags, and parses args with it.
+func LoadWithFlags(fs *flag.FlagSet, args []string) (*Config, error) {
+	cfg := Default()
+
+	// 1. フラグ定義（値の反映は最後に行う）
+	configFile := fs.String("config", "", "path to a YAML configuration file")
+	storage := fs.String("storage", cfg.Storage, "storage backend: dynamodb or memory")
+	addr := fs.String("addr", cfg.Server.Addr, "address the HTTP server listens on")
+	shutdownTimeout := fs.Duration("shutdown-timeout", cfg.Server.ShutdownTimeout, "time to wait for in-flight requests on shutdown")
+	region := fs.String("dynamodb-region", cfg.DynamoDB.Region, "AWS region of the DynamoDB table")
+	endpoint := fs.String("dynamodb-endpoint", cfg.DynamoDB.Endpoint, "DynamoDB endpoint URL, empty for AWS")
+	table := fs.String("dynamodb-table", cfg.DynamoDB.TableName, "DynamoDB table name")
+	authDisabled := fs.Bool("auth-disabled", cfg.Auth.Disabled, "accept requests without a bearer token (local development only)")
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("invalid flags: %w", err)
+	}
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	// 2. YAMLファイル
+	path, _ := os.LookupEnv(EnvConfigFile)
+	if setFlags["config"] {
+		path = *configFile
+	}
+	if path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. 環境変数
+	lookupString(EnvStorage, &cfg.Storage)
+	lookupString(EnvServerAddr, &cfg.Server.Addr)
+	if err := lookupDuration(EnvShutdownTimeout, &cfg.Server.ShutdownTimeout); err != nil {
+		return nil, err
+	}
+	lookupString(EnvDynamoDBRegion, &cfg.DynamoDB.Region)
+	lookupString(EnvDynamoDBEndpoint, &cfg.DynamoDB.Endpoint)
+	lookupString(EnvDynamoDBTable, &cfg.DynamoDB.TableName)
+	lookupString(EnvCursorSecret, &cfg.DynamoDB.CursorSecret)
+	if err := lookupBool(EnvAuthDisabled, &cfg.Auth.Disabled); err != nil {
+		return nil, err
+	}
+	lookupString(EnvAuthHMACKeyFile, &cfg.Auth.HMACKeyFile)
+	lookupString(EnvAuthRSAKeyFile, &cfg.Auth.RSAPublicKeyFile)
+	lookupString(EnvAuthJWKSFile, &cfg.Auth.JWKSFile)
+	lookupString(EnvAuthIssuer, &cfg.Auth.Issuer)
+	lookupString(EnvAuthAudience, &cfg.Auth.Audience)
+
+	// 4. フラグ（明示的に指定されたものだけ上書き）
+	if setFlags["storage"] {
+		cfg.Storage = *storage
+	}
+	if setFlags["addr"] {
+		cfg.Server.Addr = *addr
+	}
+	if setFlags["shutdown-timeout"] {
+		cfg.Server.ShutdownTimeout = *shutdownTimeout
+	}
+	if setFlags["dynamodb-region"] {
+		cfg.DynamoDB.Region = *region
+	}
+	if setFlags["dynamodb-endpoint"] {
+		cfg.DynamoDB.Endpoint = *endpoint
+	}
+	if setFlags["dynamodb-table"] {
+		cfg.DynamoDB.TableName = *table
+	}
+	if setFlags["auth-disabled"] {
+		cfg.Auth.Disabled = *authDisabled
+	}
+
+	// 5. バリデーション
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that the configuration is complete and consistent
+func (c *Config) Validate() error {
+	var errs []error
+
+	switch c.Storage {
+	case StorageDynamoDB:
+		if c.DynamoDB.Region == "" {
+			errs = append(errs, errors.New("dynamodb region is required"))
+		}
+		if c.DynamoDB.TableName == "" {
+			errs = append(errs, errors.New("dynamodb table name is required"))
+		}
+		if c.DynamoDB.Endpoint != "" {
+			u, err := url.Parse(c.DynamoDB.Endpoint)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, fmt.Errorf("dynamodb endpoint must be an http(s) URL: %q", c.DynamoDB.Endpoint))
+			}
+		}
+	case StorageMemory:
+	default:
+		errs = append(errs, fmt.Errorf("unknown storage backend: %q", c.Storage))
+	}
+
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("server address is required"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("shutdown timeout must be positive: %s", c.Server.ShutdownTimeout))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Infrastructure returns the settings for infrastructure.NewDynamoDBClient
+func (c DynamoDBConfig) Infrastructure() infrastructure.DynamoDBConfig {
+	return infrastructure.DynamoDBConfig{
+		Region:       c.Region,
+		Endpoint:     c.Endpoint,
+		TableName:    c.TableName,
+		CursorSecret: c.CursorSecret,
+	}
+}
+
+// Verifier returns the settings for auth.NewVerifier
+func (c AuthConfig) Verifier() auth.Config {
+	return auth.Config{
+		HMACKeyFile:      c.HMACKeyFile,
+		RSAPublicKeyFile: c.RSAPublicKeyFile,
+		JWKSFile:         c.JWKSFile,
+		Issuer:           c.Issuer,
+		Audience:         c.Audience,
+	}
+}
+
+// loadFile overrides cfg with the settings present in a YAML file. Unknown keys are rejected
+// so that a typo does not silently fall back to a default.
+func loadFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// lookupString overrides dst with an environment variable when it is set
+func lookupString(name string, dst *string) {
+	if v, ok := os.LookupEnv(name); ok {
+		*dst = v
+	}
+}
+
+// lookupBool overrides dst with an environment variable such as "true" when it is set
+func lookupBool(name string, dst *bool) error {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	*dst = b
+	return nil
+}
+
+// lookupDuration overrides dst with an environment variable such as "30s" when it is set
+func lookupDuration(name string, dst *time.Duration) error {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	*dst = d
+	return nil
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearEnv unsets every variable Load reads so that the developer's environment does not leak in
+func clearEnv(t *testing.T) {
+	for _, name := range []string{
+		EnvConfigFile, EnvStorage, EnvServerAddr, EnvShutdownTimeout,
+		EnvDynamoDBRegion, EnvDynamoDBEndpoint, EnvDynamoDBTable, EnvCursorSecret,
+		EnvAuthDisabled, EnvAuthHMACKeyFile, EnvAuthRSAKeyFile, EnvAuthJWKSFile, EnvAuthIssuer, EnvAuthAudience,
+	} {
+		t.Setenv(name, "")
+		require.NoError(t, os.Unsetenv(name))
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		clearEnv(t)
+
+		cfg, err := Load(nil)
+		require.NoError(t, err)
+		assert.Equal(t, Default(), *cfg)
+		assert.Equal(t, ":8080", cfg.Server.Addr)
+		assert.Equal(t, 30*time.Second, cfg.Server.ShutdownTimeout)
+		assert.Equal(t, "OnlineShop", cfg.DynamoDB.TableName)
+	})
+
+	t.Run("file, then environment, then flags", func(t *testing.T) {
+		clearEnv(t)
+		path := writeFile(t, `
+server:
+  addr: ":9000"
+  shutdown_timeout: 10s
+dynamodb:
+  region: us-east-1
+  table_name: FromFile
+`)
+		t.Setenv(EnvConfigFile, path)
+		t.Setenv(EnvDynamoDBTable, "FromEnv")
+		t.Setenv(EnvShutdownTimeout, "5s")
+
+		cfg, err := Load([]string{"-dynamodb-region", "eu-west-1"})
+		require.NoError(t, err)
+		assert.Equal(t, ":9000", cfg.Server.Addr)                  // file
+		assert.Equal(t, 5*time.Second, cfg.Server.ShutdownTimeout) // env over file
+		assert.Equal(t, "FromEnv", cfg.DynamoDB.TableName)         // env over file
+		assert.Equal(t, "eu-west-1", cfg.DynamoDB.Region)          // flag over file
+		assert.Equal(t, "http://localhost:8000", cfg.DynamoDB.Endpoint)
+	})
+
+	t.Run("config flag overrides the environment variable", func(t *testing.T) {
+		clearEnv(t)
+		t.Setenv(EnvConfigFile, filepath.Join(t.TempDir(), "missing.yml"))
+		path := writeFile(t, "storage: memory\n")
+
+		cfg, err := Load([]string{"-config", path})
+		require.NoError(t, err)
+		assert.Equal(t, StorageMemory, cfg.Storage)
+	})
+
+	t.Run("empty endpoint selects AWS", func(t *testing.T) {
+		clearEnv(t)
+		t.Setenv(EnvDynamoDBEndpoint, "")
+
+		cfg, err := Load(nil)
+		require.NoError(t, err)
+		assert.Empty(t, cfg.DynamoDB.Endpoint)
+
+		clearEnv(t)
+		cfg, err = Load([]string{"-dynamodb-endpoint="})
+		require.NoError(t, err)
+		assert.Empty(t, cfg.DynamoDB.Endpoint)
+	})
+
+	t.Run("cursor secret feeds the DynamoDB client", func(t *testing.T) {
+		clearEnv(t)
+		t.Setenv(EnvCursorSecret, "secret")
+
+		cfg, err := Load(nil)
+		require.NoError(t, err)
+		infra := cfg.DynamoDB.Infrastructure()
+		assert.Equal(t, "secret", infra.CursorSecret)
+		assert.Equal(t, cfg.DynamoDB.Region, infra.Region)
+		assert.Equal(t, cfg.DynamoDB.Endpoint, infra.Endpoint)
+		assert.Equal(t, cfg.DynamoDB.TableName, infra.TableName)
+	})
+
+	t.Run("auth settings feed the token verifier", func(t *testing.T) {
+		clearEnv(t)
+		path := writeFile(t, `
+auth:
+  jwks_file: /etc/shop/jwks.json
+  issuer: https://issuer.example.com
+`)
+		t.Setenv(EnvAuthAudience, "shop-api")
+
+		cfg, err := Load([]string{"-config", path})
+		require.NoError(t, err)
+		assert.False(t, cfg.Auth.Disabled)
+		verifier := cfg.Auth.Verifier()
+		assert.Equal(t, "/etc/shop/jwks.json", verifier.JWKSFile)
+		assert.Equal(t, "https://issuer.example.com", verifier.Issuer)
+		assert.Equal(t, "shop-api", verifier.Audience)
+
+		t.Setenv(EnvAuthDisabled, "true")
+		cfg, err = Load(nil)
+		require.NoError(t, err)
+		assert.True(t, cfg.Auth.Disabled)
+
+		cfg, err = Load([]string{"-auth-disabled=false"})
+		require.NoError(t, err)
+		assert.False(t, cfg.Auth.Disabled)
+
+		t.Setenv(EnvAuthDisabled, "maybe")
+		_, err = Load(nil)
+		assert.ErrorContains(t, err, EnvAuthDisabled)
+	})
+
+	t.Run("tool flags are parsed together with configuration flags", func(t *testing.T) {
+		clearEnv(t)
+		fs := flag.NewFlagSet("tool", flag.ContinueOnError)
+		dryRun := fs.Bool("dry-run", false, "")
+
+		cfg, err := LoadWithFlags(fs, []string{"-dry-run", "-dynamodb-table", "Other", "extra"})
+		require.NoError(t, err)
+		assert.True(t, *dryRun)
+		assert.Equal(t, "Other", cfg.DynamoDB.TableName)
+		assert.Equal(t, []string{"extra"}, fs.Args())
+	})
+
+	t.Run("invalid sources are rejected", func(t *testing.T) {
+		clearEnv(t)
+		_, err := Load([]string{"-storage", "postgres"})
+		assert.ErrorContains(t, err, "unknown storage backend")
+
+		_, err = Load([]string{"-no-such-flag"})
+		assert.Error(t, err)
+
+		t.Setenv(EnvShutdownTimeout, "soon")
+		_, err = Load(nil)
+		assert.ErrorContains(t, err, EnvShutdownTimeout)
+
+		clearEnv(t)
+		_, err = Load([]string{"-config", writeFile(t, "dynamodb:\n  tabel_name: Typo\n")})
+		assert.ErrorContains(t, err, "tabel_name")
+
+		_, err = Load([]string{"-config", filepath.Join(t.TempDir(), "missing.yml")})
+		assert.ErrorContains(t, err, "failed to open config file")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("defaults are valid", func(t *testing.T) {
+		cfg := Default()
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("every problem is reported", func(t *testing.T) {
+		cfg := Default()
+		cfg.DynamoDB.Region = ""
+		cfg.DynamoDB.TableName = ""
+		cfg.DynamoDB.Endpoint = "localhost:8000"
+		cfg.Server.Addr = ""
+		cfg.Server.ShutdownTimeout = 0
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "region is required")
+		assert.ErrorContains(t, err, "table name is required")
+		assert.ErrorContains(t, err, "endpoint must be an http(s) URL")
+		assert.ErrorContains(t, err, "server address is required")
+		assert.ErrorContains(t, err, "shutdown timeout must be positive")
+	})
+
+	t.Run("memory storage does not need DynamoDB settings", func(t *testing.T) {
+		cfg := Default()
+		cfg.Storage = StorageMemory
+		cfg.DynamoDB = DynamoDBConfig{}
+		assert.NoError(t, cfg.Validate())
+	})
+}
@@ -0,0 +1,154 @@
+package dataset
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+)
+
+// MarshalItemJSON encodes an item in DynamoDB JSON, the attribute-value form used by the AWS
+// CLI and table exports, e.g. {"PK":{"S":"CUSTOMER#1"},"Version":{"N":"2"}}. Binary values
+// are base64 encoded.
+func MarshalItemJSON(item dynamo.Item) ([]byte, error) {
+	encoded := make(map[string]any, len(item))
+	for name, value := range item {
+		v, err := encodeValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		encoded[name] = v
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalItemJSON decodes an item in DynamoDB JSON
+func UnmarshalItemJSON(data []byte) (dynamo.Item, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return decodeItem(raw)
+}
+
+func decodeItem(raw map[string]json.RawMessage) (dynamo.Item, error) {
+	item := make(dynamo.Item, len(raw))
+	for name, data := range raw {
+		value, err := decodeValue(data)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		item[name] = value
+	}
+	return item, nil
+}
+
+func encodeValue(value types.AttributeValue) (any, error) {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return map[string]string{"S": v.Value}, nil
+	case *types.AttributeValueMemberN:
+		return map[string]string{"N": v.Value}, nil
+	case *types.AttributeValueMemberB:
+		return map[string][]byte{"B": v.Value}, nil
+	case *types.AttributeValueMemberBOOL:
+		return map[string]bool{"BOOL": v.Value}, nil
+	case *types.AttributeValueMemberNULL:
+		return map[string]bool{"NULL": v.Value}, nil
+	case *types.AttributeValueMemberSS:
+		return map[string][]string{"SS": v.Value}, nil
+	case *types.AttributeValueMemberNS:
+		return map[string][]string{"NS": v.Value}, nil
+	case *types.AttributeValueMemberBS:
+		return map[string][][]byte{"BS": v.Value}, nil
+	case *types.AttributeValueMemberL:
+		list := make([]any, 0, len(v.Value))
+		for i, elem := range v.Value {
+			encoded, err := encodeValue(elem)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			list = append(list, encoded)
+		}
+		return map[string][]any{"L": list}, nil
+	case *types.AttributeValueMemberM:
+		m := make(map[string]any, len(v.Value))
+		for key, elem := range v.Value {
+			encoded, err := encodeValue(elem)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			m[key] = encoded
+		}
+		return map[string]map[string]any{"M": m}, nil
+	default:
+		return nil, fmt.Errorf("unsupported attribute value %T", value)
+	}
+}
+
+func decodeValue(data json.RawMessage) (types.AttributeValue, error) {
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("expected an attribute value object: %w", err)
+	}
+	if len(wrapper) != 1 {
+		return nil, fmt.Errorf("attribute value must have exactly one type, got %d", len(wrapper))
+	}
+
+	for typ, body := range wrapper {
+		switch typ {
+		case "S":
+			v := &types.AttributeValueMemberS{}
+			return v, json.Unmarshal(body, &v.Value)
+		case "N":
+			v := &types.AttributeValueMemberN{}
+			return v, json.Unmarshal(body, &v.Value)
+		case "B":
+			v := &types.AttributeValueMemberB{}
+			return v, json.Unmarshal(body, &v.Value)
+		case "BOOL":
+			v := &types.AttributeValueMemberBOOL{}
+			return v, json.Unmarshal(body, &v.Value)
+		case "NULL":
+			v := &types.AttributeValueMemberNULL{}
+			return v, json.Unmarshal(body, &v.Value)
+		case "SS":
+			v := &types.AttributeValueMemberSS{}
+			return v, json.Unmarshal(body, &v.Value)
+		case "NS":
+			v := &types.AttributeValueMemberNS{}
+			return v, json.Unmarshal(body, &v.Value)
+		case "BS":
+			v := &types.AttributeValueMemberBS{}
+			return v, json.Unmarshal(body, &v.Value)
+		case "L":
+			var raw []json.RawMessage
+			if err := json.Unmarshal(body, &raw); err != nil {
+				return nil, err
+			}
+			v := &types.AttributeValueMemberL{Value: make([]types.AttributeValue, 0, len(raw))}
+			for i, elem := range raw {
+				decoded, err := decodeValue(elem)
+				if err != nil {
+					return nil, fmt.Errorf("[%d]: %w", i, err)
+				}
+				v.Value = append(v.Value, decoded)
+			}
+			return v, nil
+		case "M":
+			var raw map[string]json.RawMessage
+			if err := json.Unmarshal(body, &raw); err != nil {
+				return nil, err
+			}
+			m, err := decodeItem(raw)
+			if err != nil {
+				return nil, err
+			}
+			return &types.AttributeValueMemberM{Value: m}, nil
+		default:
+			return nil, fmt.Errorf("unknown attribute value type %q", typ)
+		}
+	}
+	return nil, nil // unreachable: wrapper has exactly one entry
+}
@@ -0,0 +1,165 @@
+package dataset
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+)
+
+// bookkeepingTypes are items that describe the state of the table or of in-flight API requests
+// rather than the dataset, so exporting them to another table would be wrong
+var bookkeepingTypes = []string{"MIGRATION", "BACKFILL_CHECKPOINT", "STREAM_CHECKPOINT", "STREAM_DEAD_LETTER", "IDEMPOTENCY"}
+
+// importChunk is how many items are handed to one batch write; guregu splits it into
+// BatchWriteItem requests of 25 and retries unprocessed items with backoff
+const importChunk = 500
+
+// maxLineSize bounds one JSON line; a 400 KB item takes a few times that in DynamoDB JSON
+const maxLineSize = 4 * 1024 * 1024
+
+// Export writes the items of a table to w in DynamoDB JSON, one item per line. With itemTypes
+// given, only items whose Type is one of them are written; otherwise every item except the
+// migration, backfill and stream bookkeeping and the idempotency keys is. It returns the number of exported items.
+func Export(ctx context.Context, table dynamo.Table, w io.Writer, itemTypes []string) (int, error) {
+	scan := table.Scan()
+	if len(itemTypes) > 0 {
+		scan = scan.Filter("'Type' IN ("+placeholders(len(itemTypes))+")", toArgs(itemTypes)...)
+	} else {
+		scan = scan.Filter("NOT ('Type' IN ("+placeholders(len(bookkeepingTypes))+"))", toArgs(bookkeepingTypes)...)
+	}
+
+	buf := bufio.NewWriter(w)
+	iter := scan.Iter()
+	count := 0
+	var item dynamo.Item
+	for iter.Next(ctx, &item) {
+		line, err := MarshalItemJSON(item)
+		if err != nil {
+			return count, fmt.Errorf("failed to encode item: %w", err)
+		}
+		if _, err := buf.Write(append(line, '\n')); err != nil {
+			return count, err
+		}
+		count++
+		item = nil
+	}
+	if err := iter.Err(); err != nil {
+		return count, fmt.Errorf("failed to scan table: %w", err)
+	}
+	return count, buf.Flush()
+}
+
+// ImportJSONL writes the items in r, one DynamoDB JSON item per line, to a table. Lines of a
+// DynamoDB export to S3, which wrap each item as {"Item": {...}}, are accepted as well. Items
+// with the key of an existing item replace it. It returns the number of imported items.
+func ImportJSONL(ctx context.Context, table dynamo.Table, r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	var (
+		chunk    []dynamo.Item
+		imported int
+		line     int
+	)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(text), &raw); err != nil {
+			return imported, fmt.Errorf("line %d: %w", line, err)
+		}
+		if wrapped, ok := raw["Item"]; ok && len(raw) == 1 {
+			raw = nil
+			if err := json.Unmarshal(wrapped, &raw); err != nil {
+				return imported, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		item, err := decodeItem(raw)
+		if err != nil {
+			return imported, fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := checkKey(item); err != nil {
+			return imported, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		chunk = append(chunk, item)
+		if len(chunk) == importChunk {
+			n, err := writeItems(ctx, table, chunk)
+			imported += n
+			if err != nil {
+				return imported, err
+			}
+			chunk = chunk[:0]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return imported, fmt.Errorf("failed to read line %d: %w", line+1, err)
+	}
+
+	n, err := writeItems(ctx, table, chunk)
+	return imported + n, err
+}
+
+// writeItems puts items with BatchWriteItem. A request must not touch a key twice, so of items
+// with the same key only the last one is written.
+func writeItems(ctx context.Context, table dynamo.Table, items []dynamo.Item) (int, error) {
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	last := make(map[string]int, len(items))
+	for i, item := range items {
+		last[keyOf(item)] = i
+	}
+	puts := make([]any, 0, len(last))
+	for i, item := range items {
+		if last[keyOf(item)] == i {
+			puts = append(puts, item)
+		}
+	}
+	wrote, err := table.Batch("PK", "SK").Write().Put(puts...).Run(ctx)
+	if err != nil {
+		return wrote, fmt.Errorf("failed to write items: %w", err)
+	}
+	slog.Info("Imported items", "table", table.Name(), "count", wrote)
+	return wrote, nil
+}
+
+// checkKey verifies that an item has the string PK and SK the table is keyed by
+func checkKey(item dynamo.Item) error {
+	for _, name := range []string{"PK", "SK"} {
+		key, ok := item[name].(*types.AttributeValueMemberS)
+		if !ok || key.Value == "" {
+			return fmt.Errorf("item has no string %s", name)
+		}
+	}
+	return nil
+}
+
+// keyOf returns the PK and SK of an item checked by checkKey
+func keyOf(item dynamo.Item) string {
+	return item["PK"].(*types.AttributeValueMemberS).Value + "\x00" + item["SK"].(*types.AttributeValueMemberS).Value
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func toArgs(values []string) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
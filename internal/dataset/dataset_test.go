@@ -0,0 +1,175 @@
+package dataset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+func TestItemJSON(t *testing.T) {
+	item := dynamo.Item{
+		"PK":       &types.AttributeValueMemberS{Value: "ORDER#1"},
+		"Total":    &types.AttributeValueMemberN{Value: "2598"},
+		"Raw":      &types.AttributeValueMemberB{Value: []byte{0, 1, 2}},
+		"Paid":     &types.AttributeValueMemberBOOL{Value: true},
+		"Note":     &types.AttributeValueMemberNULL{Value: true},
+		"Tags":     &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+		"Sizes":    &types.AttributeValueMemberNS{Value: []string{"1", "2"}},
+		"Blobs":    &types.AttributeValueMemberBS{Value: [][]byte{{1}}},
+		"Lines":    &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: "x"}}},
+		"Payments": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"p-1": &types.AttributeValueMemberN{Value: "100"}}},
+	}
+
+	data, err := MarshalItemJSON(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"PK":{"S":"ORDER#1"}`)
+	assert.Contains(t, string(data), `"Total":{"N":"2598"}`)
+	assert.Contains(t, string(data), `"Raw":{"B":"AAEC"}`)
+	assert.Contains(t, string(data), `"Payments":{"M":{"p-1":{"N":"100"}}}`)
+
+	decoded, err := UnmarshalItemJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, item, decoded)
+
+	t.Run("invalid attribute values are rejected", func(t *testing.T) {
+		_, err := UnmarshalItemJSON([]byte(`{"PK":{"X":"1"}}`))
+		assert.ErrorContains(t, err, `unknown attribute value type "X"`)
+
+		_, err = UnmarshalItemJSON([]byte(`{"PK":{"S":"1","N":"1"}}`))
+		assert.ErrorContains(t, err, "exactly one type")
+
+		_, err = UnmarshalItemJSON([]byte(`{"PK":"plain"}`))
+		assert.ErrorContains(t, err, "attribute PK")
+	})
+}
+
+func TestImportValidation(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := ImportJSONL(ctx, dynamo.Table{}, strings.NewReader(`{"PK":{"S":"A"},"SK":{"S":"A"}}`+"\nnot json\n"))
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = ImportJSONL(ctx, dynamo.Table{}, strings.NewReader(`{"Item":{"PK":{"S":"A"}}}`))
+	assert.ErrorContains(t, err, "line 1: item has no string SK")
+
+	_, err = ImportWorkbench(ctx, dynamo.Table{}, strings.NewReader(`{"ModelName":"M","DataModel":[{"TableName":"A"},{"TableName":"B"}]}`), "")
+	assert.ErrorContains(t, err, "choose one of: A, B")
+
+	_, err = ImportWorkbench(ctx, dynamo.Table{}, strings.NewReader(`{"ModelName":"M","DataModel":[{"TableName":"A"}]}`), "C")
+	assert.ErrorContains(t, err, "has no table C")
+
+	_, err = ImportWorkbench(ctx, dynamo.Table{}, strings.NewReader(`{"DataModel":[{"TableName":"A",
+		"KeyAttributes":{"PartitionKey":{"AttributeName":"id","AttributeType":"S"}}}]}`), "")
+	assert.ErrorContains(t, err, "keyed by id/, not PK/SK")
+}
+
+// workbenchSample is a trimmed NoSQL Workbench model in the shape of the AnOnlineShop models
+const workbenchSample = `{
+  "ModelName": "AnOnlineShop",
+  "ModelMetadata": {"Author": "", "Description": "", "AWSService": "Amazon DynamoDB", "Version": "3.0"},
+  "DataModel": [
+    {
+      "TableName": "OnlineShop",
+      "KeyAttributes": {
+        "PartitionKey": {"AttributeName": "PK", "AttributeType": "S"},
+        "SortKey": {"AttributeName": "SK", "AttributeType": "S"}
+      },
+      "NonKeyAttributes": [{"AttributeName": "EntityType", "AttributeType": "S"}],
+      "TableData": [
+        {"PK": {"S": "c#12345"}, "SK": {"S": "c#12345"}, "EntityType": {"S": "customer"}, "Email": {"S": "johndoe@example.com"}},
+        {"PK": {"S": "p#12345"}, "SK": {"S": "p#12345"}, "EntityType": {"S": "product"}, "Name": {"S": "Widget"}}
+      ],
+      "BillingMode": "PROVISIONED"
+    }
+  ]
+}`
+
+func TestDynamoDataset(t *testing.T) {
+	// Skip if not running integration tests
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+
+	// Copy between throwaway tables so the shared one is left alone
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	newTable := func(name string) dynamo.Table {
+		dynamoCfg := cfg.DynamoDB.Infrastructure()
+		dynamoCfg.TableName = fmt.Sprintf("%s-%d", name, time.Now().UnixNano())
+		client, err := infrastructure.NewDynamoDBClient(ctx, dynamoCfg)
+		require.NoError(t, err)
+		require.NoError(t, client.DB.CreateTable(dynamoCfg.TableName, struct {
+			PK string `dynamo:"PK,hash"`
+			SK string `dynamo:"SK,range"`
+		}{}).OnDemand(true).Wait(ctx))
+		t.Cleanup(func() {
+			_ = client.GetTable().DeleteTable().Run(context.Background())
+		})
+		return client.GetTable()
+	}
+	source, target := newTable("DatasetSource"), newTable("DatasetTarget")
+
+	for i := 0; i < 60; i++ {
+		typ := "CUSTOMER"
+		if i%2 == 1 {
+			typ = "PRODUCT"
+		}
+		require.NoError(t, source.Put(map[string]any{
+			"PK": fmt.Sprintf("%s#%02d", typ, i), "SK": fmt.Sprintf("%s#%02d", typ, i), "Type": typ, "Seq": i,
+		}).Run(ctx))
+	}
+	require.NoError(t, source.Put(map[string]any{"PK": "MIGRATION#", "SK": "VERSION#0001", "Type": "MIGRATION"}).Run(ctx))
+	require.NoError(t, source.Put(map[string]any{"PK": "IDEMPOTENCY#key-1", "SK": "IDEMPOTENCY#key-1", "Type": "IDEMPOTENCY"}).Run(ctx))
+
+	t.Run("export skips bookkeeping and filters by type", func(t *testing.T) {
+		var all bytes.Buffer
+		count, err := Export(ctx, source, &all, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 60, count)
+		assert.NotContains(t, all.String(), "MIGRATION#")
+		assert.NotContains(t, all.String(), "IDEMPOTENCY#")
+
+		var products bytes.Buffer
+		count, err = Export(ctx, source, &products, []string{"PRODUCT"})
+		require.NoError(t, err)
+		assert.Equal(t, 30, count)
+		assert.NotContains(t, products.String(), "CUSTOMER#")
+	})
+
+	t.Run("import restores the exported items", func(t *testing.T) {
+		var exported bytes.Buffer
+		_, err := Export(ctx, source, &exported, nil)
+		require.NoError(t, err)
+
+		count, err := ImportJSONL(ctx, target, &exported)
+		require.NoError(t, err)
+		assert.Equal(t, 60, count)
+
+		var item map[string]any
+		require.NoError(t, target.Get("PK", "PRODUCT#07").Range("SK", dynamo.Equal, "PRODUCT#07").One(ctx, &item))
+		assert.EqualValues(t, 7, item["Seq"])
+	})
+
+	t.Run("workbench model data is imported", func(t *testing.T) {
+		count, err := ImportWorkbench(ctx, target, strings.NewReader(workbenchSample), "")
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		var item map[string]any
+		require.NoError(t, target.Get("PK", "c#12345").Range("SK", dynamo.Equal, "c#12345").One(ctx, &item))
+		assert.Equal(t, "johndoe@example.com", item["Email"])
+	})
+}
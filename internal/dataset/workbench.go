@@ -0,0 +1,83 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/guregu/dynamo/v2"
+)
+
+// workbenchModel is the part of a NoSQL Workbench data model export that holds sample data.
+// Each table of the model lists its items in DynamoDB JSON under TableData.
+type workbenchModel struct {
+	ModelName string           `json:"ModelName"`
+	DataModel []workbenchTable `json:"DataModel"`
+}
+
+type workbenchTable struct {
+	TableName     string `json:"TableName"`
+	KeyAttributes struct {
+		PartitionKey workbenchAttribute  `json:"PartitionKey"`
+		SortKey      *workbenchAttribute `json:"SortKey"`
+	} `json:"KeyAttributes"`
+	TableData []map[string]json.RawMessage `json:"TableData"`
+}
+
+type workbenchAttribute struct {
+	AttributeName string `json:"AttributeName"`
+	AttributeType string `json:"AttributeType"`
+}
+
+// ImportWorkbench writes the sample data of a NoSQL Workbench data model, such as the
+// AnOnlineShop models referenced in docs/dynamo-design.md, to a table. modelTable names the
+// table of the model to import and may be empty when the model has a single table. The model
+// table must be keyed by PK and SK like ours. It returns the number of imported items.
+func ImportWorkbench(ctx context.Context, table dynamo.Table, r io.Reader, modelTable string) (int, error) {
+	var model workbenchModel
+	if err := json.NewDecoder(r).Decode(&model); err != nil {
+		return 0, fmt.Errorf("failed to parse NoSQL Workbench model: %w", err)
+	}
+
+	source, err := model.table(modelTable)
+	if err != nil {
+		return 0, err
+	}
+	sortKey := ""
+	if source.KeyAttributes.SortKey != nil {
+		sortKey = source.KeyAttributes.SortKey.AttributeName
+	}
+	if source.KeyAttributes.PartitionKey.AttributeName != "PK" || sortKey != "SK" {
+		return 0, fmt.Errorf("model table %s is keyed by %s/%s, not PK/SK", source.TableName, source.KeyAttributes.PartitionKey.AttributeName, sortKey)
+	}
+
+	items := make([]dynamo.Item, 0, len(source.TableData))
+	for i, raw := range source.TableData {
+		item, err := decodeItem(raw)
+		if err != nil {
+			return 0, fmt.Errorf("item %d: %w", i, err)
+		}
+		if err := checkKey(item); err != nil {
+			return 0, fmt.Errorf("item %d: %w", i, err)
+		}
+		items = append(items, item)
+	}
+	return writeItems(ctx, table, items)
+}
+
+// table returns the model table with the given name, or the only table when name is empty
+func (m *workbenchModel) table(name string) (*workbenchTable, error) {
+	names := make([]string, 0, len(m.DataModel))
+	for i := range m.DataModel {
+		if m.DataModel[i].TableName == name || name == "" && len(m.DataModel) == 1 {
+			return &m.DataModel[i], nil
+		}
+		names = append(names, m.DataModel[i].TableName)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("model %s has %d tables, choose one of: %s", m.ModelName, len(names), strings.Join(names, ", "))
+	}
+	return nil, fmt.Errorf("model %s has no table %s, choose one of: %s", m.ModelName, name, strings.Join(names, ", "))
+}
@@ -13,18 +13,33 @@ type Customer struct {
 	name      string
 	createdAt time.Time
 	updatedAt time.Time
+	version   int
+	events
 }
 
 // NewCustomer creates a new Customer entity
 func NewCustomer(id value.CustomerID, email value.Email, name string) *Customer {
 	now := time.Now()
-	return &Customer{
+	customer := &Customer{
 		id:        id,
 		email:     email,
 		name:      name,
 		createdAt: now,
 		updatedAt: now,
 	}
+	customer.record(CustomerRegistered{CustomerID: id, Email: email, Name: name, At: now})
+	return customer
+}
+
+// NewCustomerWithState creates a Customer entity with explicit state (for restoration from persistence)
+func NewCustomerWithState(id value.CustomerID, email value.Email, name string, createdAt, updatedAt time.Time) *Customer {
+	return &Customer{
+		id:        id,
+		email:     email,
+		name:      name,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
 }
 
 // ID returns the customer ID
@@ -52,6 +67,16 @@ func (c *Customer) UpdatedAt() time.Time {
 	return c.updatedAt
 }
 
+// Version returns the optimistic locking version (0 until first persisted)
+func (c *Customer) Version() int {
+	return c.version
+}
+
+// SetVersion sets the optimistic locking version (used by repositories after a read or a successful write)
+func (c *Customer) SetVersion(version int) {
+	c.version = version
+}
+
 // UpdateEmail updates the customer's email address
 func (c *Customer) UpdateEmail(email value.Email) {
 	c.email = email
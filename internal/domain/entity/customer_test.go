@@ -26,6 +26,19 @@ func TestCustomer(t *testing.T) {
 		assert.Equal(t, customer.CreatedAt(), customer.UpdatedAt())
 	})
 
+	t.Run("restore customer with state", func(t *testing.T) {
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		updatedAt := createdAt.Add(48 * time.Hour)
+
+		customer := NewCustomerWithState(customerID, email, name, createdAt, updatedAt)
+
+		assert.Equal(t, customerID, customer.ID())
+		assert.Equal(t, email, customer.Email())
+		assert.Equal(t, name, customer.Name())
+		assert.Equal(t, createdAt, customer.CreatedAt())
+		assert.Equal(t, updatedAt, customer.UpdatedAt())
+	})
+
 	t.Run("update customer email", func(t *testing.T) {
 		customer := NewCustomer(customerID, email, name)
 		originalUpdatedAt := customer.UpdatedAt()
@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"time"
+
+	"dynamo-modeling/internal/domain/value"
+)
+
+// Domain event names
+const (
+	EventOrderPlaced        = "OrderPlaced"
+	EventOrderStatusChanged = "OrderStatusChanged"
+	EventStockReserved      = "StockReserved"
+	EventStockAdded         = "StockAdded"
+	EventCustomerRegistered = "CustomerRegistered"
+	EventReturnRequested    = "ReturnRequested"
+	EventReturnApproved     = "ReturnApproved"
+	EventReturnRejected     = "ReturnRejected"
+)
+
+// DomainEvent is something that happened to an aggregate. Aggregates record their events as
+// they change, and the use case publishes them once the change has been saved.
+type DomainEvent interface {
+	EventName() string
+	OccurredAt() time.Time
+}
+
+// OrderPlaced is recorded when a new order is created
+type OrderPlaced struct {
+	OrderID    value.OrderID
+	CustomerID value.CustomerID
+	Items      []OrderItem
+	Total      value.Money
+	At         time.Time
+}
+
+func (e OrderPlaced) EventName() string     { return EventOrderPlaced }
+func (e OrderPlaced) OccurredAt() time.Time { return e.At }
+
+// OrderStatusChanged is recorded on every status transition of an order
+type OrderStatusChanged struct {
+	OrderID    value.OrderID
+	CustomerID value.CustomerID
+	From       OrderStatus
+	To         OrderStatus
+	At         time.Time
+}
+
+func (e OrderStatusChanged) EventName() string     { return EventOrderStatusChanged }
+func (e OrderStatusChanged) OccurredAt() time.Time { return e.At }
+
+// StockReserved is recorded when stock of a product is reserved for an order
+type StockReserved struct {
+	ProductID value.ProductID
+	Quantity  int
+	At        time.Time
+}
+
+func (e StockReserved) EventName() string     { return EventStockReserved }
+func (e StockReserved) OccurredAt() time.Time { return e.At }
+
+// StockAdded is recorded when stock of a product is replenished
+type StockAdded struct {
+	ProductID value.ProductID
+	Quantity  int
+	At        time.Time
+}
+
+func (e StockAdded) EventName() string     { return EventStockAdded }
+func (e StockAdded) OccurredAt() time.Time { return e.At }
+
+// CustomerRegistered is recorded when a new customer is created
+type CustomerRegistered struct {
+	CustomerID value.CustomerID
+	Email      value.Email
+	Name       string
+	At         time.Time
+}
+
+func (e CustomerRegistered) EventName() string     { return EventCustomerRegistered }
+func (e CustomerRegistered) OccurredAt() time.Time { return e.At }
+
+// ReturnRequested is recorded when a customer asks to send back goods of a delivered order
+type ReturnRequested struct {
+	ReturnID   value.ReturnID
+	OrderID    value.OrderID
+	CustomerID value.CustomerID
+	At         time.Time
+}
+
+func (e ReturnRequested) EventName() string     { return EventReturnRequested }
+func (e ReturnRequested) OccurredAt() time.Time { return e.At }
+
+// ReturnApproved is recorded when a return is approved and its refund is due
+type ReturnApproved struct {
+	ReturnID   value.ReturnID
+	OrderID    value.OrderID
+	CustomerID value.CustomerID
+	Refund     value.Money
+	At         time.Time
+}
+
+func (e ReturnApproved) EventName() string     { return EventReturnApproved }
+func (e ReturnApproved) OccurredAt() time.Time { return e.At }
+
+// ReturnRejected is recorded when a return is rejected
+type ReturnRejected struct {
+	ReturnID   value.ReturnID
+	OrderID    value.OrderID
+	CustomerID value.CustomerID
+	At         time.Time
+}
+
+func (e ReturnRejected) EventName() string     { return EventReturnRejected }
+func (e ReturnRejected) OccurredAt() time.Time { return e.At }
+
+// events collects the domain events an aggregate has recorded but not yet handed out.
+// Aggregates restored from persistence start without events.
+type events struct {
+	pending []DomainEvent
+}
+
+func (e *events) record(event DomainEvent) {
+	e.pending = append(e.pending, event)
+}
+
+// PullEvents returns the recorded events and clears them, so each event is published once
+func (e *events) PullEvents() []DomainEvent {
+	pulled := e.pending
+	e.pending = nil
+	return pulled
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestDomainEvents(t *testing.T) {
+	// Setup test data
+	customerID, _ := value.NewCustomerID("customer-123")
+	productID, _ := value.NewProductID("product-123")
+	price, _ := value.NewMoney(1000)
+	item, _ := NewOrderItem(productID, 2, price)
+
+	t.Run("new order records OrderPlaced", func(t *testing.T) {
+		order, err := NewOrder(value.OrderID("order-123"), customerID, []OrderItem{*item})
+		require.NoError(t, err)
+
+		events := order.PullEvents()
+		require.Len(t, events, 1)
+		placed, ok := events[0].(OrderPlaced)
+		require.True(t, ok)
+		assert.Equal(t, EventOrderPlaced, placed.EventName())
+		assert.Equal(t, order.ID(), placed.OrderID)
+		assert.Equal(t, customerID, placed.CustomerID)
+		assert.Equal(t, order.Total(), placed.Total)
+		assert.Equal(t, order.CreatedAt(), placed.OccurredAt())
+
+		// Pulled events are handed out only once
+		assert.Empty(t, order.PullEvents())
+	})
+
+	t.Run("status transitions record OrderStatusChanged", func(t *testing.T) {
+		order, err := NewOrderWithState(value.OrderID("order-123"), customerID, []OrderItem{*item}, OrderStatusPending, price, time.Now(), time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, order.PullEvents())
+
+		require.NoError(t, order.Confirm())
+		require.NoError(t, order.Ship())
+		require.NoError(t, order.Deliver())
+		assert.Error(t, order.Cancel())
+
+		events := order.PullEvents()
+		require.Len(t, events, 3)
+		for i, want := range []struct{ from, to OrderStatus }{
+			{OrderStatusPending, OrderStatusConfirmed},
+			{OrderStatusConfirmed, OrderStatusShipped},
+			{OrderStatusShipped, OrderStatusDelivered},
+		} {
+			changed, ok := events[i].(OrderStatusChanged)
+			require.True(t, ok)
+			assert.Equal(t, EventOrderStatusChanged, changed.EventName())
+			assert.Equal(t, want.from, changed.From)
+			assert.Equal(t, want.to, changed.To)
+		}
+	})
+
+	t.Run("stock changes record events", func(t *testing.T) {
+		product, err := NewProduct(productID, "Widget", "", price, 10)
+		require.NoError(t, err)
+
+		require.NoError(t, product.ReserveStock(3))
+		require.NoError(t, product.AddStock(5))
+		assert.Error(t, product.ReserveStock(100))
+
+		events := product.PullEvents()
+		require.Len(t, events, 2)
+		assert.Equal(t, StockReserved{ProductID: productID, Quantity: 3, At: events[0].OccurredAt()}, events[0])
+		assert.Equal(t, StockAdded{ProductID: productID, Quantity: 5, At: events[1].OccurredAt()}, events[1])
+	})
+
+	t.Run("new customer records CustomerRegistered", func(t *testing.T) {
+		email, _ := value.NewEmail("test@example.com")
+
+		customer := NewCustomer(customerID, email, "John Doe")
+		events := customer.PullEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, CustomerRegistered{CustomerID: customerID, Email: email, Name: "John Doe", At: customer.CreatedAt()}, events[0])
+
+		restored := NewCustomerWithState(customerID, email, "John Doe", time.Now(), time.Now())
+		assert.Empty(t, restored.PullEvents())
+	})
+}
@@ -0,0 +1,293 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"dynamo-modeling/internal/domain/value"
+)
+
+// DefaultTaxRate is the tax rate, in percent, applied to new invoices
+const DefaultTaxRate = 10
+
+// InvoiceStatus represents the payment status of an invoice
+type InvoiceStatus string
+
+const (
+	InvoiceStatusIssued        InvoiceStatus = "issued"
+	InvoiceStatusPartiallyPaid InvoiceStatus = "partially_paid"
+	InvoiceStatusPaid          InvoiceStatus = "paid"
+)
+
+// PaymentMethod represents how a payment was made
+type PaymentMethod string
+
+const (
+	PaymentMethodCreditCard   PaymentMethod = "credit_card"
+	PaymentMethodGiftCard     PaymentMethod = "gift_card"
+	PaymentMethodDiscountCode PaymentMethod = "discount_code"
+)
+
+// IsValid checks if the payment method is a known method
+func (m PaymentMethod) IsValid() bool {
+	switch m {
+	case PaymentMethodCreditCard, PaymentMethodGiftCard, PaymentMethodDiscountCode:
+		return true
+	default:
+		return false
+	}
+}
+
+// InvoiceLine represents one billed order line
+type InvoiceLine struct {
+	ProductID value.ProductID
+	Quantity  int
+	UnitPrice value.Money
+	Total     value.Money
+}
+
+// Payment represents a payment made against an invoice
+type Payment struct {
+	Method PaymentMethod
+	Amount value.Money
+	PaidAt time.Time
+}
+
+// Invoice represents the bill for an order and the payments made against it
+type Invoice struct {
+	id         value.InvoiceID
+	orderID    value.OrderID
+	customerID value.CustomerID
+	lines      []InvoiceLine
+	subtotal   value.Money
+	taxRate    int
+	tax        value.Money
+	total      value.Money
+	payments   map[value.PaymentID]Payment
+	status     InvoiceStatus
+	issuedAt   time.Time
+	updatedAt  time.Time
+	version    int
+}
+
+// NewInvoice creates a new Invoice entity billing every line of an order.
+// The tax rate is a percentage of the subtotal, rounded half up to the cent.
+func NewInvoice(id value.InvoiceID, order *Order, taxRate int) (*Invoice, error) {
+	if order.IsCancelled() {
+		return nil, fmt.Errorf("cannot invoice a cancelled order")
+	}
+	if taxRate < 0 {
+		return nil, fmt.Errorf("tax rate cannot be negative")
+	}
+
+	items := order.Items()
+	lines := make([]InvoiceLine, 0, len(items))
+	subtotal, _ := value.NewMoney(0)
+	for _, item := range items {
+		total := item.TotalPrice()
+		lines = append(lines, InvoiceLine{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Total:     total,
+		})
+		subtotal = subtotal.Add(total)
+	}
+
+	tax, err := value.NewMoney((subtotal.Cents()*int64(taxRate) + 50) / 100)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tax: %w", err)
+	}
+
+	now := time.Now()
+	return &Invoice{
+		id:         id,
+		orderID:    order.ID(),
+		customerID: order.CustomerID(),
+		lines:      lines,
+		subtotal:   subtotal,
+		taxRate:    taxRate,
+		tax:        tax,
+		total:      subtotal.Add(tax),
+		payments:   make(map[value.PaymentID]Payment),
+		status:     InvoiceStatusIssued,
+		issuedAt:   now,
+		updatedAt:  now,
+	}, nil
+}
+
+// NewInvoiceWithState creates an Invoice entity with explicit state (for restoration from persistence)
+func NewInvoiceWithState(
+	id value.InvoiceID,
+	orderID value.OrderID,
+	customerID value.CustomerID,
+	lines []InvoiceLine,
+	subtotal value.Money,
+	taxRate int,
+	tax value.Money,
+	total value.Money,
+	payments map[value.PaymentID]Payment,
+	status InvoiceStatus,
+	issuedAt time.Time,
+	updatedAt time.Time,
+) (*Invoice, error) {
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("invoice must have at least one line")
+	}
+
+	invoice := &Invoice{
+		id:         id,
+		orderID:    orderID,
+		customerID: customerID,
+		lines:      make([]InvoiceLine, len(lines)),
+		subtotal:   subtotal,
+		taxRate:    taxRate,
+		tax:        tax,
+		total:      total,
+		payments:   make(map[value.PaymentID]Payment, len(payments)),
+		status:     status,
+		issuedAt:   issuedAt,
+		updatedAt:  updatedAt,
+	}
+
+	// Copy lines and payments
+	copy(invoice.lines, lines)
+	for paymentID, payment := range payments {
+		invoice.payments[paymentID] = payment
+	}
+
+	return invoice, nil
+}
+
+// ID returns the invoice ID
+func (i *Invoice) ID() value.InvoiceID {
+	return i.id
+}
+
+// OrderID returns the ID of the billed order
+func (i *Invoice) OrderID() value.OrderID {
+	return i.orderID
+}
+
+// CustomerID returns the ID of the billed customer
+func (i *Invoice) CustomerID() value.CustomerID {
+	return i.customerID
+}
+
+// Lines returns a copy of the invoice lines
+func (i *Invoice) Lines() []InvoiceLine {
+	lines := make([]InvoiceLine, len(i.lines))
+	copy(lines, i.lines)
+	return lines
+}
+
+// Subtotal returns the total of the lines before tax
+func (i *Invoice) Subtotal() value.Money {
+	return i.subtotal
+}
+
+// TaxRate returns the tax rate in percent
+func (i *Invoice) TaxRate() int {
+	return i.taxRate
+}
+
+// Tax returns the tax amount
+func (i *Invoice) Tax() value.Money {
+	return i.tax
+}
+
+// Total returns the amount billed, tax included
+func (i *Invoice) Total() value.Money {
+	return i.total
+}
+
+// Payments returns a copy of the payments keyed by payment ID
+func (i *Invoice) Payments() map[value.PaymentID]Payment {
+	payments := make(map[value.PaymentID]Payment, len(i.payments))
+	for paymentID, payment := range i.payments {
+		payments[paymentID] = payment
+	}
+	return payments
+}
+
+// Status returns the invoice status
+func (i *Invoice) Status() InvoiceStatus {
+	return i.status
+}
+
+// IssuedAt returns the issue timestamp
+func (i *Invoice) IssuedAt() time.Time {
+	return i.issuedAt
+}
+
+// UpdatedAt returns the last update timestamp
+func (i *Invoice) UpdatedAt() time.Time {
+	return i.updatedAt
+}
+
+// Version returns the optimistic locking version (0 until first persisted)
+func (i *Invoice) Version() int {
+	return i.version
+}
+
+// SetVersion sets the optimistic locking version (used by repositories after a read or a successful write)
+func (i *Invoice) SetVersion(version int) {
+	i.version = version
+}
+
+// AmountPaid returns the total of the payments made so far
+func (i *Invoice) AmountPaid() value.Money {
+	paid, _ := value.NewMoney(0)
+	for _, payment := range i.payments {
+		paid = paid.Add(payment.Amount)
+	}
+	return paid
+}
+
+// Balance returns the amount still to be paid
+func (i *Invoice) Balance() value.Money {
+	balance, err := i.total.Subtract(i.AmountPaid())
+	if err != nil {
+		zero, _ := value.NewMoney(0)
+		return zero
+	}
+	return balance
+}
+
+// AddPayment records a payment against the outstanding balance and updates the status
+func (i *Invoice) AddPayment(id value.PaymentID, method PaymentMethod, amount value.Money) error {
+	if !method.IsValid() {
+		return fmt.Errorf("invalid payment method: %s", method)
+	}
+	if !amount.IsPositive() {
+		return fmt.Errorf("payment amount must be positive")
+	}
+	if i.status == InvoiceStatusPaid {
+		return fmt.Errorf("invoice is already paid")
+	}
+	if _, ok := i.payments[id]; ok {
+		return fmt.Errorf("payment already recorded: %s", id)
+	}
+	if amount.GreaterThan(i.Balance()) {
+		return fmt.Errorf("payment of %s exceeds the outstanding balance of %s", amount, i.Balance())
+	}
+
+	now := time.Now()
+	i.payments[id] = Payment{
+		Method: method,
+		Amount: amount,
+		PaidAt: now,
+	}
+	if i.Balance().IsZero() {
+		i.status = InvoiceStatusPaid
+	} else {
+		i.status = InvoiceStatusPartiallyPaid
+	}
+	i.updatedAt = now
+	return nil
+}
+
+// IsPaid checks if the invoice is fully paid
+func (i *Invoice) IsPaid() bool {
+	return i.status == InvoiceStatusPaid
+}
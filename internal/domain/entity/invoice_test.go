@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestInvoice(t *testing.T) {
+	invoiceID, _ := value.NewInvoiceID("invoice-123")
+	orderID, _ := value.NewOrderID("order-123")
+	customerID, _ := value.NewCustomerID("customer-123")
+	productA, _ := value.NewProductID("product-a")
+	productB, _ := value.NewProductID("product-b")
+
+	newOrder := func(t *testing.T) *Order {
+		priceA, _ := value.NewMoney(1999)
+		priceB, _ := value.NewMoney(500)
+		itemA, _ := NewOrderItem(productA, 2, priceA)
+		itemB, _ := NewOrderItem(productB, 1, priceB)
+		order, err := NewOrder(orderID, customerID, []OrderItem{*itemA, *itemB})
+		require.NoError(t, err)
+		return order
+	}
+
+	money := func(cents int64) value.Money {
+		m, _ := value.NewMoney(cents)
+		return m
+	}
+
+	t.Run("issue invoice for an order", func(t *testing.T) {
+		invoice, err := NewInvoice(invoiceID, newOrder(t), DefaultTaxRate)
+
+		require.NoError(t, err)
+		assert.Equal(t, orderID, invoice.OrderID())
+		assert.Equal(t, customerID, invoice.CustomerID())
+		require.Len(t, invoice.Lines(), 2)
+		assert.Equal(t, int64(3998), invoice.Lines()[0].Total.Cents())
+		assert.Equal(t, int64(4498), invoice.Subtotal().Cents())
+		assert.Equal(t, int64(450), invoice.Tax().Cents()) // 449.8 rounds up
+		assert.Equal(t, int64(4948), invoice.Total().Cents())
+		assert.Equal(t, InvoiceStatusIssued, invoice.Status())
+		assert.Equal(t, int64(4948), invoice.Balance().Cents())
+		assert.Empty(t, invoice.Payments())
+	})
+
+	t.Run("cancelled order cannot be invoiced", func(t *testing.T) {
+		order := newOrder(t)
+		require.NoError(t, order.Cancel())
+
+		_, err := NewInvoice(invoiceID, order, DefaultTaxRate)
+		assert.Error(t, err)
+	})
+
+	t.Run("payments settle the balance", func(t *testing.T) {
+		invoice, _ := NewInvoice(invoiceID, newOrder(t), DefaultTaxRate)
+
+		require.NoError(t, invoice.AddPayment("payment-1", PaymentMethodGiftCard, money(1000)))
+		assert.Equal(t, InvoiceStatusPartiallyPaid, invoice.Status())
+		assert.Equal(t, int64(3948), invoice.Balance().Cents())
+
+		require.NoError(t, invoice.AddPayment("payment-2", PaymentMethodCreditCard, money(3948)))
+		assert.True(t, invoice.IsPaid())
+		assert.True(t, invoice.Balance().IsZero())
+		assert.Equal(t, int64(4948), invoice.AmountPaid().Cents())
+		assert.Len(t, invoice.Payments(), 2)
+
+		assert.Error(t, invoice.AddPayment("payment-3", PaymentMethodCreditCard, money(1)))
+	})
+
+	t.Run("invalid payments are rejected", func(t *testing.T) {
+		invoice, _ := NewInvoice(invoiceID, newOrder(t), DefaultTaxRate)
+
+		assert.Error(t, invoice.AddPayment("payment-1", PaymentMethod("cash"), money(100)))
+		assert.Error(t, invoice.AddPayment("payment-1", PaymentMethodCreditCard, money(0)))
+		assert.Error(t, invoice.AddPayment("payment-1", PaymentMethodCreditCard, money(4949)))
+
+		require.NoError(t, invoice.AddPayment("payment-1", PaymentMethodCreditCard, money(100)))
+		assert.Error(t, invoice.AddPayment("payment-1", PaymentMethodCreditCard, money(100)))
+		assert.Equal(t, int64(100), invoice.AmountPaid().Cents())
+	})
+}
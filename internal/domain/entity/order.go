@@ -16,8 +16,35 @@ const (
 	OrderStatusShipped   OrderStatus = "shipped"
 	OrderStatusDelivered OrderStatus = "delivered"
 	OrderStatusCancelled OrderStatus = "cancelled"
+	// Set by approved returns: some of the delivered units, or all of them, came back
+	OrderStatusPartiallyRefunded OrderStatus = "partially_refunded"
+	OrderStatusReturned          OrderStatus = "returned"
 )
 
+// IsValid checks if the order status is a known status
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderStatusPending, OrderStatusConfirmed, OrderStatusShipped, OrderStatusDelivered, OrderStatusCancelled,
+		OrderStatusPartiallyRefunded, OrderStatusReturned:
+		return true
+	default:
+		return false
+	}
+}
+
+// SystemActor is the actor of status changes that no user asked for, e.g. an order becoming
+// shipped once its shipments cover every ordered unit
+const SystemActor = "system"
+
+// OrderStatusChange is one entry of an order's status history
+type OrderStatusChange struct {
+	From      OrderStatus // Previous status, empty for the entry of a newly placed order
+	To        OrderStatus
+	Actor     string // Who made the change
+	Reason    string
+	ChangedAt time.Time
+}
+
 // OrderItem represents an item in an order
 type OrderItem struct {
 	ProductID value.ProductID
@@ -56,13 +83,25 @@ type Order struct {
 	total      value.Money
 	createdAt  time.Time
 	updatedAt  time.Time
+	version    int
+	events
+
+	// Status changes made since the order was loaded, appended to its history when it is saved
+	statusChanges []OrderStatusChange
 }
 
+// MaxOrderItems is the most lines an order can have. Placing an order writes every line together
+// with its stock reservations in one transaction, which has a limited size.
+const MaxOrderItems = 20
+
 // NewOrder creates a new Order entity
 func NewOrder(id value.OrderID, customerID value.CustomerID, items []OrderItem) (*Order, error) {
 	if len(items) == 0 {
 		return nil, fmt.Errorf("order must have at least one item")
 	}
+	if len(items) > MaxOrderItems {
+		return nil, fmt.Errorf("order cannot have more than %d items", MaxOrderItems)
+	}
 
 	now := time.Now()
 	order := &Order{
@@ -78,6 +117,19 @@ func NewOrder(id value.OrderID, customerID value.CustomerID, items []OrderItem)
 	copy(order.items, items)
 	order.calculateTotal()
 
+	order.statusChanges = []OrderStatusChange{{
+		To:        OrderStatusPending,
+		Actor:     SystemActor,
+		Reason:    "order placed",
+		ChangedAt: now,
+	}}
+	order.record(OrderPlaced{
+		OrderID:    id,
+		CustomerID: customerID,
+		Items:      order.Items(),
+		Total:      order.total,
+		At:         now,
+	})
 	return order, nil
 }
 
@@ -148,13 +200,22 @@ func (o *Order) UpdatedAt() time.Time {
 	return o.updatedAt
 }
 
+// Version returns the optimistic locking version (0 until first persisted)
+func (o *Order) Version() int {
+	return o.version
+}
+
+// SetVersion sets the optimistic locking version (used by repositories after a read or a successful write)
+func (o *Order) SetVersion(version int) {
+	o.version = version
+}
+
 // Confirm changes the order status to confirmed
 func (o *Order) Confirm() error {
 	if o.status != OrderStatusPending {
 		return fmt.Errorf("can only confirm pending orders, current status: %s", o.status)
 	}
-	o.status = OrderStatusConfirmed
-	o.updatedAt = time.Now()
+	o.changeStatus(OrderStatusConfirmed)
 	return nil
 }
 
@@ -163,8 +224,7 @@ func (o *Order) Ship() error {
 	if o.status != OrderStatusConfirmed {
 		return fmt.Errorf("can only ship confirmed orders, current status: %s", o.status)
 	}
-	o.status = OrderStatusShipped
-	o.updatedAt = time.Now()
+	o.changeStatus(OrderStatusShipped)
 	return nil
 }
 
@@ -173,21 +233,71 @@ func (o *Order) Deliver() error {
 	if o.status != OrderStatusShipped {
 		return fmt.Errorf("can only deliver shipped orders, current status: %s", o.status)
 	}
-	o.status = OrderStatusDelivered
-	o.updatedAt = time.Now()
+	o.changeStatus(OrderStatusDelivered)
 	return nil
 }
 
-// Cancel changes the order status to cancelled
+// Cancel changes the order status to cancelled. Orders whose goods have left a warehouse
+// cannot be cancelled.
 func (o *Order) Cancel() error {
-	if o.status == OrderStatusDelivered || o.status == OrderStatusCancelled {
-		return fmt.Errorf("cannot cancel order with status: %s", o.status)
+	if o.status != OrderStatusPending && o.status != OrderStatusConfirmed {
+		return fmt.Errorf("can only cancel pending or confirmed orders, current status: %s", o.status)
 	}
-	o.status = OrderStatusCancelled
-	o.updatedAt = time.Now()
+	o.changeStatus(OrderStatusCancelled)
 	return nil
 }
 
+// changeStatus moves the order to a new status and records the transition. The change is
+// timestamped after the previous one so that the history keeps its order even when two
+// transitions fall on the same clock tick.
+func (o *Order) changeStatus(status OrderStatus) {
+	from := o.status
+	now := time.Now()
+	if !now.After(o.updatedAt) {
+		now = o.updatedAt.Add(time.Nanosecond)
+	}
+	o.status = status
+	o.updatedAt = now
+	o.statusChanges = append(o.statusChanges, OrderStatusChange{
+		From:      from,
+		To:        status,
+		Actor:     SystemActor,
+		ChangedAt: now,
+	})
+	o.record(OrderStatusChanged{
+		OrderID:    o.id,
+		CustomerID: o.customerID,
+		From:       from,
+		To:         status,
+		At:         o.updatedAt,
+	})
+}
+
+// AttributeStatusChanges records who made the unsaved status changes and why. An empty actor
+// or reason leaves the recorded one in place.
+func (o *Order) AttributeStatusChanges(actor, reason string) {
+	for i := range o.statusChanges {
+		if actor != "" {
+			o.statusChanges[i].Actor = actor
+		}
+		if reason != "" {
+			o.statusChanges[i].Reason = reason
+		}
+	}
+}
+
+// UnsavedStatusChanges returns the status changes made since the order was loaded, oldest first
+func (o *Order) UnsavedStatusChanges() []OrderStatusChange {
+	changes := make([]OrderStatusChange, len(o.statusChanges))
+	copy(changes, o.statusChanges)
+	return changes
+}
+
+// MarkStatusChangesSaved clears the unsaved status changes (used by repositories after a successful write)
+func (o *Order) MarkStatusChangesSaved() {
+	o.statusChanges = nil
+}
+
 // IsPending checks if the order is pending
 func (o *Order) IsPending() bool {
 	return o.status == OrderStatusPending
@@ -216,11 +326,114 @@ func (o *Order) UpdateStatus(newStatus OrderStatus) error {
 		return o.Deliver()
 	case OrderStatusCancelled:
 		return o.Cancel()
+	case OrderStatusPartiallyRefunded, OrderStatusReturned:
+		return fmt.Errorf("status %s is set by approving returns", newStatus)
 	default:
 		return fmt.Errorf("invalid order status: %s", newStatus)
 	}
 }
 
+// ApplyShipments checks every shipment of the order against the ordered quantities and moves the
+// order along: it becomes shipped once every ordered unit has left a warehouse, and delivered once
+// all of those shipments have arrived. A partially shipped order stays confirmed.
+func (o *Order) ApplyShipments(shipments []*Shipment) error {
+	if o.status != OrderStatusConfirmed && o.status != OrderStatusShipped && o.status != OrderStatusDelivered {
+		return fmt.Errorf("can only ship confirmed orders, current status: %s", o.status)
+	}
+
+	ordered := make(map[value.ProductID]int, len(o.items))
+	for _, item := range o.items {
+		ordered[item.ProductID] += item.Quantity
+	}
+
+	shipped := make(map[value.ProductID]int, len(ordered))
+	delivered := true
+	for _, shipment := range shipments {
+		if shipment.OrderID() != o.id {
+			return fmt.Errorf("shipment %s belongs to another order", shipment.ID())
+		}
+		for _, item := range shipment.Items() {
+			if _, ok := ordered[item.ProductID]; !ok {
+				return fmt.Errorf("product is not part of the order: %s", item.ProductID)
+			}
+			shipped[item.ProductID] += item.Quantity
+		}
+		if !shipment.IsDelivered() {
+			delivered = false
+		}
+	}
+
+	complete := true
+	for productID, quantity := range ordered {
+		if shipped[productID] > quantity {
+			return fmt.Errorf("shipped quantity exceeds ordered quantity for product: %s", productID)
+		}
+		if shipped[productID] < quantity {
+			complete = false
+		}
+	}
+
+	if complete && o.status == OrderStatusConfirmed {
+		if err := o.Ship(); err != nil {
+			return err
+		}
+	}
+	if complete && delivered && o.status == OrderStatusShipped {
+		return o.Deliver()
+	}
+	return nil
+}
+
+// ApplyReturns checks the approved returns of the order against the ordered quantities and moves
+// the order along: it becomes partially refunded once some of its units have been returned, and
+// returned once all of them have. Requested and rejected returns do not change the order.
+func (o *Order) ApplyReturns(returns []*ReturnRequest) error {
+	if o.status != OrderStatusDelivered && o.status != OrderStatusPartiallyRefunded && o.status != OrderStatusReturned {
+		return fmt.Errorf("can only return delivered orders, current status: %s", o.status)
+	}
+
+	ordered := make(map[value.ProductID]int, len(o.items))
+	for _, item := range o.items {
+		ordered[item.ProductID] += item.Quantity
+	}
+
+	returned := make(map[value.ProductID]int, len(ordered))
+	anyReturned := false
+	for _, request := range returns {
+		if request.OrderID() != o.id {
+			return fmt.Errorf("return request %s belongs to another order", request.ID())
+		}
+		if !request.IsApproved() {
+			continue
+		}
+		for _, item := range request.Items() {
+			if _, ok := ordered[item.ProductID]; !ok {
+				return fmt.Errorf("product is not part of the order: %s", item.ProductID)
+			}
+			returned[item.ProductID] += item.Quantity
+			anyReturned = true
+		}
+	}
+
+	complete := true
+	for productID, quantity := range ordered {
+		if returned[productID] > quantity {
+			return fmt.Errorf("returned quantity exceeds ordered quantity for product: %s", productID)
+		}
+		if returned[productID] < quantity {
+			complete = false
+		}
+	}
+
+	switch {
+	case complete && o.status != OrderStatusReturned:
+		o.changeStatus(OrderStatusReturned)
+	case anyReturned && !complete && o.status == OrderStatusDelivered:
+		o.changeStatus(OrderStatusPartiallyRefunded)
+	}
+	return nil
+}
+
 // ItemCount returns the total number of items in the order
 func (o *Order) ItemCount() int {
 	total := 0
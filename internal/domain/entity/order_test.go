@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestNewOrder(t *testing.T) {
+	customerID, _ := value.NewCustomerID("customer-123")
+	productID, _ := value.NewProductID("product-123")
+	price, _ := value.NewMoney(1000)
+	item, _ := NewOrderItem(productID, 1, price)
+
+	items := make([]OrderItem, MaxOrderItems+1)
+	for i := range items {
+		items[i] = *item
+	}
+
+	_, err := NewOrder(value.OrderID("order-123"), customerID, items[:MaxOrderItems])
+	assert.NoError(t, err)
+
+	_, err = NewOrder(value.OrderID("order-123"), customerID, items)
+	assert.Error(t, err)
+
+	_, err = NewOrder(value.OrderID("order-123"), customerID, nil)
+	assert.Error(t, err)
+}
+
+func TestOrderStatusChanges(t *testing.T) {
+	// Setup test data
+	customerID, _ := value.NewCustomerID("customer-123")
+	productID, _ := value.NewProductID("product-123")
+	price, _ := value.NewMoney(1000)
+	item, _ := NewOrderItem(productID, 1, price)
+
+	t.Run("new order starts its history as pending", func(t *testing.T) {
+		order, err := NewOrder(value.OrderID("order-123"), customerID, []OrderItem{*item})
+		require.NoError(t, err)
+
+		changes := order.UnsavedStatusChanges()
+		require.Len(t, changes, 1)
+		assert.Equal(t, OrderStatusChange{
+			To:        OrderStatusPending,
+			Actor:     SystemActor,
+			Reason:    "order placed",
+			ChangedAt: order.CreatedAt(),
+		}, changes[0])
+	})
+
+	t.Run("transitions are recorded in order and attributed", func(t *testing.T) {
+		// A clock ahead of now must not put later changes before earlier ones
+		updatedAt := time.Now().Add(time.Hour)
+		order, err := NewOrderWithState(value.OrderID("order-123"), customerID, []OrderItem{*item}, OrderStatusPending, price, updatedAt, updatedAt)
+		require.NoError(t, err)
+		assert.Empty(t, order.UnsavedStatusChanges())
+
+		require.NoError(t, order.Confirm())
+		require.NoError(t, order.Ship())
+		assert.Error(t, order.Confirm())
+		order.AttributeStatusChanges("clerk", "")
+
+		changes := order.UnsavedStatusChanges()
+		require.Len(t, changes, 2)
+		assert.Equal(t, OrderStatusPending, changes[0].From)
+		assert.Equal(t, OrderStatusConfirmed, changes[0].To)
+		assert.Equal(t, OrderStatusConfirmed, changes[1].From)
+		assert.Equal(t, OrderStatusShipped, changes[1].To)
+		assert.True(t, changes[0].ChangedAt.After(updatedAt))
+		assert.True(t, changes[1].ChangedAt.After(changes[0].ChangedAt))
+		for _, change := range changes {
+			assert.Equal(t, "clerk", change.Actor)
+			assert.Empty(t, change.Reason)
+		}
+
+		order.MarkStatusChangesSaved()
+		assert.Empty(t, order.UnsavedStatusChanges())
+	})
+}
+
+func TestOrderCancel(t *testing.T) {
+	customerID, _ := value.NewCustomerID("customer-123")
+	productID, _ := value.NewProductID("product-123")
+	price, _ := value.NewMoney(1000)
+	item, _ := NewOrderItem(productID, 1, price)
+
+	for _, status := range []OrderStatus{OrderStatusPending, OrderStatusConfirmed} {
+		order, err := NewOrderWithState(value.OrderID("order-123"), customerID, []OrderItem{*item}, status, price, time.Now(), time.Now())
+		require.NoError(t, err)
+		assert.NoError(t, order.Cancel(), status)
+		assert.True(t, order.IsCancelled())
+	}
+
+	// Orders whose goods have left a warehouse, or that are already cancelled, stay as they are
+	for _, status := range []OrderStatus{OrderStatusShipped, OrderStatusDelivered, OrderStatusCancelled} {
+		order, err := NewOrderWithState(value.OrderID("order-123"), customerID, []OrderItem{*item}, status, price, time.Now(), time.Now())
+		require.NoError(t, err)
+		assert.Error(t, order.Cancel(), status)
+		assert.Equal(t, status, order.Status())
+	}
+}
+
+func TestOrderApplyReturns(t *testing.T) {
+	customerID, _ := value.NewCustomerID("customer-123")
+	warehouseID, _ := value.NewWarehouseID("warehouse-123")
+	productA, _ := value.NewProductID("product-a")
+	productB, _ := value.NewProductID("product-b")
+	price, _ := value.NewMoney(1000)
+	itemA, _ := NewOrderItem(productA, 2, price)
+	itemB, _ := NewOrderItem(productB, 1, price)
+	total, _ := value.NewMoney(3000)
+
+	newOrder := func(t *testing.T, status OrderStatus) *Order {
+		order, err := NewOrderWithState(value.OrderID("order-123"), customerID, []OrderItem{*itemA, *itemB}, status, total, time.Now(), time.Now())
+		require.NoError(t, err)
+		return order
+	}
+	approved := func(t *testing.T, order *Order, id string, previous []*ReturnRequest, items ...ReturnItem) *ReturnRequest {
+		request, err := NewReturnRequest(value.ReturnID(id), order, items, "Damaged", previous)
+		require.NoError(t, err)
+		require.NoError(t, request.Approve(warehouseID, ""))
+		return request
+	}
+
+	t.Run("partial then complete returns", func(t *testing.T) {
+		order := newOrder(t, OrderStatusDelivered)
+		first := approved(t, order, "return-1", nil, ReturnItem{ProductID: productA, Quantity: 2})
+
+		require.NoError(t, order.ApplyReturns([]*ReturnRequest{first}))
+		assert.Equal(t, OrderStatusPartiallyRefunded, order.Status())
+
+		second := approved(t, order, "return-2", []*ReturnRequest{first}, ReturnItem{ProductID: productB, Quantity: 1})
+		require.NoError(t, order.ApplyReturns([]*ReturnRequest{first, second}))
+		assert.Equal(t, OrderStatusReturned, order.Status())
+
+		changes := order.UnsavedStatusChanges()
+		require.Len(t, changes, 2)
+		assert.Equal(t, OrderStatusPartiallyRefunded, changes[0].To)
+		assert.Equal(t, OrderStatusReturned, changes[1].To)
+	})
+
+	t.Run("requested returns do not change the order", func(t *testing.T) {
+		order := newOrder(t, OrderStatusDelivered)
+		request, err := NewReturnRequest("return-1", order, []ReturnItem{{ProductID: productA, Quantity: 1}}, "Damaged", nil)
+		require.NoError(t, err)
+
+		require.NoError(t, order.ApplyReturns([]*ReturnRequest{request}))
+		assert.Equal(t, OrderStatusDelivered, order.Status())
+	})
+
+	t.Run("orders that were not delivered cannot be returned", func(t *testing.T) {
+		order := newOrder(t, OrderStatusShipped)
+		assert.Error(t, order.ApplyReturns(nil))
+	})
+
+	t.Run("return states are not set through UpdateStatus", func(t *testing.T) {
+		order := newOrder(t, OrderStatusDelivered)
+		assert.Error(t, order.UpdateStatus(OrderStatusPartiallyRefunded))
+		assert.Error(t, order.UpdateStatus(OrderStatusReturned))
+		assert.True(t, OrderStatusReturned.IsValid())
+	})
+}
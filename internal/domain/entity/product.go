@@ -16,6 +16,8 @@ type Product struct {
 	stock       int
 	createdAt   time.Time
 	updatedAt   time.Time
+	version     int
+	events
 }
 
 // NewProduct creates a new Product entity
@@ -39,6 +41,33 @@ func NewProduct(id value.ProductID, name, description string, price value.Money,
 	}, nil
 }
 
+// NewProductWithState creates a Product entity with explicit state (for restoration from persistence)
+func NewProductWithState(
+	id value.ProductID,
+	name, description string,
+	price value.Money,
+	stock int,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (*Product, error) {
+	if name == "" {
+		return nil, fmt.Errorf("product name cannot be empty")
+	}
+	if stock < 0 {
+		return nil, fmt.Errorf("product stock cannot be negative")
+	}
+
+	return &Product{
+		id:          id,
+		name:        name,
+		description: description,
+		price:       price,
+		stock:       stock,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}, nil
+}
+
 // ID returns the product ID
 func (p *Product) ID() value.ProductID {
 	return p.id
@@ -59,7 +88,7 @@ func (p *Product) Price() value.Money {
 	return p.price
 }
 
-// Stock returns the current stock level
+// Stock returns the current stock level, the total of the product's inventory across all warehouses
 func (p *Product) Stock() int {
 	return p.stock
 }
@@ -74,6 +103,16 @@ func (p *Product) UpdatedAt() time.Time {
 	return p.updatedAt
 }
 
+// Version returns the optimistic locking version (0 until first persisted)
+func (p *Product) Version() int {
+	return p.version
+}
+
+// SetVersion sets the optimistic locking version (used by repositories after a read or a successful write)
+func (p *Product) SetVersion(version int) {
+	p.version = version
+}
+
 // UpdatePrice updates the product price
 func (p *Product) UpdatePrice(price value.Money) {
 	p.price = price
@@ -97,6 +136,7 @@ func (p *Product) AddStock(amount int) error {
 	}
 	p.stock += amount
 	p.updatedAt = time.Now()
+	p.record(StockAdded{ProductID: p.id, Quantity: amount, At: p.updatedAt})
 	return nil
 }
 
@@ -110,6 +150,7 @@ func (p *Product) ReserveStock(amount int) error {
 	}
 	p.stock -= amount
 	p.updatedAt = time.Now()
+	p.record(StockReserved{ProductID: p.id, Quantity: amount, At: p.updatedAt})
 	return nil
 }
 
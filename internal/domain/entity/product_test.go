@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -29,6 +30,25 @@ func TestProduct(t *testing.T) {
 		assert.False(t, product.UpdatedAt().IsZero())
 	})
 
+	t.Run("restore product with state", func(t *testing.T) {
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		updatedAt := createdAt.Add(48 * time.Hour)
+
+		product, err := NewProductWithState(productID, name, description, price, stock, createdAt, updatedAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, productID, product.ID())
+		assert.Equal(t, name, product.Name())
+		assert.Equal(t, description, product.Description())
+		assert.Equal(t, price, product.Price())
+		assert.Equal(t, stock, product.Stock())
+		assert.Equal(t, createdAt, product.CreatedAt())
+		assert.Equal(t, updatedAt, product.UpdatedAt())
+
+		_, err = NewProductWithState(productID, "", description, price, stock, createdAt, updatedAt)
+		assert.Error(t, err)
+	})
+
 	t.Run("create product with empty name should fail", func(t *testing.T) {
 		_, err := NewProduct(productID, "", description, price, stock)
 		assert.Error(t, err)
@@ -119,6 +139,14 @@ func TestProduct(t *testing.T) {
 		assert.Contains(t, err.Error(), "name cannot be empty")
 	})
 
+	t.Run("product version", func(t *testing.T) {
+		product, _ := NewProduct(productID, name, description, price, stock)
+		assert.Equal(t, 0, product.Version()) // Not yet persisted
+
+		product.SetVersion(3)
+		assert.Equal(t, 3, product.Version())
+	})
+
 	t.Run("product equals", func(t *testing.T) {
 		product1, _ := NewProduct(productID, name, description, price, stock)
 		product2, _ := NewProduct(productID, "Different Name", description, price, stock)
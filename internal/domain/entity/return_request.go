@@ -0,0 +1,314 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"dynamo-modeling/internal/domain/value"
+)
+
+// ReturnStatus represents the decision on a return request
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+)
+
+// IsValid checks if the return status is a known status
+func (s ReturnStatus) IsValid() bool {
+	switch s {
+	case ReturnStatusRequested, ReturnStatusApproved, ReturnStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReturnItem represents the quantity of one ordered product a customer sends back
+type ReturnItem struct {
+	ProductID value.ProductID
+	Quantity  int
+	UnitPrice value.Money // Price paid, taken from the order line
+}
+
+// TotalPrice calculates the amount refunded for this item
+func (ri *ReturnItem) TotalPrice() value.Money {
+	total, _ := ri.UnitPrice.Multiply(float64(ri.Quantity))
+	return total
+}
+
+// ReturnRequest represents a customer's request to send back some or all lines of a delivered
+// order (an RMA). Back-office staff approve it, which refunds the returned lines and puts the
+// goods back into a warehouse, or reject it.
+type ReturnRequest struct {
+	events
+	id          value.ReturnID
+	orderID     value.OrderID
+	customerID  value.CustomerID
+	items       []ReturnItem
+	reason      string
+	status      ReturnStatus
+	refund      value.Money       // Zero until approved
+	warehouseID value.WarehouseID // Warehouse receiving the goods, empty until approved
+	note        string            // Staff note on the decision
+	requestedAt time.Time
+	decidedAt   time.Time
+	updatedAt   time.Time
+	version     int
+}
+
+// NewReturnRequest creates a requested return for lines of a delivered order. Only the product
+// and quantity of items are used; the unit prices are taken from the order so that the refund
+// matches what the customer paid. previous are the order's earlier return requests: units that
+// are already requested or returned cannot be requested again.
+func NewReturnRequest(id value.ReturnID, order *Order, items []ReturnItem, reason string, previous []*ReturnRequest) (*ReturnRequest, error) {
+	if order.Status() != OrderStatusDelivered && order.Status() != OrderStatusPartiallyRefunded {
+		return nil, fmt.Errorf("can only return delivered orders, current status: %s", order.Status())
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("reason cannot be empty")
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("return must have at least one item")
+	}
+
+	returnable := make(map[value.ProductID]int, len(order.Items()))
+	prices := make(map[value.ProductID]value.Money, len(order.Items()))
+	for _, item := range order.Items() {
+		returnable[item.ProductID] += item.Quantity
+		prices[item.ProductID] = item.UnitPrice
+	}
+	for _, request := range previous {
+		if request.OrderID() != order.ID() {
+			return nil, fmt.Errorf("return request %s belongs to another order", request.ID())
+		}
+		if request.Status() == ReturnStatusRejected {
+			continue
+		}
+		for _, item := range request.Items() {
+			returnable[item.ProductID] -= item.Quantity
+		}
+	}
+
+	returned := make([]ReturnItem, 0, len(items))
+	seen := make(map[value.ProductID]bool, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("quantity must be positive")
+		}
+		if seen[item.ProductID] {
+			return nil, fmt.Errorf("product appears more than once in return: %s", item.ProductID)
+		}
+		seen[item.ProductID] = true
+
+		price, ok := prices[item.ProductID]
+		if !ok {
+			return nil, fmt.Errorf("product is not part of the order: %s", item.ProductID)
+		}
+		if item.Quantity > returnable[item.ProductID] {
+			return nil, fmt.Errorf("returned quantity exceeds returnable quantity for product: %s", item.ProductID)
+		}
+		returned = append(returned, ReturnItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			UnitPrice: price,
+		})
+	}
+
+	now := time.Now()
+	request := &ReturnRequest{
+		id:          id,
+		orderID:     order.ID(),
+		customerID:  order.CustomerID(),
+		items:       returned,
+		reason:      strings.TrimSpace(reason),
+		status:      ReturnStatusRequested,
+		requestedAt: now,
+		updatedAt:   now,
+	}
+	request.record(ReturnRequested{
+		ReturnID:   id,
+		OrderID:    order.ID(),
+		CustomerID: order.CustomerID(),
+		At:         now,
+	})
+	return request, nil
+}
+
+// NewReturnRequestWithState creates a ReturnRequest entity with explicit state (for restoration from persistence)
+func NewReturnRequestWithState(
+	id value.ReturnID,
+	orderID value.OrderID,
+	customerID value.CustomerID,
+	items []ReturnItem,
+	reason string,
+	status ReturnStatus,
+	refund value.Money,
+	warehouseID value.WarehouseID,
+	note string,
+	requestedAt time.Time,
+	decidedAt time.Time,
+	updatedAt time.Time,
+) (*ReturnRequest, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("return must have at least one item")
+	}
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid return status: %s", status)
+	}
+
+	request := &ReturnRequest{
+		id:          id,
+		orderID:     orderID,
+		customerID:  customerID,
+		items:       make([]ReturnItem, len(items)),
+		reason:      reason,
+		status:      status,
+		refund:      refund,
+		warehouseID: warehouseID,
+		note:        note,
+		requestedAt: requestedAt,
+		decidedAt:   decidedAt,
+		updatedAt:   updatedAt,
+	}
+
+	// Copy items
+	copy(request.items, items)
+
+	return request, nil
+}
+
+// ID returns the return request ID
+func (r *ReturnRequest) ID() value.ReturnID {
+	return r.id
+}
+
+// OrderID returns the ID of the returned order
+func (r *ReturnRequest) OrderID() value.OrderID {
+	return r.orderID
+}
+
+// CustomerID returns the ID of the customer who ordered
+func (r *ReturnRequest) CustomerID() value.CustomerID {
+	return r.customerID
+}
+
+// Items returns a copy of the returned items
+func (r *ReturnRequest) Items() []ReturnItem {
+	items := make([]ReturnItem, len(r.items))
+	copy(items, r.items)
+	return items
+}
+
+// Reason returns why the customer sends the goods back
+func (r *ReturnRequest) Reason() string {
+	return r.reason
+}
+
+// Status returns the return status
+func (r *ReturnRequest) Status() ReturnStatus {
+	return r.status
+}
+
+// Refund returns the amount refunded (zero until approved)
+func (r *ReturnRequest) Refund() value.Money {
+	return r.refund
+}
+
+// WarehouseID returns the warehouse receiving the goods (empty until approved)
+func (r *ReturnRequest) WarehouseID() value.WarehouseID {
+	return r.warehouseID
+}
+
+// Note returns the staff note on the decision
+func (r *ReturnRequest) Note() string {
+	return r.note
+}
+
+// RequestedAt returns the request timestamp
+func (r *ReturnRequest) RequestedAt() time.Time {
+	return r.requestedAt
+}
+
+// DecidedAt returns the decision timestamp (zero until decided)
+func (r *ReturnRequest) DecidedAt() time.Time {
+	return r.decidedAt
+}
+
+// UpdatedAt returns the last update timestamp
+func (r *ReturnRequest) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// Version returns the optimistic locking version (0 until first persisted)
+func (r *ReturnRequest) Version() int {
+	return r.version
+}
+
+// SetVersion sets the optimistic locking version (used by repositories after a read or a successful write)
+func (r *ReturnRequest) SetVersion(version int) {
+	r.version = version
+}
+
+// Approve accepts the return: the goods go back into the given warehouse and the returned lines
+// are refunded at the price paid
+func (r *ReturnRequest) Approve(warehouseID value.WarehouseID, note string) error {
+	if r.status != ReturnStatusRequested {
+		return fmt.Errorf("can only approve requested returns, current status: %s", r.status)
+	}
+	if warehouseID.IsEmpty() {
+		return fmt.Errorf("warehouse receiving the goods cannot be empty")
+	}
+
+	refund, _ := value.NewMoney(0)
+	for _, item := range r.items {
+		refund = refund.Add(item.TotalPrice())
+	}
+
+	r.decide(ReturnStatusApproved, note)
+	r.refund = refund
+	r.warehouseID = warehouseID
+	r.record(ReturnApproved{
+		ReturnID:   r.id,
+		OrderID:    r.orderID,
+		CustomerID: r.customerID,
+		Refund:     refund,
+		At:         r.decidedAt,
+	})
+	return nil
+}
+
+// Reject refuses the return with a note telling the customer why
+func (r *ReturnRequest) Reject(note string) error {
+	if r.status != ReturnStatusRequested {
+		return fmt.Errorf("can only reject requested returns, current status: %s", r.status)
+	}
+	if strings.TrimSpace(note) == "" {
+		return fmt.Errorf("note cannot be empty when rejecting a return")
+	}
+
+	r.decide(ReturnStatusRejected, note)
+	r.record(ReturnRejected{
+		ReturnID:   r.id,
+		OrderID:    r.orderID,
+		CustomerID: r.customerID,
+		At:         r.decidedAt,
+	})
+	return nil
+}
+
+func (r *ReturnRequest) decide(status ReturnStatus, note string) {
+	now := time.Now()
+	r.status = status
+	r.note = strings.TrimSpace(note)
+	r.decidedAt = now
+	r.updatedAt = now
+}
+
+// IsApproved checks if the return has been approved
+func (r *ReturnRequest) IsApproved() bool {
+	return r.status == ReturnStatusApproved
+}
@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestReturnRequest(t *testing.T) {
+	orderID, _ := value.NewOrderID("order-123")
+	customerID, _ := value.NewCustomerID("customer-123")
+	warehouseID, _ := value.NewWarehouseID("warehouse-123")
+	productA, _ := value.NewProductID("product-a")
+	productB, _ := value.NewProductID("product-b")
+
+	// newDeliveredOrder returns a delivered order for two A at 10.00 and one B at 25.00
+	newDeliveredOrder := func(t *testing.T) *Order {
+		priceA, _ := value.NewMoney(1000)
+		priceB, _ := value.NewMoney(2500)
+		itemA, _ := NewOrderItem(productA, 2, priceA)
+		itemB, _ := NewOrderItem(productB, 1, priceB)
+		total, _ := value.NewMoney(4500)
+		order, err := NewOrderWithState(orderID, customerID, []OrderItem{*itemA, *itemB}, OrderStatusDelivered, total, time.Now(), time.Now())
+		require.NoError(t, err)
+		return order
+	}
+
+	newReturn := func(t *testing.T, id string, order *Order, previous []*ReturnRequest, items ...ReturnItem) *ReturnRequest {
+		request, err := NewReturnRequest(value.ReturnID(id), order, items, "Wrong size", previous)
+		require.NoError(t, err)
+		return request
+	}
+
+	t.Run("request return takes prices from the order", func(t *testing.T) {
+		order := newDeliveredOrder(t)
+		unitPrice, _ := value.NewMoney(1)
+		request := newReturn(t, "return-1", order, nil, ReturnItem{ProductID: productA, Quantity: 1, UnitPrice: unitPrice})
+
+		assert.Equal(t, orderID, request.OrderID())
+		assert.Equal(t, customerID, request.CustomerID())
+		assert.Equal(t, ReturnStatusRequested, request.Status())
+		assert.Equal(t, int64(1000), request.Items()[0].UnitPrice.Cents())
+		assert.Equal(t, int64(0), request.Refund().Cents())
+		assert.True(t, request.DecidedAt().IsZero())
+
+		events := request.PullEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, EventReturnRequested, events[0].EventName())
+	})
+
+	t.Run("invalid returns are rejected", func(t *testing.T) {
+		order := newDeliveredOrder(t)
+		item := ReturnItem{ProductID: productA, Quantity: 1}
+		missing, _ := value.NewProductID("product-missing")
+
+		_, err := NewReturnRequest("return-1", order, []ReturnItem{item}, " ", nil)
+		assert.Error(t, err)
+		_, err = NewReturnRequest("return-1", order, nil, "Wrong size", nil)
+		assert.Error(t, err)
+		_, err = NewReturnRequest("return-1", order, []ReturnItem{{ProductID: productA, Quantity: 0}}, "Wrong size", nil)
+		assert.Error(t, err)
+		_, err = NewReturnRequest("return-1", order, []ReturnItem{item, item}, "Wrong size", nil)
+		assert.Error(t, err)
+		_, err = NewReturnRequest("return-1", order, []ReturnItem{{ProductID: missing, Quantity: 1}}, "Wrong size", nil)
+		assert.Error(t, err)
+		_, err = NewReturnRequest("return-1", order, []ReturnItem{{ProductID: productA, Quantity: 3}}, "Wrong size", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("only delivered orders can be returned", func(t *testing.T) {
+		order := newDeliveredOrder(t)
+		shipped, err := NewOrderWithState(orderID, customerID, order.Items(), OrderStatusShipped, order.Total(), time.Now(), time.Now())
+		require.NoError(t, err)
+
+		_, err = NewReturnRequest("return-1", shipped, []ReturnItem{{ProductID: productA, Quantity: 1}}, "Wrong size", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("units cannot be requested twice unless rejected", func(t *testing.T) {
+		order := newDeliveredOrder(t)
+		first := newReturn(t, "return-1", order, nil, ReturnItem{ProductID: productA, Quantity: 2})
+
+		_, err := NewReturnRequest("return-2", order, []ReturnItem{{ProductID: productA, Quantity: 1}}, "Wrong size", []*ReturnRequest{first})
+		assert.Error(t, err)
+
+		require.NoError(t, first.Reject("Used item"))
+		newReturn(t, "return-2", order, []*ReturnRequest{first}, ReturnItem{ProductID: productA, Quantity: 1})
+	})
+
+	t.Run("approve computes the refund", func(t *testing.T) {
+		order := newDeliveredOrder(t)
+		request := newReturn(t, "return-1", order, nil,
+			ReturnItem{ProductID: productA, Quantity: 2},
+			ReturnItem{ProductID: productB, Quantity: 1})
+		request.PullEvents()
+
+		assert.Error(t, request.Approve("", "ok"))
+		require.NoError(t, request.Approve(warehouseID, " Resellable "))
+
+		assert.True(t, request.IsApproved())
+		assert.Equal(t, int64(4500), request.Refund().Cents())
+		assert.Equal(t, warehouseID, request.WarehouseID())
+		assert.Equal(t, "Resellable", request.Note())
+		assert.False(t, request.DecidedAt().IsZero())
+
+		events := request.PullEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, EventReturnApproved, events[0].EventName())
+		assert.Equal(t, int64(4500), events[0].(ReturnApproved).Refund.Cents())
+
+		// A decided return cannot be decided again
+		assert.Error(t, request.Approve(warehouseID, ""))
+		assert.Error(t, request.Reject("Too late"))
+	})
+
+	t.Run("reject requires a note", func(t *testing.T) {
+		order := newDeliveredOrder(t)
+		request := newReturn(t, "return-1", order, nil, ReturnItem{ProductID: productA, Quantity: 1})
+
+		assert.Error(t, request.Reject(" "))
+		require.NoError(t, request.Reject("Return window has passed"))
+		assert.Equal(t, ReturnStatusRejected, request.Status())
+		assert.Equal(t, int64(0), request.Refund().Cents())
+	})
+
+	t.Run("restore from persistence", func(t *testing.T) {
+		refund, _ := value.NewMoney(1000)
+		items := []ReturnItem{{ProductID: productA, Quantity: 1, UnitPrice: refund}}
+		now := time.Now()
+
+		request, err := NewReturnRequestWithState("return-1", orderID, customerID, items, "Wrong size",
+			ReturnStatusApproved, refund, warehouseID, "ok", now, now, now)
+		require.NoError(t, err)
+		assert.True(t, request.IsApproved())
+		assert.Empty(t, request.PullEvents())
+
+		_, err = NewReturnRequestWithState("return-1", orderID, customerID, items, "Wrong size",
+			ReturnStatus("lost"), refund, warehouseID, "", now, time.Time{}, now)
+		assert.Error(t, err)
+	})
+}
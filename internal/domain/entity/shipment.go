@@ -0,0 +1,202 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"dynamo-modeling/internal/domain/value"
+)
+
+// ShipmentStatus represents the delivery status of a shipment
+type ShipmentStatus string
+
+const (
+	ShipmentStatusInTransit ShipmentStatus = "in_transit"
+	ShipmentStatusDelivered ShipmentStatus = "delivered"
+)
+
+// ShipmentItem represents the quantity of one ordered product sent in a shipment
+type ShipmentItem struct {
+	ProductID value.ProductID
+	Quantity  int
+}
+
+// Shipment represents a parcel sent from a warehouse for part or all of an order
+type Shipment struct {
+	id             value.ShipmentID
+	orderID        value.OrderID
+	warehouseID    value.WarehouseID
+	carrier        string
+	trackingNumber string
+	items          []ShipmentItem
+	status         ShipmentStatus
+	shippedAt      time.Time
+	deliveredAt    time.Time
+	updatedAt      time.Time
+	version        int
+}
+
+// NewShipment creates a new in-transit Shipment entity
+func NewShipment(
+	id value.ShipmentID,
+	orderID value.OrderID,
+	warehouseID value.WarehouseID,
+	carrier string,
+	trackingNumber string,
+	items []ShipmentItem,
+) (*Shipment, error) {
+	if strings.TrimSpace(carrier) == "" {
+		return nil, fmt.Errorf("carrier cannot be empty")
+	}
+	if strings.TrimSpace(trackingNumber) == "" {
+		return nil, fmt.Errorf("tracking number cannot be empty")
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("shipment must have at least one item")
+	}
+
+	seen := make(map[value.ProductID]bool, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("quantity must be positive")
+		}
+		if seen[item.ProductID] {
+			return nil, fmt.Errorf("product appears more than once in shipment: %s", item.ProductID)
+		}
+		seen[item.ProductID] = true
+	}
+
+	now := time.Now()
+	shipment := &Shipment{
+		id:             id,
+		orderID:        orderID,
+		warehouseID:    warehouseID,
+		carrier:        strings.TrimSpace(carrier),
+		trackingNumber: strings.TrimSpace(trackingNumber),
+		items:          make([]ShipmentItem, len(items)),
+		status:         ShipmentStatusInTransit,
+		shippedAt:      now,
+		updatedAt:      now,
+	}
+
+	// Copy items
+	copy(shipment.items, items)
+
+	return shipment, nil
+}
+
+// NewShipmentWithState creates a Shipment entity with explicit state (for restoration from persistence)
+func NewShipmentWithState(
+	id value.ShipmentID,
+	orderID value.OrderID,
+	warehouseID value.WarehouseID,
+	carrier string,
+	trackingNumber string,
+	items []ShipmentItem,
+	status ShipmentStatus,
+	shippedAt time.Time,
+	deliveredAt time.Time,
+	updatedAt time.Time,
+) (*Shipment, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("shipment must have at least one item")
+	}
+
+	shipment := &Shipment{
+		id:             id,
+		orderID:        orderID,
+		warehouseID:    warehouseID,
+		carrier:        carrier,
+		trackingNumber: trackingNumber,
+		items:          make([]ShipmentItem, len(items)),
+		status:         status,
+		shippedAt:      shippedAt,
+		deliveredAt:    deliveredAt,
+		updatedAt:      updatedAt,
+	}
+
+	// Copy items
+	copy(shipment.items, items)
+
+	return shipment, nil
+}
+
+// ID returns the shipment ID
+func (s *Shipment) ID() value.ShipmentID {
+	return s.id
+}
+
+// OrderID returns the ID of the shipped order
+func (s *Shipment) OrderID() value.OrderID {
+	return s.orderID
+}
+
+// WarehouseID returns the ID of the warehouse the shipment left from
+func (s *Shipment) WarehouseID() value.WarehouseID {
+	return s.warehouseID
+}
+
+// Carrier returns the carrier name
+func (s *Shipment) Carrier() string {
+	return s.carrier
+}
+
+// TrackingNumber returns the carrier's tracking number
+func (s *Shipment) TrackingNumber() string {
+	return s.trackingNumber
+}
+
+// Items returns a copy of the shipped items
+func (s *Shipment) Items() []ShipmentItem {
+	items := make([]ShipmentItem, len(s.items))
+	copy(items, s.items)
+	return items
+}
+
+// Status returns the shipment status
+func (s *Shipment) Status() ShipmentStatus {
+	return s.status
+}
+
+// ShippedAt returns the shipping timestamp
+func (s *Shipment) ShippedAt() time.Time {
+	return s.shippedAt
+}
+
+// DeliveredAt returns the delivery timestamp (zero until delivered)
+func (s *Shipment) DeliveredAt() time.Time {
+	return s.deliveredAt
+}
+
+// UpdatedAt returns the last update timestamp
+func (s *Shipment) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// Version returns the optimistic locking version (0 until first persisted)
+func (s *Shipment) Version() int {
+	return s.version
+}
+
+// SetVersion sets the optimistic locking version (used by repositories after a read or a successful write)
+func (s *Shipment) SetVersion(version int) {
+	s.version = version
+}
+
+// Deliver marks the shipment as delivered
+func (s *Shipment) Deliver() error {
+	if s.status != ShipmentStatusInTransit {
+		return fmt.Errorf("can only deliver shipments in transit, current status: %s", s.status)
+	}
+	now := time.Now()
+	s.status = ShipmentStatusDelivered
+	s.deliveredAt = now
+	s.updatedAt = now
+	return nil
+}
+
+// IsDelivered checks if the shipment has been delivered
+func (s *Shipment) IsDelivered() bool {
+	return s.status == ShipmentStatusDelivered
+}
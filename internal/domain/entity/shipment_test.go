@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestShipment(t *testing.T) {
+	orderID, _ := value.NewOrderID("order-123")
+	customerID, _ := value.NewCustomerID("customer-123")
+	warehouseID, _ := value.NewWarehouseID("warehouse-123")
+	productA, _ := value.NewProductID("product-a")
+	productB, _ := value.NewProductID("product-b")
+
+	newConfirmedOrder := func(t *testing.T) *Order {
+		price, _ := value.NewMoney(1000)
+		itemA, _ := NewOrderItem(productA, 2, price)
+		itemB, _ := NewOrderItem(productB, 1, price)
+		order, err := NewOrder(orderID, customerID, []OrderItem{*itemA, *itemB})
+		require.NoError(t, err)
+		require.NoError(t, order.Confirm())
+		return order
+	}
+
+	newShipment := func(t *testing.T, id string, items ...ShipmentItem) *Shipment {
+		shipmentID, _ := value.NewShipmentID(id)
+		shipment, err := NewShipment(shipmentID, orderID, warehouseID, "Yamato", "TRACK-"+id, items)
+		require.NoError(t, err)
+		return shipment
+	}
+
+	t.Run("create shipment", func(t *testing.T) {
+		shipment := newShipment(t, "shipment-1", ShipmentItem{ProductID: productA, Quantity: 2})
+
+		assert.Equal(t, orderID, shipment.OrderID())
+		assert.Equal(t, warehouseID, shipment.WarehouseID())
+		assert.Equal(t, "Yamato", shipment.Carrier())
+		assert.Equal(t, ShipmentStatusInTransit, shipment.Status())
+		assert.True(t, shipment.DeliveredAt().IsZero())
+	})
+
+	t.Run("invalid shipments are rejected", func(t *testing.T) {
+		shipmentID, _ := value.NewShipmentID("shipment-1")
+		item := ShipmentItem{ProductID: productA, Quantity: 1}
+
+		_, err := NewShipment(shipmentID, orderID, warehouseID, "", "TRACK-1", []ShipmentItem{item})
+		assert.Error(t, err)
+		_, err = NewShipment(shipmentID, orderID, warehouseID, "Yamato", " ", []ShipmentItem{item})
+		assert.Error(t, err)
+		_, err = NewShipment(shipmentID, orderID, warehouseID, "Yamato", "TRACK-1", nil)
+		assert.Error(t, err)
+		_, err = NewShipment(shipmentID, orderID, warehouseID, "Yamato", "TRACK-1", []ShipmentItem{{ProductID: productA, Quantity: 0}})
+		assert.Error(t, err)
+		_, err = NewShipment(shipmentID, orderID, warehouseID, "Yamato", "TRACK-1", []ShipmentItem{item, item})
+		assert.Error(t, err)
+	})
+
+	t.Run("deliver shipment", func(t *testing.T) {
+		shipment := newShipment(t, "shipment-1", ShipmentItem{ProductID: productA, Quantity: 2})
+
+		require.NoError(t, shipment.Deliver())
+		assert.True(t, shipment.IsDelivered())
+		assert.False(t, shipment.DeliveredAt().IsZero())
+		assert.Error(t, shipment.Deliver())
+	})
+
+	t.Run("shipments drive the order status", func(t *testing.T) {
+		order := newConfirmedOrder(t)
+		first := newShipment(t, "shipment-1", ShipmentItem{ProductID: productA, Quantity: 1})
+		second := newShipment(t, "shipment-2",
+			ShipmentItem{ProductID: productA, Quantity: 1},
+			ShipmentItem{ProductID: productB, Quantity: 1},
+		)
+
+		// 一部出荷では確定のまま
+		require.NoError(t, order.ApplyShipments([]*Shipment{first}))
+		assert.Equal(t, OrderStatusConfirmed, order.Status())
+
+		// 全数出荷で出荷済み
+		require.NoError(t, order.ApplyShipments([]*Shipment{first, second}))
+		assert.Equal(t, OrderStatusShipped, order.Status())
+
+		// 全出荷の配達完了で配達済み
+		require.NoError(t, first.Deliver())
+		require.NoError(t, order.ApplyShipments([]*Shipment{first, second}))
+		assert.Equal(t, OrderStatusShipped, order.Status())
+
+		require.NoError(t, second.Deliver())
+		require.NoError(t, order.ApplyShipments([]*Shipment{first, second}))
+		assert.Equal(t, OrderStatusDelivered, order.Status())
+	})
+
+	t.Run("shipments cannot exceed the order", func(t *testing.T) {
+		order := newConfirmedOrder(t)
+		unknown, _ := value.NewProductID("product-x")
+
+		assert.Error(t, order.ApplyShipments([]*Shipment{newShipment(t, "shipment-1", ShipmentItem{ProductID: productA, Quantity: 3})}))
+		assert.Error(t, order.ApplyShipments([]*Shipment{newShipment(t, "shipment-1", ShipmentItem{ProductID: unknown, Quantity: 1})}))
+		assert.Equal(t, OrderStatusConfirmed, order.Status())
+	})
+
+	t.Run("only confirmed orders can be shipped", func(t *testing.T) {
+		price, _ := value.NewMoney(1000)
+		item, _ := NewOrderItem(productA, 2, price)
+		order, _ := NewOrder(orderID, customerID, []OrderItem{*item})
+
+		assert.Error(t, order.ApplyShipments([]*Shipment{newShipment(t, "shipment-1", ShipmentItem{ProductID: productA, Quantity: 2})}))
+
+		require.NoError(t, order.Cancel())
+		assert.Error(t, order.ApplyShipments([]*Shipment{newShipment(t, "shipment-1", ShipmentItem{ProductID: productA, Quantity: 2})}))
+	})
+}
@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"dynamo-modeling/internal/domain/value"
+)
+
+// Warehouse represents a warehouse entity that holds product inventory
+type Warehouse struct {
+	id        value.WarehouseID
+	name      string
+	location  string
+	createdAt time.Time
+	updatedAt time.Time
+	version   int
+}
+
+// NewWarehouse creates a new Warehouse entity
+func NewWarehouse(id value.WarehouseID, name, location string) (*Warehouse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("warehouse name cannot be empty")
+	}
+
+	now := time.Now()
+	return &Warehouse{
+		id:        id,
+		name:      name,
+		location:  location,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// NewWarehouseWithState creates a Warehouse entity with explicit state (for restoration from persistence)
+func NewWarehouseWithState(id value.WarehouseID, name, location string, createdAt, updatedAt time.Time) (*Warehouse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("warehouse name cannot be empty")
+	}
+
+	return &Warehouse{
+		id:        id,
+		name:      name,
+		location:  location,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}, nil
+}
+
+// ID returns the warehouse ID
+func (w *Warehouse) ID() value.WarehouseID {
+	return w.id
+}
+
+// Name returns the warehouse name
+func (w *Warehouse) Name() string {
+	return w.name
+}
+
+// Location returns the warehouse location
+func (w *Warehouse) Location() string {
+	return w.location
+}
+
+// CreatedAt returns the creation timestamp
+func (w *Warehouse) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// UpdatedAt returns the last update timestamp
+func (w *Warehouse) UpdatedAt() time.Time {
+	return w.updatedAt
+}
+
+// Version returns the optimistic locking version (0 until first persisted)
+func (w *Warehouse) Version() int {
+	return w.version
+}
+
+// SetVersion sets the optimistic locking version (used by repositories after a read or a successful write)
+func (w *Warehouse) SetVersion(version int) {
+	w.version = version
+}
+
+// UpdateDetails updates the warehouse name and location
+func (w *Warehouse) UpdateDetails(name, location string) error {
+	if name == "" {
+		return fmt.Errorf("warehouse name cannot be empty")
+	}
+	w.name = name
+	w.location = location
+	w.updatedAt = time.Now()
+	return nil
+}
+
+// Inventory represents the stock of one product held in one warehouse.
+// A product's total stock is the sum of its inventory across all warehouses.
+type Inventory struct {
+	productID   value.ProductID
+	warehouseID value.WarehouseID
+	quantity    int
+	updatedAt   time.Time
+	version     int
+}
+
+// NewInventory creates a new Inventory entity
+func NewInventory(productID value.ProductID, warehouseID value.WarehouseID, quantity int) (*Inventory, error) {
+	if quantity < 0 {
+		return nil, fmt.Errorf("inventory quantity cannot be negative")
+	}
+
+	return &Inventory{
+		productID:   productID,
+		warehouseID: warehouseID,
+		quantity:    quantity,
+		updatedAt:   time.Now(),
+	}, nil
+}
+
+// NewInventoryWithState creates an Inventory entity with explicit state (for restoration from persistence)
+func NewInventoryWithState(productID value.ProductID, warehouseID value.WarehouseID, quantity int, updatedAt time.Time) (*Inventory, error) {
+	if quantity < 0 {
+		return nil, fmt.Errorf("inventory quantity cannot be negative")
+	}
+
+	return &Inventory{
+		productID:   productID,
+		warehouseID: warehouseID,
+		quantity:    quantity,
+		updatedAt:   updatedAt,
+	}, nil
+}
+
+// ProductID returns the product ID
+func (i *Inventory) ProductID() value.ProductID {
+	return i.productID
+}
+
+// WarehouseID returns the warehouse ID
+func (i *Inventory) WarehouseID() value.WarehouseID {
+	return i.warehouseID
+}
+
+// Quantity returns the quantity held in the warehouse
+func (i *Inventory) Quantity() int {
+	return i.quantity
+}
+
+// UpdatedAt returns the last update timestamp
+func (i *Inventory) UpdatedAt() time.Time {
+	return i.updatedAt
+}
+
+// Version returns the optimistic locking version (0 until first persisted)
+func (i *Inventory) Version() int {
+	return i.version
+}
+
+// SetVersion sets the optimistic locking version (used by repositories after a read or a successful write)
+func (i *Inventory) SetVersion(version int) {
+	i.version = version
+}
+
+// SetQuantity sets the quantity held in the warehouse
+func (i *Inventory) SetQuantity(quantity int) error {
+	if quantity < 0 {
+		return fmt.Errorf("inventory quantity cannot be negative")
+	}
+	i.quantity = quantity
+	i.updatedAt = time.Now()
+	return nil
+}
+
+// TotalStock sums the quantities of the given inventories
+func TotalStock(inventories []*Inventory) int {
+	total := 0
+	for _, inventory := range inventories {
+		total += inventory.quantity
+	}
+	return total
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/domain/value"
+)
+
+func TestWarehouse(t *testing.T) {
+	warehouseID, _ := value.NewWarehouseID("warehouse-123")
+
+	t.Run("create new warehouse", func(t *testing.T) {
+		warehouse, err := NewWarehouse(warehouseID, "Tokyo DC", "Tokyo")
+
+		assert.NoError(t, err)
+		assert.Equal(t, warehouseID, warehouse.ID())
+		assert.Equal(t, "Tokyo DC", warehouse.Name())
+		assert.Equal(t, "Tokyo", warehouse.Location())
+		assert.False(t, warehouse.CreatedAt().IsZero())
+		assert.Equal(t, 0, warehouse.Version())
+	})
+
+	t.Run("create warehouse with empty name should fail", func(t *testing.T) {
+		_, err := NewWarehouse(warehouseID, "", "Tokyo")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "name cannot be empty")
+	})
+
+	t.Run("update details", func(t *testing.T) {
+		warehouse, _ := NewWarehouse(warehouseID, "Tokyo DC", "Tokyo")
+
+		require.NoError(t, warehouse.UpdateDetails("Osaka DC", "Osaka"))
+		assert.Equal(t, "Osaka DC", warehouse.Name())
+		assert.Equal(t, "Osaka", warehouse.Location())
+
+		assert.Error(t, warehouse.UpdateDetails("", "Osaka"))
+	})
+}
+
+func TestInventory(t *testing.T) {
+	productID, _ := value.NewProductID("product-123")
+	tokyo, _ := value.NewWarehouseID("warehouse-tokyo")
+	osaka, _ := value.NewWarehouseID("warehouse-osaka")
+
+	t.Run("create inventory", func(t *testing.T) {
+		inventory, err := NewInventory(productID, tokyo, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, productID, inventory.ProductID())
+		assert.Equal(t, tokyo, inventory.WarehouseID())
+		assert.Equal(t, 10, inventory.Quantity())
+	})
+
+	t.Run("negative quantity should fail", func(t *testing.T) {
+		_, err := NewInventory(productID, tokyo, -1)
+		assert.Error(t, err)
+
+		inventory, _ := NewInventory(productID, tokyo, 10)
+		assert.Error(t, inventory.SetQuantity(-1))
+		assert.Equal(t, 10, inventory.Quantity())
+	})
+
+	t.Run("total stock sums warehouses", func(t *testing.T) {
+		first, _ := NewInventory(productID, tokyo, 10)
+		second, _ := NewInventory(productID, osaka, 5)
+
+		assert.Equal(t, 15, TotalStock([]*Inventory{first, second}))
+		assert.Equal(t, 0, TotalStock(nil))
+	})
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // DomainError represents domain-specific errors
 type DomainError struct {
@@ -24,10 +27,38 @@ func (e *DomainError) Unwrap() error {
 
 // Common domain error codes
 const (
-	ErrCodeCustomerNotFound      = "CUSTOMER_NOT_FOUND"
-	ErrCodeCustomerAlreadyExists = "CUSTOMER_ALREADY_EXISTS"
-	ErrCodeInvalidInput          = "INVALID_INPUT"
-	ErrCodeRepositoryError       = "REPOSITORY_ERROR"
+	ErrCodeCustomerNotFound       = "CUSTOMER_NOT_FOUND"
+	ErrCodeProductNotFound        = "PRODUCT_NOT_FOUND"
+	ErrCodeOrderNotFound          = "ORDER_NOT_FOUND"
+	ErrCodeWarehouseNotFound      = "WAREHOUSE_NOT_FOUND"
+	ErrCodeInventoryNotFound      = "INVENTORY_NOT_FOUND"
+	ErrCodeInvoiceNotFound        = "INVOICE_NOT_FOUND"
+	ErrCodeShipmentNotFound       = "SHIPMENT_NOT_FOUND"
+	ErrCodeReturnNotFound         = "RETURN_NOT_FOUND"
+	ErrCodeCustomerAlreadyExists  = "CUSTOMER_ALREADY_EXISTS"
+	ErrCodeInvoiceAlreadyExists   = "INVOICE_ALREADY_EXISTS"
+	ErrCodeInsufficientStock      = "INSUFFICIENT_STOCK"
+	ErrCodeRuleViolation          = "RULE_VIOLATION"
+	ErrCodeConcurrentModification = "CONCURRENT_MODIFICATION"
+	ErrCodeInvalidInput           = "INVALID_INPUT"
+	ErrCodeRepositoryError        = "REPOSITORY_ERROR"
+)
+
+// Error kinds wrapped by domain errors, so that callers can classify an error with errors.Is
+// whatever its code
+var (
+	// ErrNotFound is wrapped by errors for entities that do not exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrAlreadyExists is wrapped by errors for entities that would duplicate an existing one
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrConcurrentModification is wrapped by errors for writes that lost an optimistic locking race
+	ErrConcurrentModification = errors.New("concurrent modification")
+
+	// ErrRuleViolation is wrapped by errors for well-formed requests that a business rule rejects
+	// in the current state, such as an invalid status transition
+	ErrRuleViolation = errors.New("business rule violation")
 )
 
 // NewDomainError creates a new domain error
@@ -44,7 +75,79 @@ func CustomerNotFoundError(customerID string) *DomainError {
 	return NewDomainError(
 		ErrCodeCustomerNotFound,
 		fmt.Sprintf("Customer with ID %s not found", customerID),
-		nil,
+		ErrNotFound,
+	)
+}
+
+// CustomerNotFoundByEmailError creates an error for an email address no customer is registered with
+func CustomerNotFoundByEmailError(email string) *DomainError {
+	return NewDomainError(
+		ErrCodeCustomerNotFound,
+		fmt.Sprintf("Customer with email %s not found", email),
+		ErrNotFound,
+	)
+}
+
+// ProductNotFoundError creates a product not found error
+func ProductNotFoundError(productID string) *DomainError {
+	return NewDomainError(
+		ErrCodeProductNotFound,
+		fmt.Sprintf("Product with ID %s not found", productID),
+		ErrNotFound,
+	)
+}
+
+// OrderNotFoundError creates an order not found error
+func OrderNotFoundError(orderID string) *DomainError {
+	return NewDomainError(
+		ErrCodeOrderNotFound,
+		fmt.Sprintf("Order with ID %s not found", orderID),
+		ErrNotFound,
+	)
+}
+
+// WarehouseNotFoundError creates a warehouse not found error
+func WarehouseNotFoundError(warehouseID string) *DomainError {
+	return NewDomainError(
+		ErrCodeWarehouseNotFound,
+		fmt.Sprintf("Warehouse with ID %s not found", warehouseID),
+		ErrNotFound,
+	)
+}
+
+// InventoryNotFoundError creates an error for a product that is not stocked in a warehouse
+func InventoryNotFoundError(warehouseID, productID string) *DomainError {
+	return NewDomainError(
+		ErrCodeInventoryNotFound,
+		fmt.Sprintf("Product %s is not stocked in warehouse %s", productID, warehouseID),
+		ErrNotFound,
+	)
+}
+
+// InvoiceNotFoundError creates an invoice not found error
+func InvoiceNotFoundError(invoiceID string) *DomainError {
+	return NewDomainError(
+		ErrCodeInvoiceNotFound,
+		fmt.Sprintf("Invoice with ID %s not found", invoiceID),
+		ErrNotFound,
+	)
+}
+
+// ShipmentNotFoundError creates a shipment not found error
+func ShipmentNotFoundError(shipmentID string) *DomainError {
+	return NewDomainError(
+		ErrCodeShipmentNotFound,
+		fmt.Sprintf("Shipment with ID %s not found", shipmentID),
+		ErrNotFound,
+	)
+}
+
+// ReturnNotFoundError creates a return request not found error
+func ReturnNotFoundError(returnID string) *DomainError {
+	return NewDomainError(
+		ErrCodeReturnNotFound,
+		fmt.Sprintf("Return request with ID %s not found", returnID),
+		ErrNotFound,
 	)
 }
 
@@ -53,7 +156,43 @@ func CustomerAlreadyExistsError(email string) *DomainError {
 	return NewDomainError(
 		ErrCodeCustomerAlreadyExists,
 		fmt.Sprintf("Customer with email %s already exists", email),
-		nil,
+		ErrAlreadyExists,
+	)
+}
+
+// InvoiceAlreadyExistsError creates an error for an order that has already been invoiced
+func InvoiceAlreadyExistsError(orderID string) *DomainError {
+	return NewDomainError(
+		ErrCodeInvoiceAlreadyExists,
+		fmt.Sprintf("Order %s has already been invoiced", orderID),
+		ErrAlreadyExists,
+	)
+}
+
+// InsufficientStockError creates an error for a product whose stock cannot cover the ordered quantity
+func InsufficientStockError(productID string) *DomainError {
+	return NewDomainError(
+		ErrCodeInsufficientStock,
+		fmt.Sprintf("Insufficient stock for product: %s", productID),
+		ErrRuleViolation,
+	)
+}
+
+// RuleViolationError creates an error for a request that a business rule rejects in the current state
+func RuleViolationError(message string) *DomainError {
+	return NewDomainError(
+		ErrCodeRuleViolation,
+		message,
+		ErrRuleViolation,
+	)
+}
+
+// ConcurrentModificationError creates an error for an entity that was changed by another writer since it was read
+func ConcurrentModificationError(entityType, id string) *DomainError {
+	return NewDomainError(
+		ErrCodeConcurrentModification,
+		fmt.Sprintf("%s with ID %s was modified by another request", entityType, id),
+		ErrConcurrentModification,
 	)
 }
 
@@ -24,6 +24,6 @@ type CustomerRepository interface {
 	// Exists checks if a customer exists by their ID
 	Exists(ctx context.Context, id value.CustomerID) (bool, error)
 
-	// ListWithLimit retrieves customers with optional limit
-	ListWithLimit(ctx context.Context, limit *int) ([]*entity.Customer, error)
+	// FindAll retrieves customers with pagination
+	FindAll(ctx context.Context, limit int, lastKey *string) ([]*entity.Customer, *string, error)
 }
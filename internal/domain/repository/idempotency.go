@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRecord remembers a request made with an Idempotency-Key and, once it has been handled,
+// the response sent for it. A record that is not completed marks a request still in flight.
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string // Hash of the method, route and body of the request
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time // The key is free again after this time
+}
+
+// IdempotencyRepository defines the interface for idempotency key persistence operations
+type IdempotencyRepository interface {
+	// Reserve stores an in-flight record for its key unless an unexpired record already holds
+	// the key. It returns nil when the key was reserved and the existing record otherwise.
+	Reserve(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error)
+
+	// Complete replaces the in-flight record of the key with the completed one
+	Complete(ctx context.Context, record *IdempotencyRecord) error
+
+	// Release deletes the in-flight record of the key so that the request can be retried
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// InvoiceRepository defines the interface for invoice persistence operations
+type InvoiceRepository interface {
+	// Save creates or updates an invoice. It returns domain.InvoiceAlreadyExistsError when a new
+	// invoice is saved for an order that already has one.
+	Save(ctx context.Context, invoice *entity.Invoice) error
+
+	// FindByID retrieves an invoice by its ID
+	FindByID(ctx context.Context, id value.InvoiceID) (*entity.Invoice, error)
+
+	// FindByOrderID retrieves the invoice for an order, or nil when the order has not been invoiced
+	FindByOrderID(ctx context.Context, orderID value.OrderID) (*entity.Invoice, error)
+
+	// FindByCustomerID retrieves a customer's invoices issued between from and to (inclusive), oldest first.
+	// A zero from or to leaves that end of the range open.
+	FindByCustomerID(ctx context.Context, customerID value.CustomerID, from, to time.Time, limit int, lastKey *string) ([]*entity.Invoice, *string, error)
+}
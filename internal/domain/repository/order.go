@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"dynamo-modeling/internal/domain/entity"
 	"dynamo-modeling/internal/domain/value"
@@ -12,6 +13,18 @@ type OrderRepository interface {
 	// Save creates or updates an order
 	Save(ctx context.Context, order *entity.Order) error
 
+	// PlaceOrder atomically reserves stock for every order line, taking it from the product's
+	// warehouse inventories, and creates the order.
+	// It returns domain.InsufficientStockError naming the product whose stock condition failed,
+	// and domain.RuleViolationError when the stock is spread over too many warehouses to take at once.
+	PlaceOrder(ctx context.Context, order *entity.Order) error
+
+	// CancelOrder atomically saves a cancelled order and gives the stock reserved for it back to
+	// the given products and the warehouse inventories it was taken from. Ordered products left out
+	// of products, e.g. because they were deleted, are not restocked.
+	// It returns domain.ConcurrentModificationError when the order changed or a product was deleted since they were read.
+	CancelOrder(ctx context.Context, order *entity.Order, products []*entity.Product) error
+
 	// FindByID retrieves an order by its ID
 	FindByID(ctx context.Context, id value.OrderID) (*entity.Order, error)
 
@@ -24,6 +37,14 @@ type OrderRepository interface {
 	// FindByCustomerAndStatus retrieves orders for a customer with a specific status
 	FindByCustomerAndStatus(ctx context.Context, customerID value.CustomerID, status entity.OrderStatus, limit int, lastKey *string) ([]*entity.Order, *string, error)
 
+	// FindByProductAndDateRange retrieves the orders containing a product that were placed between from and to
+	// (inclusive), oldest first. A zero from or to leaves that end of the range open.
+	FindByProductAndDateRange(ctx context.Context, productID value.ProductID, from, to time.Time, limit int, lastKey *string) ([]*entity.Order, *string, error)
+
+	// FindStatusHistory retrieves the status history of an order, oldest first.
+	// It returns domain.OrderNotFoundError when the order does not exist.
+	FindStatusHistory(ctx context.Context, id value.OrderID) ([]entity.OrderStatusChange, error)
+
 	// Delete removes an order by its ID
 	Delete(ctx context.Context, id value.OrderID) error
 
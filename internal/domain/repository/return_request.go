@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// ReturnRepository defines the interface for return request persistence operations
+type ReturnRepository interface {
+	// Save creates or updates a return request together with the order it belongs to. Writing the
+	// order serializes the requests of an order, so the same units cannot be requested twice.
+	Save(ctx context.Context, request *entity.ReturnRequest, order *entity.Order) error
+
+	// Approve atomically saves an approved return request and its order, and gives the returned
+	// quantities back to the given products and to the warehouse receiving the goods. Returned
+	// products left out of products, e.g. because they were deleted, are not restocked.
+	// It returns domain.ConcurrentModificationError when a product was deleted since it was read.
+	Approve(ctx context.Context, request *entity.ReturnRequest, order *entity.Order, products []*entity.Product) error
+
+	// FindByID retrieves a return request by its ID
+	FindByID(ctx context.Context, id value.ReturnID) (*entity.ReturnRequest, error)
+
+	// FindByOrderID retrieves all return requests of an order
+	FindByOrderID(ctx context.Context, orderID value.OrderID) ([]*entity.ReturnRequest, error)
+
+	// FindByStatus retrieves the return requests with a status, oldest first so that pending
+	// requests are worked through in the order they came in
+	FindByStatus(ctx context.Context, status entity.ReturnStatus, limit int, lastKey *string) ([]*entity.ReturnRequest, *string, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// ShipmentRepository defines the interface for shipment persistence operations
+type ShipmentRepository interface {
+	// Save creates or updates a shipment together with the order it belongs to,
+	// so that the order status always matches its shipments
+	Save(ctx context.Context, shipment *entity.Shipment, order *entity.Order) error
+
+	// FindByID retrieves a shipment by its ID
+	FindByID(ctx context.Context, id value.ShipmentID) (*entity.Shipment, error)
+
+	// FindByOrderID retrieves all shipments of an order
+	FindByOrderID(ctx context.Context, orderID value.OrderID) ([]*entity.Shipment, error)
+
+	// FindByWarehouseID retrieves the shipments sent from a warehouse, newest first
+	FindByWarehouseID(ctx context.Context, warehouseID value.WarehouseID, limit int, lastKey *string) ([]*entity.Shipment, *string, error)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// WarehouseRepository defines the interface for warehouse and inventory persistence operations
+type WarehouseRepository interface {
+	// Save creates or updates a warehouse
+	Save(ctx context.Context, warehouse *entity.Warehouse) error
+
+	// FindByID retrieves a warehouse by its ID
+	FindByID(ctx context.Context, id value.WarehouseID) (*entity.Warehouse, error)
+
+	// Exists checks if a warehouse exists by its ID
+	Exists(ctx context.Context, id value.WarehouseID) (bool, error)
+
+	// SaveInventory creates or updates a product's inventory in a warehouse.
+	// The product's total stock is adjusted by the change in the same write.
+	SaveInventory(ctx context.Context, inventory *entity.Inventory) error
+
+	// FindInventory retrieves a product's inventory in a warehouse
+	FindInventory(ctx context.Context, warehouseID value.WarehouseID, productID value.ProductID) (*entity.Inventory, error)
+
+	// FindInventoryByWarehouse retrieves the inventory of all products in a warehouse
+	FindInventoryByWarehouse(ctx context.Context, warehouseID value.WarehouseID, limit int, lastKey *string) ([]*entity.Inventory, *string, error)
+
+	// FindInventoryByProduct retrieves a product's inventory across all warehouses
+	FindInventoryByProduct(ctx context.Context, productID value.ProductID) ([]*entity.Inventory, error)
+}
@@ -70,6 +70,111 @@ func (o OrderID) IsEmpty() bool {
 	return string(o) == ""
 }
 
+// WarehouseID represents a unique warehouse identifier
+type WarehouseID string
+
+// NewWarehouseID creates a new WarehouseID with validation
+func NewWarehouseID(id string) (WarehouseID, error) {
+	if strings.TrimSpace(id) == "" {
+		return "", fmt.Errorf("warehouse ID cannot be empty")
+	}
+	return WarehouseID(id), nil
+}
+
+// String returns the string representation of WarehouseID
+func (w WarehouseID) String() string {
+	return string(w)
+}
+
+// IsEmpty checks if the WarehouseID is empty
+func (w WarehouseID) IsEmpty() bool {
+	return string(w) == ""
+}
+
+// InvoiceID represents a unique invoice identifier
+type InvoiceID string
+
+// NewInvoiceID creates a new InvoiceID with validation
+func NewInvoiceID(id string) (InvoiceID, error) {
+	if strings.TrimSpace(id) == "" {
+		return "", fmt.Errorf("invoice ID cannot be empty")
+	}
+	return InvoiceID(id), nil
+}
+
+// String returns the string representation of InvoiceID
+func (i InvoiceID) String() string {
+	return string(i)
+}
+
+// IsEmpty checks if the InvoiceID is empty
+func (i InvoiceID) IsEmpty() bool {
+	return string(i) == ""
+}
+
+// PaymentID represents a unique payment identifier within an invoice
+type PaymentID string
+
+// NewPaymentID creates a new PaymentID with validation
+func NewPaymentID(id string) (PaymentID, error) {
+	if strings.TrimSpace(id) == "" {
+		return "", fmt.Errorf("payment ID cannot be empty")
+	}
+	return PaymentID(id), nil
+}
+
+// String returns the string representation of PaymentID
+func (p PaymentID) String() string {
+	return string(p)
+}
+
+// IsEmpty checks if the PaymentID is empty
+func (p PaymentID) IsEmpty() bool {
+	return string(p) == ""
+}
+
+// ShipmentID represents a unique shipment identifier
+type ShipmentID string
+
+// NewShipmentID creates a new ShipmentID with validation
+func NewShipmentID(id string) (ShipmentID, error) {
+	if strings.TrimSpace(id) == "" {
+		return "", fmt.Errorf("shipment ID cannot be empty")
+	}
+	return ShipmentID(id), nil
+}
+
+// String returns the string representation of ShipmentID
+func (s ShipmentID) String() string {
+	return string(s)
+}
+
+// IsEmpty checks if the ShipmentID is empty
+func (s ShipmentID) IsEmpty() bool {
+	return string(s) == ""
+}
+
+// ReturnID represents a unique return request identifier
+type ReturnID string
+
+// NewReturnID creates a new ReturnID with validation
+func NewReturnID(id string) (ReturnID, error) {
+	if strings.TrimSpace(id) == "" {
+		return "", fmt.Errorf("return ID cannot be empty")
+	}
+	return ReturnID(id), nil
+}
+
+// String returns the string representation of ReturnID
+func (r ReturnID) String() string {
+	return string(r)
+}
+
+// IsEmpty checks if the ReturnID is empty
+func (r ReturnID) IsEmpty() bool {
+	return string(r) == ""
+}
+
 // GenerateCustomerID generates a new unique CustomerID
 func GenerateCustomerID() CustomerID {
 	id := generateUUID()
@@ -88,6 +193,24 @@ func GenerateOrderID() OrderID {
 	return OrderID(id)
 }
 
+// GenerateWarehouseID generates a new unique WarehouseID
+func GenerateWarehouseID() WarehouseID {
+	id := generateUUID()
+	return WarehouseID(id)
+}
+
+// GenerateInvoiceID generates a new unique InvoiceID
+func GenerateInvoiceID() InvoiceID {
+	id := generateUUID()
+	return InvoiceID(id)
+}
+
+// GeneratePaymentID generates a new unique PaymentID
+func GeneratePaymentID() PaymentID {
+	id := generateUUID()
+	return PaymentID(id)
+}
+
 // generateUUID generates a simple UUID v4
 func generateUUID() string {
 	b := make([]byte, 16)
@@ -103,3 +226,15 @@ func generateUUID() string {
 	return fmt.Sprintf("%x-%x-%x-%x-%x",
 		b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
 }
+
+// GenerateShipmentID generates a new unique ShipmentID
+func GenerateShipmentID() ShipmentID {
+	id := generateUUID()
+	return ShipmentID(id)
+}
+
+// GenerateReturnID generates a new unique ReturnID
+func GenerateReturnID() ReturnID {
+	id := generateUUID()
+	return ReturnID(id)
+}
@@ -83,3 +83,103 @@ func TestOrderID(t *testing.T) {
 		assert.True(t, id.IsEmpty())
 	})
 }
+
+func TestWarehouseID(t *testing.T) {
+	t.Run("valid warehouse ID", func(t *testing.T) {
+		id, err := NewWarehouseID("warehouse-012")
+		assert.NoError(t, err)
+		assert.Equal(t, "warehouse-012", id.String())
+		assert.False(t, id.IsEmpty())
+	})
+
+	t.Run("empty warehouse ID should return error", func(t *testing.T) {
+		_, err := NewWarehouseID("")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be empty")
+	})
+
+	t.Run("empty warehouse ID check", func(t *testing.T) {
+		var id WarehouseID
+		assert.True(t, id.IsEmpty())
+	})
+}
+
+func TestInvoiceID(t *testing.T) {
+	t.Run("valid invoice ID", func(t *testing.T) {
+		id, err := NewInvoiceID("invoice-345")
+		assert.NoError(t, err)
+		assert.Equal(t, "invoice-345", id.String())
+		assert.False(t, id.IsEmpty())
+	})
+
+	t.Run("empty invoice ID should return error", func(t *testing.T) {
+		_, err := NewInvoiceID("")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be empty")
+	})
+
+	t.Run("empty invoice ID check", func(t *testing.T) {
+		var id InvoiceID
+		assert.True(t, id.IsEmpty())
+	})
+}
+
+func TestPaymentID(t *testing.T) {
+	t.Run("valid payment ID", func(t *testing.T) {
+		id, err := NewPaymentID("payment-678")
+		assert.NoError(t, err)
+		assert.Equal(t, "payment-678", id.String())
+		assert.False(t, id.IsEmpty())
+	})
+
+	t.Run("empty payment ID should return error", func(t *testing.T) {
+		_, err := NewPaymentID("")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be empty")
+	})
+
+	t.Run("empty payment ID check", func(t *testing.T) {
+		var id PaymentID
+		assert.True(t, id.IsEmpty())
+	})
+}
+
+func TestShipmentID(t *testing.T) {
+	t.Run("valid shipment ID", func(t *testing.T) {
+		id, err := NewShipmentID("shipment-901")
+		assert.NoError(t, err)
+		assert.Equal(t, "shipment-901", id.String())
+		assert.False(t, id.IsEmpty())
+	})
+
+	t.Run("empty shipment ID should return error", func(t *testing.T) {
+		_, err := NewShipmentID("")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be empty")
+	})
+
+	t.Run("empty shipment ID check", func(t *testing.T) {
+		var id ShipmentID
+		assert.True(t, id.IsEmpty())
+	})
+}
+
+func TestReturnID(t *testing.T) {
+	t.Run("valid return ID", func(t *testing.T) {
+		id, err := NewReturnID("return-901")
+		assert.NoError(t, err)
+		assert.Equal(t, "return-901", id.String())
+		assert.False(t, id.IsEmpty())
+	})
+
+	t.Run("empty return ID should return error", func(t *testing.T) {
+		_, err := NewReturnID("")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be empty")
+	})
+
+	t.Run("empty return ID check", func(t *testing.T) {
+		var id ReturnID
+		assert.True(t, id.IsEmpty())
+	})
+}
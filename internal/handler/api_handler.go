@@ -9,9 +9,13 @@ import (
 
 // APIHandler implements the OpenAPI server interface
 type APIHandler struct {
-	customerController *controller.CustomerController
-	productController  *controller.ProductController
-	orderController    *controller.OrderController
+	customerController  *controller.CustomerController
+	productController   *controller.ProductController
+	orderController     *controller.OrderController
+	warehouseController *controller.WarehouseController
+	invoiceController   *controller.InvoiceController
+	shipmentController  *controller.ShipmentController
+	returnController    *controller.ReturnController
 }
 
 // NewAPIHandler creates a new API handler
@@ -19,11 +23,19 @@ func NewAPIHandler(
 	customerController *controller.CustomerController,
 	productController *controller.ProductController,
 	orderController *controller.OrderController,
+	warehouseController *controller.WarehouseController,
+	invoiceController *controller.InvoiceController,
+	shipmentController *controller.ShipmentController,
+	returnController *controller.ReturnController,
 ) *APIHandler {
 	return &APIHandler{
-		customerController: customerController,
-		productController:  productController,
-		orderController:    orderController,
+		customerController:  customerController,
+		productController:   productController,
+		orderController:     orderController,
+		warehouseController: warehouseController,
+		invoiceController:   invoiceController,
+		shipmentController:  shipmentController,
+		returnController:    returnController,
 	}
 }
 
@@ -37,8 +49,8 @@ func (h *APIHandler) ListCustomers(ctx echo.Context, params openapi.ListCustomer
 	return h.customerController.ListCustomers(ctx, params)
 }
 
-// CreateCustomer handles customer creation
-func (h *APIHandler) CreateCustomer(ctx echo.Context) error {
+// CreateCustomer handles customer creation; its Idempotency-Key is handled by the Idempotency middleware
+func (h *APIHandler) CreateCustomer(ctx echo.Context, params openapi.CreateCustomerParams) error {
 	return h.customerController.CreateCustomer(ctx)
 }
 
@@ -57,6 +69,11 @@ func (h *APIHandler) UpdateCustomer(ctx echo.Context, customerId string) error {
 	return h.customerController.UpdateCustomer(ctx, customerId)
 }
 
+// GetCustomerInvoices handles getting customer invoices
+func (h *APIHandler) GetCustomerInvoices(ctx echo.Context, customerId string, params openapi.GetCustomerInvoicesParams) error {
+	return h.invoiceController.GetCustomerInvoices(ctx, customerId, params)
+}
+
 // GetCustomerOrders handles getting customer orders
 func (h *APIHandler) GetCustomerOrders(ctx echo.Context, customerId string, params openapi.GetCustomerOrdersParams) error {
 	return h.orderController.GetCustomerOrders(ctx, customerId, params)
@@ -69,8 +86,8 @@ func (h *APIHandler) ListOrders(ctx echo.Context, params openapi.ListOrdersParam
 	return h.orderController.ListOrders(ctx, params)
 }
 
-// CreateOrder handles order creation
-func (h *APIHandler) CreateOrder(ctx echo.Context) error {
+// CreateOrder handles order creation; its Idempotency-Key is handled by the Idempotency middleware
+func (h *APIHandler) CreateOrder(ctx echo.Context, params openapi.CreateOrderParams) error {
 	return h.orderController.CreateOrder(ctx)
 }
 
@@ -84,6 +101,58 @@ func (h *APIHandler) UpdateOrderStatus(ctx echo.Context, orderId string) error {
 	return h.orderController.UpdateOrderStatus(ctx, orderId)
 }
 
+// CancelOrder handles order cancellation
+func (h *APIHandler) CancelOrder(ctx echo.Context, orderId string) error {
+	return h.orderController.CancelOrder(ctx, orderId)
+}
+
+// GetOrderHistory handles getting the status history of an order
+func (h *APIHandler) GetOrderHistory(ctx echo.Context, orderId string) error {
+	return h.orderController.GetOrderHistory(ctx, orderId)
+}
+
+// GetOrderInvoice handles getting the invoice for an order
+func (h *APIHandler) GetOrderInvoice(ctx echo.Context, orderId string) error {
+	return h.invoiceController.GetOrderInvoice(ctx, orderId)
+}
+
+// IssueInvoice handles issuing the invoice for an order
+func (h *APIHandler) IssueInvoice(ctx echo.Context, orderId string) error {
+	return h.invoiceController.IssueInvoice(ctx, orderId)
+}
+
+// ListOrderReturns handles listing the return requests of an order
+func (h *APIHandler) ListOrderReturns(ctx echo.Context, orderId string) error {
+	return h.returnController.ListOrderReturns(ctx, orderId)
+}
+
+// RequestReturn handles a customer asking to send back lines of a delivered order
+func (h *APIHandler) RequestReturn(ctx echo.Context, orderId string) error {
+	return h.returnController.RequestReturn(ctx, orderId)
+}
+
+// ListOrderShipments handles listing the shipments of an order
+func (h *APIHandler) ListOrderShipments(ctx echo.Context, orderId string) error {
+	return h.shipmentController.ListOrderShipments(ctx, orderId)
+}
+
+// CreateShipment handles shipping order items from a warehouse
+func (h *APIHandler) CreateShipment(ctx echo.Context, orderId string) error {
+	return h.shipmentController.CreateShipment(ctx, orderId)
+}
+
+// Invoice endpoints
+
+// GetInvoice handles getting an invoice by ID
+func (h *APIHandler) GetInvoice(ctx echo.Context, invoiceId string) error {
+	return h.invoiceController.GetInvoice(ctx, invoiceId)
+}
+
+// RecordPayment handles recording a payment against an invoice
+func (h *APIHandler) RecordPayment(ctx echo.Context, invoiceId string) error {
+	return h.invoiceController.RecordPayment(ctx, invoiceId)
+}
+
 // Product endpoints
 
 // ListProducts handles listing all products
@@ -91,8 +160,8 @@ func (h *APIHandler) ListProducts(ctx echo.Context, params openapi.ListProductsP
 	return h.productController.ListProducts(ctx, params)
 }
 
-// CreateProduct handles product creation
-func (h *APIHandler) CreateProduct(ctx echo.Context) error {
+// CreateProduct handles product creation; its Idempotency-Key is handled by the Idempotency middleware
+func (h *APIHandler) CreateProduct(ctx echo.Context, params openapi.CreateProductParams) error {
 	return h.productController.CreateProduct(ctx)
 }
 
@@ -110,3 +179,74 @@ func (h *APIHandler) GetProduct(ctx echo.Context, productId string) error {
 func (h *APIHandler) UpdateProduct(ctx echo.Context, productId string) error {
 	return h.productController.UpdateProduct(ctx, productId)
 }
+
+// GetProductInventory handles getting a product's inventory across all warehouses
+func (h *APIHandler) GetProductInventory(ctx echo.Context, productId string) error {
+	return h.warehouseController.GetProductInventory(ctx, productId)
+}
+
+// GetProductOrders handles getting the orders containing a product in a date range
+func (h *APIHandler) GetProductOrders(ctx echo.Context, productId string, params openapi.GetProductOrdersParams) error {
+	return h.orderController.GetProductOrders(ctx, productId, params)
+}
+
+// Return endpoints
+
+// ListReturns handles listing return requests by status
+func (h *APIHandler) ListReturns(ctx echo.Context, params openapi.ListReturnsParams) error {
+	return h.returnController.ListReturns(ctx, params)
+}
+
+// GetReturn handles getting a return request by ID
+func (h *APIHandler) GetReturn(ctx echo.Context, returnId string) error {
+	return h.returnController.GetReturn(ctx, returnId)
+}
+
+// ApproveReturn handles approving a return
+func (h *APIHandler) ApproveReturn(ctx echo.Context, returnId string) error {
+	return h.returnController.ApproveReturn(ctx, returnId)
+}
+
+// RejectReturn handles rejecting a return
+func (h *APIHandler) RejectReturn(ctx echo.Context, returnId string) error {
+	return h.returnController.RejectReturn(ctx, returnId)
+}
+
+// Shipment endpoints
+
+// GetShipment handles getting a shipment by ID
+func (h *APIHandler) GetShipment(ctx echo.Context, shipmentId string) error {
+	return h.shipmentController.GetShipment(ctx, shipmentId)
+}
+
+// DeliverShipment handles marking a shipment as delivered
+func (h *APIHandler) DeliverShipment(ctx echo.Context, shipmentId string) error {
+	return h.shipmentController.DeliverShipment(ctx, shipmentId)
+}
+
+// Warehouse endpoints
+
+// CreateWarehouse handles warehouse creation
+func (h *APIHandler) CreateWarehouse(ctx echo.Context) error {
+	return h.warehouseController.CreateWarehouse(ctx)
+}
+
+// GetWarehouse handles getting a warehouse by ID
+func (h *APIHandler) GetWarehouse(ctx echo.Context, warehouseId string) error {
+	return h.warehouseController.GetWarehouse(ctx, warehouseId)
+}
+
+// ListWarehouseInventory handles listing a warehouse's inventory
+func (h *APIHandler) ListWarehouseInventory(ctx echo.Context, warehouseId string, params openapi.ListWarehouseInventoryParams) error {
+	return h.warehouseController.ListWarehouseInventory(ctx, warehouseId, params)
+}
+
+// SetInventory handles setting a product's quantity in a warehouse
+func (h *APIHandler) SetInventory(ctx echo.Context, warehouseId string, productId string) error {
+	return h.warehouseController.SetInventory(ctx, warehouseId, productId)
+}
+
+// ListWarehouseShipments handles listing the shipments sent from a warehouse
+func (h *APIHandler) ListWarehouseShipments(ctx echo.Context, warehouseId string, params openapi.ListWarehouseShipmentsParams) error {
+	return h.shipmentController.ListWarehouseShipments(ctx, warehouseId, params)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/auth"
+)
+
+// customerRoutes are the operations a customer token may call, as method and route path. Which
+// customer or order they act on is checked by the controllers against the subject of the token.
+var customerRoutes = map[string]bool{
+	http.MethodGet + " /customers/:customerId":        true,
+	http.MethodPut + " /customers/:customerId":        true,
+	http.MethodGet + " /customers/:customerId/orders": true,
+	http.MethodPost + " /orders":                      true,
+	http.MethodGet + " /orders/:orderId":              true,
+	http.MethodGet + " /orders/:orderId/history":      true,
+	http.MethodPost + " /orders/:orderId/cancel":      true,
+	http.MethodPost + " /orders/:orderId/returns":     true,
+	http.MethodGet + " /orders/:orderId/returns":      true,
+	http.MethodGet + " /returns/:returnId":            true,
+	http.MethodGet + " /products":                     true,
+	http.MethodGet + " /products/:productId":          true,
+}
+
+// Authenticate requires a valid bearer token on every request and puts the principal it identifies
+// on the request context. Admin tokens may call every operation; other tokens only customerRoutes.
+func Authenticate(verifier *auth.Verifier) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			token, ok := bearerToken(req.Header.Get(echo.HeaderAuthorization))
+			if !ok {
+				ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return echo.NewHTTPError(http.StatusUnauthorized, "Bearer token is required")
+			}
+
+			principal, err := verifier.Verify(token)
+			if err != nil {
+				slog.Info("Rejected bearer token", "method", req.Method, "path", ctx.Path(), "error", err)
+				ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid bearer token")
+			}
+			if !principal.IsAdmin() && !customerRoutes[req.Method+" "+ctx.Path()] {
+				return echo.NewHTTPError(http.StatusForbidden, "This operation requires an admin token")
+			}
+
+			ctx.SetRequest(req.WithContext(auth.NewContext(req.Context(), principal)))
+			return next(ctx)
+		}
+	}
+}
+
+// bearerToken extracts the token from an Authorization header of the Bearer scheme
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/adapter/controller"
+	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/adapter/presenter"
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/auth"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/usecase"
+)
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	keyFile := filepath.Join(t.TempDir(), "hmac.key")
+	require.NoError(t, os.WriteFile(keyFile, secret, 0o600))
+	verifier, err := auth.NewVerifier(auth.Config{HMACKeyFile: keyFile})
+	require.NoError(t, err)
+
+	// API with the customer, order and return endpoints on memory repositories
+	store, err := repository.NewMemoryStore()
+	require.NoError(t, err)
+	customerRepo := repository.NewMemoryCustomerRepository(store)
+	productRepo := repository.NewMemoryProductRepository(store)
+	orderRepo := repository.NewMemoryOrderRepository(store)
+	shipmentRepo := repository.NewMemoryShipmentRepository(store)
+	warehouseRepo := repository.NewMemoryWarehouseRepository(store)
+	returnRepo := repository.NewMemoryReturnRepository(store)
+	events := usecase.NewEventDispatcher()
+	cancelOrder := usecase.NewCancelOrderUseCase(orderRepo, productRepo, shipmentRepo, events)
+	apiHandler := NewAPIHandler(
+		controller.NewCustomerController(
+			usecase.NewCreateCustomerUseCase(customerRepo, events),
+			usecase.NewGetCustomerUseCase(customerRepo),
+			usecase.NewListCustomersUseCase(customerRepo),
+			usecase.NewUpdateCustomerUseCase(customerRepo),
+			usecase.NewDeleteCustomerUseCase(customerRepo),
+			presenter.NewCustomerPresenter(),
+		),
+		nil,
+		controller.NewOrderController(
+			usecase.NewCreateOrderUseCase(orderRepo, customerRepo, productRepo, events),
+			usecase.NewGetOrderUseCase(orderRepo),
+			usecase.NewListOrdersUseCase(orderRepo),
+			usecase.NewListProductOrdersUseCase(orderRepo),
+			usecase.NewUpdateOrderStatusUseCase(orderRepo, cancelOrder, events),
+			cancelOrder,
+			usecase.NewGetOrderHistoryUseCase(orderRepo),
+			presenter.NewOrderPresenter(),
+		),
+		nil, nil, nil,
+		controller.NewReturnController(
+			usecase.NewRequestReturnUseCase(returnRepo, orderRepo, events),
+			usecase.NewGetReturnUseCase(returnRepo),
+			usecase.NewListOrderReturnsUseCase(returnRepo),
+			usecase.NewListReturnsUseCase(returnRepo),
+			usecase.NewApproveReturnUseCase(returnRepo, orderRepo, productRepo, warehouseRepo, events),
+			usecase.NewRejectReturnUseCase(returnRepo, orderRepo, events),
+			usecase.NewGetOrderUseCase(orderRepo),
+			presenter.NewReturnPresenter(),
+		),
+	)
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	e.Use(Authenticate(verifier))
+	openapi.RegisterHandlers(e, apiHandler)
+
+	// Two customers, the first with a pending order and a delivered order with a return request
+	for _, id := range []string{"customer-1", "customer-2"} {
+		customerID, _ := value.NewCustomerID(id)
+		email, _ := value.NewEmail(id + "@example.com")
+		require.NoError(t, customerRepo.Save(ctx, entity.NewCustomer(customerID, email, id)))
+	}
+	orderID, _ := value.NewOrderID("order-1")
+	customerID, _ := value.NewCustomerID("customer-1")
+	productID, _ := value.NewProductID("product-1")
+	price, _ := value.NewMoney(1000)
+	product, err := entity.NewProduct(productID, "Widget", "", price, 5)
+	require.NoError(t, err)
+	require.NoError(t, productRepo.Save(ctx, product))
+	orderItem, _ := entity.NewOrderItem(productID, 1, price)
+	order, err := entity.NewOrderWithState(orderID, customerID, []entity.OrderItem{*orderItem},
+		entity.OrderStatusPending, price, time.Now(), time.Now())
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Save(ctx, order))
+	returnItem, _ := entity.NewOrderItem(productID, 2, price)
+	delivered, err := entity.NewOrderWithState("order-2", customerID, []entity.OrderItem{*returnItem},
+		entity.OrderStatusDelivered, price.Add(price), time.Now(), time.Now())
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Save(ctx, delivered))
+	returnRequest, err := entity.NewReturnRequest("return-1", delivered,
+		[]entity.ReturnItem{{ProductID: productID, Quantity: 1}}, "Damaged", nil)
+	require.NoError(t, err)
+	require.NoError(t, returnRepo.Save(ctx, returnRequest, delivered))
+
+	token := func(subject, role string) string {
+		claims := jwt.MapClaims{"sub": subject, "exp": time.Now().Add(time.Hour).Unix()}
+		if role != "" {
+			claims["role"] = role
+		}
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		require.NoError(t, err)
+		return signed
+	}
+	customer := token("customer-1", "")
+	admin := token("staff-1", auth.RoleAdmin)
+
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("missing or invalid token is 401", func(t *testing.T) {
+		rec := request(http.MethodGet, "/customers/customer-1", "", "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+
+		rec = request(http.MethodGet, "/customers/customer-1", customer+"x", "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Header().Get(echo.HeaderWWWAuthenticate), "invalid_token")
+	})
+
+	t.Run("customer reads and updates their own record and orders", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/customers/customer-1", customer, "").Code)
+		assert.Equal(t, http.StatusOK, request(http.MethodPut, "/customers/customer-1", customer,
+			`{"email":"customer-1@example.com","name":"Renamed"}`).Code)
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/customers/customer-1/orders", customer, "").Code)
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/orders/order-1", customer, "").Code)
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/orders/order-1/history", customer, "").Code)
+	})
+
+	t.Run("customer requests and reads returns of their own orders", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, request(http.MethodPost, "/orders/order-2/returns", customer,
+			`{"items":[{"product_id":"product-1","quantity":1}],"reason":"Wrong size"}`).Code)
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/orders/order-2/returns", customer, "").Code)
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/returns/return-1", customer, "").Code)
+	})
+
+	t.Run("customer cannot reach another customer", func(t *testing.T) {
+		other := token("customer-2", auth.RoleCustomer)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/customers/customer-1", other, "").Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodPut, "/customers/customer-1", other,
+			`{"email":"customer-1@example.com","name":"Hijacked"}`).Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/customers/customer-1/orders", other, "").Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/orders/order-1", other, "").Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/orders/order-1/history", other, "").Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodPost, "/orders/order-1/cancel", other, `{"reason":"mine now"}`).Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodPost, "/orders", other,
+			`{"customer_id":"customer-1","items":[{"product_id":"product-1","quantity":1}]}`).Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodPost, "/orders/order-2/returns", other,
+			`{"items":[{"product_id":"product-1","quantity":1}],"reason":"Not mine"}`).Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/orders/order-2/returns", other, "").Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/returns/return-1", other, "").Code)
+	})
+
+	t.Run("customer cannot call back office operations", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/customers", customer, "").Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodDelete, "/customers/customer-1", customer, "").Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodPut, "/orders/order-1", customer, `{"status":"shipped"}`).Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/returns", customer, "").Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodPost, "/returns/return-1/approval", customer,
+			`{"warehouse_id":"warehouse-1"}`).Code)
+	})
+
+	t.Run("admin reaches every customer", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/customers", admin, "").Code)
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/customers/customer-2", admin, "").Code)
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/orders/order-1", admin, "").Code)
+	})
+
+	t.Run("status history records the subject as actor", func(t *testing.T) {
+		rec := request(http.MethodPost, "/orders/order-1/cancel", customer, `{"reason":"changed my mind"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		history, err := orderRepo.FindStatusHistory(ctx, orderID)
+		require.NoError(t, err)
+		require.NotEmpty(t, history)
+		assert.Equal(t, "customer-1", history[len(history)-1].Actor)
+	})
+}
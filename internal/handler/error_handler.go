@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/domain"
+)
+
+// ErrorHandler renders every error returned by a handler as an openapi.Error. Domain errors are
+// mapped by their kind: not found is 404, duplicates and lost optimistic locking races are 409,
+// business rule violations are 422 and invalid input is 400. Anything else is a 500 whose cause
+// is logged but not exposed to the client.
+func ErrorHandler(err error, ctx echo.Context) {
+	if ctx.Response().Committed {
+		return
+	}
+
+	status, code, message := errorResponse(err)
+	if status == http.StatusInternalServerError {
+		slog.Error("Request failed", "method", ctx.Request().Method, "path", ctx.Path(), "error", err)
+	}
+
+	var writeErr error
+	if ctx.Request().Method == http.MethodHead {
+		writeErr = ctx.NoContent(status)
+	} else {
+		writeErr = ctx.JSON(status, openapi.Error{
+			Code:    code,
+			Message: message,
+		})
+	}
+	if writeErr != nil {
+		slog.Error("Failed to write error response", "error", writeErr)
+	}
+}
+
+// errorResponse maps an error to its HTTP status, error code and client-facing message
+func errorResponse(err error) (int, string, string) {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		message, ok := httpErr.Message.(string)
+		if !ok {
+			message = http.StatusText(httpErr.Code)
+		}
+		return httpErr.Code, httpErrorCode(httpErr.Code), message
+	}
+
+	var domainErr *domain.DomainError
+	if !errors.As(err, &domainErr) {
+		return http.StatusInternalServerError, "internal_error", "Internal server error"
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound, "not_found", domainErr.Message
+	case errors.Is(err, domain.ErrAlreadyExists), errors.Is(err, domain.ErrConcurrentModification):
+		return http.StatusConflict, "conflict", domainErr.Message
+	case errors.Is(err, domain.ErrRuleViolation):
+		return http.StatusUnprocessableEntity, "rule_violation", domainErr.Message
+	case domainErr.Code == domain.ErrCodeInvalidInput:
+		return http.StatusBadRequest, "validation_error", domainErr.Message
+	default:
+		return http.StatusInternalServerError, "internal_error", "Internal server error"
+	}
+}
+
+// httpErrorCode derives an error code from the status of an echo.HTTPError, e.g. "not_found"
+func httpErrorCode(status int) string {
+	if status == http.StatusBadRequest {
+		return "validation_error"
+	}
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/domain"
+)
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not found", domain.OrderNotFoundError("order-1"), http.StatusNotFound, "not_found"},
+		{"wrapped not found", fmt.Errorf("lookup: %w", domain.ProductNotFoundError("p-1")), http.StatusNotFound, "not_found"},
+		{"already exists", domain.CustomerAlreadyExistsError("a@example.com"), http.StatusConflict, "conflict"},
+		{"concurrent modification", domain.ConcurrentModificationError("Order", "order-1"), http.StatusConflict, "conflict"},
+		{"insufficient stock", domain.InsufficientStockError("p-1"), http.StatusUnprocessableEntity, "rule_violation"},
+		{"rule violation", domain.RuleViolationError("cannot cancel"), http.StatusUnprocessableEntity, "rule_violation"},
+		{"invalid input", domain.InvalidInputError("invalid order ID"), http.StatusBadRequest, "validation_error"},
+		{"repository error", domain.RepositoryError("failed to save", errors.New("boom")), http.StatusInternalServerError, "internal_error"},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError, "internal_error"},
+		{"echo not found", echo.ErrNotFound, http.StatusNotFound, "not_found"},
+		{"echo bad request", echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter limit"), http.StatusBadRequest, "validation_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			ErrorHandler(tt.err, ctx)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body openapi.Error
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantCode, body.Code)
+			if tt.wantStatus == http.StatusInternalServerError {
+				assert.NotContains(t, body.Message, "boom")
+			}
+		})
+	}
+
+	t.Run("committed response is left alone", func(t *testing.T) {
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+		require.NoError(t, ctx.NoContent(http.StatusNoContent))
+
+		ErrorHandler(domain.OrderNotFoundError("order-1"), ctx)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+}
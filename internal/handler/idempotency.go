@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"dynamo-modeling/internal/auth"
+	domainrepo "dynamo-modeling/internal/domain/repository"
+)
+
+// IdempotencyKeyHeader carries the client's key of a retryable request, and
+// IdempotentReplayedHeader marks a response replayed from the one stored for the key
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// IdempotencyRetention is how long the response of a completed request is kept for replay
+const IdempotencyRetention = 24 * time.Hour
+
+const (
+	maxIdempotencyKeyLength = 255
+	idempotencyLockTimeout  = time.Minute // Longest time a running request holds its key
+)
+
+// IdempotentRoutes are the operations that honour an Idempotency-Key, as method and route path
+var IdempotentRoutes = []string{
+	http.MethodPost + " /customers",
+	http.MethodPost + " /orders",
+	http.MethodPost + " /products",
+}
+
+// Idempotency makes the given routes safe to retry. A request carrying an Idempotency-Key reserves
+// the key before it runs and stores its response afterwards; a repeat with the same body gets the
+// stored response replayed, a repeat with another body is rejected with 422, and a repeat that
+// arrives while the first request is still running is rejected with 409. Server errors and
+// conflicts release the key instead of being stored, so the client can retry them.
+// Keys are scoped to the subject of the request's token, so callers cannot collide on a key.
+// The key is reserved for at most idempotencyLockTimeout while a request runs and is kept for
+// IdempotencyRetention after it completes.
+func Idempotency(repo domainrepo.IdempotencyRepository, routes ...string) echo.MiddlewareFunc {
+	idempotent := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		idempotent[route] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			key := req.Header.Get(IdempotencyKeyHeader)
+			if key == "" || !idempotent[req.Method+" "+ctx.Path()] {
+				return next(ctx)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return echo.NewHTTPError(http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			}
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body")
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			// 呼び出し元ごとにキーを分け、他人の応答が再生されないようにする
+			if principal := auth.FromContext(req.Context()); principal != nil {
+				key = principal.Subject + "#" + key
+			}
+
+			now := time.Now().UTC()
+			record := &domainrepo.IdempotencyRecord{
+				Key:         key,
+				RequestHash: requestHash(req.Method, ctx.Path(), body),
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(idempotencyLockTimeout),
+			}
+			existing, err := repo.Reserve(req.Context(), record)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				return replay(ctx, record, existing)
+			}
+
+			// 応答を記録しながらハンドラーを実行する（エラーもここで応答に変換する）
+			res := ctx.Response()
+			recorder := &responseRecorder{ResponseWriter: res.Writer}
+			res.Writer = recorder
+			if err := next(ctx); err != nil {
+				ctx.Error(err)
+			}
+			res.Writer = recorder.ResponseWriter
+
+			// クライアントが切断していても結果は残す
+			storeCtx := context.WithoutCancel(req.Context())
+			if res.Status >= http.StatusInternalServerError || res.Status == http.StatusConflict {
+				if err := repo.Release(storeCtx, key); err != nil {
+					slog.Error("Failed to release idempotency key", "key", key, "error", err)
+				}
+				return nil
+			}
+
+			record.Completed = true
+			record.StatusCode = res.Status
+			record.ContentType = res.Header().Get(echo.HeaderContentType)
+			record.Body = recorder.body.Bytes()
+			record.ExpiresAt = time.Now().UTC().Add(IdempotencyRetention)
+			if err := repo.Complete(storeCtx, record); err != nil {
+				slog.Error("Failed to store idempotent response", "key", key, "error", err)
+			}
+			return nil
+		}
+	}
+}
+
+// replay answers a repeated request from the record that holds its key
+func replay(ctx echo.Context, record, existing *domainrepo.IdempotencyRecord) error {
+	if existing.RequestHash != record.RequestHash {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request")
+	}
+	if !existing.Completed {
+		return echo.NewHTTPError(http.StatusConflict, "A request with this Idempotency-Key is still in progress")
+	}
+	ctx.Response().Header().Set(IdempotentReplayedHeader, "true")
+	return ctx.Blob(existing.StatusCode, existing.ContentType, existing.Body)
+}
+
+// requestHash identifies a request by its method, route and body
+func requestHash(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder copies the response body while it is written to the client
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
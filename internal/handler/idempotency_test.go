@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/domain"
+)
+
+func TestIdempotency(t *testing.T) {
+	// newServer returns a server whose POST /orders creates an order numbered by its call count.
+	// A body of "fail" makes the handler fail with a 500 and "block" makes it wait on release.
+	newServer := func(t *testing.T) (*echo.Echo, *atomic.Int32, chan struct{}) {
+		store, err := repository.NewMemoryStore()
+		require.NoError(t, err)
+
+		var calls atomic.Int32
+		release := make(chan struct{})
+		e := echo.New()
+		e.HTTPErrorHandler = ErrorHandler
+		e.Use(Idempotency(repository.NewMemoryIdempotencyRepository(store), IdempotentRoutes...))
+		handle := func(ctx echo.Context) error {
+			n := calls.Add(1)
+			body, err := io.ReadAll(ctx.Request().Body)
+			if err != nil {
+				return err
+			}
+			switch string(body) {
+			case "fail":
+				return domain.RepositoryError("failed to save", nil)
+			case "block":
+				<-release
+			}
+			return ctx.JSON(http.StatusCreated, map[string]int32{"order": n})
+		}
+		e.POST("/orders", handle)
+		e.POST("/warehouses", handle)
+		return e, &calls, release
+	}
+
+	post := func(e *echo.Echo, path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("repeat replays the stored response", func(t *testing.T) {
+		e, calls, _ := newServer(t)
+
+		first := post(e, "/orders", "key-1", `{"items":1}`)
+		require.Equal(t, http.StatusCreated, first.Code)
+		second := post(e, "/orders", "key-1", `{"items":1}`)
+
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+		assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("same key with another body is rejected", func(t *testing.T) {
+		e, calls, _ := newServer(t)
+
+		require.Equal(t, http.StatusCreated, post(e, "/orders", "key-1", `{"items":1}`).Code)
+		rec := post(e, "/orders", "key-1", `{"items":2}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("in-flight duplicate is rejected", func(t *testing.T) {
+		e, calls, release := newServer(t)
+
+		done := make(chan *httptest.ResponseRecorder)
+		go func() { done <- post(e, "/orders", "key-1", "block") }()
+		require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+		rec := post(e, "/orders", "key-1", "block")
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		close(release)
+		assert.Equal(t, http.StatusCreated, (<-done).Code)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("server error releases the key", func(t *testing.T) {
+		e, calls, _ := newServer(t)
+
+		assert.Equal(t, http.StatusInternalServerError, post(e, "/orders", "key-1", "fail").Code)
+		assert.Equal(t, http.StatusInternalServerError, post(e, "/orders", "key-1", "fail").Code)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("requests without a key or on other routes run every time", func(t *testing.T) {
+		e, calls, _ := newServer(t)
+
+		post(e, "/orders", "", `{"items":1}`)
+		post(e, "/orders", "", `{"items":1}`)
+		post(e, "/warehouses", "key-1", `{"items":1}`)
+		post(e, "/warehouses", "key-1", `{"items":1}`)
+		assert.Equal(t, int32(4), calls.Load())
+	})
+
+	t.Run("too long key is rejected", func(t *testing.T) {
+		e, calls, _ := newServer(t)
+
+		rec := post(e, "/orders", strings.Repeat("k", 256), `{"items":1}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, int32(0), calls.Load())
+	})
+}
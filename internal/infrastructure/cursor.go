@@ -0,0 +1,123 @@
+package infrastructure
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor is malformed, tampered with or used for another query
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// CursorCodec converts DynamoDB LastEvaluatedKeys to opaque, HMAC-signed cursor strings and back
+type CursorCodec struct {
+	secret []byte
+}
+
+// cursorAttribute is the JSON form of a key attribute (key attributes are always strings or numbers)
+type cursorAttribute struct {
+	S *string `json:"S,omitempty"`
+	N *string `json:"N,omitempty"`
+}
+
+// NewCursorCodec creates a cursor codec that signs cursors with the given secret
+func NewCursorCodec(secret []byte) *CursorCodec {
+	return &CursorCodec{
+		secret: secret,
+	}
+}
+
+// NewRandomCursorCodec creates a cursor codec with a random secret (cursors do not survive a restart)
+func NewRandomCursorCodec() (*CursorCodec, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return NewCursorCodec(secret), nil
+}
+
+// Encode turns a paging key into a cursor bound to scope. A nil key (last page) yields a nil cursor.
+func (c *CursorCodec) Encode(scope string, key dynamo.PagingKey) (*string, error) {
+	if len(key) == 0 {
+		return nil, nil
+	}
+
+	attrs := make(map[string]cursorAttribute, len(key))
+	for name, av := range key {
+		switch v := av.(type) {
+		case *types.AttributeValueMemberS:
+			attrs[name] = cursorAttribute{S: &v.Value}
+		case *types.AttributeValueMemberN:
+			attrs[name] = cursorAttribute{N: &v.Value}
+		default:
+			return nil, fmt.Errorf("unsupported key attribute type for %s: %T", name, av)
+		}
+	}
+
+	payload, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal paging key: %w", err)
+	}
+
+	cursor := base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(c.sign(scope, payload))
+	return &cursor, nil
+}
+
+// Decode verifies a cursor issued for scope and returns its paging key. A nil or empty cursor yields a nil key.
+func (c *CursorCodec) Decode(scope string, cursor *string) (dynamo.PagingKey, error) {
+	if cursor == nil || *cursor == "" {
+		return nil, nil
+	}
+
+	encodedPayload, encodedSignature, ok := strings.Cut(*cursor, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if !hmac.Equal(signature, c.sign(scope, payload)) {
+		return nil, ErrInvalidCursor
+	}
+
+	var attrs map[string]cursorAttribute
+	if err := json.Unmarshal(payload, &attrs); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	key := make(dynamo.PagingKey, len(attrs))
+	for name, attr := range attrs {
+		switch {
+		case attr.S != nil:
+			key[name] = &types.AttributeValueMemberS{Value: *attr.S}
+		case attr.N != nil:
+			key[name] = &types.AttributeValueMemberN{Value: *attr.N}
+		default:
+			return nil, ErrInvalidCursor
+		}
+	}
+	return key, nil
+}
+
+// sign computes the HMAC of the payload bound to the query scope
+func (c *CursorCodec) sign(scope string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(scope))
+	mac.Write([]byte{0})
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorCodec(t *testing.T) {
+	codec := NewCursorCodec([]byte("test-secret"))
+	key := dynamo.PagingKey{
+		"PK":     &types.AttributeValueMemberS{Value: "PRODUCT#product-1"},
+		"SK":     &types.AttributeValueMemberS{Value: "PRODUCT#product-1"},
+		"GSI1PK": &types.AttributeValueMemberS{Value: "PRODUCT#ALL"},
+		"Stock":  &types.AttributeValueMemberN{Value: "10"},
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		cursor, err := codec.Encode("products:all", key)
+		require.NoError(t, err)
+		require.NotNil(t, cursor)
+
+		decoded, err := codec.Decode("products:all", cursor)
+		require.NoError(t, err)
+		assert.Equal(t, key, decoded)
+	})
+
+	t.Run("nil key and empty cursor", func(t *testing.T) {
+		cursor, err := codec.Encode("products:all", nil)
+		assert.NoError(t, err)
+		assert.Nil(t, cursor)
+
+		decoded, err := codec.Decode("products:all", nil)
+		assert.NoError(t, err)
+		assert.Nil(t, decoded)
+
+		empty := ""
+		decoded, err = codec.Decode("products:all", &empty)
+		assert.NoError(t, err)
+		assert.Nil(t, decoded)
+	})
+
+	t.Run("tampered cursor is rejected", func(t *testing.T) {
+		cursor, err := codec.Encode("products:all", key)
+		require.NoError(t, err)
+
+		payload, signature, _ := strings.Cut(*cursor, ".")
+		tampered := "f" + payload[1:] + "." + signature // payloads always start with "e" ({" in base64)
+		_, err = codec.Decode("products:all", &tampered)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+
+		garbage := "not-a-cursor"
+		_, err = codec.Decode("products:all", &garbage)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("cursor from another scope is rejected", func(t *testing.T) {
+		cursor, err := codec.Encode("orders:customer:customer-1", key)
+		require.NoError(t, err)
+
+		_, err = codec.Decode("orders:customer:customer-2", cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("cursor signed with another secret is rejected", func(t *testing.T) {
+		cursor, err := NewCursorCodec([]byte("other-secret")).Encode("products:all", key)
+		require.NoError(t, err)
+
+		_, err = codec.Decode("products:all", cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
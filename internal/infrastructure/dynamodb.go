@@ -8,36 +8,31 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
 	"github.com/guregu/dynamo/v2"
 )
 
 // DynamoDBConfig holds the configuration for DynamoDB connection
 type DynamoDBConfig struct {
-	Region    string
-	Endpoint  string // For local development
-	TableName string
+	Region       string
+	Endpoint     string // For local development
+	TableName    string
+	CursorSecret string // HMAC key for pagination cursors (random per process when empty)
 }
 
 // DynamoDBClient wraps the guregu dynamo client
 type DynamoDBClient struct {
 	DB        *dynamo.DB
 	TableName string
+	Cursors   *CursorCodec
 }
 
-// NewDynamoDBClient creates a new DynamoDB client using guregu/dynamo
-func NewDynamoDBClient(ctx context.Context, cfg DynamoDBConfig) (*DynamoDBClient, error) {
-	slog.Info("Initializing DynamoDB client",
-		"region", cfg.Region,
-		"endpoint", cfg.Endpoint,
-		"tableName", cfg.TableName)
-
-	// AWS SDK config
-	var awsCfg aws.Config
-	var err error
-
+// LoadAWSConfig loads the AWS SDK configuration for a DynamoDB connection. With an endpoint set it
+// targets DynamoDB Local with dummy credentials, otherwise it uses the default AWS credential chain.
+func LoadAWSConfig(ctx context.Context, cfg DynamoDBConfig) (aws.Config, error) {
 	if cfg.Endpoint != "" {
 		// For local development with DynamoDB Local
-		awsCfg, err = config.LoadDefaultConfig(ctx,
+		return config.LoadDefaultConfig(ctx,
 			config.WithRegion(cfg.Region),
 			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("dummy", "dummy", "")),
 			config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(
@@ -48,13 +43,23 @@ func NewDynamoDBClient(ctx context.Context, cfg DynamoDBConfig) (*DynamoDBClient
 					}, nil
 				})),
 		)
-	} else {
-		// For production with real AWS DynamoDB
-		awsCfg, err = config.LoadDefaultConfig(ctx,
-			config.WithRegion(cfg.Region),
-		)
 	}
 
+	// For production with real AWS DynamoDB
+	return config.LoadDefaultConfig(ctx,
+		config.WithRegion(cfg.Region),
+	)
+}
+
+// NewDynamoDBClient creates a new DynamoDB client using guregu/dynamo
+func NewDynamoDBClient(ctx context.Context, cfg DynamoDBConfig) (*DynamoDBClient, error) {
+	slog.Info("Initializing DynamoDB client",
+		"region", cfg.Region,
+		"endpoint", cfg.Endpoint,
+		"tableName", cfg.TableName)
+
+	// AWS SDK config
+	awsCfg, err := LoadAWSConfig(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -65,14 +70,36 @@ func NewDynamoDBClient(ctx context.Context, cfg DynamoDBConfig) (*DynamoDBClient
 	// Create guregu dynamo DB client
 	db := dynamo.NewFromIface(dynamoSvc)
 
+	// Pagination cursor codec
+	var cursors *CursorCodec
+	if cfg.CursorSecret != "" {
+		cursors = NewCursorCodec([]byte(cfg.CursorSecret))
+	} else {
+		slog.Warn("No cursor secret configured, pagination cursors will not survive a restart")
+		cursors, err = NewRandomCursorCodec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	slog.Info("DynamoDB client initialized successfully")
 
 	return &DynamoDBClient{
 		DB:        db,
 		TableName: cfg.TableName,
+		Cursors:   cursors,
 	}, nil
 }
 
+// NewDynamoDBStreamsClient creates a client for the streams of the configured tables
+func NewDynamoDBStreamsClient(ctx context.Context, cfg DynamoDBConfig) (*dynamodbstreams.Client, error) {
+	awsCfg, err := LoadAWSConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return dynamodbstreams.NewFromConfig(awsCfg), nil
+}
+
 // GetTable returns a dynamo table instance
 func (c *DynamoDBClient) GetTable() dynamo.Table {
 	return c.DB.Table(c.TableName)
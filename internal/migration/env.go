@@ -0,0 +1,169 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// pollInterval is how often the schema helpers check whether the table has become active
+var pollInterval = 2 * time.Second
+
+// Env gives a migration access to the table it migrates. The schema helpers skip changes that
+// are already in place and wait until the table and its indexes are active again, so that a
+// migration can be retried and the next one starts from a usable table.
+type Env struct {
+	Client *infrastructure.DynamoDBClient
+}
+
+// Table returns the migrated table
+func (e *Env) Table() dynamo.Table {
+	return e.Client.GetTable()
+}
+
+// TableExists reports whether the migrated table exists
+func (e *Env) TableExists(ctx context.Context) (bool, error) {
+	_, err := e.Table().Describe().Run(ctx)
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to describe table %s: %w", e.Client.TableName, err)
+	}
+	return true, nil
+}
+
+// CreateTable creates the table with the primary key declared by the dynamo struct tags of keys,
+// e.g. `dynamo:"PK,hash"`, on-demand billing and the given GSIs. An existing table is left as is.
+func (e *Env) CreateTable(ctx context.Context, keys any, indexes ...dynamo.Index) error {
+	exists, err := e.TableExists(ctx)
+	if err != nil || exists {
+		return err
+	}
+
+	create := e.Client.DB.CreateTable(e.Client.TableName, keys).OnDemand(true)
+	for _, index := range indexes {
+		create = create.Index(index)
+	}
+	if err := create.Run(ctx); err != nil {
+		return fmt.Errorf("failed to create table %s: %w", e.Client.TableName, err)
+	}
+	return e.WaitActive(ctx)
+}
+
+// AddGSI creates a global secondary index and waits until it has been backfilled. An index
+// with the same name is left as is.
+func (e *Env) AddGSI(ctx context.Context, index dynamo.Index) error {
+	desc, err := e.Table().Describe().Run(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", e.Client.TableName, err)
+	}
+	if findIndex(desc, index.Name) != nil {
+		return nil
+	}
+
+	if _, err := e.Table().UpdateTable().CreateIndex(index).Run(ctx); err != nil {
+		return fmt.Errorf("failed to create index %s: %w", index.Name, err)
+	}
+	return e.WaitActive(ctx)
+}
+
+// RemoveGSI deletes a global secondary index and waits until it is gone. A missing index is
+// not an error.
+func (e *Env) RemoveGSI(ctx context.Context, name string) error {
+	desc, err := e.Table().Describe().Run(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", e.Client.TableName, err)
+	}
+	if findIndex(desc, name) == nil {
+		return nil
+	}
+
+	if _, err := e.Table().UpdateTable().DeleteIndex(name).Run(ctx); err != nil {
+		return fmt.Errorf("failed to delete index %s: %w", name, err)
+	}
+	return e.WaitActive(ctx)
+}
+
+// EnableTTL makes DynamoDB expire items whose attribute holds a past Unix time in seconds
+func (e *Env) EnableTTL(ctx context.Context, attribute string) error {
+	ttl, err := e.Table().DescribeTTL().Run(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to describe TTL of %s: %w", e.Client.TableName, err)
+	}
+	if ttl.Enabled() && ttl.Attribute == attribute {
+		return nil
+	}
+
+	if err := e.Table().UpdateTTL(attribute, true).Run(ctx); err != nil {
+		return fmt.Errorf("failed to enable TTL on %s: %w", attribute, err)
+	}
+	return nil
+}
+
+// EnableStream turns on the table's stream with the given view. A stream that is already
+// enabled is left as is, even with another view, because changing the view needs the stream
+// to be disabled first and consumers to start over.
+func (e *Env) EnableStream(ctx context.Context, view dynamo.StreamView) error {
+	desc, err := e.Table().Describe().Run(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", e.Client.TableName, err)
+	}
+	if desc.StreamEnabled {
+		return nil
+	}
+
+	if _, err := e.Table().UpdateTable().Stream(view).Run(ctx); err != nil {
+		return fmt.Errorf("failed to enable stream: %w", err)
+	}
+	return e.WaitActive(ctx)
+}
+
+// WaitActive blocks until the table and all of its GSIs are active and backfilled
+func (e *Env) WaitActive(ctx context.Context) error {
+	for {
+		desc, err := e.Table().Describe().Run(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to describe table %s: %w", e.Client.TableName, err)
+		}
+		if isActive(desc) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("table %s did not become active: %w", e.Client.TableName, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// isActive reports whether the table and every GSI can serve requests
+func isActive(desc dynamo.Description) bool {
+	if desc.Status != dynamo.ActiveStatus {
+		return false
+	}
+	for _, index := range desc.GSI {
+		if index.Status != dynamo.ActiveStatus || index.Backfilling {
+			return false
+		}
+	}
+	return true
+}
+
+// findIndex returns the GSI with the given name, or nil
+func findIndex(desc dynamo.Description, name string) *dynamo.Index {
+	for i := range desc.GSI {
+		if desc.GSI[i].Name == name {
+			return &desc.GSI[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/guregu/dynamo/v2"
+
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// Migration is one numbered change to the table: a schema change such as creating a GSI or
+// enabling TTL, or a data step that rewrites existing items. Migrations run once, in ascending
+// Version order, and are never edited after they have been applied anywhere; a follow-up change
+// gets a new version. Up should be safe to run again after a partial failure.
+type Migration struct {
+	Version     int
+	Name        string // snake_case, e.g. "create_table"
+	Description string // What the migration changes, shown by plan
+	Up          func(ctx context.Context, env *Env) error
+}
+
+// Label returns the version and name of the migration, e.g. "0001_create_table"
+func (m Migration) Label() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Status is a migration together with when it was applied
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Record is the item that records an applied migration. All records share the MIGRATION#
+// partition so that status reads them with a single query.
+type Record struct {
+	PK        string    `dynamo:"PK"`        // MIGRATION#
+	SK        string    `dynamo:"SK"`        // VERSION#{Version:0000}
+	Type      string    `dynamo:"Type"`      // "MIGRATION"
+	Version   int       `dynamo:"Version"`   // Migration version
+	Name      string    `dynamo:"Name"`      // Migration name
+	AppliedAt time.Time `dynamo:"AppliedAt"` // When the migration finished
+}
+
+// recordPK is the partition that holds every migration record
+const recordPK = "MIGRATION#"
+
+// recordSK returns the sort key of the record of a version
+func recordSK(version int) string {
+	return fmt.Sprintf("VERSION#%04d", version)
+}
+
+// store reads and writes migration records
+type store interface {
+	// applied returns the records of the applied migrations keyed by version
+	applied(ctx context.Context) (map[int]Record, error)
+	// record stores that a migration has been applied
+	record(ctx context.Context, record Record) error
+}
+
+// Runner applies migrations to the table of a DynamoDB client
+type Runner struct {
+	env        *Env
+	migrations []Migration
+	store      store
+}
+
+// NewRunner creates a runner for the given migrations, recording them in the migrated table
+func NewRunner(client *infrastructure.DynamoDBClient, migrations []Migration) (*Runner, error) {
+	env := &Env{Client: client}
+	return newRunner(env, migrations, &dynamoStore{env: env})
+}
+
+// newRunner validates the migrations and sorts them by version
+func newRunner(env *Env, migrations []Migration, store store) (*Runner, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i, m := range sorted {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("migration %q: version must be positive", m.Name)
+		}
+		if m.Name == "" || m.Up == nil {
+			return nil, fmt.Errorf("migration %04d: name and Up are required", m.Version)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("duplicate migration version %04d: %s and %s", m.Version, sorted[i-1].Name, m.Name)
+		}
+	}
+
+	return &Runner{env: env, migrations: sorted, store: store}, nil
+}
+
+// Status returns every known migration in version order with whether it has been applied
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	applied, err := r.store.applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	statuses := make([]Status, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		status := Status{Migration: m}
+		if record, ok := applied[m.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Plan returns the migrations that Up would apply, in the order it would apply them
+func (r *Runner) Plan(ctx context.Context) ([]Migration, error) {
+	statuses, err := r.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, status := range statuses {
+		if !status.Applied {
+			pending = append(pending, status.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies the pending migrations in version order and records each one as soon as it
+// succeeds. It stops at the first failure, so running it again resumes from that migration.
+func (r *Runner) Up(ctx context.Context) ([]Migration, error) {
+	pending, err := r.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, m := range pending {
+		slog.Info("Applying migration", "migration", m.Label())
+		started := time.Now()
+
+		if err := m.Up(ctx, r.env); err != nil {
+			return done, fmt.Errorf("migration %s failed: %w", m.Label(), err)
+		}
+
+		record := Record{
+			PK:        recordPK,
+			SK:        recordSK(m.Version),
+			Type:      "MIGRATION",
+			Version:   m.Version,
+			Name:      m.Name,
+			AppliedAt: time.Now().UTC(),
+		}
+		if err := r.store.record(ctx, record); err != nil {
+			return done, fmt.Errorf("migration %s applied but not recorded: %w", m.Label(), err)
+		}
+
+		slog.Info("Migration applied", "migration", m.Label(), "duration", time.Since(started))
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// dynamoStore keeps migration records in the migrated table itself
+type dynamoStore struct {
+	env *Env
+}
+
+func (s *dynamoStore) applied(ctx context.Context) (map[int]Record, error) {
+	// The table does not exist before the first migration creates it
+	exists, err := s.env.TableExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int]Record)
+	if !exists {
+		return applied, nil
+	}
+
+	var records []Record
+	if err := s.env.Table().Get("PK", recordPK).All(ctx, &records); err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+func (s *dynamoStore) record(ctx context.Context, record Record) error {
+	// A concurrent run that recorded the same version first makes this one fail
+	err := s.env.Table().Put(record).If("attribute_not_exists(PK)").Run(ctx)
+	if dynamo.IsCondCheckFailed(err) {
+		return fmt.Errorf("migration %04d was recorded by another run", record.Version)
+	}
+	return err
+}
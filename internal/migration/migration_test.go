@@ -0,0 +1,261 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/guregu/dynamo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// memoryStore keeps migration records in a map for tests without DynamoDB
+type memoryStore struct {
+	records map[int]Record
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: make(map[int]Record)}
+}
+
+func (s *memoryStore) applied(ctx context.Context) (map[int]Record, error) {
+	applied := make(map[int]Record, len(s.records))
+	for version, record := range s.records {
+		applied[version] = record
+	}
+	return applied, nil
+}
+
+func (s *memoryStore) record(ctx context.Context, record Record) error {
+	s.records[record.Version] = record
+	return nil
+}
+
+// testMigration returns a migration that appends its version to ran
+func testMigration(version int, ran *[]int) Migration {
+	return Migration{
+		Version: version,
+		Name:    fmt.Sprintf("step_%d", version),
+		Up: func(ctx context.Context, env *Env) error {
+			*ran = append(*ran, version)
+			return nil
+		},
+	}
+}
+
+func TestNewRunner(t *testing.T) {
+	var ran []int
+
+	t.Run("migrations are sorted by version", func(t *testing.T) {
+		runner, err := newRunner(&Env{}, []Migration{testMigration(3, &ran), testMigration(1, &ran)}, newMemoryStore())
+		require.NoError(t, err)
+		assert.Equal(t, 1, runner.migrations[0].Version)
+		assert.Equal(t, 3, runner.migrations[1].Version)
+	})
+
+	t.Run("invalid migrations are rejected", func(t *testing.T) {
+		_, err := newRunner(&Env{}, []Migration{testMigration(1, &ran), testMigration(1, &ran)}, newMemoryStore())
+		assert.ErrorContains(t, err, "duplicate migration version 0001")
+
+		_, err = newRunner(&Env{}, []Migration{testMigration(0, &ran)}, newMemoryStore())
+		assert.ErrorContains(t, err, "version must be positive")
+
+		_, err = newRunner(&Env{}, []Migration{{Version: 1, Name: "no_up"}}, newMemoryStore())
+		assert.ErrorContains(t, err, "name and Up are required")
+	})
+
+	t.Run("registered migrations are valid", func(t *testing.T) {
+		_, err := newRunner(&Env{}, All(), newMemoryStore())
+		assert.NoError(t, err)
+	})
+}
+
+func TestRunner(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("up applies pending migrations in order and records them", func(t *testing.T) {
+		var ran []int
+		store := newMemoryStore()
+		runner, err := newRunner(&Env{}, []Migration{testMigration(2, &ran), testMigration(1, &ran)}, store)
+		require.NoError(t, err)
+
+		pending, err := runner.Plan(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		assert.Equal(t, "0001_step_1", pending[0].Label())
+		assert.Empty(t, ran) // plan does not run anything
+
+		done, err := runner.Up(ctx)
+		require.NoError(t, err)
+		assert.Len(t, done, 2)
+		assert.Equal(t, []int{1, 2}, ran)
+		assert.Equal(t, "MIGRATION#", store.records[1].PK)
+		assert.Equal(t, "VERSION#0002", store.records[2].SK)
+		assert.WithinDuration(t, time.Now(), store.records[2].AppliedAt, time.Second)
+
+		// A second run has nothing to do
+		done, err = runner.Up(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, done)
+		assert.Equal(t, []int{1, 2}, ran)
+	})
+
+	t.Run("status reports applied and pending migrations", func(t *testing.T) {
+		var ran []int
+		store := newMemoryStore()
+		appliedAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		store.records[1] = Record{Version: 1, Name: "step_1", AppliedAt: appliedAt}
+		runner, err := newRunner(&Env{}, []Migration{testMigration(1, &ran), testMigration(2, &ran)}, store)
+		require.NoError(t, err)
+
+		statuses, err := runner.Status(ctx)
+		require.NoError(t, err)
+		require.Len(t, statuses, 2)
+		assert.True(t, statuses[0].Applied)
+		assert.Equal(t, appliedAt, statuses[0].AppliedAt)
+		assert.False(t, statuses[1].Applied)
+
+		_, err = runner.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, ran)
+	})
+
+	t.Run("up stops at the first failure and resumes from it", func(t *testing.T) {
+		var ran []int
+		store := newMemoryStore()
+		fail := true
+		failing := Migration{
+			Version: 2,
+			Name:    "flaky",
+			Up: func(ctx context.Context, env *Env) error {
+				if fail {
+					return errors.New("boom")
+				}
+				ran = append(ran, 2)
+				return nil
+			},
+		}
+		runner, err := newRunner(&Env{}, []Migration{testMigration(1, &ran), failing, testMigration(3, &ran)}, store)
+		require.NoError(t, err)
+
+		done, err := runner.Up(ctx)
+		assert.ErrorContains(t, err, "migration 0002_flaky failed: boom")
+		assert.Len(t, done, 1)
+		assert.Equal(t, []int{1}, ran)
+		assert.NotContains(t, store.records, 2)
+
+		fail = false
+		done, err = runner.Up(ctx)
+		require.NoError(t, err)
+		assert.Len(t, done, 2)
+		assert.Equal(t, []int{1, 2, 3}, ran)
+	})
+}
+
+func TestIsActive(t *testing.T) {
+	active := dynamo.Description{
+		Status: dynamo.ActiveStatus,
+		GSI:    []dynamo.Index{{Name: "GSI1", Status: dynamo.ActiveStatus}},
+	}
+	assert.True(t, isActive(active))
+	assert.NotNil(t, findIndex(active, "GSI1"))
+	assert.Nil(t, findIndex(active, "GSI3"))
+
+	updating := active
+	updating.Status = dynamo.UpdatingStatus
+	assert.False(t, isActive(updating))
+
+	backfilling := active
+	backfilling.GSI = []dynamo.Index{{Name: "GSI1", Status: dynamo.ActiveStatus, Backfilling: true}}
+	assert.False(t, isActive(backfilling))
+}
+
+func TestDynamoMigrations(t *testing.T) {
+	// Skip if not running integration tests
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+	pollInterval = 100 * time.Millisecond
+
+	// Migrate a throwaway table so the shared one is left alone
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	dynamoCfg := cfg.DynamoDB.Infrastructure()
+	dynamoCfg.TableName = fmt.Sprintf("MigrationTest-%d", time.Now().UnixNano())
+	client, err := infrastructure.NewDynamoDBClient(ctx, dynamoCfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.GetTable().DeleteTable().Run(context.Background())
+	})
+
+	migrations := append(All(), Migration{
+		Version: 100,
+		Name:    "schema_changes",
+		Up: func(ctx context.Context, env *Env) error {
+			if err := env.AddGSI(ctx, dynamo.Index{
+				Name:           "GSI3",
+				HashKey:        "GSI3PK",
+				HashKeyType:    dynamo.StringType,
+				RangeKey:       "GSI3SK",
+				RangeKeyType:   dynamo.StringType,
+				ProjectionType: dynamo.KeysOnlyProjection,
+			}); err != nil {
+				return err
+			}
+			if err := env.RemoveGSI(ctx, "GSI3"); err != nil {
+				return err
+			}
+			return env.EnableStream(ctx, dynamo.NewAndOldImagesView)
+		},
+	})
+	runner, err := NewRunner(client, migrations)
+	require.NoError(t, err)
+
+	t.Run("plan on a missing table lists every migration", func(t *testing.T) {
+		pending, err := runner.Plan(ctx)
+		require.NoError(t, err)
+		assert.Len(t, pending, len(migrations))
+	})
+
+	t.Run("up creates the table and records versions", func(t *testing.T) {
+		done, err := runner.Up(ctx)
+		require.NoError(t, err)
+		assert.Len(t, done, len(migrations))
+
+		desc, err := client.GetTable().Describe().Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "PK", desc.HashKey)
+		assert.Equal(t, "SK", desc.RangeKey)
+		assert.NotNil(t, findIndex(desc, "GSI1"))
+		assert.NotNil(t, findIndex(desc, "GSI2"))
+		assert.Nil(t, findIndex(desc, "GSI3"))
+		assert.True(t, desc.StreamEnabled)
+
+		statuses, err := runner.Status(ctx)
+		require.NoError(t, err)
+		for _, status := range statuses {
+			assert.True(t, status.Applied, status.Label())
+		}
+	})
+
+	t.Run("up again has nothing to do", func(t *testing.T) {
+		done, err := runner.Up(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, done)
+	})
+
+	t.Run("schema helpers skip changes already in place", func(t *testing.T) {
+		env := &Env{Client: client}
+		require.NoError(t, env.CreateTable(ctx, tableKeys{}))
+		require.NoError(t, env.RemoveGSI(ctx, "GSI3"))
+		require.NoError(t, env.EnableStream(ctx, dynamo.NewAndOldImagesView))
+	})
+}
@@ -0,0 +1,150 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/guregu/dynamo/v2"
+
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/backfill"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// All returns every migration of the OnlineShop table. Append new migrations with the next
+// version; never change or renumber one that has been released.
+func All() []Migration {
+	return []Migration{
+		{
+			Version:     1,
+			Name:        "create_table",
+			Description: "Create the table with PK/SK, GSI1 and GSI2 (on-demand, ALL projection)",
+			Up:          createTable,
+		},
+		{
+			Version:     2,
+			Name:        "email_sentinels",
+			Description: "Write the EMAIL# sentinel of customers saved before email uniqueness was enforced",
+			Up:          emailSentinels,
+		},
+		{
+			Version:     3,
+			Name:        "convert_legacy_order_lines",
+			Description: "Move order lines from the JSON Items attribute to LINE# items",
+			Up:          convertLegacyOrderLines,
+		},
+		{
+			Version:     4,
+			Name:        "product_stock_to_inventory",
+			Description: "Create the default warehouse and move product stock that no warehouse holds into it",
+			Up:          productStockToInventory,
+		},
+		{
+			Version:     5,
+			Name:        "order_line_gsi1_keys",
+			Description: "Add the PRODUCT# GSI1 keys to order lines written without them",
+			Up:          orderLineGSI1Keys,
+		},
+		{
+			Version:     6,
+			Name:        "enable_stream",
+			Description: "Enable the table stream with new and old images for stream consumers",
+			Up:          enableStream,
+		},
+		{
+			Version:     7,
+			Name:        "enable_ttl",
+			Description: "Enable TTL on ExpiresAt so that expired idempotency keys are deleted",
+			Up:          enableTTL,
+		},
+	}
+}
+
+// tableKeys declares the primary key of the single table
+type tableKeys struct {
+	PK string `dynamo:"PK,hash"`
+	SK string `dynamo:"SK,range"`
+}
+
+// createTable creates the single table. Environments provisioned before migrations existed
+// already have it, so there the migration only records that version 1 is applied.
+func createTable(ctx context.Context, env *Env) error {
+	return env.CreateTable(ctx, tableKeys{},
+		dynamo.Index{
+			Name:           "GSI1",
+			HashKey:        "GSI1PK",
+			HashKeyType:    dynamo.StringType,
+			RangeKey:       "GSI1SK",
+			RangeKeyType:   dynamo.StringType,
+			ProjectionType: dynamo.AllProjection,
+		},
+		dynamo.Index{
+			Name:           "GSI2",
+			HashKey:        "GSI2PK",
+			HashKeyType:    dynamo.StringType,
+			RangeKey:       "GSI2SK",
+			RangeKeyType:   dynamo.StringType,
+			ProjectionType: dynamo.AllProjection,
+		},
+	)
+}
+
+// runBackfill returns a data step that runs a registered backfill to completion
+func runBackfill(name string) func(ctx context.Context, env *Env) error {
+	return func(ctx context.Context, env *Env) error {
+		runner, err := backfill.NewRunner(env.Client, backfill.All())
+		if err != nil {
+			return err
+		}
+		_, err = runner.Run(ctx, name, backfill.Options{})
+		return err
+	}
+}
+
+// emailSentinels reserves the email addresses of existing customers, so that a concurrent create
+// cannot take them. Customers whose address another customer already holds are logged and skipped.
+func emailSentinels(ctx context.Context, env *Env) error {
+	return runBackfill("email_sentinels")(ctx, env)
+}
+
+// convertLegacyOrderLines is a data step that runs a backfill. Orders modified while it runs are
+// skipped and keep their JSON lines, which the repository still reads, until they are next saved.
+func convertLegacyOrderLines(ctx context.Context, env *Env) error {
+	return runBackfill("order_lines_from_json")(ctx, env)
+}
+
+// productStockToInventory gives stock set on products before warehouses existed to the default
+// warehouse, so that orders can reserve it and Stock equals the total of the inventories again
+func productStockToInventory(ctx context.Context, env *Env) error {
+	warehouse, err := entity.NewWarehouse(value.WarehouseID(backfill.LegacyStockWarehouseID), "Default warehouse",
+		"Stock of products created before warehouses existed")
+	if err != nil {
+		return err
+	}
+	item := repository.WarehouseItemFromEntity(warehouse)
+	item.Version = 1
+	err = env.Table().Put(item).If("attribute_not_exists('PK')").Run(ctx)
+	if err != nil && !dynamo.IsCondCheckFailed(err) {
+		return fmt.Errorf("failed to create the default warehouse: %w", err)
+	}
+	return runBackfill("product_stock_to_inventory")(ctx, env)
+}
+
+// orderLineGSI1Keys indexes order lines written before orders by product existed, which
+// FindByProductAndDateRange would otherwise never return
+func orderLineGSI1Keys(ctx context.Context, env *Env) error {
+	return runBackfill("order_line_gsi1_keys")(ctx, env)
+}
+
+// enableStream turns on the stream that internal/stream consumers read. Consumers decode both
+// images of a change, so the stream carries new and old images.
+func enableStream(ctx context.Context, env *Env) error {
+	return env.EnableStream(ctx, dynamo.NewAndOldImagesView)
+}
+
+// enableTTL lets DynamoDB delete IDEMPOTENCY# items once their ExpiresAt has passed. Deletion can
+// lag by days, so readers still treat an item with a past ExpiresAt as absent.
+func enableTTL(ctx context.Context, env *Env) error {
+	return env.EnableTTL(ctx, "ExpiresAt")
+}
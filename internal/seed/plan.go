@@ -0,0 +1,222 @@
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"dynamo-modeling/internal/domain/entity"
+)
+
+// Counts at scale 1; a run multiplies each of them by Options.Scale
+const (
+	baseCustomers  = 1000
+	baseProducts   = 200
+	baseWarehouses = 3
+	baseOrders     = 3000
+)
+
+var (
+	firstNames = []string{
+		"Haruto", "Yui", "Sota", "Hina", "Ren", "Mio", "Yuto", "Sakura", "Riku", "Aoi",
+		"Emma", "Liam", "Olivia", "Noah", "Ava", "Lucas", "Mia", "Leo", "Chloe", "Ethan",
+	}
+	lastNames = []string{
+		"Sato", "Suzuki", "Takahashi", "Tanaka", "Watanabe", "Ito", "Yamamoto", "Nakamura", "Kobayashi", "Kato",
+		"Smith", "Johnson", "Brown", "Garcia", "Miller", "Davis", "Wilson", "Moore", "Clark", "Lewis",
+	}
+	productAdjectives = []string{
+		"Classic", "Compact", "Deluxe", "Eco", "Ergonomic", "Lightweight", "Premium", "Rugged", "Smart", "Wireless",
+	}
+	productNouns = []string{
+		"Backpack", "Blender", "Desk Lamp", "Headphones", "Keyboard", "Kettle", "Monitor Stand", "Mouse",
+		"Notebook", "Speaker", "Teapot", "Thermos", "Umbrella", "Wallet", "Watch",
+	}
+	warehouseLocations = []string{"Tokyo", "Osaka", "Nagoya", "Fukuoka", "Sapporo", "Sendai", "Hiroshima", "Yokohama"}
+	carriers           = []string{"Yamato", "Sagawa", "Japan Post"}
+)
+
+// plan is everything a run writes, drawn from the seed before anything is stored so the same
+// options always produce the same data whatever order the writes happen in
+type plan struct {
+	customers  []customerPlan
+	warehouses []warehousePlan
+	products   []productPlan
+	orders     []orderPlan
+}
+
+type customerPlan struct {
+	Name  string
+	Email string
+}
+
+type warehousePlan struct {
+	Name     string
+	Location string
+}
+
+type productPlan struct {
+	Name        string
+	Description string
+	Price       int64
+	// Stock is the quantity held per warehouse index
+	Stock map[int]int
+}
+
+type orderPlan struct {
+	Customer  int
+	Lines     []linePlan
+	CreatedAt time.Time
+	Status    entity.OrderStatus
+	// Cancelled orders that were confirmed first
+	ConfirmedFirst bool
+	// Shipments split the lines between warehouses, one shipment per group
+	Shipments []shipmentPlan
+}
+
+type linePlan struct {
+	Product  int
+	Quantity int
+}
+
+type shipmentPlan struct {
+	Warehouse      int
+	Carrier        string
+	TrackingNumber string
+	Lines          []linePlan
+}
+
+// newPlan draws the data of a run from its seed
+func newPlan(opts Options) *plan {
+	rng := rand.New(rand.NewPCG(uint64(opts.Seed), 0x5eed))
+	p := &plan{}
+
+	// 1. 顧客（メールアドレスは連番とシードで一意にする）
+	for i := range scaled(baseCustomers, opts.Scale) {
+		first := firstNames[rng.IntN(len(firstNames))]
+		last := lastNames[rng.IntN(len(lastNames))]
+		p.customers = append(p.customers, customerPlan{
+			Name:  first + " " + last,
+			Email: fmt.Sprintf("%s.%s.%d@seed%d.example.com", strings.ToLower(first), strings.ToLower(last), i+1, opts.Seed),
+		})
+	}
+
+	// 2. 倉庫
+	for i := range scaled(baseWarehouses, opts.Scale) {
+		location := warehouseLocations[i%len(warehouseLocations)]
+		name := location + " Fulfillment Center"
+		if i >= len(warehouseLocations) {
+			name = fmt.Sprintf("%s %d", name, i/len(warehouseLocations)+1)
+		}
+		p.warehouses = append(p.warehouses, warehousePlan{Name: name, Location: location + ", Japan"})
+	}
+
+	// 3. 商品（価格は 1.99〜199.99 ドル）
+	for i := range scaled(baseProducts, opts.Scale) {
+		adjective := productAdjectives[rng.IntN(len(productAdjectives))]
+		noun := productNouns[rng.IntN(len(productNouns))]
+		p.products = append(p.products, productPlan{
+			Name:        fmt.Sprintf("%s %s %03d", adjective, noun, i+1),
+			Description: fmt.Sprintf("A %s %s for everyday use", strings.ToLower(adjective), strings.ToLower(noun)),
+			Price:       int64(rng.IntN(199)+1)*100 + 99,
+		})
+	}
+
+	// 4. 注文（最近の注文ほど多く、古い注文ほど処理が進んでいる）
+	demand := make([]int, len(p.products))
+	for i := range scaled(baseOrders, opts.Scale) {
+		age := time.Duration(math.Pow(rng.Float64(), 2) * float64(opts.Days) * float64(24*time.Hour))
+		order := orderPlan{
+			Customer:  rng.IntN(len(p.customers)),
+			CreatedAt: opts.Now.Add(-age).Truncate(time.Second),
+			Status:    statusForAge(rng, age),
+		}
+
+		for _, product := range rng.Perm(len(p.products))[:1+rng.IntN(min(4, len(p.products)))] {
+			quantity := 1 + rng.IntN(3)
+			order.Lines = append(order.Lines, linePlan{Product: product, Quantity: quantity})
+			demand[product] += quantity
+		}
+
+		switch order.Status {
+		case entity.OrderStatusCancelled:
+			order.ConfirmedFirst = rng.IntN(2) == 0
+		case entity.OrderStatusShipped, entity.OrderStatusDelivered:
+			groups := 1
+			if len(order.Lines) > 1 && len(p.warehouses) > 1 && rng.IntN(4) == 0 {
+				groups = 2
+			}
+			split := len(order.Lines) / groups
+			for g := range groups {
+				lines := order.Lines[g*split:]
+				if g < groups-1 {
+					lines = order.Lines[g*split : (g+1)*split]
+				}
+				carrier := carriers[rng.IntN(len(carriers))]
+				order.Shipments = append(order.Shipments, shipmentPlan{
+					Warehouse:      rng.IntN(len(p.warehouses)),
+					Carrier:        carrier,
+					TrackingNumber: fmt.Sprintf("S%d-%06d-%d", opts.Seed, i+1, g+1),
+					Lines:          lines,
+				})
+			}
+		}
+		p.orders = append(p.orders, order)
+	}
+
+	// 5. 在庫（全注文の引当に足りる数を複数の倉庫に分けて置く）
+	for i := range p.products {
+		total := demand[i] + 10 + rng.IntN(90)
+		holders := rng.Perm(len(p.warehouses))[:1+rng.IntN(len(p.warehouses))]
+		stock := make(map[int]int, len(holders))
+		for j, warehouse := range holders {
+			quantity := total / len(holders)
+			if j == 0 {
+				quantity += total % len(holders)
+			}
+			stock[warehouse] = quantity
+		}
+		p.products[i].Stock = stock
+	}
+
+	return p
+}
+
+// statusForAge picks where an order of the given age has got to: recent orders are mostly
+// pending or confirmed, orders of a few days are on their way and older ones have arrived.
+// A few of every age are cancelled.
+func statusForAge(rng *rand.Rand, age time.Duration) entity.OrderStatus {
+	day := 24 * time.Hour
+	n := rng.IntN(100)
+	switch {
+	case n < 8:
+		return entity.OrderStatusCancelled
+	case age < 2*day:
+		if n < 60 {
+			return entity.OrderStatusPending
+		}
+		return entity.OrderStatusConfirmed
+	case age < 7*day:
+		if n < 15 {
+			return entity.OrderStatusPending
+		}
+		if n < 45 {
+			return entity.OrderStatusConfirmed
+		}
+		return entity.OrderStatusShipped
+	case age < 14*day:
+		if n < 45 {
+			return entity.OrderStatusShipped
+		}
+		return entity.OrderStatusDelivered
+	default:
+		return entity.OrderStatusDelivered
+	}
+}
+
+// scaled multiplies a base count by the scale, keeping at least one of everything
+func scaled(base int, scale float64) int {
+	return max(1, int(math.Round(float64(base)*scale)))
+}
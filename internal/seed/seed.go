@@ -0,0 +1,260 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/repository"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/usecase"
+)
+
+// Options controls what a run generates. The same options produce the same customers,
+// products, stock and orders; only the IDs, which the use cases generate, differ.
+type Options struct {
+	// Seed drives every random choice. Emails include it, so runs with different seeds can
+	// share a table.
+	Seed int64
+	// Scale multiplies the counts of scale 1: 1,000 customers, 200 products, 3 warehouses
+	// and 3,000 orders
+	Scale float64
+	// Days is how far back orders are spread; most of them are recent
+	Days int
+	// Now is when the newest orders are placed
+	Now time.Time
+}
+
+// Repositories are the repositories a run writes through
+type Repositories struct {
+	Customers  repository.CustomerRepository
+	Products   repository.ProductRepository
+	Orders     repository.OrderRepository
+	Warehouses repository.WarehouseRepository
+	Shipments  repository.ShipmentRepository
+}
+
+// Summary counts what a run created
+type Summary struct {
+	Customers  int
+	Warehouses int
+	Products   int
+	Orders     int
+	Shipments  int
+	Statuses   map[entity.OrderStatus]int
+}
+
+// Seeder fills a store with generated data through the same use cases as the API, so every
+// invariant holds: emails are unique, product stock is the total of its warehouse inventory,
+// orders reserve stock, cancelled orders give it back and statuses only move along allowed
+// transitions.
+type Seeder struct {
+	orderRepo       repository.OrderRepository
+	createCustomer  *usecase.CreateCustomerUseCase
+	createWarehouse *usecase.CreateWarehouseUseCase
+	createProduct   *usecase.CreateProductUseCase
+	setInventory    *usecase.SetInventoryUseCase
+	updateStatus    *usecase.UpdateOrderStatusUseCase
+	createShipment  *usecase.CreateShipmentUseCase
+	deliverShipment *usecase.DeliverShipmentUseCase
+}
+
+// NewSeeder creates a seeder writing through the given repositories. Seeded history is not
+// published as domain events.
+func NewSeeder(repos Repositories) *Seeder {
+	cancelOrder := usecase.NewCancelOrderUseCase(repos.Orders, repos.Products, repos.Shipments, nil)
+	return &Seeder{
+		orderRepo:       repos.Orders,
+		createCustomer:  usecase.NewCreateCustomerUseCase(repos.Customers, nil),
+		createWarehouse: usecase.NewCreateWarehouseUseCase(repos.Warehouses),
+		createProduct:   usecase.NewCreateProductUseCase(repos.Products),
+		setInventory:    usecase.NewSetInventoryUseCase(repos.Warehouses, repos.Products),
+		updateStatus:    usecase.NewUpdateOrderStatusUseCase(repos.Orders, cancelOrder, nil),
+		createShipment:  usecase.NewCreateShipmentUseCase(repos.Shipments, repos.Orders, repos.Warehouses, nil),
+		deliverShipment: usecase.NewDeliverShipmentUseCase(repos.Shipments, repos.Orders, nil),
+	}
+}
+
+// Run generates the data for opts and stores it. It stops at the first error, returning what
+// was created until then.
+func (s *Seeder) Run(ctx context.Context, opts Options) (*Summary, error) {
+	if opts.Scale <= 0 {
+		return nil, fmt.Errorf("scale must be positive, got %g", opts.Scale)
+	}
+	if opts.Days <= 0 {
+		return nil, fmt.Errorf("days must be positive, got %d", opts.Days)
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	p := newPlan(opts)
+	summary := &Summary{Statuses: make(map[entity.OrderStatus]int)}
+
+	// 1. 顧客の作成
+	customerIDs := make([]value.CustomerID, len(p.customers))
+	for i, c := range p.customers {
+		customer, err := s.createCustomer.Execute(ctx, usecase.CreateCustomerCommand{Name: c.Name, Email: c.Email})
+		if err != nil {
+			return summary, fmt.Errorf("customer %s: %w", c.Email, err)
+		}
+		customerIDs[i] = customer.ID()
+		summary.Customers++
+	}
+	slog.Info("Seeded customers", "count", summary.Customers)
+
+	// 2. 倉庫の作成
+	warehouseIDs := make([]value.WarehouseID, len(p.warehouses))
+	for i, w := range p.warehouses {
+		warehouse, err := s.createWarehouse.Execute(ctx, usecase.CreateWarehouseCommand{Name: w.Name, Location: w.Location})
+		if err != nil {
+			return summary, fmt.Errorf("warehouse %s: %w", w.Name, err)
+		}
+		warehouseIDs[i] = warehouse.ID()
+		summary.Warehouses++
+	}
+	slog.Info("Seeded warehouses", "count", summary.Warehouses)
+
+	// 3. 商品の作成と倉庫在庫の登録（商品の在庫合計は在庫登録で加算される）
+	products := make([]*entity.Product, len(p.products))
+	for i, pp := range p.products {
+		product, err := s.createProduct.Execute(ctx, usecase.CreateProductCommand{
+			Name:        pp.Name,
+			Description: pp.Description,
+			Price:       pp.Price,
+		})
+		if err != nil {
+			return summary, fmt.Errorf("product %s: %w", pp.Name, err)
+		}
+		for w := range p.warehouses {
+			quantity, ok := pp.Stock[w]
+			if !ok {
+				continue
+			}
+			_, err := s.setInventory.Execute(ctx, usecase.SetInventoryCommand{
+				WarehouseID: warehouseIDs[w].String(),
+				ProductID:   product.ID().String(),
+				Quantity:    quantity,
+			})
+			if err != nil {
+				return summary, fmt.Errorf("inventory of %s: %w", pp.Name, err)
+			}
+		}
+		products[i] = product
+		summary.Products++
+	}
+	slog.Info("Seeded products", "count", summary.Products)
+
+	// 4. 注文の作成とステータスの遷移
+	for i, op := range p.orders {
+		shipments, err := s.seedOrder(ctx, op, customerIDs[op.Customer], products, warehouseIDs)
+		if err != nil {
+			return summary, fmt.Errorf("order %d: %w", i+1, err)
+		}
+		summary.Orders++
+		summary.Shipments += shipments
+		summary.Statuses[op.Status]++
+		if summary.Orders%500 == 0 {
+			slog.Info("Seeding orders", "done", summary.Orders, "total", len(p.orders))
+		}
+	}
+	slog.Info("Seeded orders", "count", summary.Orders, "shipments", summary.Shipments)
+
+	return summary, nil
+}
+
+// seedActor is the actor of the status changes the seeder makes
+const seedActor = "seed"
+
+// seedOrder places an order at its planned time and moves it to its planned status. It
+// returns the number of shipments created.
+func (s *Seeder) seedOrder(
+	ctx context.Context,
+	op orderPlan,
+	customerID value.CustomerID,
+	products []*entity.Product,
+	warehouseIDs []value.WarehouseID,
+) (int, error) {
+	// 1. 注文エンティティ作成（作成日時を過去にするため、注文作成ユースケースではなく同じ手順をここで行う）
+	items := make([]entity.OrderItem, 0, len(op.Lines))
+	var total value.Money
+	for _, line := range op.Lines {
+		product := products[line.Product]
+		item, err := entity.NewOrderItem(product.ID(), line.Quantity, product.Price())
+		if err != nil {
+			return 0, err
+		}
+		items = append(items, *item)
+		total = total.Add(item.TotalPrice())
+	}
+	order, err := entity.NewOrderWithState(
+		value.GenerateOrderID(), customerID, items, entity.OrderStatusPending, total, op.CreatedAt, op.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. 在庫の予約と注文の保存
+	if err := s.orderRepo.PlaceOrder(ctx, order); err != nil {
+		return 0, err
+	}
+	orderID := order.ID().String()
+
+	// 3. ステータス遷移（出荷と配達は出荷ユースケース経由で注文に反映される）
+	switch op.Status {
+	case entity.OrderStatusPending:
+		return 0, nil
+	case entity.OrderStatusCancelled:
+		if op.ConfirmedFirst {
+			if err := s.setStatus(ctx, orderID, entity.OrderStatusConfirmed); err != nil {
+				return 0, err
+			}
+		}
+		return 0, s.setStatus(ctx, orderID, entity.OrderStatusCancelled)
+	}
+
+	if err := s.setStatus(ctx, orderID, entity.OrderStatusConfirmed); err != nil {
+		return 0, err
+	}
+	shipmentIDs := make([]string, 0, len(op.Shipments))
+	for _, sp := range op.Shipments {
+		cmd := usecase.CreateShipmentCommand{
+			OrderID:        orderID,
+			WarehouseID:    warehouseIDs[sp.Warehouse].String(),
+			Carrier:        sp.Carrier,
+			TrackingNumber: sp.TrackingNumber,
+			Actor:          seedActor,
+		}
+		for _, line := range sp.Lines {
+			cmd.Items = append(cmd.Items, usecase.ShipmentItemCommand{
+				ProductID: products[line.Product].ID().String(),
+				Quantity:  line.Quantity,
+			})
+		}
+		shipment, err := s.createShipment.Execute(ctx, cmd)
+		if err != nil {
+			return len(shipmentIDs), err
+		}
+		shipmentIDs = append(shipmentIDs, shipment.ID().String())
+	}
+	if op.Status == entity.OrderStatusDelivered {
+		for _, id := range shipmentIDs {
+			if _, err := s.deliverShipment.Execute(ctx, usecase.DeliverShipmentCommand{ShipmentID: id, Actor: seedActor}); err != nil {
+				return len(shipmentIDs), err
+			}
+		}
+	}
+	return len(shipmentIDs), nil
+}
+
+func (s *Seeder) setStatus(ctx context.Context, orderID string, status entity.OrderStatus) error {
+	_, err := s.updateStatus.Execute(ctx, usecase.UpdateOrderStatusCommand{
+		OrderID: orderID,
+		Status:  string(status),
+		Actor:   seedActor,
+		Reason:  "seed data",
+	})
+	return err
+}
@@ -0,0 +1,154 @@
+package seed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+)
+
+var now = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func TestNewPlan(t *testing.T) {
+	opts := Options{Seed: 42, Scale: 1, Days: 365, Now: now}
+	p := newPlan(opts)
+
+	t.Run("counts follow the scale", func(t *testing.T) {
+		assert.Len(t, p.customers, 1000)
+		assert.Len(t, p.products, 200)
+		assert.Len(t, p.warehouses, 3)
+		assert.Len(t, p.orders, 3000)
+
+		small := newPlan(Options{Seed: 42, Scale: 0.001, Days: 365, Now: now})
+		assert.Len(t, small.customers, 1)
+		assert.Len(t, small.warehouses, 1)
+	})
+
+	t.Run("the same seed gives the same data", func(t *testing.T) {
+		assert.Equal(t, p, newPlan(opts))
+
+		other := newPlan(Options{Seed: 43, Scale: 1, Days: 365, Now: now})
+		assert.NotEqual(t, p.orders, other.orders)
+		assert.NotEqual(t, p.customers[0].Email, other.customers[0].Email)
+	})
+
+	t.Run("emails are unique and valid", func(t *testing.T) {
+		seen := make(map[string]bool, len(p.customers))
+		for _, c := range p.customers {
+			assert.False(t, seen[c.Email], c.Email)
+			seen[c.Email] = true
+			_, err := value.NewEmail(c.Email)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("stock covers every order", func(t *testing.T) {
+		demand := make(map[int]int)
+		for _, o := range p.orders {
+			for _, line := range o.Lines {
+				demand[line.Product] += line.Quantity
+			}
+		}
+		for i, product := range p.products {
+			stock := 0
+			for _, quantity := range product.Stock {
+				stock += quantity
+			}
+			assert.Greater(t, stock, demand[i], product.Name)
+		}
+	})
+
+	t.Run("orders are spread over time in every status", func(t *testing.T) {
+		statuses := make(map[entity.OrderStatus]int)
+		for _, o := range p.orders {
+			statuses[o.Status]++
+			assert.False(t, o.CreatedAt.After(now))
+			assert.False(t, o.CreatedAt.Before(now.AddDate(0, 0, -365)))
+
+			// Shipments cover every line exactly once
+			if o.Status == entity.OrderStatusShipped || o.Status == entity.OrderStatusDelivered {
+				var shipped []linePlan
+				for _, s := range o.Shipments {
+					shipped = append(shipped, s.Lines...)
+				}
+				assert.Equal(t, o.Lines, shipped)
+			} else {
+				assert.Empty(t, o.Shipments)
+			}
+		}
+		for _, status := range []entity.OrderStatus{
+			entity.OrderStatusPending, entity.OrderStatusConfirmed, entity.OrderStatusShipped,
+			entity.OrderStatusDelivered, entity.OrderStatusCancelled,
+		} {
+			assert.NotZero(t, statuses[status], status)
+		}
+	})
+}
+
+func TestSeederRun(t *testing.T) {
+	ctx := context.Background()
+	store, err := repository.NewMemoryStore()
+	require.NoError(t, err)
+	repos := Repositories{
+		Customers:  repository.NewMemoryCustomerRepository(store),
+		Products:   repository.NewMemoryProductRepository(store),
+		Orders:     repository.NewMemoryOrderRepository(store),
+		Warehouses: repository.NewMemoryWarehouseRepository(store),
+		Shipments:  repository.NewMemoryShipmentRepository(store),
+	}
+	seeder := NewSeeder(repos)
+
+	opts := Options{Seed: 7, Scale: 0.05, Days: 90, Now: now}
+	summary, err := seeder.Run(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 50, summary.Customers)
+	assert.Equal(t, 10, summary.Products)
+	assert.Equal(t, 150, summary.Orders)
+	assert.Positive(t, summary.Shipments)
+
+	p := newPlan(opts)
+	planned := make(map[entity.OrderStatus]int)
+	for _, o := range p.orders {
+		planned[o.Status]++
+	}
+	assert.Equal(t, planned, summary.Statuses)
+
+	// Orders landed in their planned status with their planned date
+	customer, err := repos.Customers.FindByEmail(ctx, mustEmail(t, p.customers[p.orders[0].Customer].Email))
+	require.NoError(t, err)
+	orders, _, err := repos.Orders.FindByCustomerID(ctx, customer.ID(), 100, nil)
+	require.NoError(t, err)
+	var found bool
+	for _, order := range orders {
+		if order.CreatedAt().Equal(p.orders[0].CreatedAt) {
+			found = true
+			assert.Equal(t, p.orders[0].Status, order.Status())
+		}
+	}
+	assert.True(t, found, "first planned order not found")
+
+	t.Run("seeding again with the same seed collides on emails", func(t *testing.T) {
+		_, err := seeder.Run(ctx, opts)
+		assert.ErrorContains(t, err, p.customers[0].Email)
+	})
+
+	t.Run("invalid options are rejected", func(t *testing.T) {
+		_, err := seeder.Run(ctx, Options{Seed: 1, Scale: 0, Days: 1})
+		assert.ErrorContains(t, err, "scale must be positive")
+		_, err = seeder.Run(ctx, Options{Seed: 1, Scale: 1})
+		assert.ErrorContains(t, err, "days must be positive")
+	})
+}
+
+func mustEmail(t *testing.T, email string) value.Email {
+	t.Helper()
+	e, err := value.NewEmail(email)
+	require.NoError(t, err)
+	return e
+}
@@ -0,0 +1,376 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// Options tunes how a consumer reads the stream and retries handlers. Zero values take the defaults.
+type Options struct {
+	BatchSize    int32         // Records per GetRecords call (default 100, at most 1000)
+	PollInterval time.Duration // Wait between polls once the stream is caught up (default 1s)
+	MaxAttempts  int           // Attempts per handler before a record becomes a dead letter (default 5)
+	Backoff      time.Duration // Wait after the first failed attempt, doubled after each (default 100ms)
+	MaxBackoff   time.Duration // Longest wait between attempts (default 5s)
+	FromLatest   bool          // Start shards without a checkpoint at new records instead of the oldest ones
+}
+
+func (o Options) withDefaults() Options {
+	if o.BatchSize <= 0 || o.BatchSize > 1000 {
+		o.BatchSize = 100
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = time.Second
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 5
+	}
+	if o.Backoff <= 0 {
+		o.Backoff = 100 * time.Millisecond
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 5 * time.Second
+	}
+	return o
+}
+
+// streamsAPI is the part of the DynamoDB Streams client a consumer uses
+type streamsAPI interface {
+	DescribeStream(ctx context.Context, in *dynamodbstreams.DescribeStreamInput, opts ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error)
+	GetShardIterator(ctx context.Context, in *dynamodbstreams.GetShardIteratorInput, opts ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error)
+	GetRecords(ctx context.Context, in *dynamodbstreams.GetRecordsInput, opts ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error)
+}
+
+type handler struct {
+	name string
+	fn   HandlerFunc
+}
+
+// Consumer reads the table's stream and dispatches each record to the handlers registered for
+// the Type of the changed item. Shards are read parent first, so the records of an item arrive
+// in order. The position in each shard is saved as a checkpoint after every batch that reached a
+// handler, so records are delivered at least once: after a restart, handlers may see the records
+// of the last unsaved batch again. A consumer is not safe for concurrent use.
+type Consumer struct {
+	name      string
+	streams   streamsAPI
+	store     store
+	streamARN func(ctx context.Context) (string, error)
+	opts      Options
+	handlers  map[string][]handler
+	iterators map[string]string // Open shard iterators carried between polls
+}
+
+// NewConsumer creates a consumer of the client's table stream. The name identifies its
+// checkpoints and dead letters, so consumers with different names read the stream independently.
+func NewConsumer(client *infrastructure.DynamoDBClient, streams *dynamodbstreams.Client, name string, opts Options) (*Consumer, error) {
+	if name == "" {
+		return nil, fmt.Errorf("consumer name is required")
+	}
+	table := client.GetTable()
+	streamARN := func(ctx context.Context) (string, error) {
+		desc, err := table.Describe().Run(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to describe table %s: %w", client.TableName, err)
+		}
+		if !desc.StreamEnabled || desc.LatestStreamARN == "" {
+			return "", fmt.Errorf("table %s has no stream, apply the migrations first", client.TableName)
+		}
+		return desc.LatestStreamARN, nil
+	}
+	return newConsumer(name, streams, &dynamoStore{table: table, consumer: name}, streamARN, opts), nil
+}
+
+func newConsumer(name string, streams streamsAPI, store store, streamARN func(ctx context.Context) (string, error), opts Options) *Consumer {
+	return &Consumer{
+		name:      name,
+		streams:   streams,
+		store:     store,
+		streamARN: streamARN,
+		opts:      opts.withDefaults(),
+		handlers:  make(map[string][]handler),
+		iterators: make(map[string]string),
+	}
+}
+
+// Register adds a handler for changes to items of the given Type. The name identifies the
+// handler in logs and dead letters and must be unique per Type.
+func (c *Consumer) Register(itemType, name string, fn HandlerFunc) error {
+	if itemType == "" || name == "" || fn == nil {
+		return fmt.Errorf("item type, name and handler are required")
+	}
+	for _, h := range c.handlers[itemType] {
+		if h.name == name {
+			return fmt.Errorf("duplicate handler %s for %s", name, itemType)
+		}
+	}
+	c.handlers[itemType] = append(c.handlers[itemType], handler{name: name, fn: fn})
+	return nil
+}
+
+// Run polls the stream until ctx is cancelled, waiting PollInterval whenever it is caught up.
+// Errors reading the stream or saving checkpoints are logged and the next poll starts over
+// from the saved checkpoints.
+func (c *Consumer) Run(ctx context.Context) error {
+	for {
+		n, err := c.Poll(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			slog.Error("Stream poll failed", "consumer", c.name, "error", err)
+			c.iterators = make(map[string]string)
+		} else if n > 0 {
+			slog.Info("Stream records handled", "consumer", c.name, "records", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(c.opts.PollInterval):
+		}
+	}
+}
+
+// Poll reads every shard up to its latest record and returns the number of records read
+func (c *Consumer) Poll(ctx context.Context) (int, error) {
+	// 1. ストリームのシャード一覧とチェックポイントを取得
+	arn, err := c.streamARN(ctx)
+	if err != nil {
+		return 0, err
+	}
+	shards, err := c.listShards(ctx, arn)
+	if err != nil {
+		return 0, err
+	}
+	checkpoints, err := c.store.checkpoints(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. 親シャードを読み終えたシャードから順に読む（同じアイテムの変更順を保つ）
+	listed := make(map[string]bool, len(shards))
+	for _, shard := range shards {
+		listed[deref(shard.ShardId)] = true
+	}
+	done := func(id string) bool {
+		cp, ok := checkpoints[id]
+		return ok && cp.Done
+	}
+
+	read := 0
+	visited := make(map[string]bool, len(shards))
+	for progress := true; progress; {
+		progress = false
+		for _, shard := range shards {
+			id := deref(shard.ShardId)
+			if visited[id] || done(id) {
+				continue
+			}
+			if parent := deref(shard.ParentShardId); parent != "" && listed[parent] && !done(parent) {
+				continue
+			}
+			visited[id] = true
+			progress = true
+
+			cp := checkpoints[id]
+			if cp == nil {
+				cp = &checkpoint{ShardID: id}
+				checkpoints[id] = cp
+			}
+			n, err := c.readShard(ctx, arn, cp)
+			read += n
+			if err != nil {
+				return read, fmt.Errorf("shard %s: %w", id, err)
+			}
+		}
+	}
+	return read, nil
+}
+
+func (c *Consumer) listShards(ctx context.Context, arn string) ([]types.Shard, error) {
+	var (
+		shards []types.Shard
+		start  *string
+	)
+	for {
+		out, err := c.streams.DescribeStream(ctx, &dynamodbstreams.DescribeStreamInput{
+			StreamArn:             aws.String(arn),
+			ExclusiveStartShardId: start,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe stream: %w", err)
+		}
+		shards = append(shards, out.StreamDescription.Shards...)
+		start = out.StreamDescription.LastEvaluatedShardId
+		if start == nil {
+			return shards, nil
+		}
+	}
+}
+
+// readShard reads one shard until it is caught up or closed, dispatching every record
+func (c *Consumer) readShard(ctx context.Context, arn string, cp *checkpoint) (int, error) {
+	read := 0
+	for {
+		// 1. シャードイテレーターの取得（前回の続き、なければチェックポイントから）
+		iterator, ok := c.iterators[cp.ShardID]
+		if !ok {
+			var err error
+			if iterator, err = c.shardIterator(ctx, arn, cp); err != nil {
+				return read, err
+			}
+		}
+
+		// 2. レコードの取得
+		out, err := c.streams.GetRecords(ctx, &dynamodbstreams.GetRecordsInput{
+			ShardIterator: aws.String(iterator),
+			Limit:         aws.Int32(c.opts.BatchSize),
+		})
+		var expired *types.ExpiredIteratorException
+		if errors.As(err, &expired) {
+			// イテレーターの有効期限（15分）切れはチェックポイントから取り直す
+			delete(c.iterators, cp.ShardID)
+			continue
+		}
+		if err != nil {
+			delete(c.iterators, cp.ShardID)
+			return read, fmt.Errorf("failed to get records: %w", err)
+		}
+
+		// 3. レコードをハンドラーへ配信
+		handled := false
+		for _, raw := range out.Records {
+			rec, err := newRecord(cp.ShardID, raw)
+			if err != nil {
+				return read, err
+			}
+			dispatched, err := c.dispatch(ctx, rec)
+			if err != nil {
+				// チェックポイント以降を次のポーリングで読み直す
+				delete(c.iterators, cp.ShardID)
+				return read, err
+			}
+			handled = handled || dispatched
+			cp.SequenceNumber = rec.SequenceNumber
+			cp.Records++
+			read++
+		}
+
+		// 4. チェックポイントの保存（閉じたシャードは読み終わりを記録する）
+		if out.NextShardIterator == nil {
+			delete(c.iterators, cp.ShardID)
+			cp.Done = true
+			return read, c.store.saveCheckpoint(ctx, cp)
+		}
+		c.iterators[cp.ShardID] = *out.NextShardIterator
+		if handled {
+			// ハンドラーに届いたバッチだけ保存する（保存自体のレコードで読み続けないため）
+			if err := c.store.saveCheckpoint(ctx, cp); err != nil {
+				delete(c.iterators, cp.ShardID)
+				return read, err
+			}
+		}
+		if len(out.Records) == 0 {
+			return read, nil
+		}
+	}
+}
+
+func (c *Consumer) shardIterator(ctx context.Context, arn string, cp *checkpoint) (string, error) {
+	in := &dynamodbstreams.GetShardIteratorInput{
+		StreamArn:         aws.String(arn),
+		ShardId:           aws.String(cp.ShardID),
+		ShardIteratorType: types.ShardIteratorTypeTrimHorizon,
+	}
+	switch {
+	case cp.SequenceNumber != "":
+		in.ShardIteratorType = types.ShardIteratorTypeAfterSequenceNumber
+		in.SequenceNumber = aws.String(cp.SequenceNumber)
+	case c.opts.FromLatest:
+		in.ShardIteratorType = types.ShardIteratorTypeLatest
+	}
+
+	out, err := c.streams.GetShardIterator(ctx, in)
+	var trimmed *types.TrimmedDataAccessException
+	if errors.As(err, &trimmed) {
+		// ストリームの保持期間（24時間）を過ぎたチェックポイントは残っている最古のレコードから読む
+		slog.Warn("Stream records after the checkpoint were trimmed, reading from the oldest record",
+			"consumer", c.name, "shard", cp.ShardID, "sequenceNumber", cp.SequenceNumber)
+		in.ShardIteratorType = types.ShardIteratorTypeTrimHorizon
+		in.SequenceNumber = nil
+		out, err = c.streams.GetShardIterator(ctx, in)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get shard iterator: %w", err)
+	}
+	if out.ShardIterator == nil {
+		return "", fmt.Errorf("no shard iterator returned")
+	}
+	return *out.ShardIterator, nil
+}
+
+// dispatch hands a record to each handler of its item type and reports whether it had any.
+// A handler that still fails after its attempts, or fails permanently, gets a dead letter and
+// the other handlers carry on. An error means the record could not be dealt with, neither
+// handled nor stored as a dead letter, so it must be read again.
+func (c *Consumer) dispatch(ctx context.Context, rec Record) (bool, error) {
+	handlers := c.handlers[rec.ItemType]
+	for _, h := range handlers {
+		attempts, err := c.attempt(ctx, h, rec)
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if err == nil {
+			continue
+		}
+
+		slog.Error("Stream handler failed, sending the record to the dead letters",
+			"consumer", c.name, "handler", h.name, "itemType", rec.ItemType,
+			"sequenceNumber", rec.SequenceNumber, "attempts", attempts, "error", err)
+		dl, err := newDeadLetter(c.name, h.name, rec, attempts, err)
+		if err != nil {
+			return false, err
+		}
+		if err := c.store.deadLetter(ctx, dl); err != nil {
+			return false, err
+		}
+	}
+	return len(handlers) > 0, nil
+}
+
+// attempt runs a handler until it succeeds, fails permanently or runs out of attempts,
+// doubling the wait between attempts up to MaxBackoff
+func (c *Consumer) attempt(ctx context.Context, h handler, rec Record) (int, error) {
+	wait := c.opts.Backoff
+	for attempt := 1; ; attempt++ {
+		err := h.fn(ctx, rec)
+		if err == nil || isPermanent(err) || attempt == c.opts.MaxAttempts {
+			return attempt, err
+		}
+		slog.Warn("Stream handler failed, retrying",
+			"consumer", c.name, "handler", h.name, "attempt", attempt, "wait", wait, "error", err)
+		if !sleep(ctx, wait) {
+			return attempt, ctx.Err()
+		}
+		wait = min(wait*2, c.opts.MaxBackoff)
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
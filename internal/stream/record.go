@@ -0,0 +1,183 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ddbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/guregu/dynamo/v2"
+)
+
+// Event names of stream records
+const (
+	EventInsert = "INSERT"
+	EventModify = "MODIFY"
+	EventRemove = "REMOVE"
+)
+
+// Record is a change to one item of the table, read from its stream
+type Record struct {
+	EventID        string
+	EventName      string // INSERT, MODIFY or REMOVE
+	ShardID        string
+	SequenceNumber string
+	ItemType       string // Type attribute of the changed item
+	Keys           dynamo.Item
+	NewImage       dynamo.Item // Item after the change, nil for REMOVE
+	OldImage       dynamo.Item // Item before the change, nil for INSERT
+	CreatedAt      time.Time   // Approximate time of the change
+}
+
+// newRecord converts a record of the streams API. Its attribute values are a type of their
+// own, so the images are converted to the DynamoDB types the rest of the code decodes.
+func newRecord(shardID string, rec types.Record) (Record, error) {
+	if rec.Dynamodb == nil {
+		return Record{}, fmt.Errorf("record %s has no change", deref(rec.EventID))
+	}
+	r := Record{
+		EventID:        deref(rec.EventID),
+		EventName:      string(rec.EventName),
+		ShardID:        shardID,
+		SequenceNumber: deref(rec.Dynamodb.SequenceNumber),
+	}
+	if rec.Dynamodb.ApproximateCreationDateTime != nil {
+		r.CreatedAt = *rec.Dynamodb.ApproximateCreationDateTime
+	}
+
+	var err error
+	if r.Keys, err = convertItem(rec.Dynamodb.Keys); err != nil {
+		return Record{}, fmt.Errorf("keys: %w", err)
+	}
+	if r.NewImage, err = convertItem(rec.Dynamodb.NewImage); err != nil {
+		return Record{}, fmt.Errorf("new image: %w", err)
+	}
+	if r.OldImage, err = convertItem(rec.Dynamodb.OldImage); err != nil {
+		return Record{}, fmt.Errorf("old image: %w", err)
+	}
+
+	for _, image := range []dynamo.Item{r.NewImage, r.OldImage} {
+		if typ, ok := image["Type"].(*ddbtypes.AttributeValueMemberS); ok {
+			r.ItemType = typ.Value
+			break
+		}
+	}
+	return r, nil
+}
+
+func convertItem(item map[string]types.AttributeValue) (dynamo.Item, error) {
+	if item == nil {
+		return nil, nil
+	}
+	converted := make(dynamo.Item, len(item))
+	for name, value := range item {
+		v, err := convertValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		converted[name] = v
+	}
+	return converted, nil
+}
+
+func convertValue(value types.AttributeValue) (ddbtypes.AttributeValue, error) {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return &ddbtypes.AttributeValueMemberS{Value: v.Value}, nil
+	case *types.AttributeValueMemberN:
+		return &ddbtypes.AttributeValueMemberN{Value: v.Value}, nil
+	case *types.AttributeValueMemberB:
+		return &ddbtypes.AttributeValueMemberB{Value: v.Value}, nil
+	case *types.AttributeValueMemberBOOL:
+		return &ddbtypes.AttributeValueMemberBOOL{Value: v.Value}, nil
+	case *types.AttributeValueMemberNULL:
+		return &ddbtypes.AttributeValueMemberNULL{Value: v.Value}, nil
+	case *types.AttributeValueMemberSS:
+		return &ddbtypes.AttributeValueMemberSS{Value: v.Value}, nil
+	case *types.AttributeValueMemberNS:
+		return &ddbtypes.AttributeValueMemberNS{Value: v.Value}, nil
+	case *types.AttributeValueMemberBS:
+		return &ddbtypes.AttributeValueMemberBS{Value: v.Value}, nil
+	case *types.AttributeValueMemberL:
+		list := make([]ddbtypes.AttributeValue, 0, len(v.Value))
+		for i, elem := range v.Value {
+			converted, err := convertValue(elem)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			list = append(list, converted)
+		}
+		return &ddbtypes.AttributeValueMemberL{Value: list}, nil
+	case *types.AttributeValueMemberM:
+		m, err := convertItem(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &ddbtypes.AttributeValueMemberM{Value: m}, nil
+	default:
+		return nil, fmt.Errorf("unsupported attribute value %T", value)
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// HandlerFunc reacts to one record. Returned errors are retried with backoff unless they are
+// wrapped with Permanent.
+type HandlerFunc func(ctx context.Context, rec Record) error
+
+// Change is a record with its images decoded into the item type T
+type Change[T any] struct {
+	Record
+	Old *T // nil for INSERT
+	New *T // nil for REMOVE
+}
+
+// Typed adapts a handler of decoded items, e.g. Change[repository.OrderItem], to a HandlerFunc.
+// A record that does not decode into T can never succeed, so it goes to the dead letters
+// without retries.
+func Typed[T any](fn func(ctx context.Context, change Change[T]) error) HandlerFunc {
+	return func(ctx context.Context, rec Record) error {
+		change := Change[T]{Record: rec}
+		if rec.OldImage != nil {
+			change.Old = new(T)
+			if err := dynamo.UnmarshalItem(rec.OldImage, change.Old); err != nil {
+				return Permanent(fmt.Errorf("failed to decode old image: %w", err))
+			}
+		}
+		if rec.NewImage != nil {
+			change.New = new(T)
+			if err := dynamo.UnmarshalItem(rec.NewImage, change.New); err != nil {
+				return Permanent(fmt.Errorf("failed to decode new image: %w", err))
+			}
+		}
+		return fn(ctx, change)
+	}
+}
+
+// permanentError marks a handler error that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error so the record goes to the dead letters without further attempts
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
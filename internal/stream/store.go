@@ -0,0 +1,135 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/guregu/dynamo/v2"
+
+	"dynamo-modeling/internal/dataset"
+)
+
+// checkpoint records how far a consumer has read one shard. Checkpoints of a consumer share
+// its STREAM# partition so that a poll loads them with a single query.
+type checkpoint struct {
+	PK             string    `dynamo:"PK"`             // STREAM#{Consumer}
+	SK             string    `dynamo:"SK"`             // SHARD#{ShardID}
+	Type           string    `dynamo:"Type"`           // "STREAM_CHECKPOINT"
+	ShardID        string    `dynamo:"ShardID"`        // Shard of the table's stream
+	SequenceNumber string    `dynamo:"SequenceNumber"` // Last handled record, empty before the first
+	Done           bool      `dynamo:"Done"`           // Shard is closed and has been read to the end
+	Records        int       `dynamo:"Records"`        // Records read so far
+	UpdatedAt      time.Time `dynamo:"UpdatedAt"`      // Last save
+}
+
+// DeadLetter is a record a handler kept failing on. The images are kept in DynamoDB JSON so
+// the record can be inspected and replayed after a fix.
+type DeadLetter struct {
+	PK             string            `dynamo:"PK"`             // STREAM_DLQ#{Consumer}
+	SK             string            `dynamo:"SK"`             // RECORD#{SequenceNumber}#{Handler}
+	Type           string            `dynamo:"Type"`           // "STREAM_DEAD_LETTER"
+	Consumer       string            `dynamo:"Consumer"`       // Consumer that read the record
+	Handler        string            `dynamo:"Handler"`        // Handler that failed
+	ShardID        string            `dynamo:"ShardID"`        // Shard of the record
+	SequenceNumber string            `dynamo:"SequenceNumber"` // Position of the record in its shard
+	EventName      string            `dynamo:"EventName"`      // INSERT, MODIFY or REMOVE
+	ItemType       string            `dynamo:"ItemType"`       // Type of the changed item
+	Keys           map[string]string `dynamo:"Keys"`           // PK and SK of the changed item
+	NewImage       string            `dynamo:"NewImage"`       // Item after the change
+	OldImage       string            `dynamo:"OldImage"`       // Item before the change
+	Error          string            `dynamo:"Error"`          // Error of the last attempt
+	Attempts       int               `dynamo:"Attempts"`       // Attempts made
+	FailedAt       time.Time         `dynamo:"FailedAt"`       // Time of the last attempt
+}
+
+func newDeadLetter(consumer, handler string, rec Record, attempts int, err error) (*DeadLetter, error) {
+	dl := &DeadLetter{
+		PK:             fmt.Sprintf("STREAM_DLQ#%s", consumer),
+		SK:             fmt.Sprintf("RECORD#%s#%s", rec.SequenceNumber, handler),
+		Type:           "STREAM_DEAD_LETTER",
+		Consumer:       consumer,
+		Handler:        handler,
+		ShardID:        rec.ShardID,
+		SequenceNumber: rec.SequenceNumber,
+		EventName:      rec.EventName,
+		ItemType:       rec.ItemType,
+		Keys:           make(map[string]string, len(rec.Keys)),
+		Error:          err.Error(),
+		Attempts:       attempts,
+		FailedAt:       time.Now().UTC(),
+	}
+	for name, value := range rec.Keys {
+		if s, ok := value.(*types.AttributeValueMemberS); ok {
+			dl.Keys[name] = s.Value
+		}
+	}
+	for _, image := range []struct {
+		item dynamo.Item
+		dst  *string
+	}{{rec.NewImage, &dl.NewImage}, {rec.OldImage, &dl.OldImage}} {
+		if image.item == nil {
+			continue
+		}
+		data, err := dataset.MarshalItemJSON(image.item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode image: %w", err)
+		}
+		*image.dst = string(data)
+	}
+	return dl, nil
+}
+
+// store keeps the checkpoints and dead letters of one consumer
+type store interface {
+	checkpoints(ctx context.Context) (map[string]*checkpoint, error)
+	saveCheckpoint(ctx context.Context, cp *checkpoint) error
+	deadLetter(ctx context.Context, dl *DeadLetter) error
+}
+
+// dynamoStore keeps them in the table the consumer reads. Its own writes show up in the
+// stream as items of types no handler is registered for.
+type dynamoStore struct {
+	table    dynamo.Table
+	consumer string
+}
+
+func (s *dynamoStore) checkpoints(ctx context.Context) (map[string]*checkpoint, error) {
+	var list []*checkpoint
+	if err := s.table.Get("PK", fmt.Sprintf("STREAM#%s", s.consumer)).All(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to load checkpoints: %w", err)
+	}
+	checkpoints := make(map[string]*checkpoint, len(list))
+	for _, cp := range list {
+		checkpoints[cp.ShardID] = cp
+	}
+	return checkpoints, nil
+}
+
+func (s *dynamoStore) saveCheckpoint(ctx context.Context, cp *checkpoint) error {
+	cp.PK = fmt.Sprintf("STREAM#%s", s.consumer)
+	cp.SK = fmt.Sprintf("SHARD#%s", cp.ShardID)
+	cp.Type = "STREAM_CHECKPOINT"
+	cp.UpdatedAt = time.Now().UTC()
+	if err := s.table.Put(cp).Run(ctx); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (s *dynamoStore) deadLetter(ctx context.Context, dl *DeadLetter) error {
+	if err := s.table.Put(dl).Run(ctx); err != nil {
+		return fmt.Errorf("failed to save dead letter: %w", err)
+	}
+	return nil
+}
+
+// DeadLetters lists the dead letters of a consumer
+func DeadLetters(ctx context.Context, table dynamo.Table, consumer string) ([]DeadLetter, error) {
+	var list []DeadLetter
+	if err := table.Get("PK", fmt.Sprintf("STREAM_DLQ#%s", consumer)).All(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to load dead letters: %w", err)
+	}
+	return list, nil
+}
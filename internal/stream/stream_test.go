@@ -0,0 +1,377 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/guregu/dynamo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/infrastructure"
+)
+
+// fakeStreams serves shards of records from memory. Iterators are "{shard}:{position}".
+type fakeStreams struct {
+	shards []types.Shard
+	closed map[string]bool
+	data   map[string][]types.Record
+}
+
+func newFakeStreams() *fakeStreams {
+	return &fakeStreams{closed: make(map[string]bool), data: make(map[string][]types.Record)}
+}
+
+func (f *fakeStreams) addShard(id, parent string) {
+	shard := types.Shard{ShardId: aws.String(id)}
+	if parent != "" {
+		shard.ParentShardId = aws.String(parent)
+	}
+	f.shards = append(f.shards, shard)
+}
+
+func (f *fakeStreams) put(shardID, eventName, pk, itemType string, attrs map[string]types.AttributeValue) {
+	seq := fmt.Sprintf("%s-%03d", shardID, len(f.data[shardID])+1)
+	image := map[string]types.AttributeValue{
+		"PK":   &types.AttributeValueMemberS{Value: pk},
+		"SK":   &types.AttributeValueMemberS{Value: pk},
+		"Type": &types.AttributeValueMemberS{Value: itemType},
+	}
+	for name, v := range attrs {
+		image[name] = v
+	}
+	change := &types.StreamRecord{
+		SequenceNumber: aws.String(seq),
+		Keys:           map[string]types.AttributeValue{"PK": image["PK"], "SK": image["SK"]},
+	}
+	if eventName == EventRemove {
+		change.OldImage = image
+	} else {
+		change.NewImage = image
+	}
+	f.data[shardID] = append(f.data[shardID], types.Record{
+		EventID:   aws.String("event-" + seq),
+		EventName: types.OperationType(eventName),
+		Dynamodb:  change,
+	})
+}
+
+func (f *fakeStreams) DescribeStream(ctx context.Context, in *dynamodbstreams.DescribeStreamInput, opts ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error) {
+	return &dynamodbstreams.DescribeStreamOutput{StreamDescription: &types.StreamDescription{Shards: f.shards}}, nil
+}
+
+func (f *fakeStreams) GetShardIterator(ctx context.Context, in *dynamodbstreams.GetShardIteratorInput, opts ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error) {
+	id := *in.ShardId
+	pos := 0
+	switch in.ShardIteratorType {
+	case types.ShardIteratorTypeLatest:
+		pos = len(f.data[id])
+	case types.ShardIteratorTypeAfterSequenceNumber:
+		for i, rec := range f.data[id] {
+			if *rec.Dynamodb.SequenceNumber == *in.SequenceNumber {
+				pos = i + 1
+			}
+		}
+	}
+	return &dynamodbstreams.GetShardIteratorOutput{ShardIterator: aws.String(fmt.Sprintf("%s:%d", id, pos))}, nil
+}
+
+func (f *fakeStreams) GetRecords(ctx context.Context, in *dynamodbstreams.GetRecordsInput, opts ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error) {
+	id, p, _ := strings.Cut(*in.ShardIterator, ":")
+	pos, _ := strconv.Atoi(p)
+	end := min(pos+int(*in.Limit), len(f.data[id]))
+	out := &dynamodbstreams.GetRecordsOutput{Records: f.data[id][pos:end]}
+	if !f.closed[id] || end < len(f.data[id]) {
+		out.NextShardIterator = aws.String(fmt.Sprintf("%s:%d", id, end))
+	}
+	return out, nil
+}
+
+// memoryStore keeps checkpoints and dead letters in maps for tests without DynamoDB
+type memoryStore struct {
+	saved       map[string]checkpoint
+	saves       int
+	deadLetters []*DeadLetter
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{saved: make(map[string]checkpoint)}
+}
+
+func (s *memoryStore) checkpoints(ctx context.Context) (map[string]*checkpoint, error) {
+	checkpoints := make(map[string]*checkpoint, len(s.saved))
+	for id, cp := range s.saved {
+		cp := cp
+		checkpoints[id] = &cp
+	}
+	return checkpoints, nil
+}
+
+func (s *memoryStore) saveCheckpoint(ctx context.Context, cp *checkpoint) error {
+	s.saved[cp.ShardID] = *cp
+	s.saves++
+	return nil
+}
+
+func (s *memoryStore) deadLetter(ctx context.Context, dl *DeadLetter) error {
+	s.deadLetters = append(s.deadLetters, dl)
+	return nil
+}
+
+func testConsumer(streams *fakeStreams, store *memoryStore) *Consumer {
+	arn := func(ctx context.Context) (string, error) { return "arn:test", nil }
+	return newConsumer("test", streams, store, arn, Options{BatchSize: 2, Backoff: time.Millisecond, MaxAttempts: 3})
+}
+
+func TestNewRecord(t *testing.T) {
+	streams := newFakeStreams()
+	streams.put("shard-1", EventInsert, "ORDER#order-1", "ORDER", map[string]types.AttributeValue{
+		"ID":      &types.AttributeValueMemberS{Value: "order-1"},
+		"Status":  &types.AttributeValueMemberS{Value: "pending"},
+		"Total":   &types.AttributeValueMemberN{Value: "2598"},
+		"Version": &types.AttributeValueMemberN{Value: "1"},
+		"Tags": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"a": &types.AttributeValueMemberBOOL{Value: true}}},
+		}},
+	})
+
+	rec, err := newRecord("shard-1", streams.data["shard-1"][0])
+	require.NoError(t, err)
+	assert.Equal(t, EventInsert, rec.EventName)
+	assert.Equal(t, "ORDER", rec.ItemType)
+	assert.Equal(t, "shard-1-001", rec.SequenceNumber)
+	assert.Nil(t, rec.OldImage)
+	assert.Len(t, rec.Keys, 2)
+
+	var decoded Change[repository.OrderItem]
+	err = Typed(func(ctx context.Context, change Change[repository.OrderItem]) error {
+		decoded = change
+		return nil
+	})(context.Background(), rec)
+	require.NoError(t, err)
+	assert.Nil(t, decoded.Old)
+	require.NotNil(t, decoded.New)
+	assert.Equal(t, "order-1", decoded.New.ID)
+	assert.Equal(t, int64(2598), decoded.New.Total)
+	assert.Equal(t, 1, decoded.New.Version)
+
+	t.Run("images that do not decode fail permanently", func(t *testing.T) {
+		rec.NewImage["Total"] = rec.NewImage["Status"]
+		err := Typed(func(ctx context.Context, change Change[repository.OrderItem]) error {
+			return nil
+		})(context.Background(), rec)
+		assert.True(t, isPermanent(err))
+		assert.ErrorContains(t, err, "failed to decode new image")
+	})
+}
+
+func TestRegister(t *testing.T) {
+	consumer := testConsumer(newFakeStreams(), newMemoryStore())
+	noop := func(ctx context.Context, rec Record) error { return nil }
+
+	require.NoError(t, consumer.Register("ORDER", "audit", noop))
+	require.NoError(t, consumer.Register("PRODUCT", "audit", noop))
+	assert.ErrorContains(t, consumer.Register("ORDER", "audit", noop), "duplicate handler audit for ORDER")
+	assert.ErrorContains(t, consumer.Register("", "audit", noop), "are required")
+}
+
+func TestConsumerPoll(t *testing.T) {
+	ctx := context.Background()
+	streams := newFakeStreams()
+	store := newMemoryStore()
+	consumer := testConsumer(streams, store)
+
+	// The child shard is listed first but must be read after its closed parent
+	streams.addShard("child", "parent")
+	streams.addShard("parent", "")
+	streams.closed["parent"] = true
+	streams.put("parent", EventInsert, "ORDER#1", "ORDER", nil)
+	streams.put("parent", EventModify, "ORDER#1", "ORDER", nil)
+	streams.put("parent", EventInsert, "CUSTOMER#1", "CUSTOMER", nil)
+	streams.put("child", EventRemove, "ORDER#1", "ORDER", nil)
+
+	var seen []string
+	require.NoError(t, consumer.Register("ORDER", "log", func(ctx context.Context, rec Record) error {
+		seen = append(seen, rec.EventName+" "+rec.SequenceNumber)
+		return nil
+	}))
+
+	n, err := consumer.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []string{"INSERT parent-001", "MODIFY parent-002", "REMOVE child-001"}, seen)
+	assert.True(t, store.saved["parent"].Done)
+	assert.Equal(t, "child-001", store.saved["child"].SequenceNumber)
+
+	t.Run("a caught up stream reads nothing", func(t *testing.T) {
+		n, err := consumer.Poll(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+
+	t.Run("a new consumer resumes after the checkpoints", func(t *testing.T) {
+		streams.put("child", EventInsert, "ORDER#2", "ORDER", nil)
+		seen = nil
+		restarted := testConsumer(streams, store)
+		require.NoError(t, restarted.Register("ORDER", "log", func(ctx context.Context, rec Record) error {
+			seen = append(seen, rec.EventName+" "+rec.SequenceNumber)
+			return nil
+		}))
+
+		n, err := restarted.Poll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, []string{"INSERT child-002"}, seen)
+	})
+
+	t.Run("batches no handler wanted are not saved", func(t *testing.T) {
+		// Saving a checkpoint writes to the table, so saving after records of checkpoints
+		// would keep the consumer reading its own writes
+		saves := store.saves
+		streams.put("child", EventModify, "STREAM#test", "STREAM_CHECKPOINT", nil)
+		n, err := consumer.Poll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n) // ORDER#2 was only read by the restarted consumer so far
+		assert.Equal(t, saves+1, store.saves)
+
+		n, err = consumer.Poll(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		assert.Equal(t, saves+1, store.saves)
+	})
+}
+
+func TestConsumerRetries(t *testing.T) {
+	ctx := context.Background()
+	streams := newFakeStreams()
+	streams.addShard("shard-1", "")
+	streams.put("shard-1", EventInsert, "PRODUCT#1", "PRODUCT", nil)
+	store := newMemoryStore()
+	consumer := testConsumer(streams, store)
+
+	attempts := map[string]int{}
+	register := func(name string, fn HandlerFunc) {
+		require.NoError(t, consumer.Register("PRODUCT", name, func(ctx context.Context, rec Record) error {
+			attempts[name]++
+			return fn(ctx, rec)
+		}))
+	}
+	register("flaky", func(ctx context.Context, rec Record) error {
+		if attempts["flaky"] < 3 {
+			return errors.New("temporarily unavailable")
+		}
+		return nil
+	})
+	register("broken", func(ctx context.Context, rec Record) error {
+		return errors.New("always fails")
+	})
+	register("poison", func(ctx context.Context, rec Record) error {
+		return Permanent(errors.New("cannot handle"))
+	})
+	register("healthy", func(ctx context.Context, rec Record) error {
+		return nil
+	})
+
+	n, err := consumer.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, map[string]int{"flaky": 3, "broken": 3, "poison": 1, "healthy": 1}, attempts)
+
+	require.Len(t, store.deadLetters, 2)
+	broken := store.deadLetters[0]
+	assert.Equal(t, "STREAM_DLQ#test", broken.PK)
+	assert.Equal(t, "RECORD#shard-1-001#broken", broken.SK)
+	assert.Equal(t, "STREAM_DEAD_LETTER", broken.Type)
+	assert.Equal(t, "PRODUCT", broken.ItemType)
+	assert.Equal(t, 3, broken.Attempts)
+	assert.Equal(t, "always fails", broken.Error)
+	assert.Equal(t, map[string]string{"PK": "PRODUCT#1", "SK": "PRODUCT#1"}, broken.Keys)
+	assert.Contains(t, broken.NewImage, `"Type":{"S":"PRODUCT"}`)
+	assert.Empty(t, broken.OldImage)
+	assert.Equal(t, "poison", store.deadLetters[1].Handler)
+	assert.Equal(t, 1, store.deadLetters[1].Attempts)
+
+	// Dead-lettered records are behind the checkpoint
+	assert.Equal(t, "shard-1-001", store.saved["shard-1"].SequenceNumber)
+}
+
+func TestDynamoStream(t *testing.T) {
+	// Skip if not running integration tests
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+
+	// Read the stream of a throwaway table so the shared one is left alone
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	dynamoCfg := cfg.DynamoDB.Infrastructure()
+	dynamoCfg.TableName = fmt.Sprintf("StreamTest-%d", time.Now().UnixNano())
+	client, err := infrastructure.NewDynamoDBClient(ctx, dynamoCfg)
+	require.NoError(t, err)
+	require.NoError(t, client.DB.CreateTable(dynamoCfg.TableName, struct {
+		PK string `dynamo:"PK,hash"`
+		SK string `dynamo:"SK,range"`
+	}{}).OnDemand(true).Stream(dynamo.NewAndOldImagesView).Wait(ctx))
+	t.Cleanup(func() {
+		_ = client.GetTable().DeleteTable().Run(context.Background())
+	})
+	streams, err := infrastructure.NewDynamoDBStreamsClient(ctx, dynamoCfg)
+	require.NoError(t, err)
+
+	consumer, err := NewConsumer(client, streams, "test", Options{Backoff: time.Millisecond, MaxAttempts: 2})
+	require.NoError(t, err)
+	var changes []Change[repository.ProductItem]
+	require.NoError(t, consumer.Register("PRODUCT", "collect", Typed(func(ctx context.Context, change Change[repository.ProductItem]) error {
+		changes = append(changes, change)
+		return nil
+	})))
+	require.NoError(t, consumer.Register("PRODUCT", "broken", func(ctx context.Context, rec Record) error {
+		return errors.New("always fails")
+	}))
+
+	// Create and update a product through the repository
+	products := repository.NewDynamoProductRepository(client)
+	price, err := value.NewMoney(1299)
+	require.NoError(t, err)
+	product, err := entity.NewProduct(value.GenerateProductID(), "Stream Widget", "", price, 0)
+	require.NoError(t, err)
+	require.NoError(t, products.Save(ctx, product))
+	product.UpdatePrice(price.Add(price))
+	require.NoError(t, products.Save(ctx, product))
+
+	n, err := consumer.Poll(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, n, 2)
+	require.Len(t, changes, 2)
+	assert.Equal(t, EventInsert, changes[0].EventName)
+	assert.Equal(t, "Stream Widget", changes[0].New.Name)
+	assert.Equal(t, EventModify, changes[1].EventName)
+	assert.Equal(t, 1299, changes[1].Old.Price)
+	assert.Equal(t, 2598, changes[1].New.Price)
+
+	deadLetters, err := DeadLetters(ctx, client.GetTable(), "test")
+	require.NoError(t, err)
+	assert.Len(t, deadLetters, 2)
+
+	// The consumer's own checkpoint and dead-letter writes are not read as changes
+	_, err = consumer.Poll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, changes, 2)
+	n, err = consumer.Poll(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
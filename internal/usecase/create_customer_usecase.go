@@ -12,6 +12,7 @@ import (
 // CreateCustomerUseCase handles customer creation business logic
 type CreateCustomerUseCase struct {
 	customerRepo repository.CustomerRepository
+	events       EventPublisher
 }
 
 // CreateCustomerCommand represents the input for creating a customer
@@ -20,10 +21,11 @@ type CreateCustomerCommand struct {
 	Email string
 }
 
-// NewCreateCustomerUseCase creates a new create customer use case
-func NewCreateCustomerUseCase(customerRepo repository.CustomerRepository) *CreateCustomerUseCase {
+// NewCreateCustomerUseCase creates a new create customer use case. events may be nil.
+func NewCreateCustomerUseCase(customerRepo repository.CustomerRepository, events EventPublisher) *CreateCustomerUseCase {
 	return &CreateCustomerUseCase{
 		customerRepo: customerRepo,
+		events:       events,
 	}
 }
 
@@ -41,17 +43,14 @@ func (uc *CreateCustomerUseCase) Execute(ctx context.Context, cmd CreateCustomer
 	// 3. エンティティ作成
 	customer := entity.NewCustomer(customerID, email, cmd.Name)
 
-	// 4. ビジネスルール: 同じメールアドレスの顧客が存在しないかチェック
-	existingCustomer, err := uc.customerRepo.FindByEmail(ctx, email)
-	if err == nil && existingCustomer != nil {
-		return nil, domain.CustomerAlreadyExistsError(cmd.Email)
-	}
-
-	// 5. リポジトリに保存
+	// 4. リポジトリに保存（メールアドレスの重複はリポジトリが CustomerAlreadyExistsError で返す）
 	err = uc.customerRepo.Save(ctx, customer)
 	if err != nil {
-		return nil, domain.RepositoryError("failed to save customer", err)
+		return nil, repositoryError("failed to save customer", err)
 	}
 
+	// 5. 保存に成功したらドメインイベントを発行
+	publish(ctx, uc.events, customer.PullEvents()...)
+
 	return customer, nil
 }
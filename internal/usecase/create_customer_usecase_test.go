@@ -24,6 +24,9 @@ func NewMockCustomerRepository() *MockCustomerRepository {
 }
 
 func (m *MockCustomerRepository) Save(ctx context.Context, customer *entity.Customer) error {
+	if owner, exists := m.emailIndex[customer.Email().String()]; exists && owner.ID() != customer.ID() {
+		return domain.CustomerAlreadyExistsError(customer.Email().String())
+	}
 	m.customers[customer.ID().String()] = customer
 	m.emailIndex[customer.Email().String()] = customer
 	return nil
@@ -40,7 +43,7 @@ func (m *MockCustomerRepository) FindByID(ctx context.Context, id value.Customer
 func (m *MockCustomerRepository) FindByEmail(ctx context.Context, email value.Email) (*entity.Customer, error) {
 	customer, exists := m.emailIndex[email.String()]
 	if !exists {
-		return nil, domain.CustomerNotFoundError(email.String())
+		return nil, domain.CustomerNotFoundByEmailError(email.String())
 	}
 	return customer, nil
 }
@@ -60,23 +63,23 @@ func (m *MockCustomerRepository) Exists(ctx context.Context, id value.CustomerID
 	return exists, nil
 }
 
-func (m *MockCustomerRepository) ListWithLimit(ctx context.Context, limit *int) ([]*entity.Customer, error) {
+func (m *MockCustomerRepository) FindAll(ctx context.Context, limit int, lastKey *string) ([]*entity.Customer, *string, error) {
 	customers := make([]*entity.Customer, 0, len(m.customers))
 	count := 0
 	for _, customer := range m.customers {
-		if limit != nil && count >= *limit {
+		if limit > 0 && count >= limit {
 			break
 		}
 		customers = append(customers, customer)
 		count++
 	}
-	return customers, nil
+	return customers, nil, nil
 }
 
 func TestCreateCustomerUseCase_Success(t *testing.T) {
 	// Arrange
 	repo := NewMockCustomerRepository()
-	uc := usecase.NewCreateCustomerUseCase(repo)
+	uc := usecase.NewCreateCustomerUseCase(repo, nil)
 	ctx := context.Background()
 
 	cmd := usecase.CreateCustomerCommand{
@@ -108,7 +111,7 @@ func TestCreateCustomerUseCase_Success(t *testing.T) {
 func TestCreateCustomerUseCase_DuplicateEmail(t *testing.T) {
 	// Arrange
 	repo := NewMockCustomerRepository()
-	uc := usecase.NewCreateCustomerUseCase(repo)
+	uc := usecase.NewCreateCustomerUseCase(repo, nil)
 	ctx := context.Background()
 
 	// Create first customer
@@ -146,7 +149,7 @@ func TestCreateCustomerUseCase_DuplicateEmail(t *testing.T) {
 func TestCreateCustomerUseCase_InvalidEmail(t *testing.T) {
 	// Arrange
 	repo := NewMockCustomerRepository()
-	uc := usecase.NewCreateCustomerUseCase(repo)
+	uc := usecase.NewCreateCustomerUseCase(repo, nil)
 	ctx := context.Background()
 
 	cmd := usecase.CreateCustomerCommand{
@@ -166,3 +169,42 @@ func TestCreateCustomerUseCase_InvalidEmail(t *testing.T) {
 		t.Fatal("Expected no customer to be created")
 	}
 }
+
+// racingCustomerRepository simulates a concurrent request that claimed the email
+// just before the use case's Save
+type racingCustomerRepository struct {
+	*MockCustomerRepository
+}
+
+func (r *racingCustomerRepository) Save(ctx context.Context, customer *entity.Customer) error {
+	return domain.CustomerAlreadyExistsError(customer.Email().String())
+}
+
+func TestCreateCustomerUseCase_DuplicateEmailRace(t *testing.T) {
+	// Arrange
+	repo := &racingCustomerRepository{NewMockCustomerRepository()}
+	uc := usecase.NewCreateCustomerUseCase(repo, nil)
+	ctx := context.Background()
+
+	cmd := usecase.CreateCustomerCommand{
+		Name:  "Test Customer",
+		Email: "test@example.com",
+	}
+
+	// Act
+	customer, err := uc.Execute(ctx, cmd)
+
+	// Assert
+	if customer != nil {
+		t.Fatal("Expected no customer to be created")
+	}
+
+	domainErr, ok := err.(*domain.DomainError)
+	if !ok {
+		t.Fatalf("Expected DomainError, got %T", err)
+	}
+
+	if domainErr.Code != domain.ErrCodeCustomerAlreadyExists {
+		t.Errorf("Expected error code %s, got %s", domain.ErrCodeCustomerAlreadyExists, domainErr.Code)
+	}
+}
@@ -34,11 +34,7 @@ func (uc *GetCustomerUseCase) Execute(ctx context.Context, cmd GetCustomerComman
 	// 2. リポジトリから取得
 	customer, err := uc.customerRepo.FindByID(ctx, customerID)
 	if err != nil {
-		return nil, err
-	}
-
-	if customer == nil {
-		return nil, domain.CustomerNotFoundError(cmd.CustomerID)
+		return nil, repositoryError("failed to get customer", err)
 	}
 
 	return customer, nil
@@ -51,7 +47,8 @@ type ListCustomersUseCase struct {
 
 // ListCustomersCommand represents the input for listing customers
 type ListCustomersCommand struct {
-	Limit int
+	Limit  int
+	Cursor *string
 }
 
 // NewListCustomersUseCase creates a new list customers use case
@@ -62,19 +59,19 @@ func NewListCustomersUseCase(customerRepo repository.CustomerRepository) *ListCu
 }
 
 // Execute executes the list customers use case
-func (uc *ListCustomersUseCase) Execute(ctx context.Context, cmd ListCustomersCommand) ([]*entity.Customer, error) {
+func (uc *ListCustomersUseCase) Execute(ctx context.Context, cmd ListCustomersCommand) ([]*entity.Customer, *string, error) {
 	// ビジネスロジック: デフォルトの制限値設定
 	if cmd.Limit <= 0 || cmd.Limit > 1000 {
 		cmd.Limit = 100 // デフォルト制限
 	}
 
 	// リポジトリから取得
-	customers, err := uc.customerRepo.ListWithLimit(ctx, &cmd.Limit)
+	customers, nextCursor, err := uc.customerRepo.FindAll(ctx, cmd.Limit, cmd.Cursor)
 	if err != nil {
-		return nil, domain.RepositoryError("failed to list customers", err)
+		return nil, nil, repositoryError("failed to list customers", err)
 	}
 
-	return customers, nil
+	return customers, nextCursor, nil
 }
 
 // UpdateCustomerUseCase handles customer update
@@ -102,16 +99,13 @@ func (uc *UpdateCustomerUseCase) Execute(ctx context.Context, cmd UpdateCustomer
 	customerID := value.CustomerID(cmd.CustomerID)
 	email, err := value.NewEmail(cmd.Email)
 	if err != nil {
-		return nil, err
+		return nil, domain.InvalidInputError("invalid email format")
 	}
 
 	// 2. 既存の顧客を取得
 	customer, err := uc.customerRepo.FindByID(ctx, customerID)
 	if err != nil {
-		return nil, err
-	}
-	if customer == nil {
-		return nil, domain.CustomerNotFoundError(cmd.CustomerID)
+		return nil, repositoryError("failed to get customer", err)
 	}
 
 	// 3. エンティティの更新
@@ -121,7 +115,7 @@ func (uc *UpdateCustomerUseCase) Execute(ctx context.Context, cmd UpdateCustomer
 	// 4. リポジトリに保存
 	err = uc.customerRepo.Save(ctx, customer)
 	if err != nil {
-		return nil, err
+		return nil, repositoryError("failed to update customer", err)
 	}
 
 	return customer, nil
@@ -150,12 +144,9 @@ func (uc *DeleteCustomerUseCase) Execute(ctx context.Context, cmd DeleteCustomer
 	customerID := value.CustomerID(cmd.CustomerID)
 
 	// 2. 既存の顧客を確認
-	customer, err := uc.customerRepo.FindByID(ctx, customerID)
+	_, err := uc.customerRepo.FindByID(ctx, customerID)
 	if err != nil {
-		return err
-	}
-	if customer == nil {
-		return domain.CustomerNotFoundError(cmd.CustomerID)
+		return repositoryError("failed to get customer", err)
 	}
 
 	// 3. ビジネスルール: 注文がある顧客は削除できない（実装予定）
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"errors"
+
+	"dynamo-modeling/internal/domain"
+)
+
+// repositoryError passes domain errors returned by a repository through unchanged, so that their
+// kind (not found, conflict, ...) reaches the caller, and wraps any other error as a repository error
+func repositoryError(message string, err error) error {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+	return domain.RepositoryError(message, err)
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"dynamo-modeling/internal/domain/entity"
+)
+
+// EventHandler reacts to a published domain event
+type EventHandler func(ctx context.Context, event entity.DomainEvent) error
+
+// EventPublisher publishes the domain events of saved aggregates
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...entity.DomainEvent)
+}
+
+// EventDispatcher is an in-process EventPublisher that hands events to the handlers subscribed
+// to them. Notifications, analytics and audit subscribe here instead of being called from each
+// use case.
+type EventDispatcher struct {
+	mu       sync.RWMutex
+	handlers map[string][]EventHandler // by event name, "" for handlers of every event
+}
+
+// NewEventDispatcher creates a dispatcher without subscribers
+func NewEventDispatcher() *EventDispatcher {
+	return &EventDispatcher{
+		handlers: make(map[string][]EventHandler),
+	}
+}
+
+// Subscribe registers a handler for the events with the given names, e.g. entity.EventOrderPlaced,
+// or for every event when no name is given
+func (d *EventDispatcher) Subscribe(handler EventHandler, eventNames ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(eventNames) == 0 {
+		eventNames = []string{""}
+	}
+	for _, name := range eventNames {
+		d.handlers[name] = append(d.handlers[name], handler)
+	}
+}
+
+// Publish hands each event to its subscribers in the order they subscribed. The change behind
+// the events has already been saved and cannot be undone, so a failing subscriber is logged and
+// does not stop the others.
+func (d *EventDispatcher) Publish(ctx context.Context, events ...entity.DomainEvent) {
+	for _, event := range events {
+		d.mu.RLock()
+		handlers := append(append([]EventHandler(nil), d.handlers[event.EventName()]...), d.handlers[""]...)
+		d.mu.RUnlock()
+
+		for _, handler := range handlers {
+			if err := handle(ctx, handler, event); err != nil {
+				slog.ErrorContext(ctx, "Domain event subscriber failed", "event", event.EventName(), "error", err)
+			}
+		}
+	}
+}
+
+func handle(ctx context.Context, handler EventHandler, event entity.DomainEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, event)
+}
+
+// publish publishes events through an optional publisher; use cases built without one drop them
+func publish(ctx context.Context, publisher EventPublisher, events ...entity.DomainEvent) {
+	if publisher == nil || len(events) == 0 {
+		return
+	}
+	publisher.Publish(ctx, events...)
+}
@@ -0,0 +1,127 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/value"
+	"dynamo-modeling/internal/usecase"
+)
+
+// recordEvents subscribes to every event and returns the names published so far
+func recordEvents(dispatcher *usecase.EventDispatcher) func() []string {
+	var names []string
+	dispatcher.Subscribe(func(ctx context.Context, event entity.DomainEvent) error {
+		names = append(names, event.EventName())
+		return nil
+	})
+	return func() []string { return names }
+}
+
+func TestEventDispatcher(t *testing.T) {
+	ctx := context.Background()
+	customerID, _ := value.NewCustomerID("customer-123")
+	email, _ := value.NewEmail("test@example.com")
+	registered := entity.NewCustomer(customerID, email, "Test Customer").PullEvents()[0]
+	changed := entity.OrderStatusChanged{From: entity.OrderStatusPending, To: entity.OrderStatusConfirmed}
+
+	t.Run("routes events to their subscribers", func(t *testing.T) {
+		dispatcher := usecase.NewEventDispatcher()
+		all := recordEvents(dispatcher)
+		var statusChanges []entity.DomainEvent
+		dispatcher.Subscribe(func(ctx context.Context, event entity.DomainEvent) error {
+			statusChanges = append(statusChanges, event)
+			return nil
+		}, entity.EventOrderStatusChanged)
+
+		dispatcher.Publish(ctx, registered, changed)
+
+		assert.Equal(t, []string{entity.EventCustomerRegistered, entity.EventOrderStatusChanged}, all())
+		assert.Equal(t, []entity.DomainEvent{changed}, statusChanges)
+	})
+
+	t.Run("failing subscribers do not stop the others", func(t *testing.T) {
+		dispatcher := usecase.NewEventDispatcher()
+		dispatcher.Subscribe(func(ctx context.Context, event entity.DomainEvent) error {
+			return errors.New("notification service unavailable")
+		})
+		dispatcher.Subscribe(func(ctx context.Context, event entity.DomainEvent) error {
+			panic("analytics bug")
+		})
+		all := recordEvents(dispatcher)
+
+		dispatcher.Publish(ctx, registered, changed)
+
+		assert.Equal(t, []string{entity.EventCustomerRegistered, entity.EventOrderStatusChanged}, all())
+	})
+}
+
+func TestUseCasesPublishEvents(t *testing.T) {
+	ctx := context.Background()
+	store, err := repository.NewMemoryStore()
+	require.NoError(t, err)
+	customerRepo := repository.NewMemoryCustomerRepository(store)
+	productRepo := repository.NewMemoryProductRepository(store)
+	orderRepo := repository.NewMemoryOrderRepository(store)
+	warehouseRepo := repository.NewMemoryWarehouseRepository(store)
+
+	dispatcher := usecase.NewEventDispatcher()
+	published := recordEvents(dispatcher)
+
+	// Setup test data
+	customer, err := usecase.NewCreateCustomerUseCase(customerRepo, dispatcher).Execute(ctx, usecase.CreateCustomerCommand{
+		Name:  "Test Customer",
+		Email: "test@example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{entity.EventCustomerRegistered}, published())
+
+	product, err := usecase.NewCreateProductUseCase(productRepo).Execute(ctx, usecase.CreateProductCommand{Name: "Widget", Price: 1000})
+	require.NoError(t, err)
+	warehouse, err := usecase.NewCreateWarehouseUseCase(warehouseRepo).Execute(ctx, usecase.CreateWarehouseCommand{Name: "Tokyo", Location: "Tokyo"})
+	require.NoError(t, err)
+	_, err = usecase.NewSetInventoryUseCase(warehouseRepo, productRepo).Execute(ctx, usecase.SetInventoryCommand{
+		WarehouseID: warehouse.ID().String(),
+		ProductID:   product.ID().String(),
+		Quantity:    5,
+	})
+	require.NoError(t, err)
+
+	t.Run("create order publishes OrderPlaced and StockReserved", func(t *testing.T) {
+		before := len(published())
+		uc := usecase.NewCreateOrderUseCase(orderRepo, customerRepo, productRepo, dispatcher)
+
+		order, err := uc.Execute(ctx, usecase.CreateOrderCommand{
+			CustomerID: customer.ID().String(),
+			Items:      []usecase.CreateOrderItemCommand{{ProductID: product.ID().String(), Quantity: 2}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{entity.EventOrderPlaced, entity.EventStockReserved}, published()[before:])
+
+		// Nothing is published when the order is not placed
+		before = len(published())
+		_, err = uc.Execute(ctx, usecase.CreateOrderCommand{
+			CustomerID: customer.ID().String(),
+			Items:      []usecase.CreateOrderItemCommand{{ProductID: product.ID().String(), Quantity: 100}},
+		})
+		require.Error(t, err)
+		assert.Len(t, published(), before)
+
+		t.Run("status update publishes OrderStatusChanged", func(t *testing.T) {
+			before := len(published())
+
+			_, err := usecase.NewUpdateOrderStatusUseCase(orderRepo, nil, dispatcher).Execute(ctx, usecase.UpdateOrderStatusCommand{
+				OrderID: order.ID().String(),
+				Status:  string(entity.OrderStatusConfirmed),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{entity.EventOrderStatusChanged}, published()[before:])
+		})
+	})
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/repository"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// IssueInvoiceUseCase handles issuing the invoice for an order
+type IssueInvoiceUseCase struct {
+	invoiceRepo repository.InvoiceRepository
+	orderRepo   repository.OrderRepository
+}
+
+// IssueInvoiceCommand represents the input for issuing an invoice
+type IssueInvoiceCommand struct {
+	OrderID string
+}
+
+// NewIssueInvoiceUseCase creates a new issue invoice use case
+func NewIssueInvoiceUseCase(invoiceRepo repository.InvoiceRepository, orderRepo repository.OrderRepository) *IssueInvoiceUseCase {
+	return &IssueInvoiceUseCase{
+		invoiceRepo: invoiceRepo,
+		orderRepo:   orderRepo,
+	}
+}
+
+// Execute executes the issue invoice use case. An order is invoiced at most once.
+func (uc *IssueInvoiceUseCase) Execute(ctx context.Context, cmd IssueInvoiceCommand) (*entity.Invoice, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	orderID, err := value.NewOrderID(cmd.OrderID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid order ID")
+	}
+
+	// 2. 注文の存在確認
+	order, err := uc.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, repositoryError("failed to find order", err)
+	}
+
+	// 3. エンティティ作成（キャンセルされた注文には請求できない）
+	invoice, err := entity.NewInvoice(value.GenerateInvoiceID(), order, entity.DefaultTaxRate)
+	if err != nil {
+		return nil, domain.RuleViolationError("cannot invoice order: " + err.Error())
+	}
+
+	// 4. リポジトリに保存（1注文につき請求書は1枚。2枚目は保存時に InvoiceAlreadyExists になる）
+	err = uc.invoiceRepo.Save(ctx, invoice)
+	if err != nil {
+		return nil, repositoryError("failed to save invoice", err)
+	}
+
+	return invoice, nil
+}
+
+// GetInvoiceUseCase handles getting an invoice by ID
+type GetInvoiceUseCase struct {
+	invoiceRepo repository.InvoiceRepository
+}
+
+// GetInvoiceCommand represents the input for getting an invoice
+type GetInvoiceCommand struct {
+	InvoiceID string
+}
+
+// NewGetInvoiceUseCase creates a new get invoice use case
+func NewGetInvoiceUseCase(invoiceRepo repository.InvoiceRepository) *GetInvoiceUseCase {
+	return &GetInvoiceUseCase{
+		invoiceRepo: invoiceRepo,
+	}
+}
+
+// Execute executes the get invoice use case
+func (uc *GetInvoiceUseCase) Execute(ctx context.Context, cmd GetInvoiceCommand) (*entity.Invoice, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	invoiceID, err := value.NewInvoiceID(cmd.InvoiceID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid invoice ID")
+	}
+
+	// 2. リポジトリから取得
+	invoice, err := uc.invoiceRepo.FindByID(ctx, invoiceID)
+	if err != nil {
+		return nil, repositoryError("failed to get invoice", err)
+	}
+
+	return invoice, nil
+}
+
+// GetOrderInvoiceUseCase handles getting the invoice for an order
+type GetOrderInvoiceUseCase struct {
+	invoiceRepo repository.InvoiceRepository
+}
+
+// GetOrderInvoiceCommand represents the input for getting an order's invoice
+type GetOrderInvoiceCommand struct {
+	OrderID string
+}
+
+// NewGetOrderInvoiceUseCase creates a new get order invoice use case
+func NewGetOrderInvoiceUseCase(invoiceRepo repository.InvoiceRepository) *GetOrderInvoiceUseCase {
+	return &GetOrderInvoiceUseCase{
+		invoiceRepo: invoiceRepo,
+	}
+}
+
+// Execute executes the get order invoice use case
+func (uc *GetOrderInvoiceUseCase) Execute(ctx context.Context, cmd GetOrderInvoiceCommand) (*entity.Invoice, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	orderID, err := value.NewOrderID(cmd.OrderID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid order ID")
+	}
+
+	// 2. リポジトリから取得
+	invoice, err := uc.invoiceRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, repositoryError("failed to get order invoice", err)
+	}
+
+	if invoice == nil {
+		return nil, domain.NewDomainError(
+			domain.ErrCodeInvoiceNotFound,
+			"Order "+cmd.OrderID+" has not been invoiced",
+			domain.ErrNotFound,
+		)
+	}
+
+	return invoice, nil
+}
+
+// ListCustomerInvoicesUseCase handles listing a customer's invoices in a date range
+type ListCustomerInvoicesUseCase struct {
+	invoiceRepo repository.InvoiceRepository
+}
+
+// ListCustomerInvoicesCommand represents the input for listing a customer's invoices.
+// From and To bound the issue date (inclusive); nil leaves that end open.
+type ListCustomerInvoicesCommand struct {
+	CustomerID string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Cursor     *string
+}
+
+// NewListCustomerInvoicesUseCase creates a new list customer invoices use case
+func NewListCustomerInvoicesUseCase(invoiceRepo repository.InvoiceRepository) *ListCustomerInvoicesUseCase {
+	return &ListCustomerInvoicesUseCase{
+		invoiceRepo: invoiceRepo,
+	}
+}
+
+// Execute executes the list customer invoices use case
+func (uc *ListCustomerInvoicesUseCase) Execute(ctx context.Context, cmd ListCustomerInvoicesCommand) ([]*entity.Invoice, *string, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	customerID, err := value.NewCustomerID(cmd.CustomerID)
+	if err != nil {
+		return nil, nil, domain.InvalidInputError("invalid customer ID")
+	}
+
+	var from, to time.Time
+	if cmd.From != nil {
+		from = *cmd.From
+	}
+	if cmd.To != nil {
+		to = *cmd.To
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return nil, nil, domain.InvalidInputError("from must not be after to")
+	}
+
+	// ビジネスロジック: デフォルトの制限値設定
+	if cmd.Limit <= 0 || cmd.Limit > 1000 {
+		cmd.Limit = 100 // デフォルト制限
+	}
+
+	// 2. リポジトリから取得
+	invoices, nextCursor, err := uc.invoiceRepo.FindByCustomerID(ctx, customerID, from, to, cmd.Limit, cmd.Cursor)
+	if err != nil {
+		return nil, nil, repositoryError("failed to list customer invoices", err)
+	}
+
+	return invoices, nextCursor, nil
+}
+
+// RecordPaymentUseCase handles recording a payment against an invoice
+type RecordPaymentUseCase struct {
+	invoiceRepo repository.InvoiceRepository
+}
+
+// RecordPaymentCommand represents the input for recording a payment
+type RecordPaymentCommand struct {
+	InvoiceID string
+	Method    string
+	Amount    int64
+}
+
+// NewRecordPaymentUseCase creates a new record payment use case
+func NewRecordPaymentUseCase(invoiceRepo repository.InvoiceRepository) *RecordPaymentUseCase {
+	return &RecordPaymentUseCase{
+		invoiceRepo: invoiceRepo,
+	}
+}
+
+// Execute executes the record payment use case
+func (uc *RecordPaymentUseCase) Execute(ctx context.Context, cmd RecordPaymentCommand) (*entity.Invoice, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	invoiceID, err := value.NewInvoiceID(cmd.InvoiceID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid invoice ID")
+	}
+	amount, err := value.NewMoney(cmd.Amount)
+	if err != nil || !amount.IsPositive() {
+		return nil, domain.InvalidInputError("invalid payment amount")
+	}
+	method := entity.PaymentMethod(cmd.Method)
+	if !method.IsValid() {
+		return nil, domain.InvalidInputError("invalid payment method: " + cmd.Method)
+	}
+
+	// 2. 既存の請求書を取得
+	invoice, err := uc.invoiceRepo.FindByID(ctx, invoiceID)
+	if err != nil {
+		return nil, repositoryError("failed to get invoice", err)
+	}
+
+	// 3. 支払いの記録（支払い済みの請求書や残高を超える支払いは拒否）
+	err = invoice.AddPayment(value.GeneratePaymentID(), method, amount)
+	if err != nil {
+		return nil, domain.RuleViolationError("invalid payment: " + err.Error())
+	}
+
+	// 4. リポジトリに保存
+	err = uc.invoiceRepo.Save(ctx, invoice)
+	if err != nil {
+		return nil, repositoryError("failed to save invoice", err)
+	}
+
+	return invoice, nil
+}
@@ -2,6 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"time"
 
 	"dynamo-modeling/internal/domain"
 	"dynamo-modeling/internal/domain/entity"
@@ -14,12 +17,14 @@ type CreateOrderUseCase struct {
 	orderRepo    repository.OrderRepository
 	customerRepo repository.CustomerRepository
 	productRepo  repository.ProductRepository
+	events       EventPublisher
 }
 
 // CreateOrderCommand represents the input for creating an order
 type CreateOrderCommand struct {
 	CustomerID string
 	Items      []CreateOrderItemCommand
+	Actor      string // Who places the order, recorded in the status history
 }
 
 // CreateOrderItemCommand represents an order item
@@ -28,16 +33,18 @@ type CreateOrderItemCommand struct {
 	Quantity  int
 }
 
-// NewCreateOrderUseCase creates a new create order use case
+// NewCreateOrderUseCase creates a new create order use case. events may be nil.
 func NewCreateOrderUseCase(
 	orderRepo repository.OrderRepository,
 	customerRepo repository.CustomerRepository,
 	productRepo repository.ProductRepository,
+	events EventPublisher,
 ) *CreateOrderUseCase {
 	return &CreateOrderUseCase{
 		orderRepo:    orderRepo,
 		customerRepo: customerRepo,
 		productRepo:  productRepo,
+		events:       events,
 	}
 }
 
@@ -47,30 +54,22 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, cmd CreateOrderComman
 	customerID := value.CustomerID(cmd.CustomerID)
 	customerExists, err := uc.customerRepo.Exists(ctx, customerID)
 	if err != nil {
-		return nil, domain.RepositoryError("failed to check customer existence", err)
+		return nil, repositoryError("failed to check customer existence", err)
 	}
 	if !customerExists {
-		return nil, domain.NewDomainError("CUSTOMER_NOT_FOUND", "Customer not found", nil)
+		return nil, domain.CustomerNotFoundError(cmd.CustomerID)
 	}
 
 	// 2. 注文商品の検証と在庫確認
 	var orderItems []entity.OrderItem
+	var products []*entity.Product
 	for _, itemCmd := range cmd.Items {
 		productID := value.ProductID(itemCmd.ProductID)
 
 		// 商品の存在確認
 		product, err := uc.productRepo.FindByID(ctx, productID)
 		if err != nil {
-			return nil, domain.RepositoryError("failed to find product", err)
-		}
-		if product == nil {
-			return nil, domain.NewDomainError("PRODUCT_NOT_FOUND", "Product not found: "+itemCmd.ProductID, nil)
-		}
-
-		// 在庫確認
-		if !product.IsInStock(itemCmd.Quantity) {
-			return nil, domain.NewDomainError("INSUFFICIENT_STOCK",
-				"Insufficient stock for product: "+itemCmd.ProductID, nil)
+			return nil, repositoryError("failed to find product", err)
 		}
 
 		// 注文アイテム作成
@@ -79,6 +78,12 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, cmd CreateOrderComman
 			return nil, domain.InvalidInputError("invalid order item: " + err.Error())
 		}
 
+		// 在庫の引き当て（最終的な判定と在庫の更新は PlaceOrder のトランザクションで行う）
+		if err := product.ReserveStock(itemCmd.Quantity); err != nil {
+			return nil, domain.InsufficientStockError(itemCmd.ProductID)
+		}
+		products = append(products, product)
+
 		orderItems = append(orderItems, *orderItem)
 	}
 
@@ -90,29 +95,18 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, cmd CreateOrderComman
 	if err != nil {
 		return nil, domain.InvalidInputError("failed to create order: " + err.Error())
 	}
+	order.AttributeStatusChanges(cmd.Actor, "")
 
-	// 5. 在庫の予約（商品の在庫を減らす）
-	for _, itemCmd := range cmd.Items {
-		productID := value.ProductID(itemCmd.ProductID)
-		product, _ := uc.productRepo.FindByID(ctx, productID)
-
-		err = product.ReserveStock(itemCmd.Quantity)
-		if err != nil {
-			return nil, domain.NewDomainError("STOCK_RESERVATION_FAILED",
-				"Failed to reserve stock: "+err.Error(), nil)
-		}
-
-		// 商品の在庫更新をDBに反映
-		err = uc.productRepo.Save(ctx, product)
-		if err != nil {
-			return nil, domain.RepositoryError("failed to update product stock", err)
-		}
+	// 5. 在庫の予約と注文の保存を1つのトランザクションで実行
+	err = uc.orderRepo.PlaceOrder(ctx, order)
+	if err != nil {
+		return nil, repositoryError("failed to place order", err)
 	}
 
-	// 6. 注文をリポジトリに保存
-	err = uc.orderRepo.Save(ctx, order)
-	if err != nil {
-		return nil, domain.RepositoryError("failed to save order", err)
+	// 6. 保存に成功したらドメインイベントを発行
+	publish(ctx, uc.events, order.PullEvents()...)
+	for _, product := range products {
+		publish(ctx, uc.events, product.PullEvents()...)
 	}
 
 	return order, nil
@@ -143,11 +137,7 @@ func (uc *GetOrderUseCase) Execute(ctx context.Context, cmd GetOrderCommand) (*e
 	// 2. リポジトリから取得
 	order, err := uc.orderRepo.FindByID(ctx, orderID)
 	if err != nil {
-		return nil, err
-	}
-
-	if order == nil {
-		return nil, domain.NewDomainError("ORDER_NOT_FOUND", "Order not found", nil)
+		return nil, repositoryError("failed to get order", err)
 	}
 
 	return order, nil
@@ -162,6 +152,7 @@ type ListOrdersUseCase struct {
 type ListOrdersCommand struct {
 	CustomerID *string
 	Limit      int
+	Cursor     *string
 }
 
 // NewListOrdersUseCase creates a new list orders use case
@@ -172,7 +163,7 @@ func NewListOrdersUseCase(orderRepo repository.OrderRepository) *ListOrdersUseCa
 }
 
 // Execute executes the list orders use case
-func (uc *ListOrdersUseCase) Execute(ctx context.Context, cmd ListOrdersCommand) ([]*entity.Order, error) {
+func (uc *ListOrdersUseCase) Execute(ctx context.Context, cmd ListOrdersCommand) ([]*entity.Order, *string, error) {
 	// ビジネスロジック: デフォルトの制限値設定
 	if cmd.Limit <= 0 || cmd.Limit > 1000 {
 		cmd.Limit = 100 // デフォルト制限
@@ -181,34 +172,96 @@ func (uc *ListOrdersUseCase) Execute(ctx context.Context, cmd ListOrdersCommand)
 	// 顧客別 or 全体の注文リスト取得
 	if cmd.CustomerID != nil && *cmd.CustomerID != "" {
 		customerID := value.CustomerID(*cmd.CustomerID)
-		orders, _, err := uc.orderRepo.FindByCustomerID(ctx, customerID, cmd.Limit, nil)
+		orders, nextCursor, err := uc.orderRepo.FindByCustomerID(ctx, customerID, cmd.Limit, cmd.Cursor)
 		if err != nil {
-			return nil, domain.RepositoryError("failed to list orders by customer", err)
+			return nil, nil, repositoryError("failed to list orders by customer", err)
 		}
-		return orders, nil
+		return orders, nextCursor, nil
 	}
 
 	// 全注文リスト（将来的にはページネーション対応予定）
 	// 現在は簡単な実装として空配列を返す
 	// TODO: OrderRepository にFindAllメソッドを追加
-	return []*entity.Order{}, nil
+	return []*entity.Order{}, nil, nil
+}
+
+// ListProductOrdersUseCase handles listing the orders containing a product in a date range
+type ListProductOrdersUseCase struct {
+	orderRepo repository.OrderRepository
+}
+
+// ListProductOrdersCommand represents the input for listing a product's orders.
+// From and To bound the order date (inclusive); nil leaves that end open.
+type ListProductOrdersCommand struct {
+	ProductID string
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+	Cursor    *string
+}
+
+// NewListProductOrdersUseCase creates a new list product orders use case
+func NewListProductOrdersUseCase(orderRepo repository.OrderRepository) *ListProductOrdersUseCase {
+	return &ListProductOrdersUseCase{
+		orderRepo: orderRepo,
+	}
+}
+
+// Execute executes the list product orders use case
+func (uc *ListProductOrdersUseCase) Execute(ctx context.Context, cmd ListProductOrdersCommand) ([]*entity.Order, *string, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	productID, err := value.NewProductID(cmd.ProductID)
+	if err != nil {
+		return nil, nil, domain.InvalidInputError("invalid product ID")
+	}
+
+	var from, to time.Time
+	if cmd.From != nil {
+		from = *cmd.From
+	}
+	if cmd.To != nil {
+		to = *cmd.To
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return nil, nil, domain.InvalidInputError("from must not be after to")
+	}
+
+	// ビジネスロジック: デフォルトの制限値設定
+	if cmd.Limit <= 0 || cmd.Limit > 1000 {
+		cmd.Limit = 100 // デフォルト制限
+	}
+
+	// 2. リポジトリから取得
+	orders, nextCursor, err := uc.orderRepo.FindByProductAndDateRange(ctx, productID, from, to, cmd.Limit, cmd.Cursor)
+	if err != nil {
+		return nil, nil, repositoryError("failed to list product orders", err)
+	}
+
+	return orders, nextCursor, nil
 }
 
 // UpdateOrderStatusUseCase handles order status update
 type UpdateOrderStatusUseCase struct {
 	orderRepo repository.OrderRepository
+	cancel    *CancelOrderUseCase
+	events    EventPublisher
 }
 
 // UpdateOrderStatusCommand represents the input for updating order status
 type UpdateOrderStatusCommand struct {
 	OrderID string
 	Status  string
+	Actor   string // Who changes the status, recorded in the status history
+	Reason  string // Why the status is changed, recorded in the status history
 }
 
-// NewUpdateOrderStatusUseCase creates a new update order status use case
-func NewUpdateOrderStatusUseCase(orderRepo repository.OrderRepository) *UpdateOrderStatusUseCase {
+// NewUpdateOrderStatusUseCase creates a new update order status use case. Cancellations are
+// handed to cancel so that they return the reserved stock. events may be nil.
+func NewUpdateOrderStatusUseCase(orderRepo repository.OrderRepository, cancel *CancelOrderUseCase, events EventPublisher) *UpdateOrderStatusUseCase {
 	return &UpdateOrderStatusUseCase{
 		orderRepo: orderRepo,
+		cancel:    cancel,
+		events:    events,
 	}
 }
 
@@ -216,28 +269,164 @@ func NewUpdateOrderStatusUseCase(orderRepo repository.OrderRepository) *UpdateOr
 func (uc *UpdateOrderStatusUseCase) Execute(ctx context.Context, cmd UpdateOrderStatusCommand) (*entity.Order, error) {
 	// 1. 値オブジェクトの作成・バリデーション
 	orderID := value.OrderID(cmd.OrderID)
+	status := entity.OrderStatus(cmd.Status)
+	if !status.IsValid() {
+		return nil, domain.InvalidInputError("invalid order status: " + cmd.Status)
+	}
+	if status == entity.OrderStatusCancelled {
+		return uc.cancel.Execute(ctx, CancelOrderCommand{OrderID: cmd.OrderID, Reason: cmd.Reason, Actor: cmd.Actor})
+	}
 
 	// 2. 既存の注文を取得
 	order, err := uc.orderRepo.FindByID(ctx, orderID)
 	if err != nil {
-		return nil, err
+		return nil, repositoryError("failed to get order", err)
 	}
 
-	if order == nil {
-		return nil, domain.NewDomainError("ORDER_NOT_FOUND", "Order not found", nil)
-	}
-
-	// 3. ステータス更新（ビジネスロジックチェック含む）
-	err = order.UpdateStatus(entity.OrderStatus(cmd.Status))
+	// 3. ステータス更新（ビジネスロジックチェック含む）と履歴への記録
+	err = order.UpdateStatus(status)
 	if err != nil {
-		return nil, domain.InvalidInputError("invalid status transition: " + err.Error())
+		return nil, domain.RuleViolationError("invalid status transition: " + err.Error())
 	}
+	order.AttributeStatusChanges(cmd.Actor, cmd.Reason)
 
 	// 4. リポジトリに保存
 	err = uc.orderRepo.Save(ctx, order)
 	if err != nil {
-		return nil, domain.RepositoryError("failed to update order", err)
+		return nil, repositoryError("failed to update order", err)
 	}
 
+	// 5. 保存に成功したらドメインイベントを発行
+	publish(ctx, uc.events, order.PullEvents()...)
+
 	return order, nil
 }
+
+// CancelOrderUseCase handles order cancellation, which gives the order's reserved stock back
+type CancelOrderUseCase struct {
+	orderRepo    repository.OrderRepository
+	productRepo  repository.ProductRepository
+	shipmentRepo repository.ShipmentRepository
+	events       EventPublisher
+}
+
+// CancelOrderCommand represents the input for cancelling an order
+type CancelOrderCommand struct {
+	OrderID string
+	Reason  string // Why the order is cancelled, recorded in the status history
+	Actor   string // Who cancels the order, recorded in the status history
+}
+
+// NewCancelOrderUseCase creates a new cancel order use case. events may be nil.
+func NewCancelOrderUseCase(
+	orderRepo repository.OrderRepository,
+	productRepo repository.ProductRepository,
+	shipmentRepo repository.ShipmentRepository,
+	events EventPublisher,
+) *CancelOrderUseCase {
+	return &CancelOrderUseCase{
+		orderRepo:    orderRepo,
+		productRepo:  productRepo,
+		shipmentRepo: shipmentRepo,
+		events:       events,
+	}
+}
+
+// Execute executes the cancel order use case
+func (uc *CancelOrderUseCase) Execute(ctx context.Context, cmd CancelOrderCommand) (*entity.Order, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	orderID, err := value.NewOrderID(cmd.OrderID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid order ID")
+	}
+
+	// 2. 既存の注文を取得
+	order, err := uc.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, repositoryError("failed to get order", err)
+	}
+
+	// 3. 出荷済みの商品がある注文はキャンセルできない（一部出荷の注文は確定のまま）
+	shipments, err := uc.shipmentRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, repositoryError("failed to get shipments", err)
+	}
+	if len(shipments) > 0 {
+		return nil, domain.RuleViolationError("cannot cancel an order with shipped goods: " + cmd.OrderID)
+	}
+
+	// 4. ステータス更新と履歴への記録
+	if err := order.Cancel(); err != nil {
+		return nil, domain.RuleViolationError("invalid status transition: " + err.Error())
+	}
+	order.AttributeStatusChanges(cmd.Actor, cmd.Reason)
+
+	// 5. 明細ごとに在庫を戻す（削除済みの商品には戻さない。在庫の更新はキャンセルと同じトランザクションで行う）
+	var products []*entity.Product
+	loaded := make(map[value.ProductID]*entity.Product)
+	for _, item := range order.Items() {
+		product, ok := loaded[item.ProductID]
+		if !ok {
+			product, err = uc.productRepo.FindByID(ctx, item.ProductID)
+			if errors.Is(err, domain.ErrNotFound) {
+				slog.InfoContext(ctx, "Product of cancelled order was deleted, not restocking it", "orderID", cmd.OrderID, "productID", item.ProductID.String())
+				continue
+			}
+			if err != nil {
+				return nil, repositoryError("failed to find product", err)
+			}
+			loaded[item.ProductID] = product
+			products = append(products, product)
+		}
+		if err := product.AddStock(item.Quantity); err != nil {
+			return nil, domain.RuleViolationError("cannot restock product: " + err.Error())
+		}
+	}
+
+	// 6. キャンセルと在庫の戻しを1つのトランザクションで実行
+	if err := uc.orderRepo.CancelOrder(ctx, order, products); err != nil {
+		return nil, repositoryError("failed to cancel order", err)
+	}
+
+	// 7. 保存に成功したらドメインイベントを発行
+	publish(ctx, uc.events, order.PullEvents()...)
+	for _, product := range products {
+		publish(ctx, uc.events, product.PullEvents()...)
+	}
+
+	return order, nil
+}
+
+// GetOrderHistoryUseCase handles getting the status history of an order
+type GetOrderHistoryUseCase struct {
+	orderRepo repository.OrderRepository
+}
+
+// GetOrderHistoryCommand represents the input for getting an order's status history
+type GetOrderHistoryCommand struct {
+	OrderID string
+}
+
+// NewGetOrderHistoryUseCase creates a new get order history use case
+func NewGetOrderHistoryUseCase(orderRepo repository.OrderRepository) *GetOrderHistoryUseCase {
+	return &GetOrderHistoryUseCase{
+		orderRepo: orderRepo,
+	}
+}
+
+// Execute executes the get order history use case
+func (uc *GetOrderHistoryUseCase) Execute(ctx context.Context, cmd GetOrderHistoryCommand) ([]entity.OrderStatusChange, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	orderID, err := value.NewOrderID(cmd.OrderID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid order ID")
+	}
+
+	// 2. リポジトリから取得
+	history, err := uc.orderRepo.FindStatusHistory(ctx, orderID)
+	if err != nil {
+		return nil, repositoryError("failed to get order history", err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,164 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/usecase"
+)
+
+func TestCancelOrderUseCase(t *testing.T) {
+	ctx := context.Background()
+	store, err := repository.NewMemoryStore()
+	require.NoError(t, err)
+	customerRepo := repository.NewMemoryCustomerRepository(store)
+	productRepo := repository.NewMemoryProductRepository(store)
+	orderRepo := repository.NewMemoryOrderRepository(store)
+	warehouseRepo := repository.NewMemoryWarehouseRepository(store)
+	shipmentRepo := repository.NewMemoryShipmentRepository(store)
+
+	dispatcher := usecase.NewEventDispatcher()
+	published := recordEvents(dispatcher)
+	cancel := usecase.NewCancelOrderUseCase(orderRepo, productRepo, shipmentRepo, dispatcher)
+
+	// Setup test data
+	customer, err := usecase.NewCreateCustomerUseCase(customerRepo, nil).Execute(ctx, usecase.CreateCustomerCommand{
+		Name:  "Test Customer",
+		Email: "test@example.com",
+	})
+	require.NoError(t, err)
+	product, err := usecase.NewCreateProductUseCase(productRepo).Execute(ctx, usecase.CreateProductCommand{Name: "Widget", Price: 1000})
+	require.NoError(t, err)
+	warehouse, err := usecase.NewCreateWarehouseUseCase(warehouseRepo).Execute(ctx, usecase.CreateWarehouseCommand{Name: "Tokyo", Location: "Tokyo"})
+	require.NoError(t, err)
+	_, err = usecase.NewSetInventoryUseCase(warehouseRepo, productRepo).Execute(ctx, usecase.SetInventoryCommand{
+		WarehouseID: warehouse.ID().String(),
+		ProductID:   product.ID().String(),
+		Quantity:    10,
+	})
+	require.NoError(t, err)
+
+	placeOrder := func(t *testing.T, quantity int) *entity.Order {
+		order, err := usecase.NewCreateOrderUseCase(orderRepo, customerRepo, productRepo, nil).Execute(ctx, usecase.CreateOrderCommand{
+			CustomerID: customer.ID().String(),
+			Items:      []usecase.CreateOrderItemCommand{{ProductID: product.ID().String(), Quantity: quantity}},
+		})
+		require.NoError(t, err)
+		return order
+	}
+	stock := func(t *testing.T) (int, int) {
+		found, err := productRepo.FindByID(ctx, product.ID())
+		require.NoError(t, err)
+		inventory, err := warehouseRepo.FindInventory(ctx, warehouse.ID(), product.ID())
+		require.NoError(t, err)
+		return found.Stock(), inventory.Quantity()
+	}
+
+	t.Run("cancelling returns the reserved stock", func(t *testing.T) {
+		order := placeOrder(t, 4)
+		total, held := stock(t)
+		assert.Equal(t, []int{6, 6}, []int{total, held})
+
+		before := len(published())
+		cancelled, err := cancel.Execute(ctx, usecase.CancelOrderCommand{
+			OrderID: order.ID().String(),
+			Reason:  "customer changed their mind",
+			Actor:   "clerk",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusCancelled, cancelled.Status())
+		assert.Equal(t, []string{entity.EventOrderStatusChanged, entity.EventStockAdded}, published()[before:])
+
+		total, held = stock(t)
+		assert.Equal(t, []int{10, 10}, []int{total, held})
+
+		history, err := orderRepo.FindStatusHistory(ctx, order.ID())
+		require.NoError(t, err)
+		last := history[len(history)-1]
+		assert.Equal(t, "clerk", last.Actor)
+		assert.Equal(t, "customer changed their mind", last.Reason)
+
+		// Cancelling twice must not return the stock twice
+		_, err = cancel.Execute(ctx, usecase.CancelOrderCommand{OrderID: order.ID().String(), Reason: "again"})
+		assert.ErrorIs(t, err, domain.ErrRuleViolation)
+		total, held = stock(t)
+		assert.Equal(t, []int{10, 10}, []int{total, held})
+	})
+
+	t.Run("status update to cancelled returns the reserved stock", func(t *testing.T) {
+		order := placeOrder(t, 3)
+
+		_, err := usecase.NewUpdateOrderStatusUseCase(orderRepo, cancel, nil).Execute(ctx, usecase.UpdateOrderStatusCommand{
+			OrderID: order.ID().String(),
+			Status:  string(entity.OrderStatusCancelled),
+		})
+		require.NoError(t, err)
+
+		total, held := stock(t)
+		assert.Equal(t, []int{10, 10}, []int{total, held})
+	})
+
+	t.Run("orders with shipped goods cannot be cancelled", func(t *testing.T) {
+		order := placeOrder(t, 2)
+		_, err := usecase.NewUpdateOrderStatusUseCase(orderRepo, cancel, nil).Execute(ctx, usecase.UpdateOrderStatusCommand{
+			OrderID: order.ID().String(),
+			Status:  string(entity.OrderStatusConfirmed),
+		})
+		require.NoError(t, err)
+		// A partial shipment leaves the order confirmed
+		_, err = usecase.NewCreateShipmentUseCase(shipmentRepo, orderRepo, warehouseRepo, nil).Execute(ctx, usecase.CreateShipmentCommand{
+			OrderID:        order.ID().String(),
+			WarehouseID:    warehouse.ID().String(),
+			Carrier:        "Yamato",
+			TrackingNumber: "1234-5678",
+			Items:          []usecase.ShipmentItemCommand{{ProductID: product.ID().String(), Quantity: 1}},
+		})
+		require.NoError(t, err)
+
+		_, err = cancel.Execute(ctx, usecase.CancelOrderCommand{OrderID: order.ID().String(), Reason: "too late"})
+		assert.ErrorIs(t, err, domain.ErrRuleViolation)
+
+		found, err := orderRepo.FindByID(ctx, order.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusConfirmed, found.Status())
+		total, _ := stock(t)
+		assert.Equal(t, 8, total)
+	})
+
+	t.Run("orders of deleted products are cancelled without restocking them", func(t *testing.T) {
+		discontinued, err := usecase.NewCreateProductUseCase(productRepo).Execute(ctx, usecase.CreateProductCommand{Name: "Gadget", Price: 500})
+		require.NoError(t, err)
+		_, err = usecase.NewSetInventoryUseCase(warehouseRepo, productRepo).Execute(ctx, usecase.SetInventoryCommand{
+			WarehouseID: warehouse.ID().String(),
+			ProductID:   discontinued.ID().String(),
+			Quantity:    5,
+		})
+		require.NoError(t, err)
+		order, err := usecase.NewCreateOrderUseCase(orderRepo, customerRepo, productRepo, nil).Execute(ctx, usecase.CreateOrderCommand{
+			CustomerID: customer.ID().String(),
+			Items: []usecase.CreateOrderItemCommand{
+				{ProductID: product.ID().String(), Quantity: 2},
+				{ProductID: discontinued.ID().String(), Quantity: 1},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, productRepo.Delete(ctx, discontinued.ID()))
+
+		before := len(published())
+		cancelled, err := cancel.Execute(ctx, usecase.CancelOrderCommand{OrderID: order.ID().String(), Reason: "discontinued"})
+		require.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusCancelled, cancelled.Status())
+		// Only the product that still exists is restocked and reported
+		assert.Equal(t, []string{entity.EventOrderStatusChanged, entity.EventStockAdded}, published()[before:])
+		total, held := stock(t)
+		assert.Equal(t, []int{8, 8}, []int{total, held})
+		_, err = productRepo.FindByID(ctx, discontinued.ID())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
@@ -14,12 +14,12 @@ type CreateProductUseCase struct {
 	productRepo repository.ProductRepository
 }
 
-// CreateProductCommand represents the input for creating a product
+// CreateProductCommand represents the input for creating a product.
+// Stock is not part of the command: it is the total of the product's warehouse inventory.
 type CreateProductCommand struct {
 	Name        string
 	Description string
 	Price       int64
-	Stock       int
 }
 
 // NewCreateProductUseCase creates a new create product use case
@@ -40,8 +40,8 @@ func (uc *CreateProductUseCase) Execute(ctx context.Context, cmd CreateProductCo
 	// 2. 新しいProduct IDを生成
 	productID := value.GenerateProductID()
 
-	// 3. エンティティ作成
-	product, err := entity.NewProduct(productID, cmd.Name, cmd.Description, price, cmd.Stock)
+	// 3. エンティティ作成（在庫は倉庫在庫の登録時に加算される）
+	product, err := entity.NewProduct(productID, cmd.Name, cmd.Description, price, 0)
 	if err != nil {
 		return nil, domain.InvalidInputError("failed to create product: " + err.Error())
 	}
@@ -80,11 +80,7 @@ func (uc *GetProductUseCase) Execute(ctx context.Context, cmd GetProductCommand)
 	// 2. リポジトリから取得
 	product, err := uc.productRepo.FindByID(ctx, productID)
 	if err != nil {
-		return nil, err
-	}
-
-	if product == nil {
-		return nil, domain.NewDomainError("PRODUCT_NOT_FOUND", "Product not found", nil)
+		return nil, repositoryError("failed to get product", err)
 	}
 
 	return product, nil
@@ -97,7 +93,8 @@ type ListProductsUseCase struct {
 
 // ListProductsCommand represents the input for listing products
 type ListProductsCommand struct {
-	Limit int
+	Limit  int
+	Cursor *string
 }
 
 // NewListProductsUseCase creates a new list products use case
@@ -108,19 +105,19 @@ func NewListProductsUseCase(productRepo repository.ProductRepository) *ListProdu
 }
 
 // Execute executes the list products use case
-func (uc *ListProductsUseCase) Execute(ctx context.Context, cmd ListProductsCommand) ([]*entity.Product, error) {
+func (uc *ListProductsUseCase) Execute(ctx context.Context, cmd ListProductsCommand) ([]*entity.Product, *string, error) {
 	// ビジネスロジック: デフォルトの制限値設定
 	if cmd.Limit <= 0 || cmd.Limit > 1000 {
 		cmd.Limit = 100 // デフォルト制限
 	}
 
 	// リポジトリから取得
-	products, _, err := uc.productRepo.FindAll(ctx, cmd.Limit, nil)
+	products, nextCursor, err := uc.productRepo.FindAll(ctx, cmd.Limit, cmd.Cursor)
 	if err != nil {
-		return nil, domain.RepositoryError("failed to list products", err)
+		return nil, nil, repositoryError("failed to list products", err)
 	}
 
-	return products, nil
+	return products, nextCursor, nil
 }
 
 // UpdateProductUseCase handles product update
@@ -128,13 +125,13 @@ type UpdateProductUseCase struct {
 	productRepo repository.ProductRepository
 }
 
-// UpdateProductCommand represents the input for updating a product
+// UpdateProductCommand represents the input for updating a product.
+// Stock is changed through the warehouse inventory instead.
 type UpdateProductCommand struct {
 	ProductID   string
 	Name        string
 	Description string
 	Price       int64
-	Stock       int
 }
 
 // NewUpdateProductUseCase creates a new update product use case
@@ -156,26 +153,18 @@ func (uc *UpdateProductUseCase) Execute(ctx context.Context, cmd UpdateProductCo
 	// 2. 既存の商品を取得
 	product, err := uc.productRepo.FindByID(ctx, productID)
 	if err != nil {
-		return nil, err
-	}
-
-	if product == nil {
-		return nil, domain.NewDomainError("PRODUCT_NOT_FOUND", "Product not found", nil)
+		return nil, repositoryError("failed to get product", err)
 	}
 
 	// 3. エンティティの更新
 	product.UpdateName(cmd.Name)
 	product.UpdateDescription(cmd.Description)
 	product.UpdatePrice(price)
-	err = product.UpdateStock(cmd.Stock)
-	if err != nil {
-		return nil, domain.InvalidInputError("invalid stock value: " + err.Error())
-	}
 
 	// 4. リポジトリに保存
 	err = uc.productRepo.Save(ctx, product)
 	if err != nil {
-		return nil, domain.RepositoryError("failed to update product", err)
+		return nil, repositoryError("failed to update product", err)
 	}
 
 	return product, nil
@@ -206,11 +195,11 @@ func (uc *DeleteProductUseCase) Execute(ctx context.Context, cmd DeleteProductCo
 	// 2. 商品の存在確認
 	exists, err := uc.productRepo.Exists(ctx, productID)
 	if err != nil {
-		return domain.RepositoryError("failed to check product existence", err)
+		return repositoryError("failed to check product existence", err)
 	}
 
 	if !exists {
-		return domain.NewDomainError("PRODUCT_NOT_FOUND", "Product not found", nil)
+		return domain.ProductNotFoundError(cmd.ProductID)
 	}
 
 	// 3. ビジネスルール: 注文に含まれている商品は削除できない（将来実装）
@@ -0,0 +1,383 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/repository"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// RequestReturnUseCase handles a customer asking to send back lines of a delivered order
+type RequestReturnUseCase struct {
+	returnRepo repository.ReturnRepository
+	orderRepo  repository.OrderRepository
+	events     EventPublisher
+}
+
+// RequestReturnCommand represents the input for requesting a return
+type RequestReturnCommand struct {
+	OrderID string
+	Items   []ReturnItemCommand
+	Reason  string
+}
+
+// ReturnItemCommand represents a returned product in a request return command
+type ReturnItemCommand struct {
+	ProductID string
+	Quantity  int
+}
+
+// NewRequestReturnUseCase creates a new request return use case. events may be nil.
+func NewRequestReturnUseCase(returnRepo repository.ReturnRepository, orderRepo repository.OrderRepository, events EventPublisher) *RequestReturnUseCase {
+	return &RequestReturnUseCase{
+		returnRepo: returnRepo,
+		orderRepo:  orderRepo,
+		events:     events,
+	}
+}
+
+// Execute executes the request return use case
+func (uc *RequestReturnUseCase) Execute(ctx context.Context, cmd RequestReturnCommand) (*entity.ReturnRequest, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	orderID, err := value.NewOrderID(cmd.OrderID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid order ID")
+	}
+
+	items := make([]entity.ReturnItem, 0, len(cmd.Items))
+	for _, item := range cmd.Items {
+		productID, err := value.NewProductID(item.ProductID)
+		if err != nil {
+			return nil, domain.InvalidInputError("invalid product ID")
+		}
+		items = append(items, entity.ReturnItem{
+			ProductID: productID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	// 2. 注文とこれまでの返品を取得
+	order, err := uc.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, repositoryError("failed to find order", err)
+	}
+	previous, err := uc.returnRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, repositoryError("failed to load order returns", err)
+	}
+
+	// 3. ビジネスルール: 配達済みの注文で、返品数は未返品の注文数を超えない
+	request, err := entity.NewReturnRequest(value.GenerateReturnID(), order, items, cmd.Reason, previous)
+	if err != nil {
+		return nil, domain.RuleViolationError("invalid return: " + err.Error())
+	}
+
+	// 4. 注文のバージョンと一緒に保存（同じ商品の二重申請を防ぐ）
+	err = uc.returnRepo.Save(ctx, request, order)
+	if err != nil {
+		return nil, repositoryError("failed to save return request", err)
+	}
+
+	// 5. 保存に成功したらドメインイベントを発行
+	publish(ctx, uc.events, request.PullEvents()...)
+
+	return request, nil
+}
+
+// GetReturnUseCase handles getting a return request by ID
+type GetReturnUseCase struct {
+	returnRepo repository.ReturnRepository
+}
+
+// GetReturnCommand represents the input for getting a return request
+type GetReturnCommand struct {
+	ReturnID string
+}
+
+// NewGetReturnUseCase creates a new get return use case
+func NewGetReturnUseCase(returnRepo repository.ReturnRepository) *GetReturnUseCase {
+	return &GetReturnUseCase{
+		returnRepo: returnRepo,
+	}
+}
+
+// Execute executes the get return use case
+func (uc *GetReturnUseCase) Execute(ctx context.Context, cmd GetReturnCommand) (*entity.ReturnRequest, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	returnID, err := value.NewReturnID(cmd.ReturnID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid return ID")
+	}
+
+	// 2. リポジトリから取得
+	request, err := uc.returnRepo.FindByID(ctx, returnID)
+	if err != nil {
+		return nil, repositoryError("failed to get return request", err)
+	}
+
+	return request, nil
+}
+
+// ListOrderReturnsUseCase handles listing the return requests of an order
+type ListOrderReturnsUseCase struct {
+	returnRepo repository.ReturnRepository
+}
+
+// ListOrderReturnsCommand represents the input for listing an order's return requests
+type ListOrderReturnsCommand struct {
+	OrderID string
+}
+
+// NewListOrderReturnsUseCase creates a new list order returns use case
+func NewListOrderReturnsUseCase(returnRepo repository.ReturnRepository) *ListOrderReturnsUseCase {
+	return &ListOrderReturnsUseCase{
+		returnRepo: returnRepo,
+	}
+}
+
+// Execute executes the list order returns use case
+func (uc *ListOrderReturnsUseCase) Execute(ctx context.Context, cmd ListOrderReturnsCommand) ([]*entity.ReturnRequest, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	orderID, err := value.NewOrderID(cmd.OrderID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid order ID")
+	}
+
+	// 2. リポジトリから取得
+	requests, err := uc.returnRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, repositoryError("failed to list order returns", err)
+	}
+
+	return requests, nil
+}
+
+// ListReturnsUseCase handles listing return requests by status for back-office review
+type ListReturnsUseCase struct {
+	returnRepo repository.ReturnRepository
+}
+
+// ListReturnsCommand represents the input for listing return requests
+type ListReturnsCommand struct {
+	Status string // Defaults to requested
+	Limit  int
+	Cursor *string
+}
+
+// NewListReturnsUseCase creates a new list returns use case
+func NewListReturnsUseCase(returnRepo repository.ReturnRepository) *ListReturnsUseCase {
+	return &ListReturnsUseCase{
+		returnRepo: returnRepo,
+	}
+}
+
+// Execute executes the list returns use case
+func (uc *ListReturnsUseCase) Execute(ctx context.Context, cmd ListReturnsCommand) ([]*entity.ReturnRequest, *string, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	status := entity.ReturnStatusRequested
+	if cmd.Status != "" {
+		status = entity.ReturnStatus(cmd.Status)
+	}
+	if !status.IsValid() {
+		return nil, nil, domain.InvalidInputError("invalid return status")
+	}
+
+	// ビジネスロジック: デフォルトの制限値設定
+	if cmd.Limit <= 0 || cmd.Limit > 1000 {
+		cmd.Limit = 100 // デフォルト制限
+	}
+
+	// 2. リポジトリから取得
+	requests, nextCursor, err := uc.returnRepo.FindByStatus(ctx, status, cmd.Limit, cmd.Cursor)
+	if err != nil {
+		return nil, nil, repositoryError("failed to list returns", err)
+	}
+
+	return requests, nextCursor, nil
+}
+
+// ApproveReturnUseCase handles approving a return: refunding the returned lines and restocking the goods
+type ApproveReturnUseCase struct {
+	returnRepo    repository.ReturnRepository
+	orderRepo     repository.OrderRepository
+	productRepo   repository.ProductRepository
+	warehouseRepo repository.WarehouseRepository
+	events        EventPublisher
+}
+
+// ApproveReturnCommand represents the input for approving a return
+type ApproveReturnCommand struct {
+	ReturnID    string
+	WarehouseID string // Warehouse receiving the goods
+	Note        string
+	Actor       string // Who approves, recorded in the order's status history
+}
+
+// NewApproveReturnUseCase creates a new approve return use case. events may be nil.
+func NewApproveReturnUseCase(
+	returnRepo repository.ReturnRepository,
+	orderRepo repository.OrderRepository,
+	productRepo repository.ProductRepository,
+	warehouseRepo repository.WarehouseRepository,
+	events EventPublisher,
+) *ApproveReturnUseCase {
+	return &ApproveReturnUseCase{
+		returnRepo:    returnRepo,
+		orderRepo:     orderRepo,
+		productRepo:   productRepo,
+		warehouseRepo: warehouseRepo,
+		events:        events,
+	}
+}
+
+// Execute executes the approve return use case. The order becomes partially refunded, or returned once every
+// ordered unit has been returned.
+func (uc *ApproveReturnUseCase) Execute(ctx context.Context, cmd ApproveReturnCommand) (*entity.ReturnRequest, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	returnID, err := value.NewReturnID(cmd.ReturnID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid return ID")
+	}
+	warehouseID, err := value.NewWarehouseID(cmd.WarehouseID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid warehouse ID")
+	}
+
+	// 2. 返品・注文・倉庫を取得
+	request, err := uc.returnRepo.FindByID(ctx, returnID)
+	if err != nil {
+		return nil, repositoryError("failed to get return request", err)
+	}
+	order, err := uc.orderRepo.FindByID(ctx, request.OrderID())
+	if err != nil {
+		return nil, repositoryError("failed to get order", err)
+	}
+	exists, err := uc.warehouseRepo.Exists(ctx, warehouseID)
+	if err != nil {
+		return nil, repositoryError("failed to check warehouse existence", err)
+	}
+	if !exists {
+		return nil, domain.WarehouseNotFoundError(cmd.WarehouseID)
+	}
+
+	// 3. 承認（返金額は注文時の単価で計算）
+	err = request.Approve(warehouseID, cmd.Note)
+	if err != nil {
+		return nil, domain.RuleViolationError("invalid return status: " + err.Error())
+	}
+
+	// 4. ビジネスルール: 承認済みの返品で注文ステータスを一部返金・返品済みにする
+	requests, err := uc.returnRepo.FindByOrderID(ctx, order.ID())
+	if err != nil {
+		return nil, repositoryError("failed to load order returns", err)
+	}
+	for i, r := range requests {
+		if r.ID() == request.ID() {
+			requests[i] = request
+		}
+	}
+	err = order.ApplyReturns(requests)
+	if err != nil {
+		return nil, domain.RuleViolationError("invalid return: " + err.Error())
+	}
+	order.AttributeStatusChanges(cmd.Actor, fmt.Sprintf("return %s approved", request.ID()))
+
+	// 5. 返品された明細ごとに在庫を戻す（削除済みの商品には戻さない。在庫の更新は承認と同じトランザクションで行う）
+	var products []*entity.Product
+	loaded := make(map[value.ProductID]*entity.Product)
+	for _, item := range request.Items() {
+		product, ok := loaded[item.ProductID]
+		if !ok {
+			product, err = uc.productRepo.FindByID(ctx, item.ProductID)
+			if errors.Is(err, domain.ErrNotFound) {
+				slog.InfoContext(ctx, "Returned product was deleted, not restocking it", "returnID", cmd.ReturnID, "productID", item.ProductID.String())
+				continue
+			}
+			if err != nil {
+				return nil, repositoryError("failed to find product", err)
+			}
+			loaded[item.ProductID] = product
+			products = append(products, product)
+		}
+		if err := product.AddStock(item.Quantity); err != nil {
+			return nil, domain.RuleViolationError("cannot restock product: " + err.Error())
+		}
+	}
+
+	// 6. 承認・注文・在庫の戻しを1つのトランザクションで実行
+	if err := uc.returnRepo.Approve(ctx, request, order, products); err != nil {
+		return nil, repositoryError("failed to approve return", err)
+	}
+
+	// 7. 保存に成功したらドメインイベントを発行
+	publish(ctx, uc.events, request.PullEvents()...)
+	publish(ctx, uc.events, order.PullEvents()...)
+	for _, product := range products {
+		publish(ctx, uc.events, product.PullEvents()...)
+	}
+
+	return request, nil
+}
+
+// RejectReturnUseCase handles rejecting a return
+type RejectReturnUseCase struct {
+	returnRepo repository.ReturnRepository
+	orderRepo  repository.OrderRepository
+	events     EventPublisher
+}
+
+// RejectReturnCommand represents the input for rejecting a return
+type RejectReturnCommand struct {
+	ReturnID string
+	Note     string // Why the return is refused, shown to the customer
+}
+
+// NewRejectReturnUseCase creates a new reject return use case. events may be nil.
+func NewRejectReturnUseCase(returnRepo repository.ReturnRepository, orderRepo repository.OrderRepository, events EventPublisher) *RejectReturnUseCase {
+	return &RejectReturnUseCase{
+		returnRepo: returnRepo,
+		orderRepo:  orderRepo,
+		events:     events,
+	}
+}
+
+// Execute executes the reject return use case. The rejected units can be requested again.
+func (uc *RejectReturnUseCase) Execute(ctx context.Context, cmd RejectReturnCommand) (*entity.ReturnRequest, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	returnID, err := value.NewReturnID(cmd.ReturnID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid return ID")
+	}
+
+	// 2. 返品と注文を取得
+	request, err := uc.returnRepo.FindByID(ctx, returnID)
+	if err != nil {
+		return nil, repositoryError("failed to get return request", err)
+	}
+	order, err := uc.orderRepo.FindByID(ctx, request.OrderID())
+	if err != nil {
+		return nil, repositoryError("failed to get order", err)
+	}
+
+	// 3. 却下
+	err = request.Reject(cmd.Note)
+	if err != nil {
+		return nil, domain.RuleViolationError("invalid return: " + err.Error())
+	}
+
+	// 4. 注文のバージョンと一緒に保存
+	err = uc.returnRepo.Save(ctx, request, order)
+	if err != nil {
+		return nil, repositoryError("failed to save return request", err)
+	}
+
+	// 5. 保存に成功したらドメインイベントを発行
+	publish(ctx, uc.events, request.PullEvents()...)
+
+	return request, nil
+}
@@ -0,0 +1,222 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dynamo-modeling/internal/adapter/repository"
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/usecase"
+)
+
+func TestReturnUseCases(t *testing.T) {
+	ctx := context.Background()
+	store, err := repository.NewMemoryStore()
+	require.NoError(t, err)
+	customerRepo := repository.NewMemoryCustomerRepository(store)
+	productRepo := repository.NewMemoryProductRepository(store)
+	orderRepo := repository.NewMemoryOrderRepository(store)
+	warehouseRepo := repository.NewMemoryWarehouseRepository(store)
+	shipmentRepo := repository.NewMemoryShipmentRepository(store)
+	returnRepo := repository.NewMemoryReturnRepository(store)
+
+	dispatcher := usecase.NewEventDispatcher()
+	published := recordEvents(dispatcher)
+	requestReturn := usecase.NewRequestReturnUseCase(returnRepo, orderRepo, dispatcher)
+	approve := usecase.NewApproveReturnUseCase(returnRepo, orderRepo, productRepo, warehouseRepo, dispatcher)
+	reject := usecase.NewRejectReturnUseCase(returnRepo, orderRepo, dispatcher)
+
+	// Setup test data
+	customer, err := usecase.NewCreateCustomerUseCase(customerRepo, nil).Execute(ctx, usecase.CreateCustomerCommand{
+		Name:  "Test Customer",
+		Email: "test@example.com",
+	})
+	require.NoError(t, err)
+	product, err := usecase.NewCreateProductUseCase(productRepo).Execute(ctx, usecase.CreateProductCommand{Name: "Widget", Price: 1000})
+	require.NoError(t, err)
+	warehouse, err := usecase.NewCreateWarehouseUseCase(warehouseRepo).Execute(ctx, usecase.CreateWarehouseCommand{Name: "Tokyo", Location: "Tokyo"})
+	require.NoError(t, err)
+	_, err = usecase.NewSetInventoryUseCase(warehouseRepo, productRepo).Execute(ctx, usecase.SetInventoryCommand{
+		WarehouseID: warehouse.ID().String(),
+		ProductID:   product.ID().String(),
+		Quantity:    10,
+	})
+	require.NoError(t, err)
+
+	// deliverOrder places, ships and delivers an order for the given quantity
+	deliverOrder := func(t *testing.T, quantity int) *entity.Order {
+		order, err := usecase.NewCreateOrderUseCase(orderRepo, customerRepo, productRepo, nil).Execute(ctx, usecase.CreateOrderCommand{
+			CustomerID: customer.ID().String(),
+			Items:      []usecase.CreateOrderItemCommand{{ProductID: product.ID().String(), Quantity: quantity}},
+		})
+		require.NoError(t, err)
+		_, err = usecase.NewUpdateOrderStatusUseCase(orderRepo, nil, nil).Execute(ctx, usecase.UpdateOrderStatusCommand{
+			OrderID: order.ID().String(),
+			Status:  string(entity.OrderStatusConfirmed),
+		})
+		require.NoError(t, err)
+		shipment, err := usecase.NewCreateShipmentUseCase(shipmentRepo, orderRepo, warehouseRepo, nil).Execute(ctx, usecase.CreateShipmentCommand{
+			OrderID:        order.ID().String(),
+			WarehouseID:    warehouse.ID().String(),
+			Carrier:        "Yamato",
+			TrackingNumber: "1234-5678",
+			Items:          []usecase.ShipmentItemCommand{{ProductID: product.ID().String(), Quantity: quantity}},
+		})
+		require.NoError(t, err)
+		_, err = usecase.NewDeliverShipmentUseCase(shipmentRepo, orderRepo, nil).Execute(ctx, usecase.DeliverShipmentCommand{
+			ShipmentID: shipment.ID().String(),
+		})
+		require.NoError(t, err)
+		return order
+	}
+	stock := func(t *testing.T) (int, int) {
+		found, err := productRepo.FindByID(ctx, product.ID())
+		require.NoError(t, err)
+		inventory, err := warehouseRepo.FindInventory(ctx, warehouse.ID(), product.ID())
+		require.NoError(t, err)
+		return found.Stock(), inventory.Quantity()
+	}
+	orderStatus := func(t *testing.T, order *entity.Order) entity.OrderStatus {
+		found, err := orderRepo.FindByID(ctx, order.ID())
+		require.NoError(t, err)
+		return found.Status()
+	}
+
+	t.Run("approving returns refunds and restocks", func(t *testing.T) {
+		order := deliverOrder(t, 3)
+		total, held := stock(t)
+		assert.Equal(t, []int{7, 7}, []int{total, held})
+
+		before := len(published())
+		first, err := requestReturn.Execute(ctx, usecase.RequestReturnCommand{
+			OrderID: order.ID().String(),
+			Items:   []usecase.ReturnItemCommand{{ProductID: product.ID().String(), Quantity: 1}},
+			Reason:  "Damaged",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{entity.EventReturnRequested}, published()[before:])
+
+		approved, err := approve.Execute(ctx, usecase.ApproveReturnCommand{
+			ReturnID:    first.ID().String(),
+			WarehouseID: warehouse.ID().String(),
+			Actor:       "clerk",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), approved.Refund().Cents())
+		assert.Equal(t, entity.OrderStatusPartiallyRefunded, orderStatus(t, order))
+		assert.Equal(t, []string{
+			entity.EventReturnRequested,
+			entity.EventReturnApproved,
+			entity.EventOrderStatusChanged,
+			entity.EventStockAdded,
+		}, published()[before:])
+
+		total, held = stock(t)
+		assert.Equal(t, []int{8, 8}, []int{total, held})
+
+		second, err := requestReturn.Execute(ctx, usecase.RequestReturnCommand{
+			OrderID: order.ID().String(),
+			Items:   []usecase.ReturnItemCommand{{ProductID: product.ID().String(), Quantity: 2}},
+			Reason:  "Damaged",
+		})
+		require.NoError(t, err)
+		_, err = approve.Execute(ctx, usecase.ApproveReturnCommand{
+			ReturnID:    second.ID().String(),
+			WarehouseID: warehouse.ID().String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusReturned, orderStatus(t, order))
+
+		history, err := usecase.NewGetOrderHistoryUseCase(orderRepo).Execute(ctx, usecase.GetOrderHistoryCommand{OrderID: order.ID().String()})
+		require.NoError(t, err)
+		last := history[len(history)-1]
+		assert.Equal(t, entity.OrderStatusReturned, last.To)
+		assert.Contains(t, last.Reason, second.ID().String())
+
+		total, held = stock(t)
+		assert.Equal(t, []int{10, 10}, []int{total, held})
+	})
+
+	t.Run("rejected units can be requested again", func(t *testing.T) {
+		order := deliverOrder(t, 1)
+		command := usecase.RequestReturnCommand{
+			OrderID: order.ID().String(),
+			Items:   []usecase.ReturnItemCommand{{ProductID: product.ID().String(), Quantity: 1}},
+			Reason:  "Changed my mind",
+		}
+		request, err := requestReturn.Execute(ctx, command)
+		require.NoError(t, err)
+
+		// The unit is already requested
+		_, err = requestReturn.Execute(ctx, command)
+		assert.ErrorIs(t, err, domain.ErrRuleViolation)
+
+		rejected, err := reject.Execute(ctx, usecase.RejectReturnCommand{ReturnID: request.ID().String(), Note: "Opened"})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ReturnStatusRejected, rejected.Status())
+		assert.Equal(t, entity.OrderStatusDelivered, orderStatus(t, order))
+
+		_, err = approve.Execute(ctx, usecase.ApproveReturnCommand{
+			ReturnID:    request.ID().String(),
+			WarehouseID: warehouse.ID().String(),
+		})
+		assert.ErrorIs(t, err, domain.ErrRuleViolation)
+
+		_, err = requestReturn.Execute(ctx, command)
+		assert.NoError(t, err)
+	})
+
+	t.Run("orders that were not delivered cannot be returned", func(t *testing.T) {
+		order, err := usecase.NewCreateOrderUseCase(orderRepo, customerRepo, productRepo, nil).Execute(ctx, usecase.CreateOrderCommand{
+			CustomerID: customer.ID().String(),
+			Items:      []usecase.CreateOrderItemCommand{{ProductID: product.ID().String(), Quantity: 1}},
+		})
+		require.NoError(t, err)
+
+		_, err = requestReturn.Execute(ctx, usecase.RequestReturnCommand{
+			OrderID: order.ID().String(),
+			Items:   []usecase.ReturnItemCommand{{ProductID: product.ID().String(), Quantity: 1}},
+			Reason:  "Damaged",
+		})
+		assert.ErrorIs(t, err, domain.ErrRuleViolation)
+	})
+
+	t.Run("approving into an unknown warehouse is not found", func(t *testing.T) {
+		order := deliverOrder(t, 1)
+		request, err := requestReturn.Execute(ctx, usecase.RequestReturnCommand{
+			OrderID: order.ID().String(),
+			Items:   []usecase.ReturnItemCommand{{ProductID: product.ID().String(), Quantity: 1}},
+			Reason:  "Damaged",
+		})
+		require.NoError(t, err)
+
+		_, err = approve.Execute(ctx, usecase.ApproveReturnCommand{ReturnID: request.ID().String(), WarehouseID: "missing"})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	// Deletes the product, so it has to stay the last subtest
+	t.Run("approving returns of deleted products skips the restock", func(t *testing.T) {
+		order := deliverOrder(t, 1)
+		request, err := requestReturn.Execute(ctx, usecase.RequestReturnCommand{
+			OrderID: order.ID().String(),
+			Items:   []usecase.ReturnItemCommand{{ProductID: product.ID().String(), Quantity: 1}},
+			Reason:  "Damaged",
+		})
+		require.NoError(t, err)
+		require.NoError(t, productRepo.Delete(ctx, product.ID()))
+
+		before := len(published())
+		approved, err := approve.Execute(ctx, usecase.ApproveReturnCommand{
+			ReturnID:    request.ID().String(),
+			WarehouseID: warehouse.ID().String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ReturnStatusApproved, approved.Status())
+		assert.Equal(t, entity.OrderStatusReturned, orderStatus(t, order))
+		assert.Equal(t, []string{entity.EventReturnApproved, entity.EventOrderStatusChanged}, published()[before:])
+	})
+}
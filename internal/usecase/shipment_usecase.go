@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/repository"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// CreateShipmentUseCase handles shipping part or all of an order from a warehouse
+type CreateShipmentUseCase struct {
+	shipmentRepo  repository.ShipmentRepository
+	orderRepo     repository.OrderRepository
+	warehouseRepo repository.WarehouseRepository
+	events        EventPublisher
+}
+
+// CreateShipmentCommand represents the input for creating a shipment
+type CreateShipmentCommand struct {
+	OrderID        string
+	WarehouseID    string
+	Carrier        string
+	TrackingNumber string
+	Items          []ShipmentItemCommand
+	Actor          string // Who ships, recorded in the order's status history
+}
+
+// ShipmentItemCommand represents a shipped product in a create shipment command
+type ShipmentItemCommand struct {
+	ProductID string
+	Quantity  int
+}
+
+// NewCreateShipmentUseCase creates a new create shipment use case. events may be nil.
+func NewCreateShipmentUseCase(
+	shipmentRepo repository.ShipmentRepository,
+	orderRepo repository.OrderRepository,
+	warehouseRepo repository.WarehouseRepository,
+	events EventPublisher,
+) *CreateShipmentUseCase {
+	return &CreateShipmentUseCase{
+		shipmentRepo:  shipmentRepo,
+		orderRepo:     orderRepo,
+		warehouseRepo: warehouseRepo,
+		events:        events,
+	}
+}
+
+// Execute executes the create shipment use case. The order becomes shipped once every ordered unit has been shipped.
+func (uc *CreateShipmentUseCase) Execute(ctx context.Context, cmd CreateShipmentCommand) (*entity.Shipment, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	orderID, err := value.NewOrderID(cmd.OrderID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid order ID")
+	}
+	warehouseID, err := value.NewWarehouseID(cmd.WarehouseID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid warehouse ID")
+	}
+
+	items := make([]entity.ShipmentItem, 0, len(cmd.Items))
+	for _, item := range cmd.Items {
+		productID, err := value.NewProductID(item.ProductID)
+		if err != nil {
+			return nil, domain.InvalidInputError("invalid product ID")
+		}
+		items = append(items, entity.ShipmentItem{
+			ProductID: productID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	// 2. 注文と倉庫の存在確認
+	order, err := uc.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, repositoryError("failed to find order", err)
+	}
+	exists, err := uc.warehouseRepo.Exists(ctx, warehouseID)
+	if err != nil {
+		return nil, repositoryError("failed to check warehouse existence", err)
+	}
+	if !exists {
+		return nil, domain.WarehouseNotFoundError(cmd.WarehouseID)
+	}
+
+	// 3. エンティティ作成
+	shipment, err := entity.NewShipment(value.GenerateShipmentID(), orderID, warehouseID, cmd.Carrier, cmd.TrackingNumber, items)
+	if err != nil {
+		return nil, domain.InvalidInputError("failed to create shipment: " + err.Error())
+	}
+
+	// 4. ビジネスルール: 出荷数は注文数を超えない（全数出荷で注文を出荷済みにする）
+	shipments, err := uc.shipmentRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, repositoryError("failed to load order shipments", err)
+	}
+	err = order.ApplyShipments(append(shipments, shipment))
+	if err != nil {
+		return nil, domain.RuleViolationError("invalid shipment: " + err.Error())
+	}
+	order.AttributeStatusChanges(cmd.Actor, fmt.Sprintf("shipment %s created", shipment.ID()))
+
+	// 5. 出荷と注文を同時に保存
+	err = uc.shipmentRepo.Save(ctx, shipment, order)
+	if err != nil {
+		return nil, repositoryError("failed to save shipment", err)
+	}
+
+	// 6. 保存に成功したらドメインイベントを発行
+	publish(ctx, uc.events, order.PullEvents()...)
+
+	return shipment, nil
+}
+
+// GetShipmentUseCase handles getting a shipment by ID
+type GetShipmentUseCase struct {
+	shipmentRepo repository.ShipmentRepository
+}
+
+// GetShipmentCommand represents the input for getting a shipment
+type GetShipmentCommand struct {
+	ShipmentID string
+}
+
+// NewGetShipmentUseCase creates a new get shipment use case
+func NewGetShipmentUseCase(shipmentRepo repository.ShipmentRepository) *GetShipmentUseCase {
+	return &GetShipmentUseCase{
+		shipmentRepo: shipmentRepo,
+	}
+}
+
+// Execute executes the get shipment use case
+func (uc *GetShipmentUseCase) Execute(ctx context.Context, cmd GetShipmentCommand) (*entity.Shipment, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	shipmentID, err := value.NewShipmentID(cmd.ShipmentID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid shipment ID")
+	}
+
+	// 2. リポジトリから取得
+	shipment, err := uc.shipmentRepo.FindByID(ctx, shipmentID)
+	if err != nil {
+		return nil, repositoryError("failed to get shipment", err)
+	}
+
+	return shipment, nil
+}
+
+// ListOrderShipmentsUseCase handles listing the shipments of an order
+type ListOrderShipmentsUseCase struct {
+	shipmentRepo repository.ShipmentRepository
+}
+
+// ListOrderShipmentsCommand represents the input for listing an order's shipments
+type ListOrderShipmentsCommand struct {
+	OrderID string
+}
+
+// NewListOrderShipmentsUseCase creates a new list order shipments use case
+func NewListOrderShipmentsUseCase(shipmentRepo repository.ShipmentRepository) *ListOrderShipmentsUseCase {
+	return &ListOrderShipmentsUseCase{
+		shipmentRepo: shipmentRepo,
+	}
+}
+
+// Execute executes the list order shipments use case
+func (uc *ListOrderShipmentsUseCase) Execute(ctx context.Context, cmd ListOrderShipmentsCommand) ([]*entity.Shipment, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	orderID, err := value.NewOrderID(cmd.OrderID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid order ID")
+	}
+
+	// 2. リポジトリから取得
+	shipments, err := uc.shipmentRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, domain.RepositoryError("failed to list order shipments", err)
+	}
+
+	return shipments, nil
+}
+
+// ListWarehouseShipmentsUseCase handles listing the shipments sent from a warehouse
+type ListWarehouseShipmentsUseCase struct {
+	shipmentRepo repository.ShipmentRepository
+}
+
+// ListWarehouseShipmentsCommand represents the input for listing a warehouse's shipments
+type ListWarehouseShipmentsCommand struct {
+	WarehouseID string
+	Limit       int
+	Cursor      *string
+}
+
+// NewListWarehouseShipmentsUseCase creates a new list warehouse shipments use case
+func NewListWarehouseShipmentsUseCase(shipmentRepo repository.ShipmentRepository) *ListWarehouseShipmentsUseCase {
+	return &ListWarehouseShipmentsUseCase{
+		shipmentRepo: shipmentRepo,
+	}
+}
+
+// Execute executes the list warehouse shipments use case
+func (uc *ListWarehouseShipmentsUseCase) Execute(ctx context.Context, cmd ListWarehouseShipmentsCommand) ([]*entity.Shipment, *string, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	warehouseID, err := value.NewWarehouseID(cmd.WarehouseID)
+	if err != nil {
+		return nil, nil, domain.InvalidInputError("invalid warehouse ID")
+	}
+
+	// ビジネスロジック: デフォルトの制限値設定
+	if cmd.Limit <= 0 || cmd.Limit > 1000 {
+		cmd.Limit = 100 // デフォルト制限
+	}
+
+	// 2. リポジトリから取得
+	shipments, nextCursor, err := uc.shipmentRepo.FindByWarehouseID(ctx, warehouseID, cmd.Limit, cmd.Cursor)
+	if err != nil {
+		return nil, nil, repositoryError("failed to list warehouse shipments", err)
+	}
+
+	return shipments, nextCursor, nil
+}
+
+// DeliverShipmentUseCase handles marking a shipment as delivered
+type DeliverShipmentUseCase struct {
+	shipmentRepo repository.ShipmentRepository
+	orderRepo    repository.OrderRepository
+	events       EventPublisher
+}
+
+// DeliverShipmentCommand represents the input for delivering a shipment
+type DeliverShipmentCommand struct {
+	ShipmentID string
+	Actor      string // Who records the delivery, recorded in the order's status history
+}
+
+// NewDeliverShipmentUseCase creates a new deliver shipment use case. events may be nil.
+func NewDeliverShipmentUseCase(shipmentRepo repository.ShipmentRepository, orderRepo repository.OrderRepository, events EventPublisher) *DeliverShipmentUseCase {
+	return &DeliverShipmentUseCase{
+		shipmentRepo: shipmentRepo,
+		orderRepo:    orderRepo,
+		events:       events,
+	}
+}
+
+// Execute executes the deliver shipment use case. The order becomes delivered once all of its shipments have arrived.
+func (uc *DeliverShipmentUseCase) Execute(ctx context.Context, cmd DeliverShipmentCommand) (*entity.Shipment, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	shipmentID, err := value.NewShipmentID(cmd.ShipmentID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid shipment ID")
+	}
+
+	// 2. 既存の出荷と注文を取得
+	shipment, err := uc.shipmentRepo.FindByID(ctx, shipmentID)
+	if err != nil {
+		return nil, repositoryError("failed to get shipment", err)
+	}
+	order, err := uc.orderRepo.FindByID(ctx, shipment.OrderID())
+	if err != nil {
+		return nil, repositoryError("failed to get order", err)
+	}
+
+	// 3. 配達完了の記録
+	err = shipment.Deliver()
+	if err != nil {
+		return nil, domain.RuleViolationError("invalid shipment status: " + err.Error())
+	}
+
+	// 4. ビジネスルール: 全出荷の配達完了で注文を配達済みにする
+	shipments, err := uc.shipmentRepo.FindByOrderID(ctx, order.ID())
+	if err != nil {
+		return nil, repositoryError("failed to load order shipments", err)
+	}
+	for i, s := range shipments {
+		if s.ID() == shipment.ID() {
+			shipments[i] = shipment
+		}
+	}
+	err = order.ApplyShipments(shipments)
+	if err != nil {
+		return nil, domain.RuleViolationError("invalid shipment: " + err.Error())
+	}
+	order.AttributeStatusChanges(cmd.Actor, fmt.Sprintf("shipment %s delivered", shipment.ID()))
+
+	// 5. 出荷と注文を同時に保存
+	err = uc.shipmentRepo.Save(ctx, shipment, order)
+	if err != nil {
+		return nil, repositoryError("failed to save shipment", err)
+	}
+
+	// 6. 保存に成功したらドメインイベントを発行
+	publish(ctx, uc.events, order.PullEvents()...)
+
+	return shipment, nil
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"dynamo-modeling/internal/domain"
+	"dynamo-modeling/internal/domain/entity"
+	"dynamo-modeling/internal/domain/repository"
+	"dynamo-modeling/internal/domain/value"
+)
+
+// CreateWarehouseUseCase handles warehouse creation business logic
+type CreateWarehouseUseCase struct {
+	warehouseRepo repository.WarehouseRepository
+}
+
+// CreateWarehouseCommand represents the input for creating a warehouse
+type CreateWarehouseCommand struct {
+	Name     string
+	Location string
+}
+
+// NewCreateWarehouseUseCase creates a new create warehouse use case
+func NewCreateWarehouseUseCase(warehouseRepo repository.WarehouseRepository) *CreateWarehouseUseCase {
+	return &CreateWarehouseUseCase{
+		warehouseRepo: warehouseRepo,
+	}
+}
+
+// Execute executes the create warehouse use case
+func (uc *CreateWarehouseUseCase) Execute(ctx context.Context, cmd CreateWarehouseCommand) (*entity.Warehouse, error) {
+	// 1. 新しいWarehouse IDを生成
+	warehouseID := value.GenerateWarehouseID()
+
+	// 2. エンティティ作成
+	warehouse, err := entity.NewWarehouse(warehouseID, cmd.Name, cmd.Location)
+	if err != nil {
+		return nil, domain.InvalidInputError("failed to create warehouse: " + err.Error())
+	}
+
+	// 3. リポジトリに保存
+	err = uc.warehouseRepo.Save(ctx, warehouse)
+	if err != nil {
+		return nil, domain.RepositoryError("failed to save warehouse", err)
+	}
+
+	return warehouse, nil
+}
+
+// GetWarehouseUseCase handles getting a warehouse by ID
+type GetWarehouseUseCase struct {
+	warehouseRepo repository.WarehouseRepository
+}
+
+// GetWarehouseCommand represents the input for getting a warehouse
+type GetWarehouseCommand struct {
+	WarehouseID string
+}
+
+// NewGetWarehouseUseCase creates a new get warehouse use case
+func NewGetWarehouseUseCase(warehouseRepo repository.WarehouseRepository) *GetWarehouseUseCase {
+	return &GetWarehouseUseCase{
+		warehouseRepo: warehouseRepo,
+	}
+}
+
+// Execute executes the get warehouse use case
+func (uc *GetWarehouseUseCase) Execute(ctx context.Context, cmd GetWarehouseCommand) (*entity.Warehouse, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	warehouseID, err := value.NewWarehouseID(cmd.WarehouseID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid warehouse ID")
+	}
+
+	// 2. リポジトリから取得
+	warehouse, err := uc.warehouseRepo.FindByID(ctx, warehouseID)
+	if err != nil {
+		return nil, repositoryError("failed to get warehouse", err)
+	}
+
+	return warehouse, nil
+}
+
+// SetInventoryUseCase handles setting a product's quantity in a warehouse
+type SetInventoryUseCase struct {
+	warehouseRepo repository.WarehouseRepository
+	productRepo   repository.ProductRepository
+}
+
+// SetInventoryCommand represents the input for setting a product's quantity in a warehouse
+type SetInventoryCommand struct {
+	WarehouseID string
+	ProductID   string
+	Quantity    int
+}
+
+// NewSetInventoryUseCase creates a new set inventory use case
+func NewSetInventoryUseCase(warehouseRepo repository.WarehouseRepository, productRepo repository.ProductRepository) *SetInventoryUseCase {
+	return &SetInventoryUseCase{
+		warehouseRepo: warehouseRepo,
+		productRepo:   productRepo,
+	}
+}
+
+// Execute executes the set inventory use case. The product's total stock follows the change.
+func (uc *SetInventoryUseCase) Execute(ctx context.Context, cmd SetInventoryCommand) (*entity.Inventory, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	warehouseID, err := value.NewWarehouseID(cmd.WarehouseID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid warehouse ID")
+	}
+	productID, err := value.NewProductID(cmd.ProductID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid product ID")
+	}
+
+	// 2. 倉庫と商品の存在確認
+	exists, err := uc.warehouseRepo.Exists(ctx, warehouseID)
+	if err != nil {
+		return nil, repositoryError("failed to check warehouse existence", err)
+	}
+	if !exists {
+		return nil, domain.WarehouseNotFoundError(cmd.WarehouseID)
+	}
+	if _, err := uc.productRepo.FindByID(ctx, productID); err != nil {
+		return nil, repositoryError("failed to find product", err)
+	}
+
+	// 3. 既存の在庫を更新、なければ新規作成
+	inventory, err := uc.warehouseRepo.FindInventory(ctx, warehouseID, productID)
+	switch {
+	case err == nil:
+		err = inventory.SetQuantity(cmd.Quantity)
+	case errors.Is(err, domain.ErrNotFound):
+		inventory, err = entity.NewInventory(productID, warehouseID, cmd.Quantity)
+	default:
+		return nil, repositoryError("failed to find inventory", err)
+	}
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid quantity: " + err.Error())
+	}
+
+	// 4. リポジトリに保存（商品の在庫合計も同時に更新される）
+	err = uc.warehouseRepo.SaveInventory(ctx, inventory)
+	if err != nil {
+		return nil, repositoryError("failed to save inventory", err)
+	}
+
+	return inventory, nil
+}
+
+// ListWarehouseInventoryUseCase handles listing the inventory of all products in a warehouse
+type ListWarehouseInventoryUseCase struct {
+	warehouseRepo repository.WarehouseRepository
+}
+
+// ListWarehouseInventoryCommand represents the input for listing a warehouse's inventory
+type ListWarehouseInventoryCommand struct {
+	WarehouseID string
+	Limit       int
+	Cursor      *string
+}
+
+// NewListWarehouseInventoryUseCase creates a new list warehouse inventory use case
+func NewListWarehouseInventoryUseCase(warehouseRepo repository.WarehouseRepository) *ListWarehouseInventoryUseCase {
+	return &ListWarehouseInventoryUseCase{
+		warehouseRepo: warehouseRepo,
+	}
+}
+
+// Execute executes the list warehouse inventory use case
+func (uc *ListWarehouseInventoryUseCase) Execute(ctx context.Context, cmd ListWarehouseInventoryCommand) ([]*entity.Inventory, *string, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	warehouseID, err := value.NewWarehouseID(cmd.WarehouseID)
+	if err != nil {
+		return nil, nil, domain.InvalidInputError("invalid warehouse ID")
+	}
+
+	// ビジネスロジック: デフォルトの制限値設定
+	if cmd.Limit <= 0 || cmd.Limit > 1000 {
+		cmd.Limit = 100 // デフォルト制限
+	}
+
+	// 2. リポジトリから取得
+	inventories, nextCursor, err := uc.warehouseRepo.FindInventoryByWarehouse(ctx, warehouseID, cmd.Limit, cmd.Cursor)
+	if err != nil {
+		return nil, nil, repositoryError("failed to list warehouse inventory", err)
+	}
+
+	return inventories, nextCursor, nil
+}
+
+// GetProductInventoryUseCase handles getting a product's inventory across all warehouses
+type GetProductInventoryUseCase struct {
+	warehouseRepo repository.WarehouseRepository
+}
+
+// GetProductInventoryCommand represents the input for getting a product's inventory
+type GetProductInventoryCommand struct {
+	ProductID string
+}
+
+// NewGetProductInventoryUseCase creates a new get product inventory use case
+func NewGetProductInventoryUseCase(warehouseRepo repository.WarehouseRepository) *GetProductInventoryUseCase {
+	return &GetProductInventoryUseCase{
+		warehouseRepo: warehouseRepo,
+	}
+}
+
+// Execute executes the get product inventory use case
+func (uc *GetProductInventoryUseCase) Execute(ctx context.Context, cmd GetProductInventoryCommand) ([]*entity.Inventory, error) {
+	// 1. 値オブジェクトの作成・バリデーション
+	productID, err := value.NewProductID(cmd.ProductID)
+	if err != nil {
+		return nil, domain.InvalidInputError("invalid product ID")
+	}
+
+	// 2. リポジトリから取得
+	inventories, err := uc.warehouseRepo.FindInventoryByProduct(ctx, productID)
+	if err != nil {
+		return nil, domain.RepositoryError("failed to get product inventory", err)
+	}
+
+	return inventories, nil
+}
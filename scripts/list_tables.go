@@ -7,28 +7,24 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"os"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	appconfig "dynamo-modeling/internal/config"
+	"dynamo-modeling/internal/infrastructure"
 )
 
 func main() {
 	slog.Info("DynamoDBテーブル一覧を取得します")
 
-	// DynamoDB Local用のクライアント設定
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithRegion("ap-northeast-1"),
-		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("dummy", "dummy", "")),
-		config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(
-			func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-				return aws.Endpoint{
-					URL:           "http://localhost:8000",
-					SigningRegion: "ap-northeast-1",
-				}, nil
-			})),
-	)
+	// 接続先の設定（デフォルト < YAMLファイル < 環境変数 < フラグ）
+	appCfg, err := appconfig.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("設定の読み込みに失敗: %v", err)
+	}
+	cfg, err := infrastructure.LoadAWSConfig(context.TODO(), appCfg.DynamoDB.Infrastructure())
 	if err != nil {
 		log.Fatalf("AWS設定の読み込みに失敗: %v", err)
 	}
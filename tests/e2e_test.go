@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"dynamo-modeling/internal/adapter/openapi"
+	"dynamo-modeling/internal/config"
 	"dynamo-modeling/internal/infrastructure"
 )
 
@@ -41,12 +42,9 @@ func (suite *E2ETestSuite) SetupSuite() {
 
 	// DynamoDB Localクライアント初期化（テストデータクリーンアップ用）
 	ctx := context.Background()
-	config := infrastructure.DynamoDBConfig{
-		Endpoint:  "http://localhost:8000",
-		TableName: "OnlineShop",
-		Region:    "ap-northeast-1",
-	}
-	client, err := infrastructure.NewDynamoDBClient(ctx, config)
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	client, err := infrastructure.NewDynamoDBClient(ctx, cfg.DynamoDB.Infrastructure())
 	require.NoError(t, err)
 	suite.dbClient = client
 
@@ -140,7 +138,6 @@ func (suite *E2ETestSuite) TestE2E_HappyPath() {
 		Name:        "Test Product " + timestamp,
 		Description: "A test product for E2E testing",
 		Price:       2999, // 29.99 in cents
-		Stock:       10,
 	}
 
 	resp, err = suite.makeRequest("POST", "/products", productReq)
@@ -154,9 +151,12 @@ func (suite *E2ETestSuite) TestE2E_HappyPath() {
 
 	assert.Equal(t, "Test Product "+timestamp, productResp.Name)
 	assert.Equal(t, 2999, productResp.Price)
-	assert.Equal(t, 10, productResp.Stock)
+	assert.Equal(t, 0, productResp.Stock) // 在庫は倉庫在庫の登録で加算される
 	productID := productResp.Id
 
+	// 倉庫に在庫を登録
+	suite.stockProduct(productID, 10)
+
 	// 3. Order作成
 	orderReq := openapi.OrderRequest{
 		CustomerId: customerID,
@@ -259,12 +259,12 @@ func (suite *E2ETestSuite) TestE2E_InsufficientStock() {
 		Name:        "Limited Stock Product " + timestamp,
 		Description: "A product with limited stock",
 		Price:       1500,
-		Stock:       3, // 在庫3個のみ
 	}
 
 	resp, err = suite.makeRequest("POST", "/products", productReq)
 	require.NoError(t, err)
 	productID := suite.extractProductID(resp)
+	suite.stockProduct(productID, 3) // 在庫3個のみ
 
 	// 3. 在庫以上の数量で注文（エラーが発生するはず）
 	orderReq := openapi.OrderRequest{
@@ -279,7 +279,7 @@ func (suite *E2ETestSuite) TestE2E_InsufficientStock() {
 
 	resp, err = suite.makeRequest("POST", "/orders", orderReq)
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
 
 	// エラーレスポンスの内容を確認
 	bodyBytes, err := io.ReadAll(resp.Body)
@@ -302,12 +302,12 @@ func (suite *E2ETestSuite) TestE2E_InvalidCustomer() {
 		Name:        "Test Product " + timestamp,
 		Description: "A test product",
 		Price:       1000,
-		Stock:       10,
 	}
 
 	resp, err := suite.makeRequest("POST", "/products", productReq)
 	require.NoError(t, err)
 	productID := suite.extractProductID(resp)
+	suite.stockProduct(productID, 10)
 
 	// 2. 存在しない顧客IDで注文
 	orderReq := openapi.OrderRequest{
@@ -322,7 +322,7 @@ func (suite *E2ETestSuite) TestE2E_InvalidCustomer() {
 
 	resp, err = suite.makeRequest("POST", "/orders", orderReq)
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 
 	// エラーレスポンスの内容を確認
 	bodyBytes, err := io.ReadAll(resp.Body)
@@ -356,12 +356,12 @@ func (suite *E2ETestSuite) TestE2E_ConcurrentOrders() {
 		Name:        "Limited Product " + timestamp,
 		Description: "Product for concurrent test",
 		Price:       2000,
-		Stock:       5, // 在庫5個
 	}
 
 	resp, err = suite.makeRequest("POST", "/products", productReq)
 	require.NoError(t, err)
 	productID := suite.extractProductID(resp)
+	suite.stockProduct(productID, 5) // 在庫5個
 
 	// 3. 並行で複数の注文を送信
 	orderReq := openapi.OrderRequest{
@@ -431,6 +431,28 @@ func (suite *E2ETestSuite) TestE2E_ConcurrentOrders() {
 
 // ヘルパーメソッド群
 
+// stockProduct は倉庫を作成し、商品の在庫を登録する
+func (suite *E2ETestSuite) stockProduct(productID string, quantity int) {
+	t := suite.T()
+
+	resp, err := suite.makeRequest("POST", "/warehouses", openapi.WarehouseRequest{
+		Name:     "E2E Warehouse",
+		Location: "Tokyo",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var warehouseResp openapi.WarehouseResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&warehouseResp))
+	resp.Body.Close()
+
+	resp, err = suite.makeRequest("PUT", "/warehouses/"+warehouseResp.Id+"/inventory/"+productID, openapi.InventoryRequest{
+		Quantity: quantity,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
 func (suite *E2ETestSuite) extractCustomerID(resp *http.Response) string {
 	var customerResp openapi.CustomerResponse
 	json.NewDecoder(resp.Body).Decode(&customerResp)